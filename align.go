@@ -0,0 +1,192 @@
+package cview
+
+import (
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Align is a container which positions another primitive within its area,
+// such as centering a fixed-size Form on the screen. The contained primitive
+// is limited to a maximum width and height and is aligned horizontally and
+// vertically within the remaining space.
+type Align struct {
+	*Box
+
+	// The contained primitive.
+	primitive Primitive
+
+	// The horizontal alignment, one of AlignLeft, AlignCenter or AlignRight.
+	horizontal int
+
+	// The vertical alignment.
+	vertical VerticalAlignment
+
+	// The maximum size of the contained primitive. Zero means no limit.
+	maxWidth, maxHeight int
+
+	sync.RWMutex
+}
+
+// NewAlign returns a new Align container which centers the provided
+// primitive. Call SetMaxSize to limit the size of the primitive, otherwise it
+// fills the available space.
+func NewAlign(primitive Primitive) *Align {
+	a := &Align{
+		Box:        NewBox(),
+		primitive:  primitive,
+		horizontal: AlignCenter,
+		vertical:   AlignMiddle,
+	}
+	a.focus = a
+	return a
+}
+
+// SetPrimitive sets the contained primitive.
+func (a *Align) SetPrimitive(p Primitive) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.primitive = p
+}
+
+// GetPrimitive returns the contained primitive.
+func (a *Align) GetPrimitive() Primitive {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.primitive
+}
+
+// SetAlignment sets the horizontal alignment (one of AlignLeft, AlignCenter
+// or AlignRight) and the vertical alignment (one of AlignTop, AlignMiddle or
+// AlignBottom) of the contained primitive.
+func (a *Align) SetAlignment(horizontal int, vertical VerticalAlignment) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.horizontal, a.vertical = horizontal, vertical
+}
+
+// GetAlignment returns the horizontal and vertical alignment of the contained
+// primitive.
+func (a *Align) GetAlignment() (horizontal int, vertical VerticalAlignment) {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.horizontal, a.vertical
+}
+
+// SetMaxSize sets the maximum width and height of the contained primitive. A
+// value of zero means the primitive fills the available space in that
+// direction.
+func (a *Align) SetMaxSize(width, height int) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.maxWidth, a.maxHeight = width, height
+}
+
+// GetMaxSize returns the maximum width and height of the contained primitive.
+func (a *Align) GetMaxSize() (width, height int) {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.maxWidth, a.maxHeight
+}
+
+// children returns the contained primitive.
+func (a *Align) children() []Primitive {
+	a.RLock()
+	defer a.RUnlock()
+
+	if a.primitive == nil {
+		return nil
+	}
+	return []Primitive{a.primitive}
+}
+
+// Draw draws this primitive onto the screen.
+func (a *Align) Draw(screen tcell.Screen) {
+	if !a.GetVisible() {
+		return
+	}
+
+	a.Box.Draw(screen)
+
+	a.RLock()
+	primitive := a.primitive
+	horizontal, vertical := a.horizontal, a.vertical
+	maxWidth, maxHeight := a.maxWidth, a.maxHeight
+	a.RUnlock()
+
+	if primitive == nil {
+		return
+	}
+
+	x, y, width, height := a.GetInnerRect()
+	if maxWidth > 0 && maxWidth < width {
+		switch horizontal {
+		case AlignCenter:
+			x += (width - maxWidth) / 2
+		case AlignRight:
+			x += width - maxWidth
+		}
+		width = maxWidth
+	}
+	if maxHeight > 0 && maxHeight < height {
+		switch vertical {
+		case AlignMiddle:
+			y += (height - maxHeight) / 2
+		case AlignBottom:
+			y += height - maxHeight
+		}
+		height = maxHeight
+	}
+
+	primitive.SetRect(x, y, width, height)
+	a.passColors(primitive)
+	drawPrimitive(primitive, screen)
+}
+
+// Focus is called when this primitive receives focus.
+func (a *Align) Focus(delegate func(p Primitive)) {
+	a.RLock()
+	primitive := a.primitive
+	a.RUnlock()
+
+	if primitive == nil {
+		a.Box.Focus(delegate)
+		return
+	}
+	delegate(primitive)
+}
+
+// HasFocus returns whether or not this primitive has focus.
+func (a *Align) HasFocus() bool {
+	a.RLock()
+	primitive := a.primitive
+	a.RUnlock()
+
+	if primitive == nil {
+		return a.Box.HasFocus()
+	}
+	return primitive.GetFocusable().HasFocus()
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (a *Align) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return a.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		if !a.InRect(event.Position()) {
+			return false, nil
+		}
+
+		primitive := a.GetPrimitive()
+		if primitive == nil {
+			return false, nil
+		}
+
+		// Pass mouse events on to contained primitive.
+		return primitive.MouseHandler()(action, event, setFocus)
+	})
+}
@@ -0,0 +1,50 @@
+package cview
+
+import (
+	"testing"
+)
+
+func TestAlign(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	app, err := newTestApp(nil)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+
+	b := NewBox()
+	a := NewAlign(b)
+	a.SetRect(0, 0, 20, 10)
+
+	// Section
+
+	testCases := []struct {
+		horizontal          int
+		vertical            VerticalAlignment
+		maxWidth, maxHeight int
+		x, y, w, h          int
+	}{
+		{AlignCenter, AlignMiddle, 0, 0, 0, 0, 20, 10},
+		{AlignCenter, AlignMiddle, 6, 4, 7, 3, 6, 4},
+		{AlignLeft, AlignTop, 6, 4, 0, 0, 6, 4},
+		{AlignRight, AlignBottom, 6, 4, 14, 6, 6, 4},
+		{AlignRight, AlignBottom, 30, 0, 0, 0, 20, 10},
+	}
+	for i, c := range testCases {
+		a.SetAlignment(c.horizontal, c.vertical)
+		a.SetMaxSize(c.maxWidth, c.maxHeight)
+		a.Draw(app.screen)
+
+		x, y, w, h := b.GetRect()
+		if x != c.x || y != c.y || w != c.w || h != c.h {
+			t.Errorf("failed to align primitive (case %d): expected %d,%d %dx%d, got %d,%d %dx%d", i, c.x, c.y, c.w, c.h, x, y, w, h)
+		}
+	}
+}
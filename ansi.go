@@ -26,6 +26,11 @@ type ansi struct {
 	csiParameter, csiIntermediate *bytes.Buffer // Partial CSI strings.
 	attributes                    string        // The buffer's current text attributes (a tview attribute string).
 
+	// If set to true, text which resembles color or region tags is escaped.
+	// Text is collected separately until the next escape sequence.
+	escape bool
+	text   *bytes.Buffer
+
 	// The current state of the parser. One of the ansi constants.
 	state int
 }
@@ -35,15 +40,32 @@ type ansi struct {
 // and are simply removed. The translated text is written to the provided
 // writer.
 func ANSIWriter(writer io.Writer) io.Writer {
+	return newANSIWriter(writer, false)
+}
+
+// newANSIWriter returns a new ANSI writer. When escape is true, text which
+// resembles color or region tags is escaped so that it is displayed as is.
+func newANSIWriter(writer io.Writer, escape bool) *ansi {
 	return &ansi{
 		Writer:          writer,
 		buffer:          new(bytes.Buffer),
 		csiParameter:    new(bytes.Buffer),
 		csiIntermediate: new(bytes.Buffer),
+		escape:          escape,
+		text:            new(bytes.Buffer),
 		state:           ansiText,
 	}
 }
 
+// flushText escapes the collected text and writes it to the buffer.
+func (a *ansi) flushText() {
+	if a.text.Len() == 0 {
+		return
+	}
+	a.buffer.WriteString(Escape(a.text.String()))
+	a.text.Reset()
+}
+
 // Write parses the given text as a string of runes, translates ANSI escape
 // codes to color tags and writes them to the output writer.
 func (a *ansi) Write(text []byte) (int, error) {
@@ -242,7 +264,11 @@ func (a *ansi) Write(text []byte) (int, error) {
 		default:
 			if r == 27 {
 				// This is the start of an escape sequence.
+				a.flushText()
 				a.state = ansiEscape
+			} else if a.escape {
+				// Collect text to be escaped.
+				a.text.WriteRune(r)
 			} else {
 				// Just a regular rune. Send to buffer.
 				if _, err := a.buffer.WriteRune(r); err != nil {
@@ -253,6 +279,7 @@ func (a *ansi) Write(text []byte) (int, error) {
 	}
 
 	// Write buffer to target writer.
+	a.flushText()
 	n, err := a.buffer.WriteTo(a.Writer)
 	if err != nil {
 		return int(n), err
@@ -3,6 +3,7 @@ package cview
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
@@ -29,6 +30,12 @@ const (
 //       panic(err)
 //   }
 type Application struct {
+	// The time the main goroutine started running the current event handler
+	// or update (0 when idle), and the number of handlers and updates run.
+	// These are accessed atomically and must be 64-bit aligned.
+	busySince int64
+	busyID    uint64
+
 	// The application's screen. Apart from Run(), this variable should never be
 	// set directly. Always use the screenReplacement channel after calling
 	// Fini(), to set a new screen (or nil to stop the application).
@@ -85,12 +92,19 @@ type Application struct {
 	// was drawn.
 	afterDraw func(screen tcell.Screen)
 
+	// An optional callback function which is invoked before the application
+	// stops.
+	beforeStop func() bool
+
 	// Used to send screen events from separate goroutine to main event loop
 	events chan tcell.Event
 
 	// Functions queued from goroutines, used to serialize updates to primitives.
 	updates chan func()
 
+	// Functions deferred until the current event handler or update returns.
+	deferred []func()
+
 	// An object that the screen variable will be set to after Fini() was called.
 	// Use this channel to set a new screen object for the application
 	// (screen.Init() and draw() will be called implicitly). A value of nil will
@@ -112,13 +126,119 @@ type Application struct {
 	lastMouseClick          time.Time        // The time when a mouse button was last clicked.
 	lastMouseButtons        tcell.ButtonMask // The last mouse button state.
 
+	// The distance the mouse must be moved while the left button is held to
+	// start a drag, and the distance a drag must cover to be a swipe.
+	dragThreshold, swipeThreshold int
+
+	// Whether the mouse is being dragged.
+	dragging bool
+
+	// Whether the application is suspended.
+	suspended bool
+
+	// The Modal which last received focus.
+	modal *Modal
+
+	// Functions called repeatedly via Every.
+	schedules []*Schedule
+
+	// Whether a status line is shown below the root primitive.
+	statusLine bool
+
+	// The persistent status and its level.
+	status      string
+	statusLevel LogLevel
+
+	// The transient status and its level, shown instead of the persistent
+	// status until it expires.
+	transientStatus      string
+	transientStatusLevel LogLevel
+
+	// Incremented each time a transient status is shown.
+	transientStatusID int
+
+	// Whether text is being pasted into a primitive which handles pasted text
+	// at once, and the text pasted so far.
+	pasting   bool
+	pasteText []rune
+
+	// The panel displaying the style audit report, if any, and the report
+	// last displayed.
+	styleAuditPanel  *TextView
+	styleAuditReport string
+
+	// The macro being recorded, if any, and the time the last event was
+	// recorded.
+	recording    *Macro
+	lastRecorded time.Time
+
+	// The player of the macro being replayed, if any.
+	macroPlayer *MacroPlayer
+
+	// The watchdog reporting a blocked main goroutine, if any.
+	watchdog *watchdog
+
+	// Whether the resize mode may be used and whether the arrow keys resize
+	// the focused pane.
+	resizeModeEnabled bool
+	resizeMode        bool
+
+	// Whether zooming may be toggled via Keys.Zoom and the pane which is
+	// zoomed, if any.
+	zoomEnabled bool
+	zoomed      Primitive
+
+	// Whether the mouse may be emulated via the keyboard, whether it is
+	// emulated, the position of the virtual mouse cursor and whether its left
+	// button is held.
+	mouseEmulationEnabled          bool
+	mouseEmulation                 bool
+	emulatedMouseX, emulatedMouseY int
+	emulatedDrag                   bool
+
+	// Whether the inspector may be toggled via Keys.Inspect, whether it is
+	// active, the primitives from the root to the inspected primitive and
+	// whether the inspected primitive was selected instead of hovered.
+	inspectorEnabled bool
+	inspecting       bool
+	inspectPath      []Primitive
+	inspectPinned    bool
+
+	// The rune which marks mnemonics and the marker which was last passed to
+	// the widgets of the root primitive.
+	mnemonicMarker        rune
+	mnemonicMarkerApplied rune
+
+	// The bound key sequences, the keys of the pending sequence and the time
+	// to wait for its next key.
+	keySequences       *keySequenceNode
+	pendingKeys        []string
+	keySequenceTimeout time.Duration
+
+	// Incremented each time the pending key sequence changes.
+	keySequenceID int
+
+	// The filter transforming all drawn colors and the screen applying it, if
+	// any.
+	colorFilter       ColorFilter
+	colorFilterScreen *colorFilterScreen
+
 	sync.RWMutex
 }
 
+// paster is implemented by primitives which handle pasted text at once
+// instead of as individual key events, such as Table.
+type paster interface {
+	Paste(text string)
+}
+
 // NewApplication creates and returns a new application.
 func NewApplication() *Application {
 	return &Application{
 		enableBracketedPaste: true,
+		dragThreshold:        DefaultDragThreshold,
+		swipeThreshold:       DefaultSwipeThreshold,
+		keySequenceTimeout:   DefaultKeySequenceTimeout,
 		events:               make(chan tcell.Event, queueSize),
 		updates:              make(chan func(), queueSize),
 		screenReplacement:    make(chan tcell.Screen, 1),
@@ -191,6 +311,38 @@ func (a *Application) SetDoubleClickInterval(interval time.Duration) {
 	a.doubleClickInterval = interval
 }
 
+// SetDragThreshold sets the number of screen cells the mouse must be moved
+// while the left mouse button is held for the movement to be recognized as a
+// drag. Mouse handlers then receive MouseDragStart, followed by MouseDrag for
+// each subsequent movement and MouseDragEnd when the button is released. The
+// default is DefaultDragThreshold.
+func (a *Application) SetDragThreshold(cells int) {
+	a.Lock()
+	defer a.Unlock()
+
+	if cells < 1 {
+		cells = 1
+	}
+	a.dragThreshold = cells
+}
+
+// SetSwipeThreshold sets the number of screen cells a drag must cover for it
+// to be recognized as a swipe. When a drag ends at least this far from where it
+// started, and the movement along one axis is at least twice the movement
+// along the other axis, mouse handlers receive MouseSwipeLeft, MouseSwipeRight,
+// MouseSwipeUp or MouseSwipeDown after MouseDragEnd. Vertical swipes require
+// half of the threshold, as screen cells are about twice as tall as they are
+// wide. The default is DefaultSwipeThreshold.
+func (a *Application) SetSwipeThreshold(cells int) {
+	a.Lock()
+	defer a.Unlock()
+
+	if cells < 1 {
+		cells = 1
+	}
+	a.swipeThreshold = cells
+}
+
 // SetScreen allows you to provide your own tcell.Screen object. For most
 // applications, this is not needed and you should be familiar with
 // tcell.Screen when using this function.
@@ -361,12 +513,45 @@ func (a *Application) Run() error {
 	handle := func(event interface{}) {
 		a.RLock()
 		p := a.focus
+		root := a.root
 		inputCapture := a.inputCapture
 		screen := a.screen
 		a.RUnlock()
 
+		if e, ok := event.(tcell.Event); ok {
+			a.recordEvent(e)
+		}
+
 		switch event := event.(type) {
+		case *tcell.EventPaste:
+			if event.Start() {
+				_, a.pasting = p.(paster)
+				a.pasteText = nil
+				return
+			}
+
+			if a.pasting {
+				a.pasting = false
+				if handler, ok := p.(paster); ok {
+					handler.Paste(string(a.pasteText))
+					a.draw()
+				}
+				a.pasteText = nil
+			}
 		case *tcell.EventKey:
+			// Collect pasted text.
+			if a.pasting {
+				switch event.Key() {
+				case tcell.KeyRune:
+					a.pasteText = append(a.pasteText, event.Rune())
+				case tcell.KeyEnter, tcell.KeyLF:
+					a.pasteText = append(a.pasteText, '\n')
+				case tcell.KeyTab:
+					a.pasteText = append(a.pasteText, '\t')
+				}
+				return
+			}
+
 			// Intercept keys.
 			if inputCapture != nil {
 				event = inputCapture(event)
@@ -379,9 +564,49 @@ func (a *Application) Run() error {
 			// Ctrl-C closes the application.
 			if event.Key() == tcell.KeyCtrlC {
 				a.Stop()
+				a.draw() // The stop may have been vetoed.
 				return
 			}
 
+			// Handle key sequences.
+			if a.handleKeySequence(event) {
+				a.draw()
+				return
+			}
+
+			// Inspect primitives.
+			if a.handleInspectorKey(event) {
+				a.draw()
+				return
+			}
+
+			// Emulate the mouse.
+			if a.handleMouseEmulation(event) {
+				a.draw()
+				return
+			}
+
+			// Resize panes.
+			if a.handleResizeMode(event, root, p) {
+				a.draw()
+				return
+			}
+
+			// Zoom panes.
+			if a.handleZoom(event, root, p) {
+				a.draw()
+				return
+			}
+
+			// Activate mnemonics.
+			if event.Key() == tcell.KeyRune && event.Modifiers() == tcell.ModAlt && a.GetMnemonicMarker() != 0 {
+				a.applyMnemonicMarker(root)
+				if a.activateMnemonic(root, event.Rune()) {
+					a.draw()
+					return
+				}
+			}
+
 			// Pass other key events to the currently focused primitive.
 			if p != nil {
 				if handler := p.InputHandler(); handler != nil {
@@ -428,14 +653,13 @@ func (a *Application) Run() error {
 
 			a.draw()
 		case *tcell.EventMouse:
-			consumed, isMouseDownAction := a.fireMouseActions(event)
-			if consumed {
+			if a.handleInspectorMouse(event, root) {
+				a.lastMouseButtons = event.Buttons()
 				a.draw()
+				return
 			}
-			a.lastMouseButtons = event.Buttons()
-			if isMouseDownAction {
-				a.mouseDownX, a.mouseDownY = event.Position()
-			}
+
+			a.handleMouse(event)
 		}
 	}
 
@@ -446,7 +670,7 @@ func (a *Application) Run() error {
 
 		for update := range a.updates {
 			semaphore.Lock()
-			update()
+			a.runHandler(update)
 			semaphore.Unlock()
 		}
 	}()
@@ -455,8 +679,11 @@ func (a *Application) Run() error {
 		defer a.HandlePanic()
 
 		for event := range a.events {
+			event := event // Capture
 			semaphore.Lock()
-			handle(event)
+			a.runHandler(func() {
+				handle(event)
+			})
 			semaphore.Unlock()
 		}
 	}()
@@ -478,7 +705,9 @@ func (a *Application) Run() error {
 		}
 
 		semaphore.Lock()
-		handle(event)
+		a.runHandler(func() {
+			handle(event)
+		})
 		semaphore.Unlock()
 	}
 
@@ -486,15 +715,53 @@ func (a *Application) Run() error {
 	wg.Wait()
 	a.screen = nil
 
+	a.stopSchedules()
+	a.stopWatchdog()
+
 	return nil
 }
 
+// handleMouse forwards a mouse event to the corresponding primitives and draws
+// the screen if it was consumed.
+func (a *Application) handleMouse(event *tcell.EventMouse) {
+	consumed, isMouseDownAction := a.fireMouseActions(event)
+	if consumed {
+		a.draw()
+	}
+	a.lastMouseButtons = event.Buttons()
+	if isMouseDownAction {
+		a.mouseDownX, a.mouseDownY = event.Position()
+	}
+}
+
+// swipeAction returns the swipe action for a drag covering the provided
+// distance, if the drag is a swipe with the provided threshold.
+func swipeAction(dx, dy, threshold int) (MouseAction, bool) {
+	horizontal, vertical := abs(dx), abs(dy)
+	switch {
+	case horizontal >= threshold && horizontal >= 2*vertical:
+		if dx < 0 {
+			return MouseSwipeLeft, true
+		}
+		return MouseSwipeRight, true
+	case vertical >= (threshold+1)/2 && vertical >= 2*horizontal:
+		if dy < 0 {
+			return MouseSwipeUp, true
+		}
+		return MouseSwipeDown, true
+	}
+	return 0, false
+}
+
 // fireMouseActions analyzes the provided mouse event, derives mouse actions
 // from it and then forwards them to the corresponding primitives.
 func (a *Application) fireMouseActions(event *tcell.EventMouse) (consumed, isMouseDownAction bool) {
 	// We want to relay follow-up events to the same target primitive.
 	var targetPrimitive Primitive
 
+	// Forward events to the zoomed pane instead of the root primitive.
+	zoomed := a.zoomedPane()
+
 	// Helper function to fire a mouse action.
 	fire := func(action MouseAction) {
 		switch action {
@@ -518,6 +785,8 @@ func (a *Application) fireMouseActions(event *tcell.EventMouse) (consumed, isMou
 			targetPrimitive = a.mouseCapturingPrimitive
 		} else if targetPrimitive != nil {
 			primitive = targetPrimitive
+		} else if zoomed != nil {
+			primitive = zoomed
 		} else {
 			primitive = a.root
 		}
@@ -535,6 +804,10 @@ func (a *Application) fireMouseActions(event *tcell.EventMouse) (consumed, isMou
 		a.mouseCapturingPrimitive = capturingPrimitive
 	}
 
+	a.RLock()
+	dragThreshold, swipeThreshold := a.dragThreshold, a.swipeThreshold
+	a.RUnlock()
+
 	x, y := event.Position()
 	buttons := event.Buttons()
 	clickMoved := x != a.mouseDownX || y != a.mouseDownY
@@ -544,6 +817,16 @@ func (a *Application) fireMouseActions(event *tcell.EventMouse) (consumed, isMou
 		fire(MouseMove)
 		a.lastMouseX = x
 		a.lastMouseY = y
+
+		// Recognize drags while the left button is held.
+		if buttons&a.lastMouseButtons&tcell.ButtonPrimary != 0 {
+			if a.dragging {
+				fire(MouseDrag)
+			} else if abs(x-a.mouseDownX) >= dragThreshold || abs(y-a.mouseDownY) >= dragThreshold {
+				a.dragging = true
+				fire(MouseDragStart)
+			}
+		}
 	}
 
 	for _, buttonEvent := range []struct {
@@ -572,6 +855,15 @@ func (a *Application) fireMouseActions(event *tcell.EventMouse) (consumed, isMou
 		}
 	}
 
+	// End drags when the left button is released.
+	if a.dragging && buttons&tcell.ButtonPrimary == 0 {
+		a.dragging = false
+		fire(MouseDragEnd)
+		if swipe, ok := swipeAction(x-a.mouseDownX, y-a.mouseDownY, swipeThreshold); ok {
+			fire(swipe)
+		}
+	}
+
 	for _, wheelEvent := range []struct {
 		button tcell.ButtonMask
 		action MouseAction
@@ -588,8 +880,23 @@ func (a *Application) fireMouseActions(event *tcell.EventMouse) (consumed, isMou
 	return consumed, isMouseDownAction
 }
 
-// Stop stops the application, causing Run() to return.
+// Stop stops the application, causing Run() to return. If a callback was
+// installed via SetBeforeStopFunc, it is invoked first and may veto the stop.
 func (a *Application) Stop() {
+	a.RLock()
+	beforeStop := a.beforeStop
+	a.RUnlock()
+
+	if beforeStop != nil && !beforeStop() {
+		return
+	}
+
+	a.ForceStop()
+}
+
+// ForceStop stops the application, causing Run() to return, without invoking
+// the callback installed via SetBeforeStopFunc.
+func (a *Application) ForceStop() {
 	a.Lock()
 	defer a.Unlock()
 
@@ -597,6 +904,28 @@ func (a *Application) Stop() {
 	a.screenReplacement <- nil
 }
 
+// SetBeforeStopFunc installs a callback function which is invoked when the
+// application is about to stop via Stop, which is also called when the user
+// presses Ctrl+C. Return false to keep the application running. This allows
+// asking the user to confirm before quitting, e.g. when there are unsaved
+// changes:
+//
+//   app.SetBeforeStopFunc(func() bool {
+//       if !unsavedChanges {
+//           return true
+//       }
+//       panels.ShowPanel("confirmQuit") // Calls app.ForceStop when confirmed.
+//       return false
+//   })
+//
+// Provide nil to uninstall the callback function.
+func (a *Application) SetBeforeStopFunc(handler func() bool) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.beforeStop = handler
+}
+
 func (a *Application) finalizeScreen() {
 	screen := a.screen
 	if screen == nil {
@@ -621,6 +950,7 @@ func (a *Application) Suspend(f func()) bool {
 		return false // Screen has not yet been initialized.
 	}
 	err := a.screen.Suspend()
+	a.suspended = true
 	a.Unlock()
 	if err != nil {
 		panic(err)
@@ -631,6 +961,7 @@ func (a *Application) Suspend(f func()) bool {
 
 	a.Lock()
 	err = a.screen.Resume()
+	a.suspended = false
 	a.Unlock()
 	if err != nil {
 		panic(err)
@@ -671,7 +1002,7 @@ func (a *Application) Draw(p ...Primitive) {
 func (a *Application) draw() {
 	a.Lock()
 
-	screen := a.screen
+	screen := a.filteredScreen(a.screen)
 	root := a.root
 	fullscreen := a.rootFullscreen
 	before := a.beforeDraw
@@ -685,7 +1016,11 @@ func (a *Application) draw() {
 
 	// Resize if requested.
 	if fullscreen {
-		root.SetRect(0, 0, a.width, a.height)
+		height := a.height
+		if a.statusLine {
+			height--
+		}
+		root.SetRect(0, 0, a.width, height)
 	}
 
 	// Call before handler if there is one.
@@ -700,7 +1035,20 @@ func (a *Application) draw() {
 	}
 
 	// Draw all primitives.
-	root.Draw(screen)
+	a.applyMnemonicMarker(root)
+	a.auditStyles(root)
+	if zoomed := a.zoomedPane(); zoomed != nil {
+		zoomed.SetRect(root.GetRect())
+		drawPrimitive(zoomed, screen)
+	} else {
+		drawPrimitive(root, screen)
+	}
+	a.drawStatusLine(screen)
+	a.drawMacroIndicator(screen)
+	a.drawResizeModeIndicator(screen)
+	a.drawKeySequenceIndicator(screen)
+	a.drawMouseEmulation(screen)
+	a.drawInspector(screen)
 
 	// Call after handler if there is one.
 	if after != nil {
@@ -831,6 +1179,9 @@ func (a *Application) SetFocus(p Primitive) {
 	}
 
 	a.focus = p
+	if modal, ok := p.(*Modal); ok {
+		a.modal = modal
+	}
 
 	if a.screen != nil {
 		a.screen.HideCursor()
@@ -892,6 +1243,18 @@ func (a *Application) QueueUpdate(f func()) {
 	a.updates <- f
 }
 
+// tryQueueUpdate queues a function like QueueUpdate, unless the queue is full,
+// for example because the application is not running. Unlike QueueUpdate, it
+// never blocks. Returns whether the function was queued.
+func (a *Application) tryQueueUpdate(f func()) bool {
+	select {
+	case a.updates <- f:
+		return true
+	default:
+		return false
+	}
+}
+
 // QueueUpdateDraw works like QueueUpdate() except, when one or more primitives
 // are provided, the primitives are drawn after the provided function returns.
 // When no primitives are provided, the entire screen is drawn after the
@@ -915,6 +1278,55 @@ func (a *Application) QueueUpdateDraw(f func(), p ...Primitive) {
 	})
 }
 
+// Defer calls the provided function on the main goroutine once the event
+// handler or update which is currently running returns, after which the screen
+// is drawn. Unlike QueueUpdate, Defer never blocks, and functions deferred by
+// the same handler are called in the order they were deferred.
+//
+// Defer is meant for callbacks of primitives which must mutate other
+// primitives after the primitive which called them has finished processing an
+// event, such as a selected handler of a List which clears and refills the
+// same List or one which replaces the root primitive. When no event handler or
+// update is running, the function is queued via QueueUpdateDraw.
+func (a *Application) Defer(f func()) {
+	if f == nil {
+		return
+	}
+
+	a.Lock()
+	a.deferred = append(a.deferred, f)
+	idle := atomic.LoadInt64(&a.busySince) == 0
+	a.Unlock()
+
+	if idle {
+		go a.QueueUpdateDraw(func() {})
+	}
+}
+
+// runDeferred calls the deferred functions, including any functions they
+// defer, and draws the screen. Returns whether any functions were called.
+func (a *Application) runDeferred() bool {
+	var ran bool
+	for {
+		a.Lock()
+		deferred := a.deferred
+		a.deferred = nil
+		a.Unlock()
+
+		if len(deferred) == 0 {
+			break
+		}
+		for _, f := range deferred {
+			f()
+		}
+		ran = true
+	}
+	if ran {
+		a.draw()
+	}
+	return ran
+}
+
 // QueueEvent sends an event to the Application event loop.
 //
 // It is not recommended for event to be nil.
@@ -0,0 +1,184 @@
+package cview
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestApplicationBeforeStop(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	app, err := newTestApp(NewBox())
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	var calls int
+	confirmed := false
+	app.SetBeforeStopFunc(func() bool {
+		calls++
+		return confirmed
+	})
+
+	// Veto
+
+	app.Stop()
+	if app.screen == nil {
+		t.Errorf("failed to veto stop: application stopped")
+	}
+
+	// Confirm
+
+	confirmed = true
+	app.Stop()
+	if app.screen != nil {
+		t.Errorf("failed to stop: application still running")
+	}
+	if calls != 2 {
+		t.Errorf("failed to call before stop handler: expected 2 calls, got %d", calls)
+	}
+}
+
+func TestApplicationStatusLine(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	box := NewBox()
+	app, err := newTestApp(box)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+	}
+	app.screen.(tcell.SimulationScreen).SetSize(80, 24)
+	app.width, app.height = app.screen.Size()
+
+	app.EnableStatusLine(true)
+	app.SetStatusf(LogWarning, "%d items", 3)
+
+	statusText := func() string {
+		var b []rune
+		for x := 1; x < 8; x++ {
+			r, _, _, _ := app.screen.GetContent(x, 23)
+			b = append(b, r)
+		}
+		return string(b)
+	}
+
+	// Draw
+
+	app.draw()
+	if _, _, _, height := box.GetRect(); height != 23 {
+		t.Errorf("failed to reserve status line: expected root height 23, got %d", height)
+	}
+	if text := statusText(); text != "3 items" {
+		t.Errorf("failed to draw status line: expected 3 items, got %s", text)
+	}
+
+	app.ShowTransientStatus("Saved", LogInfo, time.Hour)
+	app.draw()
+	if text := statusText(); text != "Saved  " {
+		t.Errorf("failed to draw transient status: expected Saved, got %s", text)
+	}
+	if text, level := app.GetStatus(); text != "3 items" || level != LogWarning {
+		t.Errorf("failed to get status: expected 3 items, got %s", text)
+	}
+
+	// Redraw
+
+	for len(app.updates) > 0 {
+		<-app.updates
+	}
+	app.SetStatus("Failed", LogError)
+	if len(app.updates) == 0 {
+		t.Errorf("failed to redraw status line: expected queued draw")
+	}
+	app.ShowTransientStatus("", LogInfo, 0) // Hide the transient status.
+	for len(app.updates) > 0 {
+		(<-app.updates)()
+	}
+	_, _, style, _ := app.screen.GetContent(1, 23)
+	if fg, _, _ := style.Decompose(); fg != Styles.FieldErrorColor {
+		t.Errorf("failed to draw status line: expected error color %v, got %v", Styles.FieldErrorColor, fg)
+	}
+
+	// Full update queue
+
+	for len(app.updates) < cap(app.updates) {
+		app.updates <- func() {}
+	}
+	app.SetStatus("Full", LogInfo)
+	app.ShowTransientStatus("Saved", LogInfo, time.Millisecond)
+	deadline := time.After(time.Second)
+	for {
+		app.RLock()
+		transient := app.transientStatus
+		app.RUnlock()
+		if transient == "" {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatal("failed to hide transient status: expected status to be hidden while the update queue is full")
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+func TestApplicationDefer(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	app, err := newTestApp(NewBox())
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+
+	// Defer from handler
+
+	var calls []int
+	app.runHandler(func() {
+		app.Defer(func() {
+			calls = append(calls, 1)
+			app.Defer(func() {
+				calls = append(calls, 3)
+			})
+		})
+		app.Defer(func() {
+			calls = append(calls, 2)
+		})
+		calls = append(calls, 0)
+	})
+	if len(calls) != 4 || calls[0] != 0 || calls[1] != 1 || calls[2] != 2 || calls[3] != 3 {
+		t.Errorf("failed to call deferred functions: expected [0 1 2 3], got %v", calls)
+	}
+
+	// Defer after the deferred functions were called
+
+	var called bool
+	atomic.StoreInt64(&app.busySince, time.Now().UnixNano())
+	app.Defer(func() {
+		called = true
+	})
+	app.finishHandler()
+	if !called {
+		t.Errorf("failed to call deferred function: expected function deferred while finishing a handler to be called")
+	}
+	if since := atomic.LoadInt64(&app.busySince); since != 0 {
+		t.Errorf("failed to finish handler: expected idle main goroutine, got busy since %d", since)
+	}
+}
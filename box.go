@@ -28,6 +28,26 @@ type Box struct {
 	// The box's background color.
 	backgroundColor tcell.Color
 
+	// Whether the background color was set explicitly.
+	backgroundColorSet bool
+
+	// Whether the text color of a widget displaying text was set explicitly.
+	textColorSet bool
+
+	// The colors passed to the items of a container, or ColorUnset.
+	itemBackgroundColor, itemTextColor tcell.Color
+
+	// The colors inherited from the parent container, or ColorUnset.
+	inheritedBackgroundColor, inheritedTextColor tcell.Color
+
+	// The background color and text color replaced by inherited colors,
+	// restored when the inherited colors are unset.
+	backgroundColorDefault, textColorDefault tcell.Color
+
+	// Whether the text color of a widget displaying text was inherited. This
+	// and textColorDefault are guarded by the lock of the widget.
+	textColorInherited bool
+
 	// Whether or not the box's background is transparent.
 	backgroundTransparent bool
 
@@ -68,6 +88,10 @@ type Box struct {
 	// An optional function which is called before the box is drawn.
 	draw func(screen tcell.Screen, x, y, width, height int) (int, int, int, int)
 
+	// Optional functions which are called before and after the content of the
+	// primitive is drawn.
+	preDraw, postDraw func(screen tcell.Screen, x, y, width, height int)
+
 	// An optional capture function which receives a mouse event and returns the
 	// event to be forwarded to the primitive's default mouse event handler (at
 	// least one nil if nothing should be forwarded).
@@ -76,6 +100,12 @@ type Box struct {
 	l sync.RWMutex
 }
 
+// colorInheritor is implemented by primitives which inherit colors from their
+// parent container. All primitives embedding Box implement it.
+type colorInheritor interface {
+	inheritColors(background, text tcell.Color)
+}
+
 // NewBox returns a Box without a border.
 func NewBox() *Box {
 	b := &Box{
@@ -88,6 +118,11 @@ func NewBox() *Box {
 		borderColorFocused: ColorUnset,
 		titleAlign:         AlignCenter,
 		showFocus:          true,
+
+		itemBackgroundColor:      ColorUnset,
+		itemTextColor:            ColorUnset,
+		inheritedBackgroundColor: ColorUnset,
+		inheritedTextColor:       ColorUnset,
 	}
 	b.focus = b
 	b.updateInnerRect()
@@ -213,6 +248,57 @@ func (b *Box) GetDrawFunc() func(screen tcell.Screen, x, y, width, height int) (
 	return b.draw
 }
 
+// SetPreDrawFunc sets a handler which is called after the box (its background
+// and border) has been drawn, but before the primitive draws its content. This
+// allows decorations to be layered under the content without extending the
+// primitive.
+//
+// The handler is provided with the box's inner dimensions, as returned by
+// GetInnerRect().
+func (b *Box) SetPreDrawFunc(handler func(screen tcell.Screen, x, y, width, height int)) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	b.preDraw = handler
+}
+
+// SetPostDrawFunc sets a handler which is called after the primitive has drawn
+// its content. This allows decorations to be layered over the content without
+// extending the primitive.
+//
+// The handler is provided with the box's inner dimensions, as returned by
+// GetInnerRect(). It is called when the primitive is drawn by an Application
+// or by a container primitive. When drawing a primitive directly, call its
+// Draw function followed by DrawPost.
+func (b *Box) SetPostDrawFunc(handler func(screen tcell.Screen, x, y, width, height int)) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	b.postDraw = handler
+}
+
+// DrawPost calls the handler set via SetPostDrawFunc, if any.
+func (b *Box) DrawPost(screen tcell.Screen) {
+	b.l.RLock()
+	handler := b.postDraw
+	visible := b.visible
+	b.l.RUnlock()
+
+	if handler == nil || !visible {
+		return
+	}
+	x, y, width, height := b.GetInnerRect()
+	handler(screen, x, y, width, height)
+}
+
+// drawPrimitive draws the provided primitive and calls its post-draw handler.
+func drawPrimitive(p Primitive, screen tcell.Screen) {
+	p.Draw(screen)
+	if b, ok := p.(boxer); ok {
+		b.getBox().DrawPost(screen)
+	}
+}
+
 // WrapInputHandler wraps an input handler (see InputHandler()) with the
 // functionality to capture input (see SetInputCapture()) before passing it
 // on to the provided (default) input handler.
@@ -326,6 +412,7 @@ func (b *Box) SetBackgroundColor(color tcell.Color) {
 	defer b.l.Unlock()
 
 	b.backgroundColor = color
+	b.backgroundColorSet = true
 }
 
 // GetBackgroundColor returns the box's background color.
@@ -335,6 +422,77 @@ func (b *Box) GetBackgroundColor() tcell.Color {
 	return b.backgroundColor
 }
 
+// SetItemColors sets the background color and text color inherited by the
+// items of a container, such as Flex, Grid or Panels, and by their
+// descendants. Items inherit a color unless it was set explicitly, e.g. via
+// SetBackgroundColor. This allows theming a container without setting the
+// colors of each widget within it. Provide ColorUnset to pass on the colors
+// inherited by the container itself instead. When the colors are unset again,
+// the items restore their own colors.
+func (b *Box) SetItemColors(background, text tcell.Color) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	b.itemBackgroundColor, b.itemTextColor = background, text
+}
+
+// inheritColors applies the colors inherited from the parent container.
+func (b *Box) inheritColors(background, text tcell.Color) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	if !b.backgroundColorSet {
+		inheritColor(&b.backgroundColor, &b.backgroundColorDefault, b.inheritedBackgroundColor != ColorUnset, background)
+	}
+	b.inheritedBackgroundColor, b.inheritedTextColor = background, text
+}
+
+// inheritTextColor applies the text color inherited from the parent container
+// to the provided text color of a widget, unless the text color was set
+// explicitly. It must be called while the widget is locked.
+func (b *Box) inheritTextColor(color *tcell.Color, text tcell.Color) {
+	if b.textColorSet {
+		return
+	}
+
+	inheritColor(color, &b.textColorDefault, b.textColorInherited, text)
+	b.textColorInherited = text != ColorUnset
+}
+
+// inheritColor replaces color with the inherited color, saving the original
+// color in defaultColor, or restores the original color when the inherited
+// color is unset and a color was inherited before.
+func inheritColor(color, defaultColor *tcell.Color, wasInherited bool, inherited tcell.Color) {
+	if inherited == ColorUnset {
+		if wasInherited {
+			*color = *defaultColor
+		}
+		return
+	}
+	if !wasInherited {
+		*defaultColor = *color
+	}
+	*color = inherited
+}
+
+// passColors passes the colors inherited by the items of a container to the
+// provided item.
+func (b *Box) passColors(item Primitive) {
+	b.l.RLock()
+	background, text := b.itemBackgroundColor, b.itemTextColor
+	if background == ColorUnset {
+		background = b.inheritedBackgroundColor
+	}
+	if text == ColorUnset {
+		text = b.inheritedTextColor
+	}
+	b.l.RUnlock()
+
+	if i, ok := item.(colorInheritor); ok {
+		i.inheritColors(background, text)
+	}
+}
+
 // SetBackgroundTransparent sets the flag indicating whether or not the box's
 // background is transparent. The screen is not cleared before drawing the
 // application. Overlaying transparent widgets directly onto the screen may
@@ -428,17 +586,33 @@ func (b *Box) SetTitleAlign(align int) {
 
 // Draw draws this primitive onto the screen.
 func (b *Box) Draw(screen tcell.Screen) {
+	if !b.drawBox(screen) {
+		return
+	}
+
+	// Call pre-draw handler.
+	b.l.RLock()
+	handler := b.preDraw
+	b.l.RUnlock()
+	if handler != nil {
+		x, y, width, height := b.GetInnerRect()
+		handler(screen, x, y, width, height)
+	}
+}
+
+// drawBox draws the box and returns whether it was drawn.
+func (b *Box) drawBox(screen tcell.Screen) bool {
 	b.l.Lock()
 	defer b.l.Unlock()
 
 	// Don't draw anything if the box is hidden
 	if !b.visible {
-		return
+		return false
 	}
 
 	// Don't draw anything if there is no space.
 	if b.width <= 0 || b.height <= 0 {
-		return
+		return false
 	}
 
 	def := tcell.StyleDefault
@@ -512,6 +686,7 @@ func (b *Box) Draw(screen tcell.Screen) {
 	if b.draw != nil {
 		b.innerX, b.innerY, b.innerWidth, b.innerHeight = b.draw(screen, b.x, b.y, b.width, b.height)
 	}
+	return true
 }
 
 // ShowFocus sets the flag indicating whether or not the borders of this
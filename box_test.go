@@ -2,6 +2,8 @@ package cview
 
 import (
 	"testing"
+
+	"github.com/gdamore/tcell/v2"
 )
 
 const (
@@ -54,3 +56,115 @@ func TestBox(t *testing.T) {
 
 	b.Draw(app.screen)
 }
+
+func TestBoxItemColors(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	textView := NewTextView()
+	list := NewList()
+	list.SetBackgroundColor(tcell.ColorRed)
+
+	inner := NewFlex()
+	inner.AddItem(textView, 0, 1, false)
+	inner.AddItem(list, 0, 1, false)
+
+	outer := NewFlex()
+	outer.SetItemColors(tcell.ColorNavy, tcell.ColorYellow)
+	outer.AddItem(inner, 0, 1, false)
+	outer.SetRect(0, 0, 20, 10)
+
+	// Draw
+
+	app, err := newTestApp(outer)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	outer.Draw(app.screen)
+
+	if color := textView.GetBackgroundColor(); color != tcell.ColorNavy {
+		t.Errorf("failed to inherit background color: expected %v, got %v", tcell.ColorNavy, color)
+	}
+	if color := textView.textColor; color != tcell.ColorYellow {
+		t.Errorf("failed to inherit text color: expected %v, got %v", tcell.ColorYellow, color)
+	}
+	if color := list.GetBackgroundColor(); color != tcell.ColorRed {
+		t.Errorf("failed to keep explicit background color: expected %v, got %v", tcell.ColorRed, color)
+	}
+	if color := list.mainTextColor; color != tcell.ColorYellow {
+		t.Errorf("failed to inherit text color: expected %v, got %v", tcell.ColorYellow, color)
+	}
+
+	// Unset
+
+	outer.SetItemColors(ColorUnset, ColorUnset)
+	outer.Draw(app.screen)
+
+	if color := textView.GetBackgroundColor(); color != Styles.PrimitiveBackgroundColor {
+		t.Errorf("failed to restore background color: expected %v, got %v", Styles.PrimitiveBackgroundColor, color)
+	}
+	if color := textView.textColor; color != Styles.PrimaryTextColor {
+		t.Errorf("failed to restore text color: expected %v, got %v", Styles.PrimaryTextColor, color)
+	}
+	if color := list.GetBackgroundColor(); color != tcell.ColorRed {
+		t.Errorf("failed to keep explicit background color: expected %v, got %v", tcell.ColorRed, color)
+	}
+	if color := list.mainTextColor; color != Styles.PrimaryTextColor {
+		t.Errorf("failed to restore text color: expected %v, got %v", Styles.PrimaryTextColor, color)
+	}
+}
+
+func TestBoxDrawHooks(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tv := NewTextView()
+	tv.SetBorder(true)
+	tv.SetText("ab")
+
+	var preRect, postRect [4]int
+	tv.SetPreDrawFunc(func(screen tcell.Screen, x, y, width, height int) {
+		preRect = [4]int{x, y, width, height}
+		screen.SetContent(x, y, 'P', nil, tcell.StyleDefault)
+		screen.SetContent(x+2, y, 'P', nil, tcell.StyleDefault)
+	})
+	tv.SetPostDrawFunc(func(screen tcell.Screen, x, y, width, height int) {
+		postRect = [4]int{x, y, width, height}
+		screen.SetContent(x+1, y, 'Q', nil, tcell.StyleDefault)
+	})
+
+	flex := NewFlex()
+	flex.AddItem(tv, 0, 1, false)
+
+	app, err := newTestApp(flex)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+
+	// Draw
+
+	flex.SetRect(0, 0, 10, 5)
+	drawPrimitive(flex, app.screen)
+
+	expected := [4]int{1, 1, 8, 3}
+	if preRect != expected || postRect != expected {
+		t.Errorf("failed to pass inner rect: expected %v, got %v and %v", expected, preRect, postRect)
+	}
+
+	var line []rune
+	for x := 1; x < 4; x++ {
+		r, _, _, _ := app.screen.GetContent(x, 1)
+		line = append(line, r)
+	}
+	if string(line) != "aQP" {
+		t.Errorf("failed to layer decorations: expected aQP, got %s", string(line))
+	}
+}
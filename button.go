@@ -2,6 +2,7 @@ package cview
 
 import (
 	"sync"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 )
@@ -13,6 +14,14 @@ type Button struct {
 	// The text to be displayed before the input area.
 	label []byte
 
+	// The label as it was set, the mnemonic marker it was parsed with (see
+	// Application.SetMnemonicMarker), and the mnemonic of the label and its
+	// index within the label, if any.
+	markedLabel    []byte
+	mnemonicMarker rune
+	mnemonic       rune
+	mnemonicIndex  int
+
 	// The label color.
 	labelColor tcell.Color
 
@@ -32,6 +41,18 @@ type Button struct {
 	// An optional rune which is drawn after the label when the button is focused.
 	cursorRune rune
 
+	// How long the button is drawn inverted after it is activated via the
+	// keyboard. A value of 0 disables press feedback.
+	pressFeedback time.Duration
+
+	// Whether the button is currently drawn inverted, and the timer which
+	// restores it.
+	pressed    bool
+	pressTimer *time.Timer
+
+	// An optional function which is called when the press feedback ends.
+	released func()
+
 	sync.RWMutex
 }
 
@@ -43,6 +64,7 @@ func NewButton(label string) *Button {
 	return &Button{
 		Box:                    box,
 		label:                  []byte(label),
+		markedLabel:            []byte(label),
 		labelColor:             Styles.PrimaryTextColor,
 		labelColorFocused:      Styles.PrimaryTextColor,
 		cursorRune:             Styles.ButtonCursorRune,
@@ -50,12 +72,27 @@ func NewButton(label string) *Button {
 	}
 }
 
-// SetLabel sets the button text.
+// SetLabel sets the button text. The text may contain color tags and a
+// mnemonic, see Application.SetMnemonicMarker.
 func (b *Button) SetLabel(label string) {
 	b.Lock()
 	defer b.Unlock()
 
-	b.label = []byte(label)
+	b.markedLabel = []byte(label)
+	b.label, b.mnemonic, b.mnemonicIndex = parseMnemonic(b.markedLabel, b.mnemonicMarker)
+}
+
+// setMnemonicMarker parses the mnemonic of the label using the provided
+// marker.
+func (b *Button) setMnemonicMarker(marker rune) {
+	b.Lock()
+	defer b.Unlock()
+
+	if marker == b.mnemonicMarker {
+		return
+	}
+	b.mnemonicMarker = marker
+	b.label, b.mnemonic, b.mnemonicIndex = parseMnemonic(b.markedLabel, marker)
 }
 
 // GetLabel returns the button text.
@@ -72,6 +109,25 @@ func (b *Button) SetLabelColor(color tcell.Color) {
 	defer b.Unlock()
 
 	b.labelColor = color
+	b.textColorSet = true
+}
+
+// inheritColors applies the colors inherited from the parent container.
+func (b *Button) inheritColors(background, text tcell.Color) {
+	b.Box.inheritColors(background, text)
+
+	b.Lock()
+	defer b.Unlock()
+
+	b.inheritTextColor(&b.labelColor, text)
+}
+
+// primaryTextColor returns the color of the primary text.
+func (b *Button) primaryTextColor() tcell.Color {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.labelColor
 }
 
 // SetLabelColorFocused sets the color of the button text when the button is
@@ -100,6 +156,32 @@ func (b *Button) SetBackgroundColorFocused(color tcell.Color) {
 	b.backgroundColorFocused = color
 }
 
+// SetPressFeedback sets how long the button is drawn with inverted colors
+// after it is activated via the keyboard, confirming the press even when the
+// resulting action is asynchronous. A duration of 0, the default, disables
+// press feedback. The button is drawn with its regular colors again the next
+// time it is drawn after the feedback ends. See SetReleasedFunc.
+func (b *Button) SetPressFeedback(duration time.Duration) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.pressFeedback = duration
+}
+
+// SetReleasedFunc sets a handler which is called when the press feedback ends
+// (see SetPressFeedback). The handler is called from a separate goroutine, so
+// it should typically queue a redraw:
+//
+//   button.SetReleasedFunc(func() {
+//       app.QueueUpdateDraw(func() {}, button)
+//   })
+func (b *Button) SetReleasedFunc(handler func()) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.released = handler
+}
+
 // SetSelectedFunc sets a handler which is called when the button was selected.
 func (b *Button) SetSelectedFunc(handler func()) {
 	b.Lock()
@@ -141,6 +223,9 @@ func (b *Button) Draw(screen tcell.Screen) {
 			b.borderColor = borderColor
 		}()
 	}
+	if b.pressed {
+		b.backgroundColor = b.labelColorFocused
+	}
 	b.Unlock()
 	b.Box.Draw(screen)
 	b.Lock()
@@ -154,7 +239,14 @@ func (b *Button) Draw(screen tcell.Screen) {
 		if b.focus.HasFocus() {
 			labelColor = b.labelColorFocused
 		}
-		_, pw := Print(screen, b.label, x, y, width, AlignCenter, labelColor)
+		if b.pressed {
+			labelColor = b.backgroundColorFocused
+		}
+		label := b.label
+		if b.mnemonic != 0 {
+			label = underlineMnemonic(label, b.mnemonicIndex)
+		}
+		_, pw := Print(screen, label, x, y, width, AlignCenter, labelColor)
 
 		// Draw cursor.
 		if b.focus.HasFocus() && b.cursorRune != 0 {
@@ -169,11 +261,69 @@ func (b *Button) Draw(screen tcell.Screen) {
 	}
 }
 
+// GetMnemonic returns the mnemonic of the button label in lower case, or 0 if
+// the label has no mnemonic.
+func (b *Button) GetMnemonic() rune {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.mnemonic
+}
+
+// activateMnemonic focuses and selects the button when the provided rune
+// matches its mnemonic.
+func (b *Button) activateMnemonic(r rune, setFocus func(p Primitive)) bool {
+	b.RLock()
+	mnemonic, selected := b.mnemonic, b.selected
+	b.RUnlock()
+
+	if !matchMnemonic(mnemonic, r) {
+		return false
+	}
+	setFocus(b)
+	b.press()
+	if selected != nil {
+		selected()
+	}
+	return true
+}
+
+// press draws the button inverted for the duration of the press feedback.
+func (b *Button) press() {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.pressFeedback <= 0 {
+		return
+	}
+
+	if b.pressTimer != nil {
+		b.pressTimer.Stop()
+	}
+	b.pressed = true
+	b.pressTimer = time.AfterFunc(b.pressFeedback, b.release)
+}
+
+// release restores the regular colors of the button once the press feedback
+// has elapsed and calls the released handler.
+func (b *Button) release() {
+	b.Lock()
+	b.pressed = false
+	b.pressTimer = nil
+	released := b.released
+	b.Unlock()
+
+	if released != nil {
+		released()
+	}
+}
+
 // InputHandler returns the handler for this primitive.
 func (b *Button) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
 	return b.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
 		// Process key event.
 		if HitShortcut(event, Keys.Select, Keys.Select2) {
+			b.press()
 			if b.selected != nil {
 				b.selected()
 			}
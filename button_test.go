@@ -2,6 +2,9 @@ package cview
 
 import (
 	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
 )
 
 const (
@@ -40,3 +43,62 @@ func TestButton(t *testing.T) {
 
 	b.Draw(app.screen)
 }
+
+func TestButtonPressFeedback(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	b := NewButton(testButtonLabelA)
+	b.SetPressFeedback(10 * time.Millisecond)
+
+	var selected bool
+	b.SetSelectedFunc(func() {
+		selected = true
+	})
+	released := make(chan struct{})
+	b.SetReleasedFunc(func() {
+		close(released)
+	})
+
+	app, err := newTestApp(b)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	b.SetRect(0, 0, 20, 1)
+	b.Draw(app.screen)
+
+	_, _, style, _ := app.screen.GetContent(0, 0)
+	_, background, _ := style.Decompose()
+
+	// Press
+
+	b.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	if !selected {
+		t.Errorf("failed to select button: expected selected handler to be called")
+	}
+
+	b.Draw(app.screen)
+	_, _, style, _ = app.screen.GetContent(0, 0)
+	if _, bg, _ := style.Decompose(); bg != Styles.PrimaryTextColor {
+		t.Errorf("failed to draw press feedback: expected background %v, got %v", Styles.PrimaryTextColor, bg)
+	}
+
+	// Release
+
+	select {
+	case <-released:
+	case <-time.After(time.Second):
+		t.Fatal("failed to release press feedback: expected released handler to be called")
+	}
+	b.Draw(app.screen)
+	_, _, style, _ = app.screen.GetContent(0, 0)
+	if _, bg, _ := style.Decompose(); bg != background {
+		t.Errorf("failed to release press feedback: expected background %v, got %v", background, bg)
+	}
+}
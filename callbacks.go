@@ -0,0 +1,38 @@
+package cview
+
+// callbacks collects the callbacks of a primitive while its lock is held, so
+// that they may be called once the lock is released. Primitives never call
+// callbacks while holding their lock, as callbacks commonly call methods of
+// the primitive which would otherwise deadlock. See the package documentation
+// for details.
+//
+// Callbacks are typically collected by an input or mouse handler:
+//
+//   var calls callbacks
+//   defer calls.run()
+//
+//   l.Lock()
+//   defer l.Unlock()
+//
+//   calls.add(l.done)
+//
+// As deferred functions run in reverse order, the lock is released before the
+// callbacks are called.
+type callbacks []func()
+
+// add adds a callback. Nil callbacks are ignored.
+func (c *callbacks) add(f func()) {
+	if f == nil {
+		return
+	}
+	*c = append(*c, f)
+}
+
+// run calls the collected callbacks in the order they were added.
+func (c *callbacks) run() {
+	calls := *c
+	*c = nil
+	for _, f := range calls {
+		f()
+	}
+}
@@ -2,24 +2,62 @@ package cview
 
 import (
 	"sync"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 )
 
+// CheckBoxState represents the state of a CheckBox.
+type CheckBoxState int
+
+// Check box states.
+const (
+	CheckBoxUnchecked CheckBoxState = iota
+	CheckBoxChecked
+	CheckBoxPartial
+)
+
+// LabelPosition represents the position of a label relative to its field.
+type LabelPosition int
+
+// Label positions.
+const (
+	LabelLeft LabelPosition = iota
+	LabelRight
+)
+
 // CheckBox implements a simple box for boolean values which can be checked and
-// unchecked.
+// unchecked. A check box may also be partially checked, e.g. when it is used to
+// select all items of a group of which only some are selected.
 type CheckBox struct {
 	*Box
 
-	// Whether or not this box is checked.
-	checked bool
+	// The state of this box.
+	state CheckBoxState
+
+	// The states cycled through when the user toggles the checkbox.
+	cycle []CheckBoxState
 
 	// The text to be displayed before the checkbox.
 	label []byte
 
+	// The position of the label relative to the checkbox.
+	labelPosition LabelPosition
+
 	// The text to be displayed after the checkbox.
 	message []byte
 
+	// The label and message as they were set, and the mnemonic marker they
+	// were parsed with (see Application.SetMnemonicMarker).
+	markedLabel, markedMessage []byte
+	mnemonicMarker             rune
+
+	// The mnemonic of the label or message, whether it is part of the message
+	// and its index within the label or message.
+	mnemonic          rune
+	mnemonicInMessage bool
+	mnemonicIndex     int
+
 	// The screen width of the label area. A value of 0 means use the width of
 	// the label text.
 	labelWidth int
@@ -46,6 +84,10 @@ type CheckBox struct {
 	// state of this checkbox.
 	changed func(checked bool)
 
+	// An optional function which is called when the user changes the state of
+	// this checkbox.
+	stateChanged func(state CheckBoxState)
+
 	// An optional function which is called when the user indicated that they
 	// are done entering text. The key which was pressed is provided (tab,
 	// shift-tab, or escape).
@@ -58,9 +100,28 @@ type CheckBox struct {
 	// The rune to show when the checkbox is checked
 	checkedRune rune
 
+	// The rune to show when the checkbox is partially checked
+	partialRune rune
+
 	// An optional rune to show within the checkbox when it is focused
 	cursorRune rune
 
+	// How long the checkbox is drawn inverted after it is toggled via the
+	// keyboard. A value of 0 disables press feedback.
+	pressFeedback time.Duration
+
+	// Whether the checkbox is currently drawn inverted, and the timer which
+	// restores it.
+	pressed    bool
+	pressTimer *time.Timer
+
+	// An optional function which is called when the press feedback ends.
+	released func()
+
+	// The validation state and its message.
+	fieldState        FieldState
+	fieldStateMessage string
+
 	sync.RWMutex
 }
 
@@ -72,7 +133,9 @@ func NewCheckBox() *CheckBox {
 		fieldBackgroundColor:        Styles.MoreContrastBackgroundColor,
 		fieldBackgroundColorFocused: Styles.ContrastBackgroundColor,
 		fieldTextColor:              Styles.PrimaryTextColor,
+		cycle:                       []CheckBoxState{CheckBoxUnchecked, CheckBoxChecked},
 		checkedRune:                 Styles.CheckBoxCheckedRune,
+		partialRune:                 Styles.CheckBoxPartialRune,
 		cursorRune:                  Styles.CheckBoxCursorRune,
 		labelColorFocused:           ColorUnset,
 		fieldTextColorFocused:       ColorUnset,
@@ -84,7 +147,44 @@ func (c *CheckBox) SetChecked(checked bool) {
 	c.Lock()
 	defer c.Unlock()
 
-	c.checked = checked
+	if checked {
+		c.state = CheckBoxChecked
+	} else {
+		c.state = CheckBoxUnchecked
+	}
+}
+
+// SetState sets the state of the checkbox, one of CheckBoxUnchecked,
+// CheckBoxChecked or CheckBoxPartial.
+func (c *CheckBox) SetState(state CheckBoxState) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.state = state
+}
+
+// GetState returns the state of the checkbox.
+func (c *CheckBox) GetState() CheckBoxState {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.state
+}
+
+// SetCycle sets the states cycled through when the user toggles the checkbox.
+// The default is CheckBoxUnchecked and CheckBoxChecked, which means a
+// partially checked box may only be set via SetState. When the current state
+// is not one of the provided states, the checkbox is toggled to the first
+// provided state. For example, a partially checked box is checked when it is
+// toggled after calling SetCycle(CheckBoxChecked, CheckBoxUnchecked).
+func (c *CheckBox) SetCycle(states ...CheckBoxState) {
+	c.Lock()
+	defer c.Unlock()
+
+	if len(states) == 0 {
+		states = []CheckBoxState{CheckBoxUnchecked, CheckBoxChecked}
+	}
+	c.cycle = states
 }
 
 // SetCheckedRune sets the rune to show when the checkbox is checked.
@@ -95,6 +195,14 @@ func (c *CheckBox) SetCheckedRune(rune rune) {
 	c.checkedRune = rune
 }
 
+// SetPartialRune sets the rune to show when the checkbox is partially checked.
+func (c *CheckBox) SetPartialRune(rune rune) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.partialRune = rune
+}
+
 // SetCursorRune sets the rune to show within the checkbox when it is focused.
 func (c *CheckBox) SetCursorRune(rune rune) {
 	c.Lock()
@@ -108,15 +216,17 @@ func (c *CheckBox) IsChecked() bool {
 	c.RLock()
 	defer c.RUnlock()
 
-	return c.checked
+	return c.state == CheckBoxChecked
 }
 
-// SetLabel sets the text to be displayed before the input area.
+// SetLabel sets the text to be displayed before the input area. The text may
+// contain color tags and a mnemonic, see Application.SetMnemonicMarker.
 func (c *CheckBox) SetLabel(label string) {
 	c.Lock()
 	defer c.Unlock()
 
-	c.label = []byte(label)
+	c.markedLabel = []byte(label)
+	c.parseMnemonic()
 }
 
 // GetLabel returns the text to be displayed before the input area.
@@ -127,12 +237,43 @@ func (c *CheckBox) GetLabel() string {
 	return string(c.label)
 }
 
-// SetMessage sets the text to be displayed after the checkbox
+// SetMessage sets the text to be displayed after the checkbox. The text may
+// contain color tags and a mnemonic, see Application.SetMnemonicMarker.
 func (c *CheckBox) SetMessage(message string) {
 	c.Lock()
 	defer c.Unlock()
 
-	c.message = []byte(message)
+	c.markedMessage = []byte(message)
+	c.parseMnemonic()
+}
+
+// setMnemonicMarker parses the mnemonic of the label or message using the
+// provided marker.
+func (c *CheckBox) setMnemonicMarker(marker rune) {
+	c.Lock()
+	defer c.Unlock()
+
+	if marker == c.mnemonicMarker {
+		return
+	}
+	c.mnemonicMarker = marker
+	c.parseMnemonic()
+}
+
+// parseMnemonic parses the mnemonic of the label or message. The mnemonic of
+// the label takes precedence. The lock must be held.
+func (c *CheckBox) parseMnemonic() {
+	var mnemonic rune
+	var index int
+	c.mnemonic = 0
+	c.label, mnemonic, index = parseMnemonic(c.markedLabel, c.mnemonicMarker)
+	if mnemonic != 0 {
+		c.mnemonic, c.mnemonicInMessage, c.mnemonicIndex = mnemonic, false, index
+	}
+	c.message, mnemonic, index = parseMnemonic(c.markedMessage, c.mnemonicMarker)
+	if mnemonic != 0 && c.mnemonic == 0 {
+		c.mnemonic, c.mnemonicInMessage, c.mnemonicIndex = mnemonic, true, index
+	}
 }
 
 // GetMessage returns the text to be displayed after the checkbox
@@ -152,12 +293,52 @@ func (c *CheckBox) SetLabelWidth(width int) {
 	c.labelWidth = width
 }
 
+// SetLabelPosition sets the position of the label relative to the checkbox.
+// When set to LabelLeft (the default), the label is displayed before the
+// checkbox. When set to LabelRight, the checkbox is displayed first, followed
+// by the label and the message, like the checkboxes of most graphical user
+// interfaces.
+func (c *CheckBox) SetLabelPosition(position LabelPosition) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.labelPosition = position
+}
+
+// GetLabelPosition returns the position of the label relative to the
+// checkbox.
+func (c *CheckBox) GetLabelPosition() LabelPosition {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.labelPosition
+}
+
 // SetLabelColor sets the color of the label.
 func (c *CheckBox) SetLabelColor(color tcell.Color) {
 	c.Lock()
 	defer c.Unlock()
 
 	c.labelColor = color
+	c.textColorSet = true
+}
+
+// inheritColors applies the colors inherited from the parent container.
+func (c *CheckBox) inheritColors(background, text tcell.Color) {
+	c.Box.inheritColors(background, text)
+
+	c.Lock()
+	defer c.Unlock()
+
+	c.inheritTextColor(&c.labelColor, text)
+}
+
+// primaryTextColor returns the color of the primary text.
+func (c *CheckBox) primaryTextColor() tcell.Color {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.labelColor
 }
 
 // SetLabelColorFocused sets the color of the label when focused.
@@ -200,6 +381,25 @@ func (c *CheckBox) SetFieldTextColorFocused(color tcell.Color) {
 	c.fieldTextColorFocused = color
 }
 
+// SetFieldState sets the validation state of the checkbox and a message
+// describing it. Warnings and errors are drawn using the FieldWarningColor and
+// FieldErrorColor of the theme. Within a Form, the message is shown below the
+// checkbox.
+func (c *CheckBox) SetFieldState(state FieldState, message string) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.fieldState, c.fieldStateMessage = state, message
+}
+
+// GetFieldState returns the validation state of the checkbox and its message.
+func (c *CheckBox) GetFieldState() (state FieldState, message string) {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.fieldState, c.fieldStateMessage
+}
+
 // GetFieldHeight returns the height of the field.
 func (c *CheckBox) GetFieldHeight() int {
 	return 1
@@ -214,7 +414,7 @@ func (c *CheckBox) GetFieldWidth() int {
 		return 1
 	}
 
-	return 2 + len(c.message)
+	return 2 + TaggedTextWidth(c.message)
 }
 
 // SetChangedFunc sets a handler which is called when the checked state of this
@@ -227,6 +427,96 @@ func (c *CheckBox) SetChangedFunc(handler func(checked bool)) {
 	c.changed = handler
 }
 
+// SetStateChangedFunc sets a handler which is called when the state of this
+// checkbox was changed by the user. The handler function receives the new
+// state. Unlike the handler set via SetChangedFunc, this handler is able to
+// distinguish between unchecked and partially checked boxes.
+func (c *CheckBox) SetStateChangedFunc(handler func(state CheckBoxState)) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.stateChanged = handler
+}
+
+// toggle changes the state of the checkbox to the next state of the cycle
+// and calls the changed handlers.
+func (c *CheckBox) toggle() {
+	c.Lock()
+	next := c.cycle[0]
+	for i, state := range c.cycle {
+		if state == c.state {
+			next = c.cycle[(i+1)%len(c.cycle)]
+			break
+		}
+	}
+	c.state = next
+	changed, stateChanged := c.changed, c.stateChanged
+	c.Unlock()
+
+	if changed != nil {
+		changed(next == CheckBoxChecked)
+	}
+	if stateChanged != nil {
+		stateChanged(next)
+	}
+}
+
+// SetPressFeedback sets how long the checkbox is drawn with inverted colors
+// after it is toggled via the keyboard, confirming the press even when the
+// resulting action is asynchronous. A duration of 0, the default, disables
+// press feedback. The checkbox is drawn with its regular colors again the next
+// time it is drawn after the feedback ends. See SetReleasedFunc.
+func (c *CheckBox) SetPressFeedback(duration time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.pressFeedback = duration
+}
+
+// SetReleasedFunc sets a handler which is called when the press feedback ends
+// (see SetPressFeedback). The handler is called from a separate goroutine, so
+// it should typically queue a redraw:
+//
+//   checkBox.SetReleasedFunc(func() {
+//       app.QueueUpdateDraw(func() {}, checkBox)
+//   })
+func (c *CheckBox) SetReleasedFunc(handler func()) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.released = handler
+}
+
+// press draws the checkbox inverted for the duration of the press feedback.
+func (c *CheckBox) press() {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.pressFeedback <= 0 {
+		return
+	}
+
+	if c.pressTimer != nil {
+		c.pressTimer.Stop()
+	}
+	c.pressed = true
+	c.pressTimer = time.AfterFunc(c.pressFeedback, c.release)
+}
+
+// release restores the regular colors of the checkbox once the press
+// feedback has elapsed and calls the released handler.
+func (c *CheckBox) release() {
+	c.Lock()
+	c.pressed = false
+	c.pressTimer = nil
+	released := c.released
+	c.Unlock()
+
+	if released != nil {
+		released()
+	}
+}
+
 // SetDoneFunc sets a handler which is called when the user is done using the
 // checkbox. The callback function is provided with the key that was pressed,
 // which is one of the following:
@@ -249,13 +539,62 @@ func (c *CheckBox) SetFinishedFunc(handler func(key tcell.Key)) {
 	c.finished = handler
 }
 
+// layout returns the horizontal positions of the label, the checkbox and the
+// message within the provided area, as well as the width of the label and
+// the position where the message ends.
+func (c *CheckBox) layout(x, width int) (labelX, labelWidth, boxX, messageX, end int) {
+	rightLimit := x + width
+
+	labelWidth = c.labelWidth
+	if labelWidth <= 0 {
+		labelWidth = TaggedTextWidth(c.label)
+	}
+
+	if c.labelPosition == LabelRight {
+		boxX = x
+		labelX = x + 4
+		if len(c.label) == 0 {
+			labelX = x + 3
+		}
+		messageX = labelX + labelWidth
+		if len(c.label) > 0 && len(c.message) > 0 {
+			messageX++
+		}
+	} else {
+		labelX = x
+		boxX = x + labelWidth
+		messageX = boxX + 4
+	}
+	if labelWidth > rightLimit-labelX {
+		labelWidth = rightLimit - labelX
+	}
+	if labelWidth < 0 {
+		labelWidth = 0
+	}
+
+	end = messageX + TaggedTextWidth(c.message)
+	if len(c.message) == 0 {
+		end = boxX + 3
+		if labelX+labelWidth > end {
+			end = labelX + labelWidth
+		}
+	}
+	if end > rightLimit {
+		end = rightLimit
+	}
+	return
+}
+
 // Draw draws this primitive onto the screen.
 func (c *CheckBox) Draw(screen tcell.Screen) {
 	if !c.GetVisible() {
 		return
 	}
 
-	c.Box.Draw(screen)
+	c.RLock()
+	fieldState := c.fieldState
+	c.RUnlock()
+	drawFieldStateBox(c.Box, fieldState, screen)
 
 	c.Lock()
 	defer c.Unlock()
@@ -278,6 +617,12 @@ func (c *CheckBox) Draw(screen tcell.Screen) {
 		}
 	}
 
+	if c.pressed {
+		fieldBackgroundColor, fieldTextColor = fieldTextColor, fieldBackgroundColor
+	}
+
+	fieldBackgroundColor = fieldStateColor(fieldState, fieldBackgroundColor)
+
 	// Prepare
 	x, y, width, height := c.GetInnerRect()
 	rightLimit := x + width
@@ -285,49 +630,78 @@ func (c *CheckBox) Draw(screen tcell.Screen) {
 		return
 	}
 
-	// Draw label.
-	if c.labelWidth > 0 {
-		labelWidth := c.labelWidth
-		if labelWidth > rightLimit-x {
-			labelWidth = rightLimit - x
+	labelX, labelWidth, boxX, messageX, _ := c.layout(x, width)
+
+	label, message := c.label, c.message
+	if c.mnemonic != 0 {
+		if c.mnemonicInMessage {
+			message = underlineMnemonic(message, c.mnemonicIndex)
+		} else {
+			label = underlineMnemonic(label, c.mnemonicIndex)
 		}
-		Print(screen, c.label, x, y, labelWidth, AlignLeft, labelColor)
-		x += labelWidth
-	} else {
-		_, drawnWidth := Print(screen, c.label, x, y, rightLimit-x, AlignLeft, labelColor)
-		x += drawnWidth
+	}
+
+	// Draw label.
+	if labelWidth > 0 {
+		Print(screen, label, labelX, y, labelWidth, AlignLeft, labelColor)
 	}
 
 	// Draw checkbox.
 	fieldStyle := tcell.StyleDefault.Background(fieldBackgroundColor).Foreground(fieldTextColor)
 
-	checkedRune := c.checkedRune
-	if !c.checked {
+	var checkedRune rune
+	switch c.state {
+	case CheckBoxChecked:
+		checkedRune = c.checkedRune
+	case CheckBoxPartial:
+		checkedRune = c.partialRune
+	default:
 		checkedRune = ' '
 	}
 	rightRune := ' '
 	if c.cursorRune != 0 && hasFocus {
 		rightRune = c.cursorRune
 	}
-	screen.SetContent(x, y, ' ', nil, fieldStyle)
-	screen.SetContent(x+1, y, checkedRune, nil, fieldStyle)
-	screen.SetContent(x+2, y, rightRune, nil, fieldStyle)
+	screen.SetContent(boxX, y, ' ', nil, fieldStyle)
+	screen.SetContent(boxX+1, y, checkedRune, nil, fieldStyle)
+	screen.SetContent(boxX+2, y, rightRune, nil, fieldStyle)
 
-	if len(c.message) > 0 {
-		Print(screen, c.message, x+4, y, len(c.message), AlignLeft, labelColor)
+	if len(c.message) > 0 && messageX < rightLimit {
+		Print(screen, message, messageX, y, rightLimit-messageX, AlignLeft, labelColor)
 	}
 }
 
+// GetMnemonic returns the mnemonic of the checkbox label or message in lower
+// case, or 0 if neither has a mnemonic.
+func (c *CheckBox) GetMnemonic() rune {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.mnemonic
+}
+
+// activateMnemonic focuses and toggles the checkbox when the provided rune
+// matches its mnemonic.
+func (c *CheckBox) activateMnemonic(r rune, setFocus func(p Primitive)) bool {
+	c.RLock()
+	mnemonic := c.mnemonic
+	c.RUnlock()
+
+	if !matchMnemonic(mnemonic, r) {
+		return false
+	}
+	setFocus(c)
+	c.press()
+	c.toggle()
+	return true
+}
+
 // InputHandler returns the handler for this primitive.
 func (c *CheckBox) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
 	return c.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
 		if HitShortcut(event, Keys.Select, Keys.Select2) {
-			c.Lock()
-			c.checked = !c.checked
-			c.Unlock()
-			if c.changed != nil {
-				c.changed(c.checked)
-			}
+			c.press()
+			c.toggle()
 		} else if HitShortcut(event, Keys.Cancel, Keys.MovePreviousField, Keys.MoveNextField) {
 			if c.done != nil {
 				c.done(event.Key())
@@ -343,17 +717,20 @@ func (c *CheckBox) InputHandler() func(event *tcell.EventKey, setFocus func(p Pr
 func (c *CheckBox) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return c.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 		x, y := event.Position()
-		_, rectY, _, _ := c.GetInnerRect()
+		rectX, rectY, width, _ := c.GetInnerRect()
 		if !c.InRect(x, y) {
 			return false, nil
 		}
 
-		// Process mouse event.
-		if action == MouseLeftClick && y == rectY {
+		// Process mouse event. Clicking the label, the checkbox or the message
+		// toggles the checkbox.
+		if action == MouseLeftClick {
 			setFocus(c)
-			c.checked = !c.checked
-			if c.changed != nil {
-				c.changed(c.checked)
+			c.RLock()
+			labelX, _, _, _, end := c.layout(rectX, width)
+			c.RUnlock()
+			if y == rectY && x >= labelX && x < end {
+				c.toggle()
 			}
 			consumed = true
 		}
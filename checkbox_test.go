@@ -2,6 +2,9 @@ package cview
 
 import (
 	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
 )
 
 const (
@@ -54,3 +57,181 @@ func TestCheckBox(t *testing.T) {
 
 	c.Draw(app.screen)
 }
+
+func TestCheckBoxState(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	c := NewCheckBox()
+
+	var states []CheckBoxState
+	c.SetStateChangedFunc(func(state CheckBoxState) {
+		states = append(states, state)
+	})
+
+	toggle := func() {
+		c.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	}
+
+	// Set partial
+
+	c.SetState(CheckBoxPartial)
+	if c.GetState() != CheckBoxPartial {
+		t.Errorf("failed to update CheckBox state: incorrect state: expected partial, got %d", c.GetState())
+	} else if c.IsChecked() {
+		t.Errorf("failed to update CheckBox state: incorrect state: expected partial, got checked")
+	}
+
+	// Toggle
+
+	c.SetCycle(CheckBoxChecked, CheckBoxUnchecked)
+	toggle()
+	toggle()
+	toggle()
+
+	expected := []CheckBoxState{CheckBoxChecked, CheckBoxUnchecked, CheckBoxChecked}
+	if len(states) != len(expected) {
+		t.Errorf("failed to toggle CheckBox: expected %v, got %v", expected, states)
+		return
+	}
+	for i := range expected {
+		if states[i] != expected[i] {
+			t.Errorf("failed to toggle CheckBox: expected %v, got %v", expected, states)
+		}
+	}
+
+	// Toggle through all states
+
+	c.SetState(CheckBoxUnchecked)
+	c.SetCycle(CheckBoxUnchecked, CheckBoxPartial, CheckBoxChecked)
+	toggle()
+	if c.GetState() != CheckBoxPartial {
+		t.Errorf("failed to toggle CheckBox: incorrect state: expected partial, got %d", c.GetState())
+	}
+}
+
+func TestCheckBoxLabelClick(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	c := NewCheckBox()
+	c.SetLabel("Label")
+	c.SetMessage("Message")
+	c.SetRect(0, 0, 40, 1)
+
+	click := func(x int) {
+		c.MouseHandler()(MouseLeftClick, tcell.NewEventMouse(x, 0, tcell.Button1, 0), func(p Primitive) {})
+	}
+
+	// Click label
+
+	click(2)
+	if !c.IsChecked() {
+		t.Errorf("failed to toggle CheckBox by clicking label: expected checked, got unchecked")
+	}
+
+	// Click message
+
+	click(12)
+	if c.IsChecked() {
+		t.Errorf("failed to toggle CheckBox by clicking message: expected unchecked, got checked")
+	}
+
+	// Click empty area
+
+	click(30)
+	if c.IsChecked() {
+		t.Errorf("failed to ignore click after message: expected unchecked, got checked")
+	}
+
+	// Label position
+
+	app, err := newTestApp(c)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	c.SetLabelPosition(LabelRight)
+	c.SetCursorRune(0)
+	c.SetChecked(true)
+	c.Draw(app.screen)
+
+	var line []rune
+	for x := 0; x < 17; x++ {
+		r, _, _, _ := app.screen.GetContent(x, 0)
+		line = append(line, r)
+	}
+	if expected := " " + string(Styles.CheckBoxCheckedRune) + "  Label Message"; string(line) != expected {
+		t.Errorf("failed to position label: expected %q, got %q", expected, string(line))
+	}
+}
+
+func TestCheckBoxPressFeedback(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	c := NewCheckBox()
+	c.SetPressFeedback(10 * time.Millisecond)
+	released := make(chan struct{})
+	c.SetReleasedFunc(func() {
+		close(released)
+	})
+
+	app, err := newTestApp(c)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	c.SetRect(0, 0, 5, 1)
+	c.Draw(app.screen)
+
+	fieldBackground := func() tcell.Color {
+		_, _, style, _ := app.screen.GetContent(0, 0)
+		_, bg, _ := style.Decompose()
+		return bg
+	}
+	background := fieldBackground()
+
+	// Press
+
+	c.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	if !c.IsChecked() {
+		t.Errorf("failed to toggle CheckBox: expected checked, got unchecked")
+	}
+
+	c.Draw(app.screen)
+	if bg := fieldBackground(); bg != Styles.PrimaryTextColor {
+		t.Errorf("failed to draw press feedback: expected background %v, got %v", Styles.PrimaryTextColor, bg)
+	}
+
+	// Release
+
+	select {
+	case <-released:
+	case <-time.After(time.Second):
+		t.Fatal("failed to release press feedback: expected released handler to be called")
+	}
+	c.Draw(app.screen)
+	if bg := fieldBackground(); bg != background {
+		t.Errorf("failed to release press feedback: expected background %v, got %v", background, bg)
+	}
+
+	// Disabled by default
+
+	c = NewCheckBox()
+	c.SetRect(0, 0, 5, 1)
+	c.Draw(app.screen)
+	background = fieldBackground()
+	c.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	c.Draw(app.screen)
+	if bg := fieldBackground(); bg != background {
+		t.Errorf("failed to disable press feedback by default: expected background %v, got %v", background, bg)
+	}
+}
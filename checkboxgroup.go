@@ -0,0 +1,514 @@
+package cview
+
+import (
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// checkBoxGroupOption represents a single option of a CheckBoxGroup.
+type checkBoxGroupOption struct {
+	label    []byte
+	checked  bool
+	disabled bool
+}
+
+// CheckBoxGroup implements a group of checkboxes which is displayed as a
+// single form item, one option per line. This is typically used for questions
+// where more than one answer may apply.
+//
+// The focused option is changed via the up and down arrow keys and toggled via
+// the Enter key or the space bar. Disabled options may not be toggled.
+type CheckBoxGroup struct {
+	*Box
+
+	// The options of the group.
+	options []*checkBoxGroupOption
+
+	// The index of the focused option.
+	currentOption int
+
+	// The text to be displayed before the options.
+	label []byte
+
+	// The screen width of the label area. A value of 0 means use the width of
+	// the label text.
+	labelWidth int
+
+	// The label color.
+	labelColor tcell.Color
+
+	// The label color when focused.
+	labelColorFocused tcell.Color
+
+	// The background color of the input area.
+	fieldBackgroundColor tcell.Color
+
+	// The background color of the input area when focused.
+	fieldBackgroundColorFocused tcell.Color
+
+	// The text color of the input area.
+	fieldTextColor tcell.Color
+
+	// The text color of the input area when focused.
+	fieldTextColorFocused tcell.Color
+
+	// The color of disabled options.
+	disabledColor tcell.Color
+
+	// The rune to show when an option is checked.
+	checkedRune rune
+
+	// An optional rune to show within the focused option.
+	cursorRune rune
+
+	// An optional function which is called when the user changes the checked
+	// state of an option.
+	changed func(index int, checked bool)
+
+	// An optional function which is called when the user indicated that they
+	// are done selecting options. The key which was pressed is provided (tab,
+	// shift-tab, or escape).
+	done func(tcell.Key)
+
+	// A callback function set by the Form class and called when the user leaves
+	// this form item.
+	finished func(tcell.Key)
+
+	sync.RWMutex
+}
+
+// NewCheckBoxGroup returns a new checkbox group.
+func NewCheckBoxGroup() *CheckBoxGroup {
+	return &CheckBoxGroup{
+		Box:                         NewBox(),
+		labelColor:                  Styles.SecondaryTextColor,
+		fieldBackgroundColor:        Styles.MoreContrastBackgroundColor,
+		fieldBackgroundColorFocused: Styles.ContrastBackgroundColor,
+		fieldTextColor:              Styles.PrimaryTextColor,
+		disabledColor:               tcell.ColorDarkSlateGray.TrueColor(),
+		checkedRune:                 Styles.CheckBoxCheckedRune,
+		cursorRune:                  Styles.CheckBoxCursorRune,
+		labelColorFocused:           ColorUnset,
+		fieldTextColorFocused:       ColorUnset,
+	}
+}
+
+// AddOption adds an option with the provided label and initial state.
+func (c *CheckBoxGroup) AddOption(label string, checked bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.options = append(c.options, &checkBoxGroupOption{
+		label:   []byte(label),
+		checked: checked,
+	})
+}
+
+// GetOptionCount returns the number of options.
+func (c *CheckBoxGroup) GetOptionCount() int {
+	c.RLock()
+	defer c.RUnlock()
+
+	return len(c.options)
+}
+
+// SetChecked sets the state of the option at the provided index.
+func (c *CheckBoxGroup) SetChecked(index int, checked bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	if index < 0 || index >= len(c.options) {
+		return
+	}
+	c.options[index].checked = checked
+}
+
+// IsChecked returns whether the option at the provided index is checked.
+func (c *CheckBoxGroup) IsChecked(index int) bool {
+	c.RLock()
+	defer c.RUnlock()
+
+	if index < 0 || index >= len(c.options) {
+		return false
+	}
+	return c.options[index].checked
+}
+
+// GetChecked returns the labels of all checked options.
+func (c *CheckBoxGroup) GetChecked() []string {
+	c.RLock()
+	defer c.RUnlock()
+
+	var checked []string
+	for _, option := range c.options {
+		if option.checked {
+			checked = append(checked, string(option.label))
+		}
+	}
+	return checked
+}
+
+// SetOptionEnabled sets whether the option at the provided index may be
+// toggled by the user. Disabled options are drawn using a dimmed color.
+func (c *CheckBoxGroup) SetOptionEnabled(index int, enabled bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	if index < 0 || index >= len(c.options) {
+		return
+	}
+	c.options[index].disabled = !enabled
+}
+
+// IsOptionEnabled returns whether the option at the provided index may be
+// toggled by the user.
+func (c *CheckBoxGroup) IsOptionEnabled(index int) bool {
+	c.RLock()
+	defer c.RUnlock()
+
+	if index < 0 || index >= len(c.options) {
+		return false
+	}
+	return !c.options[index].disabled
+}
+
+// SetCheckedRune sets the rune to show when an option is checked.
+func (c *CheckBoxGroup) SetCheckedRune(rune rune) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.checkedRune = rune
+}
+
+// SetCursorRune sets the rune to show within the focused option.
+func (c *CheckBoxGroup) SetCursorRune(rune rune) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.cursorRune = rune
+}
+
+// SetLabel sets the text to be displayed before the options.
+func (c *CheckBoxGroup) SetLabel(label string) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.label = []byte(label)
+}
+
+// GetLabel returns the text to be displayed before the options.
+func (c *CheckBoxGroup) GetLabel() string {
+	c.RLock()
+	defer c.RUnlock()
+
+	return string(c.label)
+}
+
+// SetLabelWidth sets the screen width of the label. A value of 0 will cause the
+// primitive to use the width of the label string.
+func (c *CheckBoxGroup) SetLabelWidth(width int) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.labelWidth = width
+}
+
+// SetLabelColor sets the color of the label.
+func (c *CheckBoxGroup) SetLabelColor(color tcell.Color) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.labelColor = color
+	c.textColorSet = true
+}
+
+// inheritColors applies the colors inherited from the parent container.
+func (c *CheckBoxGroup) inheritColors(background, text tcell.Color) {
+	c.Box.inheritColors(background, text)
+
+	c.Lock()
+	defer c.Unlock()
+
+	c.inheritTextColor(&c.labelColor, text)
+}
+
+// primaryTextColor returns the color of the primary text.
+func (c *CheckBoxGroup) primaryTextColor() tcell.Color {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.labelColor
+}
+
+// SetLabelColorFocused sets the color of the label when focused.
+func (c *CheckBoxGroup) SetLabelColorFocused(color tcell.Color) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.labelColorFocused = color
+}
+
+// SetFieldBackgroundColor sets the background color of the input area.
+func (c *CheckBoxGroup) SetFieldBackgroundColor(color tcell.Color) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.fieldBackgroundColor = color
+}
+
+// SetFieldBackgroundColorFocused sets the background color of the input area
+// when focused.
+func (c *CheckBoxGroup) SetFieldBackgroundColorFocused(color tcell.Color) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.fieldBackgroundColorFocused = color
+}
+
+// SetFieldTextColor sets the text color of the input area.
+func (c *CheckBoxGroup) SetFieldTextColor(color tcell.Color) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.fieldTextColor = color
+}
+
+// SetFieldTextColorFocused sets the text color of the input area when focused.
+func (c *CheckBoxGroup) SetFieldTextColorFocused(color tcell.Color) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.fieldTextColorFocused = color
+}
+
+// SetDisabledColor sets the color of disabled options.
+func (c *CheckBoxGroup) SetDisabledColor(color tcell.Color) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.disabledColor = color
+}
+
+// GetFieldHeight returns the height of the field.
+func (c *CheckBoxGroup) GetFieldHeight() int {
+	c.RLock()
+	defer c.RUnlock()
+
+	if len(c.options) == 0 {
+		return 1
+	}
+	return len(c.options)
+}
+
+// GetFieldWidth returns this primitive's field width.
+func (c *CheckBoxGroup) GetFieldWidth() int {
+	c.RLock()
+	defer c.RUnlock()
+
+	var width int
+	for _, option := range c.options {
+		if w := TaggedStringWidth(string(option.label)); w > width {
+			width = w
+		}
+	}
+	if width == 0 {
+		return 3
+	}
+	return 4 + width
+}
+
+// SetChangedFunc sets a handler which is called when the checked state of an
+// option was changed by the user. The handler function receives the index of
+// the option and its new state.
+func (c *CheckBoxGroup) SetChangedFunc(handler func(index int, checked bool)) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.changed = handler
+}
+
+// SetDoneFunc sets a handler which is called when the user is done using the
+// checkbox group. The callback function is provided with the key that was
+// pressed, which is one of the following:
+//
+//   - KeyEscape: Abort selection.
+//   - KeyTab: Move to the next field.
+//   - KeyBacktab: Move to the previous field.
+func (c *CheckBoxGroup) SetDoneFunc(handler func(key tcell.Key)) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.done = handler
+}
+
+// SetFinishedFunc sets a callback invoked when the user leaves this form item.
+func (c *CheckBoxGroup) SetFinishedFunc(handler func(key tcell.Key)) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.finished = handler
+}
+
+// toggle toggles the option at the provided index unless it is disabled.
+func (c *CheckBoxGroup) toggle(index int) {
+	c.Lock()
+	if index < 0 || index >= len(c.options) || c.options[index].disabled {
+		c.Unlock()
+		return
+	}
+	option := c.options[index]
+	option.checked = !option.checked
+	checked := option.checked
+	changed := c.changed
+	c.Unlock()
+
+	if changed != nil {
+		changed(index, checked)
+	}
+}
+
+// Draw draws this primitive onto the screen.
+func (c *CheckBoxGroup) Draw(screen tcell.Screen) {
+	if !c.GetVisible() {
+		return
+	}
+
+	c.Box.Draw(screen)
+
+	c.Lock()
+	defer c.Unlock()
+
+	hasFocus := c.GetFocusable().HasFocus()
+
+	// Select colors
+	labelColor := c.labelColor
+	fieldBackgroundColor := c.fieldBackgroundColor
+	fieldTextColor := c.fieldTextColor
+	if hasFocus {
+		if c.labelColorFocused != ColorUnset {
+			labelColor = c.labelColorFocused
+		}
+		if c.fieldBackgroundColorFocused != ColorUnset {
+			fieldBackgroundColor = c.fieldBackgroundColorFocused
+		}
+		if c.fieldTextColorFocused != ColorUnset {
+			fieldTextColor = c.fieldTextColorFocused
+		}
+	}
+
+	// Prepare
+	x, y, width, height := c.GetInnerRect()
+	rightLimit := x + width
+	if height < 1 || rightLimit <= x {
+		return
+	}
+
+	// Draw label.
+	if c.labelWidth > 0 {
+		labelWidth := c.labelWidth
+		if labelWidth > rightLimit-x {
+			labelWidth = rightLimit - x
+		}
+		Print(screen, c.label, x, y, labelWidth, AlignLeft, labelColor)
+		x += labelWidth
+	} else {
+		_, drawnWidth := Print(screen, c.label, x, y, rightLimit-x, AlignLeft, labelColor)
+		x += drawnWidth
+	}
+
+	// Draw options.
+	for index, option := range c.options {
+		if index >= height {
+			break
+		}
+
+		optionTextColor, optionLabelColor := fieldTextColor, c.labelColor
+		if option.disabled {
+			optionTextColor, optionLabelColor = c.disabledColor, c.disabledColor
+		}
+		fieldStyle := tcell.StyleDefault.Background(c.fieldBackgroundColor).Foreground(optionTextColor)
+		if index == c.currentOption {
+			fieldStyle = fieldStyle.Background(fieldBackgroundColor)
+		}
+
+		checkedRune := ' '
+		if option.checked {
+			checkedRune = c.checkedRune
+		}
+		rightRune := ' '
+		if c.cursorRune != 0 && hasFocus && index == c.currentOption {
+			rightRune = c.cursorRune
+		}
+		screen.SetContent(x, y+index, ' ', nil, fieldStyle)
+		screen.SetContent(x+1, y+index, checkedRune, nil, fieldStyle)
+		screen.SetContent(x+2, y+index, rightRune, nil, fieldStyle)
+
+		if len(option.label) > 0 && x+4 < rightLimit {
+			Print(screen, option.label, x+4, y+index, rightLimit-x-4, AlignLeft, optionLabelColor)
+		}
+	}
+}
+
+// InputHandler returns the handler for this primitive.
+func (c *CheckBoxGroup) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return c.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		switch {
+		case HitShortcut(event, Keys.Select, Keys.Select2):
+			c.RLock()
+			index := c.currentOption
+			c.RUnlock()
+			c.toggle(index)
+		case HitShortcut(event, Keys.MoveUp):
+			c.Lock()
+			if c.currentOption > 0 {
+				c.currentOption--
+			}
+			c.Unlock()
+		case HitShortcut(event, Keys.MoveDown):
+			c.Lock()
+			if c.currentOption < len(c.options)-1 {
+				c.currentOption++
+			}
+			c.Unlock()
+		case HitShortcut(event, Keys.Cancel, Keys.MovePreviousField, Keys.MoveNextField):
+			c.RLock()
+			done, finished := c.done, c.finished
+			c.RUnlock()
+			if done != nil {
+				done(event.Key())
+			}
+			if finished != nil {
+				finished(event.Key())
+			}
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (c *CheckBoxGroup) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return c.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		x, y := event.Position()
+		_, rectY, _, _ := c.GetInnerRect()
+		if !c.InRect(x, y) {
+			return false, nil
+		}
+
+		// Process mouse event.
+		if action == MouseLeftClick {
+			setFocus(c)
+
+			index := y - rectY
+			c.Lock()
+			if index >= 0 && index < len(c.options) {
+				c.currentOption = index
+			}
+			c.Unlock()
+
+			c.toggle(index)
+			consumed = true
+		}
+
+		return
+	})
+}
@@ -0,0 +1,50 @@
+package cview
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestCheckBoxGroup(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	c := NewCheckBoxGroup()
+	c.AddOption("Red", false)
+	c.AddOption("Green", true)
+	c.AddOption("Blue", false)
+	c.SetOptionEnabled(2, false)
+
+	if checked := strings.Join(c.GetChecked(), ","); checked != "Green" {
+		t.Errorf("failed to initialize CheckBoxGroup: expected Green, got %s", checked)
+	} else if height := c.GetFieldHeight(); height != 3 {
+		t.Errorf("failed to initialize CheckBoxGroup: expected field height 3, got %d", height)
+	}
+
+	key := func(key tcell.Key, ch rune) {
+		c.InputHandler()(tcell.NewEventKey(key, ch, tcell.ModNone), func(p Primitive) {})
+	}
+
+	// Toggle
+
+	key(tcell.KeyRune, ' ')
+	key(tcell.KeyDown, 0)
+	key(tcell.KeyEnter, 0)
+	key(tcell.KeyDown, 0)
+	key(tcell.KeyEnter, 0)
+	if checked := strings.Join(c.GetChecked(), ","); checked != "Red" {
+		t.Errorf("failed to toggle CheckBoxGroup options: expected Red, got %s", checked)
+	}
+
+	// Draw
+
+	app, err := newTestApp(c)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	c.Draw(app.screen)
+}
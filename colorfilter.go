@@ -0,0 +1,299 @@
+package cview
+
+import (
+	"math"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// ColorFilter is a transformation which is applied to all colors drawn by an
+// application. See Application.SetColorFilter.
+type ColorFilter int
+
+// Color filters.
+const (
+	// Colors are drawn unchanged.
+	ColorFilterNone ColorFilter = iota
+
+	// Colors are drawn as they are perceived with deuteranopia (green
+	// blindness), the most common form of color blindness.
+	ColorFilterDeuteranopia
+
+	// Colors are drawn as they are perceived with protanopia (red blindness).
+	ColorFilterProtanopia
+
+	// Colors are drawn as they are perceived with tritanopia (blue
+	// blindness).
+	ColorFilterTritanopia
+
+	// Colors are replaced by the closest color of the Okabe-Ito palette, which
+	// remains distinguishable with all common forms of color blindness. Black,
+	// white and shades of gray are drawn unchanged.
+	ColorFilterSafe
+)
+
+// The matrices which simulate color vision deficiencies in linear RGB, as
+// described by Machado, Oliveira and Fernandes (2009), at full severity.
+var colorFilterMatrices = map[ColorFilter][3][3]float64{
+	ColorFilterDeuteranopia: {
+		{0.367322, 0.860646, -0.227968},
+		{0.280085, 0.672501, 0.047413},
+		{-0.011820, 0.042940, 0.968881},
+	},
+	ColorFilterProtanopia: {
+		{0.152286, 1.052583, -0.204868},
+		{0.114503, 0.786281, 0.099216},
+		{-0.003882, -0.048116, 1.051998},
+	},
+	ColorFilterTritanopia: {
+		{1.255528, -0.076749, -0.178779},
+		{-0.078411, 0.930809, 0.147602},
+		{0.004733, 0.691367, 0.303900},
+	},
+}
+
+// The chromatic colors of the Okabe-Ito palette.
+var safePalette = []tcell.Color{
+	tcell.NewHexColor(0xE69F00), // Orange.
+	tcell.NewHexColor(0x56B4E9), // Sky blue.
+	tcell.NewHexColor(0x009E73), // Bluish green.
+	tcell.NewHexColor(0xF0E442), // Yellow.
+	tcell.NewHexColor(0x0072B2), // Blue.
+	tcell.NewHexColor(0xD55E00), // Vermillion.
+	tcell.NewHexColor(0xCC79A7), // Reddish purple.
+}
+
+// The saturation below which colors are left unchanged by ColorFilterSafe.
+const safePaletteMinSaturation = 0.15
+
+// FilterColor returns the provided color transformed by the provided color
+// filter. The default color and other special colors are returned unchanged.
+func FilterColor(c tcell.Color, filter ColorFilter) tcell.Color {
+	if filter == ColorFilterNone || !c.Valid() || c&tcell.ColorSpecial != 0 {
+		return c
+	}
+	r, g, b := c.RGB()
+	if r < 0 {
+		return c
+	}
+
+	if filter == ColorFilterSafe {
+		return safeColor(r, g, b)
+	}
+
+	matrix, ok := colorFilterMatrices[filter]
+	if !ok {
+		return c
+	}
+	linear := [3]float64{srgbToLinear(r), srgbToLinear(g), srgbToLinear(b)}
+	var rgb [3]int32
+	for i, row := range matrix {
+		rgb[i] = linearToSRGB(row[0]*linear[0] + row[1]*linear[1] + row[2]*linear[2])
+	}
+	return tcell.NewRGBColor(rgb[0], rgb[1], rgb[2])
+}
+
+// FilterStyle returns the provided style with its colors transformed by the
+// provided color filter.
+func FilterStyle(style tcell.Style, filter ColorFilter) tcell.Style {
+	if filter == ColorFilterNone {
+		return style
+	}
+	fg, bg, _ := style.Decompose()
+	return style.Foreground(FilterColor(fg, filter)).Background(FilterColor(bg, filter))
+}
+
+// safeColor returns the color of the Okabe-Ito palette closest to the
+// provided color in hue and lightness. Colors with a low saturation are
+// returned unchanged.
+func safeColor(r, g, b int32) tcell.Color {
+	hue, saturation, lightness := hsl(r, g, b)
+	if saturation < safePaletteMinSaturation {
+		return tcell.NewRGBColor(r, g, b)
+	}
+
+	closest, distance := safePalette[0], math.Inf(1)
+	for _, c := range safePalette {
+		h, _, l := hsl(c.RGB())
+		d := math.Abs(hue - h)
+		if d > 180 {
+			d = 360 - d
+		}
+		if d := d/180 + math.Abs(lightness-l); d < distance {
+			closest, distance = c, d
+		}
+	}
+	return closest
+}
+
+// hsl returns the hue (0-360), saturation (0-1) and lightness (0-1) of the
+// provided color.
+func hsl(r, g, b int32) (hue, saturation, lightness float64) {
+	red, green, blue := float64(r)/255, float64(g)/255, float64(b)/255
+	high := math.Max(red, math.Max(green, blue))
+	low := math.Min(red, math.Min(green, blue))
+	lightness = (high + low) / 2
+	if high == low {
+		return 0, 0, lightness
+	}
+
+	delta := high - low
+	if lightness > 0.5 {
+		saturation = delta / (2 - high - low)
+	} else {
+		saturation = delta / (high + low)
+	}
+	switch high {
+	case red:
+		hue = (green - blue) / delta
+		if green < blue {
+			hue += 6
+		}
+	case green:
+		hue = (blue-red)/delta + 2
+	default:
+		hue = (red-green)/delta + 4
+	}
+	return hue * 60, saturation, lightness
+}
+
+// srgbToLinear converts an sRGB color component (0-255) to linear RGB (0-1).
+func srgbToLinear(c int32) float64 {
+	v := float64(c) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// linearToSRGB converts a linear RGB color component (0-1) to sRGB (0-255).
+// Values outside of the range are clamped.
+func linearToSRGB(v float64) int32 {
+	if v <= 0 {
+		return 0
+	} else if v >= 1 {
+		return 255
+	}
+	if v <= 0.0031308 {
+		v *= 12.92
+	} else {
+		v = 1.055*math.Pow(v, 1/2.4) - 0.055
+	}
+	return int32(math.Round(v * 255))
+}
+
+// filteredCell is a cell of a colorFilterScreen.
+type filteredCell struct {
+	// The style the cell was drawn with.
+	style tcell.Style
+
+	// The style the cell was drawn with, transformed by the color filter.
+	filtered tcell.Style
+}
+
+// colorFilterScreen is a screen which transforms the colors of all content
+// drawn on it by a color filter. Retrieving content returns the styles it was
+// drawn with, so primitives which mix their styles with existing content are
+// not affected by the filter.
+type colorFilterScreen struct {
+	tcell.Screen
+
+	// The color filter.
+	filter ColorFilter
+
+	// The cells drawn via this screen.
+	cells map[[2]int]filteredCell
+
+	// The style the screen was last filled with, if any.
+	fill *filteredCell
+}
+
+// newColorFilterScreen returns a new colorFilterScreen drawing on the
+// provided screen.
+func newColorFilterScreen(screen tcell.Screen, filter ColorFilter) *colorFilterScreen {
+	return &colorFilterScreen{
+		Screen: screen,
+		filter: filter,
+		cells:  make(map[[2]int]filteredCell),
+	}
+}
+
+// SetContent sets the content of a cell, transforming the colors of its
+// style.
+func (s *colorFilterScreen) SetContent(x int, y int, mainc rune, combc []rune, style tcell.Style) {
+	filtered := FilterStyle(style, s.filter)
+	s.cells[[2]int{x, y}] = filteredCell{style: style, filtered: filtered}
+	s.Screen.SetContent(x, y, mainc, combc, filtered)
+}
+
+// SetCell sets the content of a cell, transforming the colors of its style.
+func (s *colorFilterScreen) SetCell(x int, y int, style tcell.Style, ch ...rune) {
+	if len(ch) > 0 {
+		s.SetContent(x, y, ch[0], ch[1:], style)
+	} else {
+		s.SetContent(x, y, ' ', nil, style)
+	}
+}
+
+// GetContent returns the content of a cell with the style it was drawn with.
+func (s *colorFilterScreen) GetContent(x, y int) (mainc rune, combc []rune, style tcell.Style, width int) {
+	mainc, combc, style, width = s.Screen.GetContent(x, y)
+	if cell, ok := s.cells[[2]int{x, y}]; ok && cell.filtered == style {
+		style = cell.style
+	} else if s.fill != nil && s.fill.filtered == style {
+		style = s.fill.style
+	}
+	return
+}
+
+// Fill fills the screen, transforming the colors of the provided style.
+func (s *colorFilterScreen) Fill(r rune, style tcell.Style) {
+	filtered := FilterStyle(style, s.filter)
+	s.cells = make(map[[2]int]filteredCell)
+	s.fill = &filteredCell{style: style, filtered: filtered}
+	s.Screen.Fill(r, filtered)
+}
+
+// Clear clears the screen.
+func (s *colorFilterScreen) Clear() {
+	s.cells = make(map[[2]int]filteredCell)
+	s.fill = nil
+	s.Screen.Clear()
+}
+
+// SetColorFilter sets a color filter which transforms all colors drawn by the
+// application, such as a simulation of color blindness or a remapping to a
+// palette which remains distinguishable with color blindness. This allows
+// developers to verify that an application is accessible, and users to select
+// a palette which suits them. The filter is applied when the screen is drawn,
+// including by handlers set via SetBeforeDrawFunc and SetAfterDrawFunc, and
+// does not modify the colors of primitives. See FilterColor.
+func (a *Application) SetColorFilter(filter ColorFilter) {
+	a.Lock()
+	a.colorFilter = filter
+	a.colorFilterScreen = nil
+	a.Unlock()
+
+	a.queueIndicatorUpdate()
+}
+
+// GetColorFilter returns the color filter set via SetColorFilter.
+func (a *Application) GetColorFilter() ColorFilter {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.colorFilter
+}
+
+// filteredScreen returns the provided screen, wrapped such that the colors
+// drawn on it are transformed by the color filter. The screen is returned
+// unchanged when no color filter is set. The lock must be held.
+func (a *Application) filteredScreen(screen tcell.Screen) tcell.Screen {
+	if screen == nil || a.colorFilter == ColorFilterNone {
+		return screen
+	}
+	if a.colorFilterScreen == nil || a.colorFilterScreen.Screen != screen {
+		a.colorFilterScreen = newColorFilterScreen(screen, a.colorFilter)
+	}
+	return a.colorFilterScreen
+}
@@ -0,0 +1,97 @@
+package cview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestFilterColor(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		color    tcell.Color
+		filter   ColorFilter
+		expected tcell.Color
+	}{
+		{tcell.ColorRed, ColorFilterNone, tcell.ColorRed},
+		{tcell.ColorDefault, ColorFilterDeuteranopia, tcell.ColorDefault},
+		{tcell.NewRGBColor(128, 128, 128), ColorFilterDeuteranopia, tcell.NewRGBColor(128, 128, 128)},
+		{tcell.NewRGBColor(255, 255, 255), ColorFilterTritanopia, tcell.NewRGBColor(255, 255, 255)},
+		{tcell.NewRGBColor(255, 0, 0), ColorFilterSafe, tcell.NewHexColor(0xD55E00)},
+		{tcell.NewRGBColor(0, 255, 0), ColorFilterSafe, tcell.NewHexColor(0x009E73)},
+		{tcell.NewRGBColor(0, 0, 128), ColorFilterSafe, tcell.NewHexColor(0x0072B2)},
+		{tcell.NewRGBColor(40, 40, 40), ColorFilterSafe, tcell.NewRGBColor(40, 40, 40)},
+	}
+	for _, c := range testCases {
+		if filtered := FilterColor(c.color, c.filter); filtered != c.expected {
+			t.Errorf("failed to filter color %s with filter %d: expected %s, got %s", ColorHex(c.color), c.filter, ColorHex(c.expected), ColorHex(filtered))
+		}
+	}
+
+	// Red and green are confused with deuteranopia and protanopia.
+	for _, filter := range []ColorFilter{ColorFilterDeuteranopia, ColorFilterProtanopia} {
+		r1, g1, b1 := FilterColor(tcell.NewRGBColor(200, 60, 0), filter).RGB()
+		r2, g2, b2 := FilterColor(tcell.NewRGBColor(60, 160, 0), filter).RGB()
+		if abs(int(r1-r2))+abs(int(g1-g2))+abs(int(b1-b2)) > 120 {
+			t.Errorf("failed to simulate filter %d: expected similar colors, got %d,%d,%d and %d,%d,%d", filter, r1, g1, b1, r2, g2, b2)
+		}
+	}
+}
+
+func TestApplicationColorFilter(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	box := NewBox()
+	box.SetBackgroundColor(tcell.NewRGBColor(255, 0, 0))
+	app, err := newTestApp(box)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	app.width, app.height = app.screen.Size()
+
+	// Draw without a filter
+
+	background := func() tcell.Color {
+		_, _, style, _ := app.screen.GetContent(2, 2)
+		_, bg, _ := style.Decompose()
+		return bg
+	}
+	app.draw()
+	if bg := background(); bg != tcell.NewRGBColor(255, 0, 0) {
+		t.Errorf("failed to draw without filter: expected #ff0000, got %s", ColorHex(bg))
+	}
+
+	// Draw with a filter
+
+	var drawnStyle tcell.Style
+	app.SetAfterDrawFunc(func(screen tcell.Screen) {
+		_, _, drawnStyle, _ = screen.GetContent(2, 2)
+	})
+	app.SetColorFilter(ColorFilterSafe)
+	if filter := app.GetColorFilter(); filter != ColorFilterSafe {
+		t.Errorf("failed to set color filter: expected %d, got %d", ColorFilterSafe, filter)
+	}
+	app.draw()
+	if bg := background(); bg != tcell.NewHexColor(0xD55E00) {
+		t.Errorf("failed to draw with filter: expected #d55e00, got %s", ColorHex(bg))
+	}
+	if _, bg, _ := drawnStyle.Decompose(); bg != tcell.NewRGBColor(255, 0, 0) {
+		t.Errorf("failed to retrieve drawn style: expected #ff0000, got %s", ColorHex(bg))
+	}
+
+	// Remove the filter
+
+	app.SetColorFilter(ColorFilterNone)
+	app.draw()
+	if bg := background(); bg != tcell.NewRGBColor(255, 0, 0) {
+		t.Errorf("failed to remove filter: expected #ff0000, got %s", ColorHex(bg))
+	}
+}
@@ -22,6 +22,8 @@ func main() {
 	form.AddFormItem(addressField)
 	form.AddPasswordField("Password", "", 10, '*', nil)
 	form.AddCheckBox("", "Age 18+", false, nil)
+	form.AddCheckBoxGroup("Interests", nil, "Music", "Sports", "Travel")
+	form.SetItemHelp(4, "At least 8 characters")
 	form.AddButton("Save", nil)
 	form.AddButton("Quit", func() {
 		app.Stop()
@@ -0,0 +1,31 @@
+// Demo code for the Marquee primitive.
+package main
+
+import (
+	"code.rocketnine.space/tslocum/cview"
+)
+
+func main() {
+	app := cview.NewApplication()
+	defer app.HandlePanic()
+	app.EnableMouse(true)
+
+	marquee := cview.NewMarquee()
+	marquee.SetBorder(true)
+	marquee.SetTitle("Alerts")
+	marquee.SetText("Scheduled maintenance begins at 02:00 UTC. Services may be briefly unavailable. Hover over this text to pause scrolling.")
+	marquee.SetChangedFunc(func() {
+		app.QueueUpdateDraw(func() {}, marquee)
+	})
+	marquee.Start()
+
+	flex := cview.NewFlex()
+	flex.SetDirection(cview.FlexRow)
+	flex.AddItem(marquee, 3, 0, false)
+	flex.AddItem(cview.NewBox(), 0, 1, true)
+
+	app.SetRoot(flex, true)
+	if err := app.Run(); err != nil {
+		panic(err)
+	}
+}
@@ -18,10 +18,8 @@ import (
 	"log"
 	"net/http"
 	_ "net/http/pprof"
-	"strconv"
 
 	"code.rocketnine.space/tslocum/cview"
-	"github.com/gdamore/tcell/v2"
 )
 
 const (
@@ -75,26 +73,10 @@ func main() {
 		End,
 	}
 
-	panels := cview.NewTabbedPanels()
+	slideShow := cview.NewSlideShow()
 
-	// Create the pages for all slides.
-	previousSlide := func() {
-		slide, _ := strconv.Atoi(panels.GetCurrentTab())
-		slide = (slide - 1 + len(slides)) % len(slides)
-		panels.SetCurrentTab(strconv.Itoa(slide))
-	}
-	nextSlide := func() {
-		slide, _ := strconv.Atoi(panels.GetCurrentTab())
-		slide = (slide + 1) % len(slides)
-		panels.SetCurrentTab(strconv.Itoa(slide))
-	}
-
-	cursor := 0
-	var slideRegions []int
-	for index, slide := range slides {
-		slideRegions = append(slideRegions, cursor)
-
-		title, info, primitive := slide(nextSlide)
+	for _, slide := range slides {
+		title, info, primitive := slide(slideShow.NextSlide)
 
 		h := cview.NewTextView()
 		if info != "" {
@@ -108,24 +90,14 @@ func main() {
 		f.AddItem(h, 1, 1, false)
 		f.AddItem(primitive, 0, 1, true)
 
-		panels.AddTab(strconv.Itoa(index), title, f)
-
-		cursor += len(title) + 4
+		slideShow.AddSlide(cview.NewSlide(title, f))
 	}
-	panels.SetCurrentTab("0")
 
 	// Shortcuts to navigate the slides.
-	app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		if event.Key() == tcell.KeyCtrlN {
-			nextSlide()
-		} else if event.Key() == tcell.KeyCtrlP {
-			previousSlide()
-		}
-		return event
-	})
+	app.SetInputCapture(slideShow.HandleNavigation)
 
 	// Start the application.
-	app.SetRoot(panels, true)
+	app.SetRoot(slideShow, true)
 	if err := app.Run(); err != nil {
 		panic(err)
 	}
@@ -0,0 +1,23 @@
+// Demo code for the QRCode primitive.
+package main
+
+import (
+	"code.rocketnine.space/tslocum/cview"
+)
+
+func main() {
+	app := cview.NewApplication()
+	defer app.HandlePanic()
+
+	qrCode := cview.NewQRCode()
+	qrCode.SetBorder(true)
+	qrCode.SetTitle("Scan to visit cview")
+	if err := qrCode.SetText("https://code.rocketnine.space/tslocum/cview"); err != nil {
+		panic(err)
+	}
+
+	app.SetRoot(qrCode, true)
+	if err := app.Run(); err != nil {
+		panic(err)
+	}
+}
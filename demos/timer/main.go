@@ -0,0 +1,33 @@
+// Demo code for the Timer primitive.
+package main
+
+import (
+	"time"
+
+	"code.rocketnine.space/tslocum/cview"
+)
+
+func main() {
+	app := cview.NewApplication()
+	defer app.HandlePanic()
+
+	timer := cview.NewTimer()
+	timer.SetBorder(true)
+	timer.SetTitle("Countdown")
+	timer.SetTextAlign(cview.AlignCenter)
+	timer.SetCountdown(10 * time.Second)
+	timer.SetChangedFunc(func() {
+		app.QueueUpdateDraw(func() {}, timer)
+	})
+	timer.SetExpiredFunc(func() {
+		app.QueueUpdateDraw(func() {
+			timer.SetTitle("Expired")
+		})
+	})
+	timer.Start()
+
+	app.SetRoot(timer, true)
+	if err := app.Run(); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,235 @@
+package cview
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The maximum number of characters of a value in a screen description.
+const describeValueLength = 200
+
+// ScreenElement describes a primitive shown on the screen, as returned by
+// Application.DescribeScreenElements.
+type ScreenElement struct {
+	// The described primitive.
+	Primitive Primitive
+
+	// The role of the primitive, a short lowercase noun such as "button" or
+	// "text field".
+	Role string
+
+	// The label or title of the primitive, without color tags.
+	Label string
+
+	// The value of the primitive, such as the text of an input field or the
+	// current item of a list, without color tags.
+	Value string
+
+	// The number of described primitives containing the primitive.
+	Depth int
+
+	// Whether the primitive has focus.
+	Focused bool
+
+	// The position and size of the primitive.
+	X, Y, Width, Height int
+}
+
+// String returns the element formatted as a single line, e.g.
+// `text field "Name": Alice (focused)`.
+func (e *ScreenElement) String() string {
+	var b strings.Builder
+	b.WriteString(e.Role)
+	if e.Label != "" {
+		fmt.Fprintf(&b, " %q", e.Label)
+	}
+	if e.Value != "" {
+		b.WriteString(": " + e.Value)
+	}
+	if e.Focused {
+		b.WriteString(" (focused)")
+	}
+	return b.String()
+}
+
+// Describer is implemented by primitives which describe themselves, such as
+// custom widgets. See Application.DescribeScreen. Primitives with an empty
+// role are omitted from descriptions, while the primitives they contain are
+// described.
+type Describer interface {
+	Describe() (role, label, value string)
+}
+
+// DescribeScreen returns a linear description of the visible primitives of
+// the application, one primitive per line in the order they are drawn. Each
+// line states the role, label and value of a primitive and whether it has
+// focus, and is indented by two spaces for each described primitive
+// containing it:
+//
+//   form "Settings"
+//     text field "Name": Alice (focused)
+//     checkbox "Subscribe": checked
+//     button "Save"
+//
+// Layout primitives such as Flex and Grid are only described when they have
+// a title. This is intended for screen readers and other external tooling,
+// and for accessibility tests which compare the description to a snapshot.
+// Primitives may describe themselves by implementing Describer.
+func (a *Application) DescribeScreen() string {
+	var b strings.Builder
+	for _, element := range a.DescribeScreenElements() {
+		b.WriteString(strings.Repeat("  ", element.Depth))
+		b.WriteString(element.String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// DescribeScreenElements returns the descriptions of the visible primitives of
+// the application in the order they are drawn. See DescribeScreen.
+func (a *Application) DescribeScreenElements() []*ScreenElement {
+	a.RLock()
+	root, focus := a.root, a.focus
+	a.RUnlock()
+
+	var elements []*ScreenElement
+	describeElements(root, focus, 0, &elements)
+	return elements
+}
+
+// describeElements adds the descriptions of a visible primitive and the
+// primitives it contains to the provided elements.
+func describeElements(p Primitive, focus Primitive, depth int, elements *[]*ScreenElement) {
+	if p == nil || !p.GetVisible() {
+		return
+	}
+
+	role, label, value := describePrimitive(p)
+	if role == "" && p == focus {
+		role = strings.ToLower(primitiveName(p))
+	}
+	if role != "" {
+		x, y, width, height := p.GetRect()
+		*elements = append(*elements, &ScreenElement{
+			Primitive: p,
+			Role:      role,
+			Label:     plainText(label),
+			Value:     truncateValue(plainText(value)),
+			Depth:     depth,
+			Focused:   p == focus,
+			X:         x,
+			Y:         y,
+			Width:     width,
+			Height:    height,
+		})
+		depth++
+	}
+
+	for _, child := range inspectChildren(p) {
+		describeElements(child, focus, depth, elements)
+	}
+}
+
+// describePrimitive returns the role, label and value of a primitive.
+func describePrimitive(p Primitive) (role, label, value string) {
+	if d, ok := p.(Describer); ok {
+		return d.Describe()
+	}
+
+	var title string
+	if b, ok := p.(boxer); ok {
+		title = b.getBox().GetTitle()
+	}
+
+	switch p := p.(type) {
+	case *Button:
+		return "button", p.GetLabel(), ""
+	case *InputField:
+		p.RLock()
+		mask, text := p.maskCharacter, string(p.text)
+		p.RUnlock()
+		if mask > 0 {
+			text = strings.Repeat(string(mask), len([]rune(text)))
+		}
+		return "text field", p.GetLabel(), text
+	case *CheckBox:
+		value = "not checked"
+		if p.IsChecked() {
+			value = "checked"
+		}
+		return "checkbox", p.GetLabel(), value
+	case *CheckBoxGroup:
+		return "checkbox group", p.GetLabel(), strings.Join(p.GetChecked(), ", ")
+	case *DropDown:
+		if _, option := p.GetCurrentOption(); option != nil {
+			value = option.GetText()
+		}
+		return "drop-down", p.GetLabel(), value
+	case *Slider:
+		return "slider", p.GetLabel(), fmt.Sprintf("%d of %d", p.GetProgress(), p.GetMax())
+	case *ProgressBar:
+		return "progress bar", title, fmt.Sprintf("%d of %d", p.GetProgress(), p.GetMax())
+	case *List:
+		if item := p.GetCurrentItem(); item != nil {
+			value = fmt.Sprintf("%s (%d of %d)", item.GetMainText(), p.GetCurrentItemIndex()+1, p.GetItemCount())
+		}
+		return "list", title, value
+	case *Table:
+		rows, columns := p.GetSelectable()
+		row, column := p.GetSelection()
+		switch {
+		case rows && columns:
+			value = p.GetCell(row, column).GetText()
+		case rows:
+			var cells []string
+			for column := 0; column < p.GetColumnCount(); column++ {
+				if text := p.GetCell(row, column).GetText(); text != "" {
+					cells = append(cells, text)
+				}
+			}
+			value = strings.Join(cells, ", ")
+		case columns:
+			value = p.GetCell(0, column).GetText()
+		}
+		return "table", title, value
+	case *TreeView:
+		if node := p.GetCurrentNode(); node != nil {
+			value = node.GetText()
+		}
+		return "tree", title, value
+	case *TextView:
+		return "text", title, p.GetText(true)
+	case *Marquee:
+		return "text", title, p.GetText()
+	case *Modal:
+		p.RLock()
+		defer p.RUnlock()
+		return "dialog", title, p.text
+	case *Form:
+		return "form", title, ""
+	case *TabbedPanels:
+		return "tab panel", title, p.GetCurrentTab()
+	case *Window:
+		return "window", title, ""
+	}
+
+	if title != "" {
+		return "group", title, ""
+	}
+	return "", "", ""
+}
+
+// plainText returns the provided text without color tags, with consecutive
+// whitespace collapsed into single spaces.
+func plainText(text string) string {
+	return strings.Join(strings.Fields(string(StripTags([]byte(text), true, false))), " ")
+}
+
+// truncateValue truncates a value to describeValueLength characters.
+func truncateValue(value string) string {
+	runes := []rune(value)
+	if len(runes) <= describeValueLength {
+		return value
+	}
+	return string(runes[:describeValueLength]) + "..."
+}
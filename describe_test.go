@@ -0,0 +1,73 @@
+package cview
+
+import (
+	"testing"
+)
+
+type describedBox struct {
+	*Box
+}
+
+func (d *describedBox) Describe() (role, label, value string) {
+	return "clock", "Time", "12:00"
+}
+
+func TestDescribeScreen(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	form := NewForm()
+	form.SetTitle("[red]Settings[-]")
+	form.AddInputField("Name", "Alice", 20, nil, nil)
+	form.AddInputField("Password", "secret", 20, nil, nil)
+	form.GetFormItem(1).(*InputField).SetMaskCharacter('*')
+	form.AddCheckBox("Subscribe", "", true, nil)
+	form.AddButton("Save", nil)
+
+	list := NewList()
+	list.AddItem(NewListItem("First"))
+	list.AddItem(NewListItem("Second"))
+	list.SetCurrentItem(1)
+
+	flex := NewFlex()
+	flex.AddItem(form, 0, 1, true)
+	flex.AddItem(list, 0, 1, false)
+	flex.AddItem(&describedBox{NewBox()}, 1, 0, false)
+
+	app, err := newTestApp(flex)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	app.SetFocus(form.GetFormItem(0))
+
+	// Describe
+
+	expected := `form "Settings"
+  text field "Name": Alice (focused)
+  text field "Password": ******
+  checkbox "Subscribe": checked
+  button "Save"
+list: Second (2 of 2)
+clock "Time": 12:00
+`
+	if description := app.DescribeScreen(); description != expected {
+		t.Errorf("failed to describe screen: expected\n%s\ngot\n%s", expected, description)
+	}
+
+	elements := app.DescribeScreenElements()
+	if len(elements) != 7 || elements[1].Primitive != form.GetFormItem(0) || !elements[1].Focused || elements[1].Depth != 1 {
+		t.Errorf("failed to describe screen elements: got %v", elements)
+	}
+
+	// Focused primitives without a role
+
+	box := NewBox()
+	flex.AddItem(box, 1, 0, false)
+	app.SetFocus(box)
+	elements = app.DescribeScreenElements()
+	if last := elements[len(elements)-1]; last.String() != "box (focused)" {
+		t.Errorf("failed to describe focused box: got %s", last)
+	}
+}
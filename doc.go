@@ -23,8 +23,10 @@ Widgets
 
 The following widgets are available:
 
+  Align - Container which centers or aligns another primitive within its area.
   Button - Button which is activated when the user selects it.
   CheckBox - Selectable checkbox for boolean values.
+  CheckBoxGroup - Group of checkboxes displayed as a single form item.
   DropDown - Drop-down selection field.
   Flex - A Flexbox based layout manager.
   Form - Form composed of input fields, drop down selections, checkboxes, and
@@ -32,14 +34,22 @@ The following widgets are available:
   Grid - A grid based layout manager.
   InputField - Single-line text entry field.
   List - A navigable text list with optional keyboard shortcuts.
+  Marquee - Single line of text which scrolls when it does not fit.
   Modal - A centered window with a text message and one or more buttons.
   Panels - A panel based layout manager.
   ProgressBar - Indicates the progress of an operation.
+  QRCode - Displays a payload as a scannable QR code.
+  ScrollView - Scrollable container for primitives larger than the available
+    space.
+  SideBar - Collapsible navigation menu which switches between panels.
+  SlideShow - Displays one slide at a time with a progress line and speaker
+    notes.
   TabbedPanels - Panels widget with tabbed navigation.
   Table - A scrollable display of tabular data. Table cells, rows, or columns
     may also be highlighted.
   TextView - A scrollable window that displays multi-colored text. Text may
     also be highlighted.
+  Timer - Displays elapsed or remaining time.
   TreeView - A scrollable display for hierarchical data. Tree nodes can be
     highlighted, collapsed, expanded, and more.
   Window - A draggable and resizable container.
@@ -54,6 +64,18 @@ from multiple threads, functions will block until the application or widget
 becomes available. Function calls may be queued with Application.QueueUpdate to
 avoid blocking.
 
+Event handlers and queued updates are called from a single goroutine, the
+main goroutine of the application. Callbacks of widgets, such as the changed
+and selected handlers of a List, are called on the same goroutine and never
+while the lock of the widget is held. Callbacks may therefore call any method
+of any widget, including the widget which called them. A callback which must
+run after the widget which called it has finished processing the current
+event, such as one which replaces the root primitive, may pass a function to
+Application.Defer. Goroutines other than the main goroutine should use
+Application.QueueUpdate, Application.QueueUpdateDraw or Application.QueueEvent
+to modify widgets, as callbacks may be called before the modification has
+been drawn otherwise.
+
 Unicode Support
 
 This package supports unicode characters including wide characters.
@@ -73,6 +95,11 @@ file.
 
 cbind: https://code.rocketnine.space/tslocum/cbind
 
+The labels of Buttons and CheckBoxes may contain a mnemonic once a marker is
+set via Application.SetMnemonicMarker. With the marker set to an ampersand,
+"&Verbose" is displayed as "Verbose" with the V underlined. Pressing Alt+V
+activates the widget while any primitive within the same Form has focus.
+
 Bracketed Paste Mode
 
 Bracketed paste mode is enabled by default. It may be disabled by calling
@@ -81,6 +108,9 @@ shows how to handle paste events and process pasted text.
 
 tcell bracketed paste demo: https://github.com/gdamore/tcell/blob/master/_demos/mouse.go
 
+When the focused primitive has a Paste(text string) method, such as Table, the
+pasted text is passed to it at once instead of as individual key events.
+
 Mouse Support
 
 Mouse support may be enabled by calling Application.EnableMouse before
@@ -91,6 +121,12 @@ Double clicks are treated single clicks by default. Specify a maximum duration
 between clicks with Application.SetDoubleClickInterval to enable double clicks.
 A standard duration is provided as StandardDoubleClick.
 
+Moving the mouse while the left button is held is recognized as a drag, which
+is reported via the MouseDragStart, MouseDrag and MouseDragEnd actions. Drags
+which cover enough distance are also reported as swipes, e.g. MouseSwipeLeft.
+TabbedPanels shows the next or previous tab when swiped. See
+Application.SetDragThreshold and Application.SetSwipeThreshold.
+
 Mouse events are passed to:
 
 - The handler set with SetMouseCapture, which is reserved for use by application
@@ -105,6 +141,18 @@ Throughout this package, colors are specified using the tcell.Color type.
 Functions such as tcell.GetColor(), tcell.NewHexColor(), and tcell.NewRGBColor()
 can be used to create colors from W3C color names or RGB values.
 
+Containers such as Flex, Grid and Panels may pass a background color and text
+color to their items via SetItemColors. Nested items inherit these colors unless
+their colors were set explicitly, and restore their own colors when ColorUnset
+is passed again. Forms pass the inherited colors on to their fields, and tables
+and tree views draw cells and nodes which use the default text color in the
+inherited text color.
+
+During development, AuditStyles reports widgets whose colors are left at their
+defaults or conflict with each other, such as text drawn in the same color as
+its background. Application.SetStyleAuditPanel renders this report into a
+TextView each time the screen is drawn.
+
 Almost all strings which are displayed can contain color tags. Color tags are
 W3C color names or six hexadecimal digits following a hash tag, wrapped in
 square brackets. Examples:
@@ -5,7 +5,6 @@ import (
 	"sync"
 
 	"github.com/gdamore/tcell/v2"
-	"github.com/mattn/go-runewidth"
 )
 
 // DropDownOption is one option that can be selected in a drop-down primitive.
@@ -50,6 +49,9 @@ func (d *DropDownOption) GetReference() interface{} {
 
 // SetReference allows you to store a reference of any type in this option.
 func (d *DropDownOption) SetReference(reference interface{}) {
+	d.Lock()
+	defer d.Unlock()
+
 	d.reference = reference
 }
 
@@ -83,6 +85,18 @@ type DropDown struct {
 	// The list element for the options.
 	list *List
 
+	// The index of the option shown by each list item. Dividers are set to -1.
+	listOptions []int
+
+	// The options which are always listed first.
+	pinned map[*DropDownOption]bool
+
+	// The most recently selected options, most recent first, and the maximum
+	// number of them which are listed after the pinned options. A limit of 0
+	// disables listing recently selected options first.
+	recent      []*DropDownOption
+	recentLimit int
+
 	// The text to be displayed before the input area.
 	label string
 
@@ -143,6 +157,10 @@ type DropDown struct {
 	// A flag that determines whether the drop down symbol is always drawn.
 	alwaysDrawDropDownSymbol bool
 
+	// The validation state and its message.
+	fieldState        FieldState
+	fieldStateMessage string
+
 	sync.RWMutex
 }
 
@@ -170,6 +188,7 @@ func NewDropDown() *DropDown {
 		labelColorFocused:           ColorUnset,
 		fieldBackgroundColorFocused: ColorUnset,
 		fieldTextColorFocused:       ColorUnset,
+		pinned:                      make(map[*DropDownOption]bool),
 	}
 
 	d.focus = d
@@ -205,29 +224,33 @@ func (d *DropDown) SetAlwaysDrawDropDownSymbol(alwaysDraw bool) {
 // be a negative value to indicate that no option is currently selected. Calling
 // this function will also trigger the "selected" callback (if there is one).
 func (d *DropDown) SetCurrentOption(index int) {
+	var calls callbacks
+	defer calls.run()
+
 	d.Lock()
 	defer d.Unlock()
 
 	if index >= 0 && index < len(d.options) {
 		d.currentOption = index
-		d.list.SetCurrentItem(index)
-		if d.selected != nil {
-			d.Unlock()
-			d.selected(index, d.options[index])
-			d.Lock()
+		d.list.SetCurrentItem(d.listIndex(index))
+		option := d.options[index]
+		if selected := d.selected; selected != nil {
+			calls.add(func() {
+				selected(index, option)
+			})
 		}
-		if d.options[index].selected != nil {
-			d.Unlock()
-			d.options[index].selected(index, d.options[index])
-			d.Lock()
+		if selected := option.selected; selected != nil {
+			calls.add(func() {
+				selected(index, option)
+			})
 		}
 	} else {
 		d.currentOption = -1
 		d.list.SetCurrentItem(0) // Set to 0 because -1 means "last item".
-		if d.selected != nil {
-			d.Unlock()
-			d.selected(-1, nil)
-			d.Lock()
+		if selected := d.selected; selected != nil {
+			calls.add(func() {
+				selected(-1, nil)
+			})
 		}
 	}
 }
@@ -245,6 +268,43 @@ func (d *DropDown) GetCurrentOption() (int, *DropDownOption) {
 	return d.currentOption, option
 }
 
+// SetOptionReference stores a reference of any type in the option at the
+// given index. The reference may be retrieved within selection callbacks via
+// DropDownOption.GetReference. Out of range indices are ignored.
+func (d *DropDown) SetOptionReference(index int, reference interface{}) {
+	d.RLock()
+	defer d.RUnlock()
+
+	if index < 0 || index >= len(d.options) {
+		return
+	}
+	d.options[index].SetReference(reference)
+}
+
+// GetOptionReference returns the reference object of the option at the given
+// index, or nil if there is no such option.
+func (d *DropDown) GetOptionReference(index int) interface{} {
+	d.RLock()
+	defer d.RUnlock()
+
+	if index < 0 || index >= len(d.options) {
+		return nil
+	}
+	return d.options[index].GetReference()
+}
+
+// GetCurrentOptionReference returns the reference object of the currently
+// selected option, or nil if no option is selected.
+func (d *DropDown) GetCurrentOptionReference() interface{} {
+	d.RLock()
+	defer d.RUnlock()
+
+	if d.currentOption < 0 || d.currentOption >= len(d.options) {
+		return nil
+	}
+	return d.options[d.currentOption].GetReference()
+}
+
 // SetTextOptions sets the text to be placed before and after each drop-down
 // option (prefix/suffix), the text placed before and after the currently
 // selected option (currentPrefix/currentSuffix) as well as the text to be
@@ -259,12 +319,164 @@ func (d *DropDown) SetTextOptions(prefix, suffix, currentPrefix, currentSuffix,
 	d.noSelection = noSelection
 	d.optionPrefix = prefix
 	d.optionSuffix = suffix
-	for index := 0; index < d.list.GetItemCount(); index++ {
-		d.list.SetItemText(index, prefix+d.options[index].text+suffix, "")
+	for index, option := range d.listOptions {
+		if option >= 0 {
+			d.list.SetItemText(index, prefix+d.options[option].text+suffix, "")
+		}
+	}
+}
+
+// SetRecentOptions sets the maximum number of recently selected options which
+// are listed first, most recent first, followed by a divider and the remaining
+// options. Pinned options are listed before recently selected options. A limit
+// of 0 (the default) lists the options in the order they were added.
+func (d *DropDown) SetRecentOptions(limit int) {
+	d.Lock()
+	defer d.Unlock()
+
+	if limit < 0 {
+		limit = 0
+	}
+	d.recentLimit = limit
+	if len(d.recent) > limit {
+		d.recent = d.recent[:limit]
 	}
+	d.updateList()
 }
 
-// SetLabel sets the text to be displayed before the input area.
+// ClearRecentOptions forgets which options were recently selected.
+func (d *DropDown) ClearRecentOptions() {
+	d.Lock()
+	defer d.Unlock()
+
+	d.recent = nil
+	d.updateList()
+}
+
+// SetOptionPinned sets whether the option at the given index is pinned.
+// Pinned options are always listed first, followed by a divider and the
+// remaining options. Out of range indices are ignored.
+func (d *DropDown) SetOptionPinned(index int, pinned bool) {
+	d.Lock()
+	defer d.Unlock()
+
+	if index < 0 || index >= len(d.options) {
+		return
+	}
+	if pinned {
+		d.pinned[d.options[index]] = true
+	} else {
+		delete(d.pinned, d.options[index])
+	}
+	d.updateList()
+}
+
+// IsOptionPinned returns whether the option at the given index is pinned.
+func (d *DropDown) IsOptionPinned(index int) bool {
+	d.RLock()
+	defer d.RUnlock()
+
+	return index >= 0 && index < len(d.options) && d.pinned[d.options[index]]
+}
+
+// addRecent records the option at the given index as the most recently
+// selected option. The list is reordered the next time it is opened.
+func (d *DropDown) addRecent(index int) {
+	if d.recentLimit == 0 {
+		return
+	}
+
+	option := d.options[index]
+	recent := []*DropDownOption{option}
+	for _, o := range d.recent {
+		if o != option && len(recent) < d.recentLimit {
+			recent = append(recent, o)
+		}
+	}
+	d.recent = recent
+}
+
+// topOptions returns the indices of the pinned and recently selected options
+// in the order they are listed.
+func (d *DropDown) topOptions() []int {
+	if len(d.pinned) == 0 && len(d.recent) == 0 {
+		return nil
+	}
+
+	var top []int
+	for index, option := range d.options {
+		if d.pinned[option] {
+			top = append(top, index)
+		}
+	}
+	for _, option := range d.recent {
+		if d.pinned[option] {
+			continue
+		}
+		for index, o := range d.options {
+			if o == option {
+				top = append(top, index)
+				break
+			}
+		}
+	}
+	return top
+}
+
+// updateList fills the list with the options, starting with the pinned and
+// recently selected options followed by a divider and the remaining options.
+func (d *DropDown) updateList() {
+	current := d.currentOption
+	if index := d.list.GetCurrentItemIndex(); index < len(d.listOptions) && d.listOptions[index] >= 0 {
+		current = d.listOptions[index]
+	}
+
+	d.list.Clear()
+	d.listOptions = nil
+
+	top := d.topOptions()
+	listed := make(map[int]bool)
+	for _, index := range top {
+		d.listOption(index)
+		listed[index] = true
+	}
+	if len(top) > 0 && len(top) < len(d.options) {
+		d.list.AddDivider("")
+		d.listOptions = append(d.listOptions, -1)
+	}
+	for index := range d.options {
+		if !listed[index] {
+			d.listOption(index)
+		}
+	}
+
+	if current >= 0 && current < len(d.options) {
+		d.list.SetCurrentItem(d.listIndex(current))
+	}
+}
+
+// listOption adds the option at the given index to the end of the list.
+func (d *DropDown) listOption(index int) {
+	d.list.AddItem(NewListItem(d.optionPrefix + d.options[index].text + d.optionSuffix))
+	d.listOptions = append(d.listOptions, index)
+}
+
+// listIndex returns the index of the list item showing the option at the
+// given index, or 0 if the option is not listed.
+func (d *DropDown) listIndex(option int) int {
+	if option < 0 {
+		return 0
+	}
+	for index, o := range d.listOptions {
+		if o == option {
+			return index
+		}
+	}
+	return 0
+}
+
+// SetLabel sets the text to be displayed before the input area. The text may
+// contain color tags.
 func (d *DropDown) SetLabel(label string) {
 	d.Lock()
 	defer d.Unlock()
@@ -295,6 +507,25 @@ func (d *DropDown) SetLabelColor(color tcell.Color) {
 	defer d.Unlock()
 
 	d.labelColor = color
+	d.textColorSet = true
+}
+
+// inheritColors applies the colors inherited from the parent container.
+func (d *DropDown) inheritColors(background, text tcell.Color) {
+	d.Box.inheritColors(background, text)
+
+	d.Lock()
+	defer d.Unlock()
+
+	d.inheritTextColor(&d.labelColor, text)
+}
+
+// primaryTextColor returns the color of the primary text.
+func (d *DropDown) primaryTextColor() tcell.Color {
+	d.RLock()
+	defer d.RUnlock()
+
+	return d.labelColor
 }
 
 // SetLabelColorFocused sets the color of the label when focused.
@@ -337,6 +568,25 @@ func (d *DropDown) SetFieldTextColorFocused(color tcell.Color) {
 	d.fieldTextColorFocused = color
 }
 
+// SetFieldState sets the validation state of the drop-down and a message
+// describing it. Warnings and errors are drawn using the FieldWarningColor and
+// FieldErrorColor of the theme. Within a Form, the message is shown below the
+// drop-down.
+func (d *DropDown) SetFieldState(state FieldState, message string) {
+	d.Lock()
+	defer d.Unlock()
+
+	d.fieldState, d.fieldStateMessage = state, message
+}
+
+// GetFieldState returns the validation state of the drop-down and its message.
+func (d *DropDown) GetFieldState() (state FieldState, message string) {
+	d.RLock()
+	defer d.RUnlock()
+
+	return d.fieldState, d.fieldStateMessage
+}
+
 // SetDropDownTextColor sets text color of the drop-down list.
 func (d *DropDown) SetDropDownTextColor(color tcell.Color) {
 	d.Lock()
@@ -437,8 +687,12 @@ func (d *DropDown) AddOptions(options ...*DropDownOption) {
 
 func (d *DropDown) addOptions(options ...*DropDownOption) {
 	d.options = append(d.options, options...)
-	for _, option := range options {
-		d.list.AddItem(NewListItem(d.optionPrefix + option.text + d.optionSuffix))
+	if len(d.pinned) > 0 || len(d.recent) > 0 {
+		d.updateList()
+		return
+	}
+	for index := len(d.options) - len(options); index < len(d.options); index++ {
+		d.listOption(index)
 	}
 }
 
@@ -457,13 +711,16 @@ func (d *DropDown) SetOptionsSimple(selected func(index int, option *DropDownOpt
 // SetOptions replaces all current options with the ones provided and installs
 // one callback function which is called when one of the options is selected.
 // It will be called with the option's index and the option itself.
-// The "selected" parameter may be nil.
+// The "selected" parameter may be nil. Pinned and recently selected options
+// are forgotten.
 func (d *DropDown) SetOptions(selected func(index int, option *DropDownOption), options ...*DropDownOption) {
 	d.Lock()
 	defer d.Unlock()
 
 	d.list.Clear()
-	d.options = nil
+	d.options, d.listOptions = nil, nil
+	d.pinned = make(map[*DropDownOption]bool)
+	d.recent = nil
 	d.addOptions(options...)
 	d.selected = selected
 }
@@ -474,7 +731,10 @@ func (d *DropDown) SetOptions(selected func(index int, option *DropDownOption),
 // -1 and nil.
 func (d *DropDown) SetChangedFunc(handler func(index int, option *DropDownOption)) {
 	d.list.SetChangedFunc(func(index int, item *ListItem) {
-		handler(index, d.options[index])
+		if index < 0 || index >= len(d.listOptions) || d.listOptions[index] < 0 {
+			return
+		}
+		handler(d.listOptions[index], d.options[d.listOptions[index]])
 	})
 }
 
@@ -514,7 +774,10 @@ func (d *DropDown) SetFinishedFunc(handler func(key tcell.Key)) {
 
 // Draw draws this primitive onto the screen.
 func (d *DropDown) Draw(screen tcell.Screen) {
-	d.Box.Draw(screen)
+	d.RLock()
+	fieldState := d.fieldState
+	d.RUnlock()
+	drawFieldStateBox(d.Box, fieldState, screen)
 	hasFocus := d.GetFocusable().HasFocus()
 
 	d.Lock()
@@ -536,6 +799,8 @@ func (d *DropDown) Draw(screen tcell.Screen) {
 		}
 	}
 
+	fieldBackgroundColor = fieldStateColor(fieldState, fieldBackgroundColor)
+
 	// Prepare.
 	x, y, width, height := d.GetInnerRect()
 	rightLimit := x + width
@@ -594,8 +859,8 @@ func (d *DropDown) Draw(screen tcell.Screen) {
 	if d.open && len(d.prefix) > 0 {
 		// Show the prefix.
 		currentOptionPrefixWidth := TaggedStringWidth(d.currentOptionPrefix)
-		prefixWidth := runewidth.StringWidth(d.prefix)
-		listItemText := d.options[d.list.GetCurrentItemIndex()].text
+		prefixWidth := stringWidth(d.prefix)
+		listItemText := d.options[d.listOptions[d.list.GetCurrentItemIndex()]].text
 		Print(screen, []byte(d.currentOptionPrefix), x, y, fieldWidth, AlignLeft, fieldTextColor)
 		Print(screen, []byte(d.prefix), x+currentOptionPrefixWidth, y, fieldWidth-currentOptionPrefixWidth, AlignLeft, d.prefixTextColor)
 		if len(d.prefix) < len(listItemText) {
@@ -630,7 +895,7 @@ func (d *DropDown) Draw(screen tcell.Screen) {
 		// We prefer to drop-down but if there is no space, maybe drop up?
 		lx := x
 		ly := y + 1
-		lheight := len(d.options)
+		lheight := d.list.GetItemCount()
 		_, sheight := screen.Size()
 		if ly+lheight >= sheight && ly-2 > lheight-ly {
 			ly = y - lheight
@@ -642,7 +907,7 @@ func (d *DropDown) Draw(screen tcell.Screen) {
 			lheight = sheight - ly
 		}
 		lwidth := maxWidth
-		if d.list.scrollBarVisibility == ScrollBarAlways || (d.list.scrollBarVisibility == ScrollBarAuto && len(d.options) > lheight) {
+		if d.list.scrollBarVisibility == ScrollBarAlways || (d.list.scrollBarVisibility == ScrollBarAuto && d.list.GetItemCount() > lheight) {
 			lwidth++ // Add space for scroll bar
 		}
 		if lwidth < fieldWidth {
@@ -685,8 +950,8 @@ func (d *DropDown) InputHandler() func(event *tcell.EventKey, setFocus func(p Pr
 // evalPrefix selects an item in the drop-down list based on the current prefix.
 func (d *DropDown) evalPrefix() {
 	if len(d.prefix) > 0 {
-		for index, option := range d.options {
-			if strings.HasPrefix(strings.ToLower(option.text), d.prefix) {
+		for index, option := range d.listOptions {
+			if option >= 0 && strings.HasPrefix(strings.ToLower(d.options[option].text), d.prefix) {
 				d.list.SetCurrentItem(index)
 				return
 			}
@@ -702,6 +967,9 @@ func (d *DropDown) evalPrefix() {
 func (d *DropDown) openList(setFocus func(Primitive)) {
 	d.open = true
 	optionBefore := d.currentOption
+	if len(d.recent) > 0 {
+		d.updateList()
+	}
 
 	d.list.SetSelectedFunc(func(index int, item *ListItem) {
 		if d.dragging {
@@ -709,8 +977,12 @@ func (d *DropDown) openList(setFocus func(Primitive)) {
 		}
 
 		// An option was selected. Close the list again.
-		d.currentOption = index
+		if index < 0 || index >= len(d.listOptions) || d.listOptions[index] < 0 {
+			return
+		}
+		d.currentOption = d.listOptions[index]
 		d.closeList(setFocus)
+		d.addRecent(d.currentOption)
 
 		// Trigger "selected" event.
 		if d.selected != nil {
@@ -732,7 +1004,7 @@ func (d *DropDown) openList(setFocus func(Primitive)) {
 			d.evalPrefix()
 		} else if event.Key() == tcell.KeyEscape {
 			d.currentOption = optionBefore
-			d.list.SetCurrentItem(d.currentOption)
+			d.list.SetCurrentItem(d.listIndex(d.currentOption))
 			d.closeList(setFocus)
 			if d.selected != nil {
 				if d.currentOption > -1 {
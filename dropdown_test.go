@@ -0,0 +1,139 @@
+package cview
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestDropDownRecentOptions(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	d := NewDropDown()
+	d.SetOptionsSimple(nil, "Alpha", "Beta", "Gamma", "Delta", "Epsilon")
+	d.SetRecentOptions(2)
+	d.SetOptionPinned(3, true)
+
+	app, err := newTestApp(d)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+
+	items := func() string {
+		var texts []string
+		for _, item := range d.list.GetItems() {
+			if item.isDivider() {
+				texts = append(texts, "-")
+				continue
+			}
+			texts = append(texts, item.GetMainText())
+		}
+		return strings.Join(texts, " ")
+	}
+	if l := items(); l != "Delta - Alpha Beta Gamma Epsilon" {
+		t.Errorf("failed to pin option: expected \"Delta - Alpha Beta Gamma Epsilon\", got %q", l)
+	}
+
+	// Select options
+
+	var selected []int
+	d.SetSelectedFunc(func(index int, option *DropDownOption) {
+		selected = append(selected, index)
+	})
+
+	setFocus := func(p Primitive) {
+		app.SetFocus(p)
+	}
+	choose := func(text string) {
+		d.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), setFocus)
+		for index, item := range d.list.GetItems() {
+			if item.GetMainText() == text {
+				d.list.SetCurrentItem(index)
+			}
+		}
+		d.list.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), setFocus)
+	}
+	choose("Gamma")
+	choose("Epsilon")
+	choose("Beta")
+
+	if len(selected) != 3 || selected[0] != 2 || selected[1] != 4 || selected[2] != 1 {
+		t.Errorf("failed to select options: expected [2 4 1], got %v", selected)
+	}
+
+	d.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), setFocus)
+	if l := items(); l != "Delta Beta Epsilon - Alpha Gamma" {
+		t.Errorf("failed to list recent options: expected \"Delta Beta Epsilon - Alpha Gamma\", got %q", l)
+	}
+	if index, _ := d.GetCurrentOption(); index != 1 || d.list.GetCurrentItemIndex() != 1 {
+		t.Errorf("failed to keep current option: expected option 1 at item 1, got option %d at item %d", index, d.list.GetCurrentItemIndex())
+	}
+
+	// Clear recent options
+
+	d.list.InputHandler()(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone), setFocus)
+	d.ClearRecentOptions()
+	d.SetOptionPinned(3, false)
+	if l := items(); l != "Alpha Beta Gamma Delta Epsilon" {
+		t.Errorf("failed to clear recent options: expected \"Alpha Beta Gamma Delta Epsilon\", got %q", l)
+	}
+}
+
+func TestDropDownOptionReference(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	d := NewDropDown()
+	d.SetOptionsSimple(nil, "Alpha", "Beta", "Gamma")
+	if reference := d.GetCurrentOptionReference(); reference != nil {
+		t.Errorf("failed to get reference: expected nil without a current option, got %v", reference)
+	}
+
+	// Set and get
+
+	for index := 0; index < 3; index++ {
+		d.SetOptionReference(index, index*10)
+	}
+	for index := 0; index < 3; index++ {
+		if reference := d.GetOptionReference(index); reference != index*10 {
+			t.Errorf("failed to get reference of option %d: expected %d, got %v", index, index*10, reference)
+		}
+	}
+
+	var selected interface{}
+	d.SetSelectedFunc(func(index int, option *DropDownOption) {
+		selected = option.GetReference()
+	})
+	d.SetCurrentOption(2)
+	if selected != 20 {
+		t.Errorf("failed to get reference in selected handler: expected 20, got %v", selected)
+	} else if reference := d.GetCurrentOptionReference(); reference != 20 {
+		t.Errorf("failed to get reference of current option: expected 20, got %v", reference)
+	}
+
+	// Out of range
+
+	d.SetOptionReference(-1, "invalid")
+	d.SetOptionReference(3, "invalid")
+	for _, index := range []int{-1, 3} {
+		if reference := d.GetOptionReference(index); reference != nil {
+			t.Errorf("failed to get reference of option %d: expected nil, got %v", index, reference)
+		}
+	}
+
+	// Removed options
+
+	d.SetOptionsSimple(nil, "Alpha")
+	if reference := d.GetOptionReference(0); reference != nil {
+		t.Errorf("failed to get reference of replaced option: expected nil, got %v", reference)
+	} else if reference := d.GetOptionReference(2); reference != nil {
+		t.Errorf("failed to get reference of removed option: expected nil, got %v", reference)
+	} else if reference := d.GetCurrentOptionReference(); reference != nil {
+		t.Errorf("failed to get reference of removed current option: expected nil, got %v", reference)
+	}
+}
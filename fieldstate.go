@@ -0,0 +1,52 @@
+package cview
+
+import "github.com/gdamore/tcell/v2"
+
+// FieldState represents the validation state of a form item.
+type FieldState int
+
+// Field states. Form items in the warning or error state are drawn using the
+// FieldWarningColor or FieldErrorColor of the theme, and their message is
+// shown below them when they are part of a Form.
+const (
+	FieldNormal FieldState = iota
+	FieldWarning
+	FieldError
+)
+
+// fieldStater is implemented by form items which have a FieldState.
+type fieldStater interface {
+	GetFieldState() (state FieldState, message string)
+}
+
+// fieldStateColor returns the theme color of the provided field state, or the
+// provided color when the state is FieldNormal.
+func fieldStateColor(state FieldState, color tcell.Color) tcell.Color {
+	switch state {
+	case FieldWarning:
+		return Styles.FieldWarningColor
+	case FieldError:
+		return Styles.FieldErrorColor
+	}
+	return color
+}
+
+// drawFieldStateBox draws the box of a form item, using the color of its field
+// state for the border.
+func drawFieldStateBox(b *Box, state FieldState, screen tcell.Screen) {
+	if state == FieldNormal {
+		b.Draw(screen)
+		return
+	}
+
+	b.l.Lock()
+	borderColor := b.borderColor
+	b.borderColor = fieldStateColor(state, borderColor)
+	b.l.Unlock()
+
+	b.Draw(screen)
+
+	b.l.Lock()
+	b.borderColor = borderColor
+	b.l.Unlock()
+}
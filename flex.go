@@ -152,6 +152,39 @@ func (f *Flex) ResizeItem(p Primitive, fixedSize, proportion int) {
 	}
 }
 
+// resizePane changes the size of the item with the provided primitive by the
+// provided delta. Proportional items are converted to fixed-size items. This
+// is used by the application's resize mode. Returns false if the primitive is
+// not an item of the flex or if the direction does not match.
+func (f *Flex) resizePane(p Primitive, horizontal bool, delta int) bool {
+	f.Lock()
+	defer f.Unlock()
+
+	if horizontal != (f.direction == FlexColumn) {
+		return false
+	}
+	for _, item := range f.items {
+		if item.Item != p {
+			continue
+		}
+		size := item.FixedSize
+		if size <= 0 {
+			_, _, width, height := p.GetRect()
+			size = height
+			if horizontal {
+				size = width
+			}
+		}
+		size += delta
+		if size < 1 {
+			size = 1
+		}
+		item.FixedSize = size
+		return true
+	}
+	return false
+}
+
 // Draw draws this primitive onto the screen.
 func (f *Flex) Draw(screen tcell.Screen) {
 	if !f.GetVisible() {
@@ -212,10 +245,11 @@ func (f *Flex) Draw(screen tcell.Screen) {
 		pos += size
 
 		if item.Item != nil {
+			f.passColors(item.Item)
 			if item.Item.GetFocusable().HasFocus() {
-				defer item.Item.Draw(screen)
+				defer drawPrimitive(item.Item, screen)
 			} else {
-				item.Item.Draw(screen)
+				drawPrimitive(item.Item, screen)
 			}
 		}
 	}
@@ -249,6 +283,20 @@ func (f *Flex) HasFocus() bool {
 	return false
 }
 
+// children returns the primitives contained in this primitive.
+func (f *Flex) children() []Primitive {
+	f.RLock()
+	defer f.RUnlock()
+
+	var children []Primitive
+	for _, item := range f.items {
+		if item.Item != nil {
+			children = append(children, item.Item)
+		}
+	}
+	return children
+}
+
 // MouseHandler returns the mouse handler for this primitive.
 func (f *Flex) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return f.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
@@ -136,6 +136,19 @@ type Form struct {
 	// The color of the button text when focused.
 	buttonTextColorFocused tcell.Color
 
+	// The help text of each item.
+	help map[FormItem]string
+
+	// The color of the help text.
+	helpTextColor tcell.Color
+
+	// An optional TextView which shows the help text of the focused item
+	// instead of showing it below the item.
+	helpPanel *TextView
+
+	// The help text last shown in the help panel.
+	helpPanelText string
+
 	// An optional function which is called when the user hits Escape.
 	cancel func()
 
@@ -160,6 +173,8 @@ func NewForm() *Form {
 		buttonTextColor:              Styles.PrimaryTextColor,
 		buttonTextColorFocused:       Styles.PrimaryTextColor,
 		labelColorFocused:            ColorUnset,
+		help:                         make(map[FormItem]string),
+		helpTextColor:                Styles.TertiaryTextColor,
 	}
 
 	f.focus = f
@@ -193,6 +208,18 @@ func (f *Form) SetLabelColor(color tcell.Color) {
 	defer f.Unlock()
 
 	f.labelColor = color
+	f.textColorSet = true
+}
+
+// inheritColors applies the colors inherited from the parent container. The
+// form passes them on to its items when it is drawn.
+func (f *Form) inheritColors(background, text tcell.Color) {
+	f.Box.inheritColors(background, text)
+
+	f.Lock()
+	defer f.Unlock()
+
+	f.inheritTextColor(&f.labelColor, text)
 }
 
 // SetLabelColorFocused sets the color of the labels when focused.
@@ -383,6 +410,23 @@ func (f *Form) AddCheckBox(label string, message string, checked bool, changed f
 	f.items = append(f.items, c)
 }
 
+// AddCheckBoxGroup adds a group of checkboxes to the form, one for each of the
+// provided options. It has a label and an (optional) callback function which is
+// invoked when the state of an option was changed by the user.
+func (f *Form) AddCheckBoxGroup(label string, changed func(index int, checked bool), options ...string) {
+	f.Lock()
+	defer f.Unlock()
+
+	c := NewCheckBoxGroup()
+	c.SetLabel(label)
+	for _, option := range options {
+		c.AddOption(option, false)
+	}
+	c.SetChangedFunc(changed)
+
+	f.items = append(f.items, c)
+}
+
 // AddSlider adds a slider to the form. It has a label, an initial value, a
 // maximum value, an amount to increment by when modified via keyboard, and an
 // (optional) callback function which is invoked when the state of the slider
@@ -461,6 +505,7 @@ func (f *Form) Clear(includeButtons bool) {
 	defer f.Unlock()
 
 	f.items = nil
+	f.help = make(map[FormItem]string)
 	if includeButtons {
 		f.buttons = nil
 	}
@@ -536,6 +581,7 @@ func (f *Form) RemoveFormItem(index int) {
 	f.Lock()
 	defer f.Unlock()
 
+	delete(f.help, f.items[index])
 	f.items = append(f.items[:index], f.items[index+1:]...)
 }
 
@@ -585,6 +631,84 @@ func (f *Form) GetFocusedItemIndex() (formItem, button int) {
 	return -1, index - len(f.items)
 }
 
+// SetItemHelp sets the help text of the form item at the given position,
+// starting with index 0. The help text of the focused item is shown on the
+// line below the item, or in the help panel if one is set via SetHelpPanel.
+// Help text is only shown below items when the form is laid out vertically.
+// Set an empty string to remove the help text.
+func (f *Form) SetItemHelp(index int, text string) {
+	f.Lock()
+	defer f.Unlock()
+
+	if index < 0 || index >= len(f.items) {
+		return
+	}
+
+	if text == "" {
+		delete(f.help, f.items[index])
+	} else {
+		f.help[f.items[index]] = text
+	}
+	f.updateHelpPanel()
+}
+
+// GetItemHelp returns the help text of the form item at the given position.
+func (f *Form) GetItemHelp(index int) string {
+	f.RLock()
+	defer f.RUnlock()
+
+	if index < 0 || index >= len(f.items) {
+		return ""
+	}
+	return f.help[f.items[index]]
+}
+
+// SetHelpTextColor sets the color of the help text shown below items.
+func (f *Form) SetHelpTextColor(color tcell.Color) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.helpTextColor = color
+}
+
+// SetHelpPanel sets a TextView which shows the help text of the focused item.
+// The text of the TextView is replaced each time the focus moves to another
+// item. When a help panel is set, help text is no longer shown below items.
+// Set nil to show help text below items again.
+func (f *Form) SetHelpPanel(panel *TextView) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.helpPanel = panel
+	if panel != nil {
+		f.helpPanelText = f.focusedHelp()
+		panel.SetText(f.helpPanelText)
+	}
+}
+
+// focusedHelp returns the help text of the focused item.
+func (f *Form) focusedHelp() string {
+	index := f.focusIndex()
+	if index < 0 || index >= len(f.items) {
+		return ""
+	}
+	return f.help[f.items[index]]
+}
+
+// updateHelpPanel shows the help text of the focused item in the help panel.
+func (f *Form) updateHelpPanel() {
+	if f.helpPanel == nil {
+		return
+	}
+
+	text := f.focusedHelp()
+	if text == f.helpPanelText {
+		return
+	}
+	f.helpPanelText = text
+	f.helpPanel.SetText(text)
+}
+
 // SetWrapAround sets the flag that determines whether navigating the form will
 // wrap around. That is, navigating downwards on the last item will move the
 // selection to the first item (similarly in the other direction). If set to
@@ -675,6 +799,14 @@ func (f *Form) Draw(screen tcell.Screen) {
 	// Calculate positions of form items.
 	positions := make([]struct{ x, y, width, height int }, len(f.items)+len(f.buttons))
 	var focusedPosition struct{ x, y, width, height int }
+	var helpText string
+	var helpPosition struct{ x, y, width int }
+	type fieldStateMessage struct {
+		x, y, width int
+		text        string
+		color       tcell.Color
+	}
+	var messages []fieldStateMessage
 	for index, item := range f.items {
 		if !item.GetVisible() {
 			continue
@@ -715,9 +847,37 @@ func (f *Form) Draw(screen tcell.Screen) {
 		positions[index].x = x
 		positions[index].y = y
 		positions[index].width = itemWidth
-		positions[index].height = 1
+		positions[index].height = item.GetFieldHeight()
+
+		// Reserve a line below the item for its validation message.
+		var messageHeight int
+		if stater, ok := item.(fieldStater); ok && !f.horizontal {
+			if state, message := stater.GetFieldState(); state != FieldNormal && message != "" {
+				messages = append(messages, fieldStateMessage{
+					x:     x + labelWidth,
+					y:     y + item.GetFieldHeight(),
+					width: itemWidth - labelWidth,
+					text:  message,
+					color: fieldStateColor(state, f.helpTextColor),
+				})
+				messageHeight = 1
+				y++
+			}
+		}
+
 		if item.GetFocusable().HasFocus() {
 			focusedPosition = positions[index]
+			focusedPosition.height += messageHeight
+
+			// Reserve a line below the item for its help text.
+			if text := f.help[item]; text != "" && f.helpPanel == nil && !f.horizontal {
+				helpText = text
+				helpPosition.x = x + labelWidth
+				helpPosition.y = y + item.GetFieldHeight()
+				helpPosition.width = itemWidth - labelWidth
+				focusedPosition.height++
+				y++
+			}
 		}
 
 		// Advance to next item.
@@ -801,6 +961,22 @@ func (f *Form) Draw(screen tcell.Screen) {
 		}
 	}
 
+	// Draw validation messages.
+	for _, message := range messages {
+		messageY := message.y - offset
+		if messageY >= topLimit && messageY < bottomLimit {
+			Print(screen, []byte(message.text), message.x, messageY, message.width, AlignLeft, message.color)
+		}
+	}
+
+	// Draw help text.
+	if helpText != "" {
+		helpY := helpPosition.y - offset
+		if helpY >= topLimit && helpY < bottomLimit {
+			Print(screen, []byte(helpText), helpPosition.x, helpY, helpPosition.width, AlignLeft, f.helpTextColor)
+		}
+	}
+
 	// Draw items.
 	for index, item := range f.items {
 		if !item.GetVisible() {
@@ -819,9 +995,9 @@ func (f *Form) Draw(screen tcell.Screen) {
 
 		// Draw items with focus last (in case of overlaps).
 		if item.GetFocusable().HasFocus() {
-			defer item.Draw(screen)
+			defer drawPrimitive(item, screen)
 		} else {
-			item.Draw(screen)
+			drawPrimitive(item, screen)
 		}
 	}
 
@@ -843,7 +1019,7 @@ func (f *Form) Draw(screen tcell.Screen) {
 		}
 
 		// Draw button.
-		button.Draw(screen)
+		drawPrimitive(button, screen)
 	}
 }
 
@@ -888,36 +1064,33 @@ func (f *Form) updateFocusedElement(decreasing bool) {
 
 func (f *Form) formItemInputHandler(delegate func(p Primitive)) func(key tcell.Key) {
 	return func(key tcell.Key) {
+		var calls callbacks
+		defer calls.run()
+
 		f.Lock()
+		defer f.Unlock()
 
+		focus := func() {
+			f.Focus(delegate)
+		}
 		switch key {
 		case tcell.KeyTab, tcell.KeyEnter:
 			f.focusedElement++
 			f.updateFocusedElement(false)
-			f.Unlock()
-			f.Focus(delegate)
-			f.Lock()
+			calls.add(focus)
 		case tcell.KeyBacktab:
 			f.focusedElement--
 			f.updateFocusedElement(true)
-			f.Unlock()
-			f.Focus(delegate)
-			f.Lock()
+			calls.add(focus)
 		case tcell.KeyEscape:
 			if f.cancel != nil {
-				f.Unlock()
-				f.cancel()
-				f.Lock()
+				calls.add(f.cancel)
 			} else {
 				f.focusedElement = 0
 				f.updateFocusedElement(true)
-				f.Unlock()
-				f.Focus(delegate)
-				f.Lock()
+				calls.add(focus)
 			}
 		}
-
-		f.Unlock()
 	}
 }
 
@@ -947,6 +1120,10 @@ func (f *Form) Focus(delegate func(p Primitive)) {
 
 		setFormItemAttributes(item, attributes)
 		delegate(item)
+
+		f.Lock()
+		f.updateHelpPanel()
+		f.Unlock()
 	} else {
 		// We're selecting a button.
 		button := f.buttons[f.focusedElement-len(f.items)]
@@ -955,6 +1132,10 @@ func (f *Form) Focus(delegate func(p Primitive)) {
 		f.Unlock()
 
 		delegate(button)
+
+		f.Lock()
+		f.updateHelpPanel()
+		f.Unlock()
 	}
 }
 
@@ -969,6 +1150,21 @@ func (f *Form) HasFocus() bool {
 	return f.focusIndex() >= 0
 }
 
+// children returns the primitives contained in this primitive.
+func (f *Form) children() []Primitive {
+	f.RLock()
+	defer f.RUnlock()
+
+	children := make([]Primitive, 0, len(f.items)+len(f.buttons))
+	for _, item := range f.items {
+		children = append(children, item)
+	}
+	for _, button := range f.buttons {
+		children = append(children, button)
+	}
+	return children
+}
+
 // focusIndex returns the index of the currently focused item, counting form
 // items first, then buttons. A negative value indicates that no containeed item
 // has focus.
@@ -993,15 +1189,24 @@ func (f *Form) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 			return false, nil
 		}
 
+		// Show the help text of items focused via the mouse.
+		setItemFocus := func(p Primitive) {
+			setFocus(p)
+
+			f.Lock()
+			f.updateHelpPanel()
+			f.Unlock()
+		}
+
 		// Determine items to pass mouse events to.
 		for _, item := range f.items {
-			consumed, capture = item.MouseHandler()(action, event, setFocus)
+			consumed, capture = item.MouseHandler()(action, event, setItemFocus)
 			if consumed {
 				return
 			}
 		}
 		for _, button := range f.buttons {
-			consumed, capture = button.MouseHandler()(action, event, setFocus)
+			consumed, capture = button.MouseHandler()(action, event, setItemFocus)
 			if consumed {
 				return
 			}
@@ -1011,9 +1216,9 @@ func (f *Form) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 		// element.
 		if action == MouseLeftClick {
 			if f.focusedElement < len(f.items) {
-				setFocus(f.items[f.focusedElement])
+				setItemFocus(f.items[f.focusedElement])
 			} else if f.focusedElement < len(f.items)+len(f.buttons) {
-				setFocus(f.buttons[f.focusedElement-len(f.items)])
+				setItemFocus(f.buttons[f.focusedElement-len(f.items)])
 			}
 			consumed = true
 		}
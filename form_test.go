@@ -0,0 +1,309 @@
+package cview
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestFormLabelTags(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	f := NewForm()
+	f.SetBorderPadding(0, 0, 0, 0)
+	f.SetItemPadding(0)
+	f.AddInputField("[red]Name[-]", "", 10, nil, nil)
+	f.AddDropDownSimple("[::b]Size", 0, nil, "S", "M")
+	f.AddCheckBox("[red]Check", "[green]Message", false, nil)
+	f.AddButton("[yellow]OK", nil)
+
+	app, err := newTestApp(f)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	app.screen.Init()
+	f.SetRect(0, 0, 40, 10)
+	f.Draw(app.screen)
+
+	line := func(y, width int) string {
+		var b strings.Builder
+		for x := 0; x < width; x++ {
+			r, _, _, _ := app.screen.GetContent(x, y)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+
+	// Labels
+
+	for y, expected := range []string{"Name  ", "Size  ", "Check "} {
+		if l := line(y, len(expected)); l != expected {
+			t.Errorf("failed to draw label %d: expected %q, got %q", y, expected, l)
+		}
+	}
+	_, _, style, _ := app.screen.GetContent(0, 0)
+	if fg, _, _ := style.Decompose(); fg != tcell.ColorRed {
+		t.Errorf("failed to apply label color: expected red, got %v", fg)
+	}
+	_, _, style, _ = app.screen.GetContent(0, 1)
+	if _, _, attr := style.Decompose(); attr&tcell.AttrBold == 0 {
+		t.Errorf("failed to apply label attributes: expected bold")
+	}
+
+	// Message
+
+	checkBox := f.GetFormItem(2).(*CheckBox)
+	if width := checkBox.GetFieldWidth(); width != 2+len("Message") {
+		t.Errorf("failed to measure message: expected width %d, got %d", 2+len("Message"), width)
+	}
+	if l := line(2, 17); !strings.HasSuffix(l, "Message") {
+		t.Errorf("failed to draw message: got %q", l)
+	}
+
+	// Button
+
+	if l := line(4, 6); l != "  OK  " {
+		t.Errorf("failed to draw button label: expected \"  OK  \", got %q", l)
+	}
+}
+
+func TestFormFieldState(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	f := NewForm()
+	f.SetBorderPadding(0, 0, 0, 0)
+	f.SetItemPadding(0)
+	f.AddInputField("Name", "", 10, nil, nil)
+	f.AddDropDownSimple("Size", 0, nil, "S", "M")
+	f.AddCheckBox("Check", "", false, nil)
+	f.AddSlider("Level", 5, 10, 1, nil)
+
+	name := f.GetFormItem(0).(*InputField)
+	name.SetFieldState(FieldError, "Name is required")
+	check := f.GetFormItem(2).(*CheckBox)
+	check.SetFieldState(FieldWarning, "")
+	if state, message := check.GetFieldState(); state != FieldWarning || message != "" {
+		t.Errorf("failed to get field state: expected warning, got %d %q", state, message)
+	}
+
+	app, err := newTestApp(f)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	app.screen.Init()
+	f.SetRect(0, 0, 40, 10)
+	f.Draw(app.screen)
+
+	line := func(y, width int) string {
+		var b strings.Builder
+		for x := 0; x < width; x++ {
+			r, _, _, _ := app.screen.GetContent(x, y)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+
+	// Draw
+
+	for y, expected := range []string{"Name  ", "      Name is required", "Size  ", "Check ", "Level "} {
+		if l := line(y, len(expected)); l != expected {
+			t.Errorf("failed to draw line %d: expected %q, got %q", y, expected, l)
+		}
+	}
+
+	for _, cell := range []struct {
+		x, y       int
+		foreground bool
+		color      tcell.Color
+	}{
+		{6, 0, false, Styles.FieldErrorColor},
+		{6, 1, true, Styles.FieldErrorColor},
+		{6, 2, false, Styles.MoreContrastBackgroundColor},
+		{6, 3, false, Styles.FieldWarningColor},
+	} {
+		_, _, style, _ := app.screen.GetContent(cell.x, cell.y)
+		fg, bg, _ := style.Decompose()
+		color := bg
+		if cell.foreground {
+			color = fg
+		}
+		if color != cell.color {
+			t.Errorf("failed to draw field state at %d,%d: expected %v, got %v", cell.x, cell.y, cell.color, color)
+		}
+	}
+}
+
+func TestFormCallbacks(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	f := NewForm()
+	f.AddInputField("Name", "", 10, nil, nil)
+	f.AddInputField("Email", "", 10, nil, nil)
+
+	var canceled int
+	f.SetCancelFunc(func() {
+		// Calling methods of the form from its callbacks must not deadlock.
+		f.GetFocusedItemIndex()
+		f.SetFocus(0)
+		canceled++
+	})
+
+	var focused Primitive
+	delegate := func(p Primitive) {
+		focused = p
+	}
+	f.Focus(delegate)
+
+	// Move to the next field and cancel
+
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+
+		name := f.GetFormItem(0).(*InputField)
+		name.InputHandler()(tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone), delegate)
+		if focused != f.GetFormItem(1) {
+			t.Errorf("failed to move focus: expected second item to be focused")
+		}
+		email := f.GetFormItem(1).(*InputField)
+		email.InputHandler()(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone), delegate)
+	}()
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("failed to call Form callbacks: deadlock")
+	}
+
+	if canceled != 1 {
+		t.Errorf("failed to call cancel handler: expected 1 call, got %d", canceled)
+	}
+}
+
+func TestFormHelpPanel(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	f := NewForm()
+	f.SetBorderPadding(0, 0, 0, 0)
+	f.SetItemPadding(0)
+	f.AddInputField("Name", "", 10, nil, nil)
+	f.AddInputField("Email", "", 10, nil, nil)
+	f.SetItemHelp(0, "Your name")
+	f.SetItemHelp(1, "Your email")
+
+	panel := NewTextView()
+	f.SetHelpPanel(panel)
+
+	app, err := newTestApp(f)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	f.SetRect(0, 0, 40, 10)
+	f.Draw(app.screen)
+
+	expectHelp := func(expected string) {
+		if text := panel.GetText(false); text != expected {
+			t.Errorf("failed to update help panel: expected %q, got %q", expected, text)
+		}
+	}
+
+	// Focus
+
+	app.SetFocus(f)
+	expectHelp("Your name")
+
+	// Draw does not update the help panel
+
+	panel.SetText("")
+	f.Draw(app.screen)
+	expectHelp("")
+
+	// Move focus via the keyboard
+
+	f.GetFormItem(0).(*InputField).InputHandler()(tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone), func(p Primitive) {})
+	expectHelp("Your email")
+
+	// Move focus via the mouse
+
+	f.MouseHandler()(MouseLeftClick, tcell.NewEventMouse(8, 0, tcell.Button1, tcell.ModNone), func(p Primitive) {
+		app.SetFocus(p)
+	})
+	expectHelp("Your name")
+
+	// Change help text of the focused item
+
+	f.SetItemHelp(0, "Your full name")
+	expectHelp("Your full name")
+	f.SetItemHelp(1, "Your email address")
+	expectHelp("Your full name")
+}
+
+func TestFormItemColors(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	f := NewForm()
+	f.AddInputField("Name", "", 0, nil, nil)
+	input := f.GetFormItem(0).(*InputField)
+
+	flex := NewFlex()
+	flex.SetItemColors(tcell.ColorNavy, tcell.ColorYellow)
+	flex.AddItem(f, 0, 1, false)
+	flex.SetRect(0, 0, 40, 10)
+
+	app, err := newTestApp(flex)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+
+	// Inherit
+
+	flex.Draw(app.screen)
+
+	if color := input.GetBackgroundColor(); color != tcell.ColorNavy {
+		t.Errorf("failed to inherit background color: expected %v, got %v", tcell.ColorNavy, color)
+	}
+	if color := input.labelColor; color != tcell.ColorYellow {
+		t.Errorf("failed to inherit label color: expected %v, got %v", tcell.ColorYellow, color)
+	}
+
+	// Unset
+
+	flex.SetItemColors(ColorUnset, ColorUnset)
+	flex.Draw(app.screen)
+
+	if color := input.GetBackgroundColor(); color != Styles.PrimitiveBackgroundColor {
+		t.Errorf("failed to restore background color: expected %v, got %v", Styles.PrimitiveBackgroundColor, color)
+	}
+	if color := input.labelColor; color != Styles.SecondaryTextColor {
+		t.Errorf("failed to restore label color: expected %v, got %v", Styles.SecondaryTextColor, color)
+	}
+
+	// Explicit label color
+
+	f.SetLabelColor(tcell.ColorRed)
+	flex.SetItemColors(tcell.ColorNavy, tcell.ColorYellow)
+	flex.Draw(app.screen)
+
+	if color := input.labelColor; color != tcell.ColorRed {
+		t.Errorf("failed to keep explicit label color: expected %v, got %v", tcell.ColorRed, color)
+	}
+}
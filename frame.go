@@ -154,7 +154,8 @@ func (f *Frame) Draw(screen tcell.Screen) {
 	f.primitive.SetRect(x, top, width, bottom+1-top)
 
 	// Finally, draw the contained primitive.
-	f.primitive.Draw(screen)
+	f.passColors(f.primitive)
+	drawPrimitive(f.primitive, screen)
 }
 
 // Focus is called when this primitive receives focus.
@@ -178,6 +179,17 @@ func (f *Frame) HasFocus() bool {
 	return false
 }
 
+// children returns the primitives contained in this primitive.
+func (f *Frame) children() []Primitive {
+	f.RLock()
+	defer f.RUnlock()
+
+	if f.primitive == nil {
+		return nil
+	}
+	return []Primitive{f.primitive}
+}
+
 // MouseHandler returns the mouse handler for this primitive.
 func (f *Frame) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return f.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
@@ -2,6 +2,7 @@ package cview
 
 import (
 	"math"
+	"sort"
 	"sync"
 
 	"github.com/gdamore/tcell/v2"
@@ -14,6 +15,8 @@ type gridItem struct {
 	Width, Height               int       // The number of rows and columns the item occupies.
 	MinGridWidth, MinGridHeight int       // The minimum grid width/height for which this item is visible.
 	Focus                       bool      // Whether or not this item attracts the layout's focus.
+	Layer                       int       // Items on higher layers are drawn above items on lower layers.
+	Hidden                      bool      // Whether or not this item is hidden.
 
 	visible    bool // Whether or not this item was visible the last time the grid was drawn.
 	x, y, w, h int  // The last position of the item relative to the top-left corner of the grid. Undefined if visible is false.
@@ -207,6 +210,10 @@ func (g *Grid) SetBordersColor(color tcell.Color) {
 // To use the same grid layout for all sizes, simply set minGridWidth and
 // minGridHeight to 0.
 //
+// Different primitives may occupy the same grid cells. Use SetItemLayer() to
+// layer primitives such as badges or placeholders over other primitives, and
+// SetItemVisible() to show and hide them.
+//
 // If the item's focus is set to true, it will receive focus when the grid
 // receives focus. If there are multiple items with a true focus flag, the last
 // visible one that was added will receive focus.
@@ -226,6 +233,58 @@ func (g *Grid) AddItem(p Primitive, row, column, rowSpan, colSpan, minGridHeight
 	})
 }
 
+// SetItemLayer sets the layer of all items of the provided primitive. Items on
+// higher layers are drawn above items on lower layers and receive mouse events
+// first. Items on the same layer are drawn in the order they were added. The
+// default layer is 0.
+func (g *Grid) SetItemLayer(p Primitive, layer int) {
+	g.Lock()
+	defer g.Unlock()
+
+	for _, item := range g.items {
+		if item.Item == p {
+			item.Layer = layer
+		}
+	}
+}
+
+// SetItemVisible sets whether the items of the provided primitive are shown.
+// Hidden items are neither drawn nor focused when the grid receives focus.
+func (g *Grid) SetItemVisible(p Primitive, visible bool) {
+	g.Lock()
+	defer g.Unlock()
+
+	for _, item := range g.items {
+		if item.Item == p {
+			item.Hidden = !visible
+		}
+	}
+}
+
+// IsItemVisible returns whether the items of the provided primitive are shown.
+// Returns false if the primitive is not an item of the grid.
+func (g *Grid) IsItemVisible(p Primitive) bool {
+	g.RLock()
+	defer g.RUnlock()
+
+	for _, item := range g.items {
+		if item.Item == p {
+			return !item.Hidden
+		}
+	}
+	return false
+}
+
+// layeredItems returns the items of the grid ordered by layer, keeping the
+// order in which they were added within each layer.
+func (g *Grid) layeredItems() []*gridItem {
+	items := append([]*gridItem(nil), g.items...)
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Layer < items[j].Layer
+	})
+	return items
+}
+
 // RemoveItem removes all items for the given primitive from the grid, keeping
 // the order of the remaining items intact.
 func (g *Grid) RemoveItem(p Primitive) {
@@ -275,7 +334,7 @@ func (g *Grid) Focus(delegate func(p Primitive)) {
 	g.Unlock()
 
 	for _, item := range items {
-		if item.Focus {
+		if item.Focus && !item.Hidden {
 			delegate(item.Item)
 			return
 		}
@@ -307,6 +366,20 @@ func (g *Grid) HasFocus() bool {
 	return g.hasFocus
 }
 
+// children returns the primitives contained in this primitive.
+func (g *Grid) children() []Primitive {
+	g.RLock()
+	defer g.RUnlock()
+
+	var children []Primitive
+	for _, item := range g.items {
+		if item.Item != nil {
+			children = append(children, item.Item)
+		}
+	}
+	return children
+}
+
 // InputHandler returns the handler for this primitive.
 func (g *Grid) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
 	return g.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
@@ -329,6 +402,44 @@ func (g *Grid) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 	})
 }
 
+// resizePane changes the size of the last row or column occupied by the
+// provided primitive by the provided delta. Proportional rows and columns are
+// converted to fixed sizes. This is used by the application's resize mode.
+// Returns false if the primitive is not an item of the grid.
+func (g *Grid) resizePane(p Primitive, horizontal bool, delta int) bool {
+	g.Lock()
+	defer g.Unlock()
+
+	for _, item := range g.items {
+		if item.Item != p || !item.visible {
+			continue
+		}
+
+		sizes := &g.rows
+		index, span, size := item.Row+item.Height-1, item.Height, item.h
+		if horizontal {
+			sizes = &g.columns
+			index, span, size = item.Column+item.Width-1, item.Width, item.w
+		}
+		*sizes = append([]int(nil), *sizes...)
+		for len(*sizes) <= index {
+			*sizes = append(*sizes, 0)
+		}
+		if (*sizes)[index] > 0 {
+			size = (*sizes)[index]
+		} else {
+			size /= span
+		}
+		size += delta
+		if size < 1 {
+			size = 1
+		}
+		(*sizes)[index] = size
+		return true
+	}
+	return false
+}
+
 // Draw draws this primitive onto the screen.
 func (g *Grid) Draw(screen tcell.Screen) {
 	if !g.GetVisible() {
@@ -347,7 +458,7 @@ func (g *Grid) Draw(screen tcell.Screen) {
 	items := make(map[Primitive]*gridItem)
 	for _, item := range g.items {
 		item.visible = false
-		if item.Width <= 0 || item.Height <= 0 || width < item.MinGridWidth || height < item.MinGridHeight {
+		if item.Hidden || item.Width <= 0 || item.Height <= 0 || width < item.MinGridWidth || height < item.MinGridHeight {
 			continue
 		}
 		previousItem, ok := items[item.Item]
@@ -606,12 +717,21 @@ func (g *Grid) Draw(screen tcell.Screen) {
 		g.columnOffset = to
 	}
 
+	// The focused item is drawn last unless items are layered above it.
+	maxLayer := math.MinInt32
+	for _, item := range items {
+		if item.Layer > maxLayer {
+			maxLayer = item.Layer
+		}
+	}
+
 	// Draw primitives and borders.
-	for primitive, item := range items {
+	for _, item := range g.layeredItems() {
 		// Final primitive position.
-		if !item.visible {
+		if items[item.Item] != item || !item.visible {
 			continue
 		}
+		primitive := item.Item
 		item.x -= offsetX
 		item.y -= offsetY
 		if item.x >= width || item.x+item.w <= 0 || item.y >= height || item.y+item.h <= 0 {
@@ -641,10 +761,11 @@ func (g *Grid) Draw(screen tcell.Screen) {
 		primitive.SetRect(item.x, item.y, item.w, item.h)
 
 		// Draw primitive.
-		if item == focus {
-			defer primitive.Draw(screen)
+		g.passColors(primitive)
+		if item == focus && item.Layer == maxLayer {
+			defer drawPrimitive(primitive, screen)
 		} else {
-			primitive.Draw(screen)
+			drawPrimitive(primitive, screen)
 		}
 
 		// Draw border around primitive.
@@ -702,9 +823,19 @@ func (g *Grid) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 			return false, nil
 		}
 
-		// Pass mouse events along to the first child item under the mouse that consumes it.
+		g.RLock()
+		var items []*gridItem
+		for _, item := range g.layeredItems() {
+			if !item.Hidden {
+				items = append(items, item)
+			}
+		}
+		g.RUnlock()
+
+		// Pass mouse events along to the topmost child item under the mouse that consumes it.
 		x, y := event.Position()
-		for _, item := range g.items {
+		for i := len(items) - 1; i >= 0; i-- {
+			item := items[i]
 			rectX, rectY, width, height := item.Item.GetRect()
 			inRect := x >= rectX && x < rectX+width && y >= rectY && y < rectY+height
 			if !inRect {
@@ -0,0 +1,89 @@
+package cview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestGridLayers(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	content := NewTextView()
+	content.SetText("content")
+	badge := NewTextView()
+	badge.SetText("badge")
+	placeholder := NewTextView()
+	placeholder.SetText("empty")
+
+	g := NewGrid()
+	g.AddItem(badge, 0, 0, 1, 1, 0, 0, false)
+	g.AddItem(placeholder, 0, 0, 1, 1, 0, 0, false)
+	g.AddItem(content, 0, 0, 1, 1, 0, 0, true)
+	g.SetItemLayer(badge, 2)
+	g.SetItemLayer(placeholder, 1)
+	g.SetItemVisible(placeholder, false)
+	g.SetRect(0, 0, 10, 1)
+
+	app, err := newTestApp(g)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+
+	screenLine := func() string {
+		var line []rune
+		for x := 0; x < 10; x++ {
+			r, _, _, _ := app.screen.GetContent(x, 0)
+			line = append(line, r)
+		}
+		return string(line)
+	}
+
+	// Draw layers
+
+	g.Draw(app.screen)
+	if line := screenLine(); line != "badge     " {
+		t.Errorf("failed to draw layers: expected %q, got %q", "badge     ", line)
+	}
+
+	g.SetItemLayer(badge, -1)
+	g.Draw(app.screen)
+	if line := screenLine(); line != "content   " {
+		t.Errorf("failed to draw layers: expected %q, got %q", "content   ", line)
+	}
+
+	// Toggle visibility
+
+	g.SetItemVisible(placeholder, true)
+	if !g.IsItemVisible(placeholder) {
+		t.Errorf("failed to show item: expected visible, got hidden")
+	}
+	g.Draw(app.screen)
+	if line := screenLine(); line != "empty     " {
+		t.Errorf("failed to show item: expected %q, got %q", "empty     ", line)
+	}
+
+	// Route mouse events to the topmost item
+
+	var clicked Primitive
+	for _, p := range []*TextView{content, badge, placeholder} {
+		p := p // Capture
+		p.SetMouseCapture(func(action MouseAction, event *tcell.EventMouse) (MouseAction, *tcell.EventMouse) {
+			if action == MouseLeftClick {
+				clicked = p
+			}
+			return action, event
+		})
+	}
+	g.MouseHandler()(MouseLeftClick, tcell.NewEventMouse(1, 0, tcell.Button1, 0), func(p Primitive) {})
+	if clicked != placeholder {
+		t.Errorf("failed to route mouse event to topmost item")
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"unicode/utf8"
 
 	"github.com/gdamore/tcell/v2"
-	"github.com/mattn/go-runewidth"
 )
 
 // InputField is a one-line box (three lines if there is a title) where the
@@ -136,6 +135,10 @@ type InputField struct {
 	// The number of bytes of the text string skipped ahead while drawing.
 	offset int
 
+	// The validation state and its message.
+	fieldState        FieldState
+	fieldStateMessage string
+
 	sync.RWMutex
 }
 
@@ -182,7 +185,8 @@ func (i *InputField) GetText() string {
 	return string(i.text)
 }
 
-// SetLabel sets the text to be displayed before the input area.
+// SetLabel sets the text to be displayed before the input area. The text may
+// contain color tags.
 func (i *InputField) SetLabel(label string) {
 	i.Lock()
 	defer i.Unlock()
@@ -221,6 +225,25 @@ func (i *InputField) SetLabelColor(color tcell.Color) {
 	defer i.Unlock()
 
 	i.labelColor = color
+	i.textColorSet = true
+}
+
+// inheritColors applies the colors inherited from the parent container.
+func (i *InputField) inheritColors(background, text tcell.Color) {
+	i.Box.inheritColors(background, text)
+
+	i.Lock()
+	defer i.Unlock()
+
+	i.inheritTextColor(&i.labelColor, text)
+}
+
+// primaryTextColor returns the color of the primary text.
+func (i *InputField) primaryTextColor() tcell.Color {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.labelColor
 }
 
 // SetLabelColorFocused sets the color of the label when focused.
@@ -264,6 +287,25 @@ func (i *InputField) SetFieldTextColorFocused(color tcell.Color) {
 	i.fieldTextColorFocused = color
 }
 
+// SetFieldState sets the validation state of the input field and a message
+// describing it. Warnings and errors are drawn using the FieldWarningColor and
+// FieldErrorColor of the theme. Within a Form, the message is shown below the
+// input field.
+func (i *InputField) SetFieldState(state FieldState, message string) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.fieldState, i.fieldStateMessage = state, message
+}
+
+// GetFieldState returns the validation state of the input field and its message.
+func (i *InputField) GetFieldState() (state FieldState, message string) {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.fieldState, i.fieldStateMessage
+}
+
 // SetPlaceholderTextColor sets the text color of placeholder text.
 func (i *InputField) SetPlaceholderTextColor(color tcell.Color) {
 	i.Lock()
@@ -541,7 +583,10 @@ func (i *InputField) Draw(screen tcell.Screen) {
 		return
 	}
 
-	i.Box.Draw(screen)
+	i.RLock()
+	fieldState := i.fieldState
+	i.RUnlock()
+	drawFieldStateBox(i.Box, fieldState, screen)
 
 	i.Lock()
 	defer i.Unlock()
@@ -562,6 +607,8 @@ func (i *InputField) Draw(screen tcell.Screen) {
 		}
 	}
 
+	fieldBackgroundColor = fieldStateColor(fieldState, fieldBackgroundColor)
+
 	// Prepare
 	x, y, width, height := i.GetInnerRect()
 	rightLimit := x + width
@@ -613,7 +660,7 @@ func (i *InputField) Draw(screen tcell.Screen) {
 			text = bytes.Repeat([]byte(string(i.maskCharacter)), utf8.RuneCount(i.text))
 		}
 		var drawnText []byte
-		if fieldWidth > runewidth.StringWidth(string(text)) {
+		if fieldWidth > stringWidth(string(text)) {
 			// We have enough space for the full text.
 			drawnText = EscapeBytes(text)
 			Print(screen, drawnText, x, y, fieldWidth, AlignLeft, fieldTextColor)
@@ -636,7 +683,7 @@ func (i *InputField) Draw(screen tcell.Screen) {
 			var shiftLeft int
 			if i.offset > i.cursorPos {
 				i.offset = i.cursorPos
-			} else if subWidth := runewidth.StringWidth(string(text[i.offset:i.cursorPos])); subWidth > fieldWidth-1 {
+			} else if subWidth := stringWidth(string(text[i.offset:i.cursorPos])); subWidth > fieldWidth-1 {
 				shiftLeft = subWidth - fieldWidth + 1
 			}
 			currentOffset := i.offset
@@ -659,7 +706,7 @@ func (i *InputField) Draw(screen tcell.Screen) {
 		}
 		// Draw suggestion
 		if i.maskCharacter == 0 && len(i.autocompleteListSuggestion) > 0 {
-			Print(screen, i.autocompleteListSuggestion, x+runewidth.StringWidth(string(drawnText)), y, fieldWidth-runewidth.StringWidth(string(drawnText)), AlignLeft, i.autocompleteSuggestionTextColor)
+			Print(screen, i.autocompleteListSuggestion, x+stringWidth(string(drawnText)), y, fieldWidth-stringWidth(string(drawnText)), AlignLeft, i.autocompleteSuggestionTextColor)
 		}
 	}
 
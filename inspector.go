@@ -0,0 +1,338 @@
+package cview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// EnableInspector sets whether the inspector may be toggled via Keys.Inspect
+// (F12 by default). The inspector is a development aid which highlights a
+// primitive and shows its type, ID, position, focus state and handlers in an
+// overlay panel. See SetInspectMode.
+func (a *Application) EnableInspector(enable bool) {
+	a.Lock()
+	a.inspectorEnabled = enable
+	a.Unlock()
+
+	if !enable {
+		a.SetInspectMode(false)
+	}
+}
+
+// SetInspectMode enables or disables the inspector. While the inspector is
+// enabled, key and mouse events are not passed to primitives. Instead, the
+// primitive under the mouse cursor is inspected. Clicking a primitive selects
+// it, and clicking the selected primitive again selects its parent, so that
+// the primitive tree may be clicked through. A right click releases the
+// selection. The arrow keys select the parent (up), the first child (down)
+// and the siblings (left and right) of the inspected primitive. Enter focuses
+// the inspected primitive, and Escape disables the inspector.
+//
+// The focused primitive is inspected when the inspector is enabled.
+func (a *Application) SetInspectMode(inspect bool) {
+	a.Lock()
+	a.inspecting = inspect
+	a.inspectPath, a.inspectPinned = nil, false
+	if inspect {
+		a.inspectPath = findPath(a.root, a.focus)
+		if a.inspectPath == nil && a.root != nil {
+			a.inspectPath = []Primitive{a.root}
+		}
+	}
+	a.Unlock()
+
+	a.queueIndicatorUpdate()
+}
+
+// GetInspectMode returns whether the inspector is enabled.
+func (a *Application) GetInspectMode() bool {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.inspecting
+}
+
+// GetInspectedPrimitive returns the primitive shown by the inspector, or nil
+// if the inspector is disabled.
+func (a *Application) GetInspectedPrimitive() Primitive {
+	a.RLock()
+	defer a.RUnlock()
+
+	if len(a.inspectPath) == 0 {
+		return nil
+	}
+	return a.inspectPath[len(a.inspectPath)-1]
+}
+
+// setInspectPath sets the primitives from the root to the inspected primitive.
+func (a *Application) setInspectPath(path []Primitive, pinned bool) {
+	if len(path) == 0 {
+		return
+	}
+
+	a.Lock()
+	defer a.Unlock()
+
+	a.inspectPath, a.inspectPinned = path, pinned
+}
+
+// handleInspectorKey handles a key event while the inspector is enabled, or
+// enables the inspector. Returns whether the event was handled.
+func (a *Application) handleInspectorKey(event *tcell.EventKey) bool {
+	a.RLock()
+	enabled, inspecting := a.inspectorEnabled, a.inspecting
+	path := append([]Primitive(nil), a.inspectPath...)
+	a.RUnlock()
+
+	if !inspecting {
+		if !enabled || !HitShortcut(event, Keys.Inspect) {
+			return false
+		}
+		a.SetInspectMode(true)
+		return true
+	}
+
+	switch {
+	case HitShortcut(event, Keys.Cancel, Keys.Inspect):
+		a.SetInspectMode(false)
+	case HitShortcut(event, Keys.Select):
+		a.SetInspectMode(false)
+		if len(path) > 0 {
+			a.SetFocus(path[len(path)-1])
+		}
+	case HitShortcut(event, Keys.MoveUp, Keys.MoveUp2):
+		if len(path) > 1 {
+			a.setInspectPath(path[:len(path)-1], true)
+		}
+	case HitShortcut(event, Keys.MoveDown, Keys.MoveDown2):
+		if len(path) > 0 {
+			if children := inspectChildren(path[len(path)-1]); len(children) > 0 {
+				a.setInspectPath(append(path, children[0]), true)
+			}
+		}
+	case HitShortcut(event, Keys.MoveLeft, Keys.MoveLeft2, Keys.MoveRight, Keys.MoveRight2):
+		if len(path) < 2 {
+			break
+		}
+		siblings := inspectChildren(path[len(path)-2])
+		for i, sibling := range siblings {
+			if sibling != path[len(path)-1] {
+				continue
+			}
+			if HitShortcut(event, Keys.MoveLeft, Keys.MoveLeft2) {
+				i--
+			} else {
+				i++
+			}
+			if i >= 0 && i < len(siblings) {
+				path[len(path)-1] = siblings[i]
+				a.setInspectPath(path, true)
+			}
+			break
+		}
+	}
+	return true // Don't pass keys to the focused primitive while inspecting.
+}
+
+// handleInspectorMouse handles a mouse event while the inspector is enabled.
+// Returns whether the event was handled.
+func (a *Application) handleInspectorMouse(event *tcell.EventMouse, root Primitive) bool {
+	a.RLock()
+	inspecting, pinned := a.inspecting, a.inspectPinned
+	path := append([]Primitive(nil), a.inspectPath...)
+	lastButtons := a.lastMouseButtons
+	a.RUnlock()
+
+	if !inspecting {
+		return false
+	}
+
+	x, y := event.Position()
+	buttons := event.Buttons()
+	pressed := buttons &^ lastButtons
+	at := primitivePathAt(root, x, y)
+	switch {
+	case pressed&tcell.ButtonPrimary != 0:
+		// Select the parent of the selected primitive when it is clicked
+		// again, otherwise the primitive under the mouse cursor.
+		if pinned && len(path) > 1 && len(at) >= len(path) && at[len(path)-1] == path[len(path)-1] {
+			a.setInspectPath(path[:len(path)-1], true)
+		} else {
+			a.setInspectPath(at, true)
+		}
+	case pressed&tcell.ButtonSecondary != 0:
+		a.setInspectPath(at, false)
+	case !pinned:
+		a.setInspectPath(at, false)
+	}
+	return true
+}
+
+// inspectChildren returns the visible primitives contained in a primitive.
+func inspectChildren(p Primitive) []Primitive {
+	if panels, ok := p.(*Panels); ok {
+		panels.RLock()
+		defer panels.RUnlock()
+
+		var children []Primitive
+		for _, panel := range panels.panels {
+			if panel.Visible && panel.Item != nil {
+				children = append(children, panel.Item)
+			}
+		}
+		return children
+	}
+
+	c, ok := p.(containerPrimitive)
+	if !ok {
+		return nil
+	}
+	var children []Primitive
+	for _, child := range c.children() {
+		if child != nil && child.GetVisible() {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// primitivePathAt returns the primitives from the provided primitive to the
+// innermost primitive at the provided position, or nil if the position is
+// outside of the primitive.
+func primitivePathAt(p Primitive, x, y int) []Primitive {
+	if p == nil || !p.GetVisible() {
+		return nil
+	}
+	px, py, width, height := p.GetRect()
+	if x < px || x >= px+width || y < py || y >= py+height {
+		return nil
+	}
+
+	// Children which are drawn last are on top.
+	children := inspectChildren(p)
+	for i := len(children) - 1; i >= 0; i-- {
+		if path := primitivePathAt(children[i], x, y); path != nil {
+			return append([]Primitive{p}, path...)
+		}
+	}
+	return []Primitive{p}
+}
+
+// inspectorText returns the lines of the inspector panel describing the last
+// primitive of the provided path.
+func (a *Application) inspectorText(path []Primitive) []string {
+	p := path[len(path)-1]
+
+	names := make([]string, len(path))
+	for i, primitive := range path {
+		names[i] = primitiveName(primitive)
+	}
+
+	x, y, width, height := p.GetRect()
+	lines := []string{
+		"Inspector  Arrows: navigate  Enter: focus  Esc: close",
+		"Path:     " + strings.Join(names, " > "),
+		fmt.Sprintf("Type:     %T", p),
+		fmt.Sprintf("ID:       %p", p),
+	}
+	if b, ok := p.(boxer); ok {
+		if title := b.getBox().GetTitle(); title != "" {
+			lines = append(lines, "Title:    "+title)
+		}
+	}
+	lines = append(lines, fmt.Sprintf("Rect:     x=%d y=%d width=%d height=%d", x, y, width, height))
+
+	focus := "not focused"
+	if p == a.GetFocus() {
+		focus = "focused"
+	} else if p.GetFocusable().HasFocus() {
+		focus = "contains focus"
+	}
+	lines = append(lines, "Focus:    "+focus)
+
+	var handlers []string
+	if p.InputHandler() != nil {
+		handlers = append(handlers, "input")
+	}
+	if p.MouseHandler() != nil {
+		handlers = append(handlers, "mouse")
+	}
+	if b, ok := p.(boxer); ok {
+		if b.getBox().GetInputCapture() != nil {
+			handlers = append(handlers, "input capture")
+		}
+		if b.getBox().GetMouseCapture() != nil {
+			handlers = append(handlers, "mouse capture")
+		}
+	}
+	if len(handlers) == 0 {
+		handlers = append(handlers, "none")
+	}
+	lines = append(lines, "Handlers: "+strings.Join(handlers, ", "))
+	return lines
+}
+
+// drawInspector highlights the inspected primitive and draws the inspector
+// panel while the inspector is enabled.
+func (a *Application) drawInspector(screen tcell.Screen) {
+	a.RLock()
+	inspecting := a.inspecting
+	path := append([]Primitive(nil), a.inspectPath...)
+	a.RUnlock()
+
+	if !inspecting || len(path) == 0 {
+		return
+	}
+	p := path[len(path)-1]
+	screenWidth, screenHeight := screen.Size()
+
+	// Highlight the outline of the inspected primitive.
+	x, y, width, height := p.GetRect()
+	invert := func(cx, cy int) {
+		if cx < 0 || cy < 0 || cx >= screenWidth || cy >= screenHeight {
+			return
+		}
+		m, c, style, _ := screen.GetContent(cx, cy)
+		_, _, attributes := style.Decompose()
+		screen.SetContent(cx, cy, m, c, style.Reverse(attributes&tcell.AttrReverse == 0))
+	}
+	for cx := x; cx < x+width; cx++ {
+		invert(cx, y)
+		if height > 1 {
+			invert(cx, y+height-1)
+		}
+	}
+	for cy := y + 1; cy < y+height-1; cy++ {
+		invert(x, cy)
+		if width > 1 {
+			invert(x+width-1, cy)
+		}
+	}
+
+	// Draw the panel at the bottom of the screen, or at the top when the
+	// inspected primitive is in the lower half of the screen.
+	lines := a.inspectorText(path)
+	var panelWidth int
+	for _, line := range lines {
+		if w := TaggedStringWidth(line) + 2; w > panelWidth {
+			panelWidth = w
+		}
+	}
+	if panelWidth > screenWidth {
+		panelWidth = screenWidth
+	}
+	panelY := screenHeight - len(lines)
+	if y+height/2 > screenHeight/2 {
+		panelY = 0
+	}
+
+	style := tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.PrimaryTextColor)
+	for i, line := range lines {
+		for cx := 0; cx < panelWidth; cx++ {
+			screen.SetContent(cx, panelY+i, ' ', nil, style)
+		}
+		PrintStyle(screen, []byte(Escape(line)), 1, panelY+i, panelWidth-2, AlignLeft, style)
+	}
+}
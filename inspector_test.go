@@ -0,0 +1,113 @@
+package cview
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestInspector(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	left, right := NewBox(), NewBox()
+	right.SetTitle("Right")
+
+	flex := NewFlex()
+	flex.AddItem(left, 0, 1, true)
+	flex.AddItem(right, 0, 1, false)
+
+	app, err := newTestApp(flex)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	flex.SetRect(0, 0, 20, 10)
+	flex.Draw(app.screen)
+
+	key := func(k tcell.Key, r rune) bool {
+		return app.handleInspectorKey(tcell.NewEventKey(k, r, tcell.ModNone))
+	}
+	mouse := func(x, y int, buttons tcell.ButtonMask) {
+		app.handleInspectorMouse(tcell.NewEventMouse(x, y, buttons, tcell.ModNone), flex)
+		app.lastMouseButtons = buttons
+	}
+
+	// Enable
+
+	if key(tcell.KeyF12, 0) {
+		t.Errorf("failed to pass key: expected false while inspector is not enabled")
+	}
+	app.EnableInspector(true)
+	if !key(tcell.KeyF12, 0) || !app.GetInspectMode() {
+		t.Errorf("failed to enable inspector")
+	}
+	if p := app.GetInspectedPrimitive(); p != left {
+		t.Errorf("failed to inspect focused primitive: expected left box, got %T", p)
+	}
+
+	// Navigate
+
+	key(tcell.KeyRight, 0)
+	if p := app.GetInspectedPrimitive(); p != right {
+		t.Errorf("failed to inspect sibling: expected right box, got %T", p)
+	}
+	key(tcell.KeyUp, 0)
+	if p := app.GetInspectedPrimitive(); p != flex {
+		t.Errorf("failed to inspect parent: expected flex, got %T", p)
+	}
+	key(tcell.KeyDown, 0)
+	if p := app.GetInspectedPrimitive(); p != left {
+		t.Errorf("failed to inspect child: expected left box, got %T", p)
+	}
+
+	// Hover and click
+
+	app.setInspectPath([]Primitive{flex, left}, false)
+	mouse(15, 5, tcell.ButtonNone)
+	if p := app.GetInspectedPrimitive(); p != right {
+		t.Errorf("failed to inspect hovered primitive: expected right box, got %T", p)
+	}
+	mouse(15, 5, tcell.ButtonPrimary)
+	mouse(15, 5, tcell.ButtonNone)
+	mouse(2, 5, tcell.ButtonNone)
+	if p := app.GetInspectedPrimitive(); p != right {
+		t.Errorf("failed to select primitive: expected right box, got %T", p)
+	}
+	mouse(15, 5, tcell.ButtonPrimary)
+	mouse(15, 5, tcell.ButtonNone)
+	if p := app.GetInspectedPrimitive(); p != flex {
+		t.Errorf("failed to click through to parent: expected flex, got %T", p)
+	}
+
+	// Draw
+
+	mouse(15, 5, tcell.ButtonPrimary)
+	app.drawInspector(app.screen)
+	var text strings.Builder
+	for y := 0; y < 24; y++ {
+		for x := 0; x < 80; x++ {
+			r, _, _, _ := app.screen.GetContent(x, y)
+			text.WriteRune(r)
+		}
+		text.WriteByte('\n')
+	}
+	for _, expected := range []string{"Flex > Box", "*cview.Box", "Title:    Right", "x=10 y=0 width=10 height=10", "not focused"} {
+		if !strings.Contains(text.String(), expected) {
+			t.Errorf("failed to draw inspector: expected panel to contain %q", expected)
+		}
+	}
+
+	// Disable
+
+	key(tcell.KeyEscape, 0)
+	if app.GetInspectMode() || app.GetInspectedPrimitive() != nil {
+		t.Errorf("failed to disable inspector")
+	}
+}
@@ -1,6 +1,8 @@
 package cview
 
 import (
+	"strings"
+
 	"code.rocketnine.space/tslocum/cbind"
 	"github.com/gdamore/tcell/v2"
 )
@@ -31,8 +33,51 @@ type Key struct {
 	MoveNextField     []string
 	MovePreviousPage  []string
 	MoveNextPage      []string
+	MoveHalfPageUp    []string
+	MoveHalfPageDown  []string
+
+	SelectUp           []string
+	SelectDown         []string
+	SelectLeft         []string
+	SelectRight        []string
+	SelectFirst        []string
+	SelectLast         []string
+	SelectPreviousPage []string
+	SelectNextPage     []string
+
+	ToggleSelection []string
+
+	ExpandRow   []string
+	CollapseRow []string
 
 	ShowContextMenu []string
+
+	ShowTooltip []string
+
+	ShowColumnChooser []string
+
+	MoveRow []string
+
+	MoveColumnLeft  []string
+	MoveColumnRight []string
+
+	DeleteItem    []string
+	DuplicateItem []string
+	EditItem      []string
+
+	PreviousAnchor []string
+	NextAnchor     []string
+
+	NextSlide     []string
+	PreviousSlide []string
+
+	ResizeMode []string
+	Zoom       []string
+
+	Inspect []string
+
+	MouseEmulation []string
+	MouseDrag      []string
 }
 
 // Keys defines the keyboard shortcuts of an application.
@@ -61,8 +106,51 @@ var Keys = Key{
 	MoveNextField:     []string{"Tab"},
 	MovePreviousPage:  []string{"PageUp", "Ctrl+B"},
 	MoveNextPage:      []string{"PageDown", "Ctrl+F"},
+	MoveHalfPageUp:    []string{"Ctrl+U"},
+	MoveHalfPageDown:  []string{"Ctrl+D"},
+
+	SelectUp:           []string{"Shift+Up"},
+	SelectDown:         []string{"Shift+Down"},
+	SelectLeft:         []string{"Shift+Left"},
+	SelectRight:        []string{"Shift+Right"},
+	SelectFirst:        []string{"Shift+Home"},
+	SelectLast:         []string{"Shift+End"},
+	SelectPreviousPage: []string{"Shift+PageUp"},
+	SelectNextPage:     []string{"Shift+PageDown"},
+
+	ToggleSelection: []string{"Space"},
+
+	ExpandRow:   []string{"+"},
+	CollapseRow: []string{"-"},
 
 	ShowContextMenu: []string{"Alt+Enter"},
+
+	ShowTooltip: []string{"Alt+t"},
+
+	ShowColumnChooser: []string{"Alt+c"},
+
+	MoveRow: []string{"Alt+m"},
+
+	MoveColumnLeft:  []string{"Ctrl+Left"},
+	MoveColumnRight: []string{"Ctrl+Right"},
+
+	DeleteItem:    []string{"Delete"},
+	DuplicateItem: []string{"Ctrl+D"},
+	EditItem:      []string{"F2"},
+
+	PreviousAnchor: []string{"["},
+	NextAnchor:     []string{"]"},
+
+	NextSlide:     []string{"Ctrl+N"},
+	PreviousSlide: []string{"Ctrl+P"},
+
+	ResizeMode: []string{"Alt+r"},
+	Zoom:       []string{"Alt+z"},
+
+	Inspect: []string{"F12"},
+
+	MouseEmulation: []string{"F9"},
+	MouseDrag:      []string{"d"},
 }
 
 // HitShortcut returns whether the EventKey provided is present in one or more
@@ -83,3 +171,35 @@ func HitShortcut(event *tcell.EventKey, keybindings ...[]string) bool {
 
 	return false
 }
+
+// normalizeKey returns the keybinding format of a key description, such as
+// "Ctrl+D" for "ctrl-d". Descriptions which may not be decoded are returned
+// unchanged.
+func normalizeKey(key string) string {
+	// Accept dashes between modifiers and keys.
+	var b strings.Builder
+	s := key
+MODIFIERS:
+	for {
+		for _, mod := range []string{"ctrl", "alt", "meta", "shift"} {
+			if len(s) > len(mod)+1 && strings.EqualFold(s[:len(mod)], mod) && (s[len(mod)] == '-' || s[len(mod)] == '+') {
+				b.WriteString(s[:len(mod)])
+				b.WriteByte('+')
+				s = s[len(mod)+1:]
+				continue MODIFIERS
+			}
+		}
+		break
+	}
+	b.WriteString(s)
+
+	mod, k, ch, err := cbind.Decode(b.String())
+	if err != nil {
+		return key
+	}
+	enc, err := cbind.Encode(mod, k, ch)
+	if err != nil {
+		return key
+	}
+	return enc
+}
@@ -0,0 +1,264 @@
+package cview
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"code.rocketnine.space/tslocum/cbind"
+	"github.com/gdamore/tcell/v2"
+)
+
+// DefaultKeySequenceTimeout is the default time to wait for the next key of a
+// key sequence.
+const DefaultKeySequenceTimeout = 2 * time.Second
+
+// keySequenceNode is a key of one or more bound key sequences.
+type keySequenceNode struct {
+	// The keys which may follow this key.
+	next map[string]*keySequenceNode
+
+	// The description of the sequence ending with this key, or of the keys
+	// which may follow it.
+	description string
+
+	// The handler of the sequence ending with this key.
+	handler func()
+}
+
+// BindKeySequence binds a handler to a sequence of keys, such as a leader key
+// followed by one or more keys ("Space f s"). The keys of the sequence are
+// separated by spaces and use the keybinding format of Keys (dashes between
+// modifiers and keys are accepted, as in "ctrl-x").
+//
+// While a sequence is pending, the keys typed so far and the keys which may
+// follow them are shown in the top-right corner of the screen, along with
+// their descriptions. Binding a description without a handler to a prefix of
+// other sequences describes the group of sequences, such as "Space f" with
+// the description "file". Escape cancels a pending sequence, as does a key
+// which is not bound or waiting longer than the timeout set via
+// SetKeySequenceTimeout.
+//
+// The first key of a sequence is not passed to the focused primitive, so
+// leader keys should not be keys used to enter text when text inputs may be
+// focused. A sequence may not be bound when it is a prefix of another bound
+// sequence or when a prefix of it is bound to a handler.
+func (a *Application) BindKeySequence(sequence string, description string, handler func()) error {
+	keys, err := parseKeySequence(sequence)
+	if err != nil {
+		return err
+	}
+
+	a.Lock()
+	defer a.Unlock()
+
+	if a.keySequences == nil {
+		a.keySequences = &keySequenceNode{}
+	}
+	node := a.keySequences
+	for i, key := range keys {
+		if node.handler != nil {
+			return fmt.Errorf("failed to bind key sequence %q: %s is bound", sequence, strings.Join(keys[:i], " "))
+		}
+		if node.next == nil {
+			node.next = make(map[string]*keySequenceNode)
+		}
+		next := node.next[key]
+		if next == nil {
+			next = &keySequenceNode{}
+			node.next[key] = next
+		}
+		node = next
+	}
+	if handler != nil && len(node.next) > 0 {
+		return fmt.Errorf("failed to bind key sequence %q: sequence is a prefix of another sequence", sequence)
+	}
+	node.description = description
+	node.handler = handler
+	return nil
+}
+
+// UnbindKeySequence removes the handler and description of a key sequence.
+func (a *Application) UnbindKeySequence(sequence string) {
+	keys, err := parseKeySequence(sequence)
+	if err != nil {
+		return
+	}
+
+	a.Lock()
+	defer a.Unlock()
+
+	path := []*keySequenceNode{a.keySequences}
+	for _, key := range keys {
+		node := path[len(path)-1]
+		if node == nil {
+			return
+		}
+		path = append(path, node.next[key])
+	}
+	node := path[len(path)-1]
+	if node == nil {
+		return
+	}
+	node.description = ""
+	node.handler = nil
+
+	// Remove keys which are no longer part of a sequence.
+	for i := len(path) - 1; i > 0; i-- {
+		if path[i].description != "" || len(path[i].next) > 0 {
+			break
+		}
+		delete(path[i-1].next, keys[i-1])
+	}
+}
+
+// SetKeySequenceTimeout sets the time to wait for the next key of a pending
+// key sequence before it is canceled. A timeout of zero disables it. The
+// default timeout is DefaultKeySequenceTimeout.
+func (a *Application) SetKeySequenceTimeout(timeout time.Duration) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.keySequenceTimeout = timeout
+}
+
+// GetPendingKeySequence returns the keys of the pending key sequence, or nil
+// if no sequence is pending.
+func (a *Application) GetPendingKeySequence() []string {
+	a.RLock()
+	defer a.RUnlock()
+
+	if len(a.pendingKeys) == 0 {
+		return nil
+	}
+	return append([]string(nil), a.pendingKeys...)
+}
+
+// parseKeySequence returns the keys of a key sequence in keybinding format.
+func parseKeySequence(sequence string) ([]string, error) {
+	keys := strings.Fields(sequence)
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("failed to parse key sequence %q: no keys", sequence)
+	}
+	for i, key := range keys {
+		keys[i] = normalizeKey(key)
+		if _, _, _, err := cbind.Decode(keys[i]); err != nil {
+			return nil, fmt.Errorf("failed to parse key sequence %q: invalid key %q", sequence, key)
+		}
+	}
+	return keys, nil
+}
+
+// handleKeySequence starts, continues or cancels a key sequence. Returns
+// whether the event was handled.
+func (a *Application) handleKeySequence(event *tcell.EventKey) bool {
+	key, err := cbind.Encode(event.Modifiers(), event.Key(), event.Rune())
+
+	a.Lock()
+	node := a.keySequences
+	pending := len(a.pendingKeys) > 0
+	for _, k := range a.pendingKeys {
+		if node == nil {
+			break
+		}
+		node = node.next[k]
+	}
+	if err != nil || node == nil || node.next[key] == nil {
+		if pending {
+			// Cancel the sequence.
+			a.pendingKeys = nil
+			a.keySequenceID++
+		}
+		a.Unlock()
+
+		if pending {
+			a.queueIndicatorUpdate()
+		}
+		return pending
+	}
+
+	next := node.next[key]
+	if len(next.next) == 0 {
+		// Complete the sequence.
+		a.pendingKeys = nil
+		a.keySequenceID++
+		a.Unlock()
+
+		if next.handler != nil {
+			next.handler()
+		}
+		return true
+	}
+
+	// Wait for the next key.
+	a.pendingKeys = append(a.pendingKeys, key)
+	a.keySequenceID++
+	id := a.keySequenceID
+	timeout := a.keySequenceTimeout
+	a.Unlock()
+
+	if timeout > 0 {
+		time.AfterFunc(timeout, func() {
+			a.Lock()
+			if a.keySequenceID != id {
+				a.Unlock()
+				return
+			}
+			a.pendingKeys = nil
+			a.keySequenceID++
+			a.Unlock()
+
+			a.queueIndicatorUpdate()
+		})
+	}
+	return true
+}
+
+// keySequenceIndicatorText returns the text of the pending key sequence
+// indicator, or an empty string if no sequence is pending.
+func (a *Application) keySequenceIndicatorText() string {
+	a.RLock()
+	defer a.RUnlock()
+
+	if len(a.pendingKeys) == 0 {
+		return ""
+	}
+	node := a.keySequences
+	for _, k := range a.pendingKeys {
+		if node == nil {
+			return ""
+		}
+		node = node.next[k]
+	}
+	if node == nil {
+		return ""
+	}
+
+	keys := make([]string, 0, len(node.next))
+	for key := range node.next {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(" " + strings.Join(a.pendingKeys, " ") + ":")
+	for _, key := range keys {
+		b.WriteString("  " + key)
+		if description := node.next[key].description; description != "" {
+			b.WriteString(" " + description)
+		}
+	}
+	b.WriteString(" ")
+	return b.String()
+}
+
+// drawKeySequenceIndicator draws the keys of the pending key sequence and the
+// keys which may follow them.
+func (a *Application) drawKeySequenceIndicator(screen tcell.Screen) {
+	a.RLock()
+	width := a.width
+	a.RUnlock()
+
+	a.drawIndicator(screen, width, a.keySequenceIndicatorText())
+}
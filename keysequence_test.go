@@ -0,0 +1,97 @@
+package cview
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestKeySequence(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	app, err := newTestApp(NewBox())
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+
+	var saved, opened int
+	if err := app.BindKeySequence("Space f", "file", nil); err != nil {
+		t.Errorf("failed to bind key sequence: %s", err)
+	}
+	if err := app.BindKeySequence("Space f s", "save", func() { saved++ }); err != nil {
+		t.Errorf("failed to bind key sequence: %s", err)
+	}
+	if err := app.BindKeySequence("space f ctrl-o", "open", func() { opened++ }); err != nil {
+		t.Errorf("failed to bind key sequence: %s", err)
+	}
+	if err := app.BindKeySequence("Space f s x", "", func() {}); err == nil {
+		t.Errorf("failed to bind key sequence: expected error when a prefix is bound, got nil")
+	}
+	if err := app.BindKeySequence("Space", "", func() {}); err == nil {
+		t.Errorf("failed to bind key sequence: expected error when the sequence is a prefix, got nil")
+	}
+
+	space := tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone)
+	f := tcell.NewEventKey(tcell.KeyRune, 'f', tcell.ModNone)
+	s := tcell.NewEventKey(tcell.KeyRune, 's', tcell.ModNone)
+	ctrlO := tcell.NewEventKey(tcell.KeyCtrlO, 0, tcell.ModCtrl)
+
+	// Complete sequences
+
+	if app.handleKeySequence(f) {
+		t.Errorf("failed to handle key sequence: expected unbound key to be passed on")
+	}
+	for _, event := range []*tcell.EventKey{space, f, s, space, f, ctrlO} {
+		if !app.handleKeySequence(event) {
+			t.Errorf("failed to handle key sequence: expected key %s to be handled", event.Name())
+		}
+	}
+	if saved != 1 || opened != 1 {
+		t.Errorf("failed to call key sequence handlers: expected 1 save and 1 open, got %d and %d", saved, opened)
+	}
+
+	// Indicator
+
+	app.handleKeySequence(space)
+	app.handleKeySequence(f)
+	expected := " Space f:  Ctrl+O open  s save "
+	if text := app.keySequenceIndicatorText(); text != expected {
+		t.Errorf("failed to draw indicator: expected %q, got %q", expected, text)
+	}
+
+	// Cancel
+
+	if !app.handleKeySequence(f) {
+		t.Errorf("failed to cancel key sequence: expected unbound key to be handled")
+	}
+	if keys := app.GetPendingKeySequence(); keys != nil {
+		t.Errorf("failed to cancel key sequence: expected no pending keys, got %v", keys)
+	}
+
+	// Timeout
+
+	app.SetKeySequenceTimeout(10 * time.Millisecond)
+	app.handleKeySequence(space)
+	if keys := app.GetPendingKeySequence(); len(keys) != 1 {
+		t.Errorf("failed to start key sequence: expected 1 pending key, got %v", keys)
+	}
+	for i := 0; i < 100 && app.GetPendingKeySequence() != nil; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if keys := app.GetPendingKeySequence(); keys != nil {
+		t.Errorf("failed to time out key sequence: expected no pending keys, got %v", keys)
+	}
+
+	// Unbind
+
+	app.UnbindKeySequence("Space f s")
+	app.UnbindKeySequence("Space f Ctrl+O")
+	app.UnbindKeySequence("Space f")
+	if app.handleKeySequence(space) {
+		t.Errorf("failed to unbind key sequences: expected key to be passed on")
+	}
+}
@@ -2,9 +2,13 @@ package cview
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"math"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 )
@@ -12,9 +16,14 @@ import (
 // ListItem represents an item in a List.
 type ListItem struct {
 	disabled      bool        // Whether or not the list item is selectable.
+	divider       bool        // Whether or not the list item is a divider. The main text is used as its caption.
 	mainText      []byte      // The main text of the list item.
 	secondaryText []byte      // A secondary text to be shown underneath the main text.
 	shortcut      rune        // The key to select the list item directly, 0 if there is no shortcut.
+	shortcutKey   string      // The named key to select the list item directly, empty if there is no named shortcut.
+	badge         []byte      // A short text to be shown at the right edge of the main text row.
+	badgeColor    tcell.Color // The color of the badge text, ColorUnset to use the list's badge color.
+	tooltip       []byte      // An optional text to be shown when the mouse rests on the item.
 	selected      func()      // The optional function which is called when the item is selected.
 	reference     interface{} // An optional reference object.
 
@@ -24,7 +33,8 @@ type ListItem struct {
 // NewListItem returns a new item for a list.
 func NewListItem(mainText string) *ListItem {
 	return &ListItem{
-		mainText: []byte(mainText),
+		mainText:   []byte(mainText),
+		badgeColor: ColorUnset,
 	}
 }
 
@@ -96,6 +106,110 @@ func (l *ListItem) GetShortcut() rune {
 	return l.shortcut
 }
 
+// SetShortcutKey sets a named key to select the ListItem directly, such as
+// "F2" or "Ctrl+D". Keys are described the same way as the keybindings in
+// Keys. An empty string removes the named shortcut. Named shortcuts take
+// precedence over rune shortcuts set via SetShortcut.
+func (l *ListItem) SetShortcutKey(key string) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.shortcutKey = normalizeKey(key)
+}
+
+// GetShortcutKey returns the ListItem's named shortcut.
+func (l *ListItem) GetShortcutKey() string {
+	l.RLock()
+	defer l.RUnlock()
+
+	return l.shortcutKey
+}
+
+// isDivider returns whether the item is drawn as a divider.
+func (l *ListItem) isDivider() bool {
+	return l.divider || (len(l.mainText) == 0 && len(l.secondaryText) == 0 && l.shortcutLabel() == "")
+}
+
+// shortcutLabel returns the text shown in the shortcut column.
+func (l *ListItem) shortcutLabel() string {
+	if l.shortcutKey != "" {
+		return l.shortcutKey
+	} else if l.shortcut != 0 {
+		return string(l.shortcut)
+	}
+	return ""
+}
+
+// SetBadgeBytes sets a short text (e.g. an unread count or a size) to be
+// shown right-aligned at the edge of the item's main text row.
+func (l *ListItem) SetBadgeBytes(val []byte) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.badge = val
+}
+
+// SetBadge sets a short text (e.g. an unread count or a size) to be shown
+// right-aligned at the edge of the item's main text row.
+func (l *ListItem) SetBadge(val string) {
+	l.SetBadgeBytes([]byte(val))
+}
+
+// GetBadgeBytes returns the item's badge text.
+func (l *ListItem) GetBadgeBytes() []byte {
+	l.RLock()
+	defer l.RUnlock()
+
+	return l.badge
+}
+
+// GetBadge returns the item's badge text.
+func (l *ListItem) GetBadge() string {
+	return string(l.GetBadgeBytes())
+}
+
+// SetBadgeColor sets the color of the item's badge text. When set to
+// ColorUnset, the list's badge color is used.
+func (l *ListItem) SetBadgeColor(color tcell.Color) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.badgeColor = color
+}
+
+// SetTooltip sets a text to be shown when the mouse rests on the item. See
+// List.SetTooltipDelay.
+func (l *ListItem) SetTooltip(tooltip string) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.tooltip = []byte(tooltip)
+}
+
+// GetTooltip returns the item's tooltip text.
+func (l *ListItem) GetTooltip() string {
+	l.RLock()
+	defer l.RUnlock()
+
+	return string(l.tooltip)
+}
+
+// SetEnabled sets whether the ListItem is selectable.
+func (l *ListItem) SetEnabled(enabled bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.disabled = !enabled
+}
+
+// IsEnabled returns whether the ListItem is selectable.
+func (l *ListItem) IsEnabled() bool {
+	l.RLock()
+	defer l.RUnlock()
+
+	return !l.disabled
+}
+
 // SetSelectedFunc sets a function which is called when the ListItem is selected.
 func (l *ListItem) SetSelectedFunc(handler func()) {
 	l.Lock()
@@ -134,6 +248,9 @@ type List struct {
 	// Whether or not to show the secondary item texts.
 	showSecondaryText bool
 
+	// Whether or not to show the secondary text of the current item only.
+	expandCurrentItem bool
+
 	// The item main text color.
 	mainTextColor tcell.Color
 
@@ -143,6 +260,9 @@ type List struct {
 	// The item shortcut text color.
 	shortcutColor tcell.Color
 
+	// The item badge text color.
+	badgeColor tcell.Color
+
 	// The text color for selected items.
 	selectedTextColor tcell.Color
 
@@ -155,6 +275,27 @@ type List struct {
 	// The scroll bar color.
 	scrollBarColor tcell.Color
 
+	// The indices of highlighted items, e.g. search results.
+	highlighted map[int]bool
+
+	// The text color for highlighted items.
+	highlightTextColor tcell.Color
+
+	// The background color for highlighted items.
+	highlightBackgroundColor tcell.Color
+
+	// The rune used to draw dividers.
+	dividerRune rune
+
+	// The color of dividers, ColorUnset to use the main text color.
+	dividerColor tcell.Color
+
+	// The text shown when the list contains no items.
+	placeholder []byte
+
+	// The text color of the placeholder.
+	placeholderTextColor tcell.Color
+
 	// The background color for selected items.
 	selectedBackgroundColor tcell.Color
 
@@ -167,6 +308,16 @@ type List struct {
 	// If true, the selection must remain centered when scrolling.
 	selectedAlwaysCentered bool
 
+	// If true, typing a letter selects the next item starting with it.
+	jumpToLetter bool
+
+	// If true, jumping to a letter ignores the case of item texts.
+	jumpToLetterIgnoreCase bool
+
+	// If true, jumping to a letter matches secondary texts instead of main
+	// texts.
+	jumpToLetterSecondary bool
+
 	// If true, the entire row is highlighted when selected.
 	highlightFullLine bool
 
@@ -176,6 +327,46 @@ type List struct {
 	// Whether or not hovering over an item will highlight it.
 	hover bool
 
+	// The index of the item the mouse is resting on, or -1.
+	hoverItem int
+
+	// An optional function which is called when the mouse moves onto a
+	// different item.
+	hoverFunc func(index int, item *ListItem)
+
+	// The time the mouse must rest on an item before its tooltip is shown.
+	tooltipDelay time.Duration
+
+	// The timer which shows the tooltip after the delay has elapsed.
+	tooltipTimer *time.Timer
+
+	// Whether the tooltip of the hovered item is visible.
+	tooltipVisible bool
+
+	// The mouse position at which the tooltip is shown.
+	tooltipX, tooltipY int
+
+	// The tooltip colors.
+	tooltipTextColor, tooltipBackgroundColor tcell.Color
+
+	// The index of the item whose tooltip is visible.
+	tooltipItem int
+
+	// An optional function which is called when the tooltip of the hovered
+	// item is to be shown.
+	tooltipShown func(index int, item *ListItem)
+
+	// Whether the full text of items whose text is truncated is shown in a
+	// tooltip.
+	showTruncatedText bool
+
+	// The items whose text was truncated, the rows the items were drawn at
+	// and the column their text was drawn at, as of the last time the list
+	// was drawn.
+	truncatedItems map[int]bool
+	itemRows       map[int]int
+	itemTextX      int
+
 	// The number of list items and columns by which the list is scrolled
 	// down/to the right.
 	itemOffset, columnOffset int
@@ -191,24 +382,87 @@ type List struct {
 	// An optional function which is called when the user presses the Escape key.
 	done func()
 
+	// An optional function which is called after an item is added.
+	itemAdded func(index int, item *ListItem)
+
+	// An optional function which is called after an item is removed.
+	itemRemoved func(index int, item *ListItem)
+
+	// Whether the user may delete, duplicate and edit items via
+	// Keys.DeleteItem, Keys.DuplicateItem and Keys.EditItem.
+	itemsDeletable, itemsDuplicable, itemsEditable bool
+
+	// Optional functions which are called before an item is deleted,
+	// duplicated or edited by the user. They return whether the action is
+	// carried out.
+	itemDeleted    func(index int, item *ListItem) bool
+	itemDuplicated func(index int, item, duplicate *ListItem) bool
+	itemEdited     func(index int, item *ListItem, text string) bool
+
+	// The input field the main text of an item is edited in and the item
+	// being edited, or nil when no item is being edited.
+	editor   *InputField
+	editItem *ListItem
+
 	// The height of the list the last time it was drawn.
 	height int
 
+	// The column of the scroll bar the last time the list was drawn, or -1
+	// when the scroll bar was not shown.
+	scrollBarX int
+
+	// Whether the scroll bar is being dragged.
+	scrollBarDrag bool
+
+	// The number of items scrolled by each mouse wheel event.
+	scrollWheelStep int
+
+	// Whether consecutive mouse wheel events in quick succession scroll by an
+	// increasing number of items.
+	scrollWheelKinetic bool
+
+	// Whether the mouse wheel scrolls by page while the Shift key is held.
+	scrollWheelPage bool
+
+	// The action and time of the last mouse wheel event, and the number of
+	// consecutive mouse wheel events in quick succession.
+	lastWheelAction MouseAction
+	lastWheelTime   time.Time
+	wheelRepeat     int
+
+	// Optional functions which encode and decode item references when the
+	// list is marshaled to and unmarshaled from JSON.
+	encodeReference func(reference interface{}) ([]byte, error)
+	decodeReference func(data []byte) (interface{}, error)
+
 	sync.RWMutex
 }
 
 // NewList returns a new form.
 func NewList() *List {
 	l := &List{
-		Box:                     NewBox(),
-		showSecondaryText:       true,
-		scrollBarVisibility:     ScrollBarAuto,
-		mainTextColor:           Styles.PrimaryTextColor,
-		secondaryTextColor:      Styles.TertiaryTextColor,
-		shortcutColor:           Styles.SecondaryTextColor,
-		selectedTextColor:       Styles.PrimitiveBackgroundColor,
-		scrollBarColor:          Styles.ScrollBarColor,
-		selectedBackgroundColor: Styles.PrimaryTextColor,
+		Box:                      NewBox(),
+		showSecondaryText:        true,
+		scrollBarVisibility:      ScrollBarAuto,
+		mainTextColor:            Styles.PrimaryTextColor,
+		secondaryTextColor:       Styles.TertiaryTextColor,
+		shortcutColor:            Styles.SecondaryTextColor,
+		badgeColor:               Styles.SecondaryTextColor,
+		placeholderTextColor:     Styles.SecondaryTextColor,
+		highlightTextColor:       Styles.InverseTextColor,
+		highlightBackgroundColor: Styles.SecondaryTextColor,
+		dividerRune:              tcell.RuneHLine,
+		dividerColor:             ColorUnset,
+		selectedTextColor:        Styles.PrimitiveBackgroundColor,
+		scrollBarColor:           Styles.ScrollBarColor,
+		selectedBackgroundColor:  Styles.PrimaryTextColor,
+		jumpToLetterIgnoreCase:   true,
+		hoverItem:                -1,
+		scrollBarX:               -1,
+		scrollWheelStep:          1,
+		tooltipDelay:             500 * time.Millisecond,
+		tooltipTextColor:         Styles.PrimaryTextColor,
+		tooltipBackgroundColor:   Styles.MoreContrastBackgroundColor,
 	}
 
 	l.ContextMenu = NewContextMenu(l)
@@ -224,7 +478,11 @@ func NewList() *List {
 //
 // Calling this function triggers a "changed" event if the selection changes.
 func (l *List) SetCurrentItem(index int) {
+	var calls callbacks
+	defer calls.run()
+
 	l.Lock()
+	defer l.Unlock()
 
 	if index < 0 {
 		index = len(l.items) + index
@@ -241,12 +499,8 @@ func (l *List) SetCurrentItem(index int) {
 
 	l.updateOffset()
 
-	if index != previousItem && index < len(l.items) && l.changed != nil {
-		item := l.items[index]
-		l.Unlock()
-		l.changed(index, item)
-	} else {
-		l.Unlock()
+	if index != previousItem {
+		l.currentItemChanged(&calls)
 	}
 }
 
@@ -270,11 +524,16 @@ func (l *List) GetCurrentItemIndex() int {
 	return l.currentItem
 }
 
-// GetItems returns all list items.
+// GetItems returns all list items. The returned slice is a copy, while the
+// items themselves are shared with the list. Items may be held on to and
+// modified across insertions and removals.
 func (l *List) GetItems() []*ListItem {
 	l.RLock()
 	defer l.RUnlock()
-	return l.items
+
+	items := make([]*ListItem, len(l.items))
+	copy(items, l.items)
+	return items
 }
 
 // RemoveItem removes the item with the given index (starting at 0) from the
@@ -286,10 +545,13 @@ func (l *List) GetItems() []*ListItem {
 // The currently selected item is shifted accordingly. If it is the one that is
 // removed, a "changed" event is fired.
 func (l *List) RemoveItem(index int) {
+	var calls callbacks
+	defer calls.run()
+
 	l.Lock()
+	defer l.Unlock()
 
 	if len(l.items) == 0 {
-		l.Unlock()
 		return
 	}
 
@@ -305,11 +567,17 @@ func (l *List) RemoveItem(index int) {
 	}
 
 	// Remove item.
+	removed := l.items[index]
 	l.items = append(l.items[:index], l.items[index+1:]...)
+	if itemRemoved := l.itemRemoved; itemRemoved != nil {
+		// Called after the changed handler.
+		defer calls.add(func() {
+			itemRemoved(index, removed)
+		})
+	}
 
 	// If there is nothing left, we're done.
 	if len(l.items) == 0 {
-		l.Unlock()
 		return
 	}
 
@@ -320,12 +588,8 @@ func (l *List) RemoveItem(index int) {
 	}
 
 	// Fire "changed" event for removed items.
-	if previousItem == index && index < len(l.items) && l.changed != nil {
-		item := l.items[l.currentItem]
-		l.Unlock()
-		l.changed(l.currentItem, item)
-	} else {
-		l.Unlock()
+	if previousItem == index && index < len(l.items) {
+		l.currentItemChanged(&calls)
 	}
 }
 
@@ -360,6 +624,25 @@ func (l *List) SetMainTextColor(color tcell.Color) {
 	defer l.Unlock()
 
 	l.mainTextColor = color
+	l.textColorSet = true
+}
+
+// inheritColors applies the colors inherited from the parent container.
+func (l *List) inheritColors(background, text tcell.Color) {
+	l.Box.inheritColors(background, text)
+
+	l.Lock()
+	defer l.Unlock()
+
+	l.inheritTextColor(&l.mainTextColor, text)
+}
+
+// primaryTextColor returns the color of the primary text.
+func (l *List) primaryTextColor() tcell.Color {
+	l.RLock()
+	defer l.RUnlock()
+
+	return l.mainTextColor
 }
 
 // SetSecondaryTextColor sets the color of the items' secondary text.
@@ -378,6 +661,15 @@ func (l *List) SetShortcutColor(color tcell.Color) {
 	l.shortcutColor = color
 }
 
+// SetBadgeColor sets the color of the items' badge text. Items may override
+// this color via ListItem.SetBadgeColor.
+func (l *List) SetBadgeColor(color tcell.Color) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.badgeColor = color
+}
+
 // SetSelectedTextColor sets the text color of selected items.
 func (l *List) SetSelectedTextColor(color tcell.Color) {
 	l.Lock()
@@ -430,6 +722,36 @@ func (l *List) SetSelectedAlwaysCentered(alwaysCentered bool) {
 	l.selectedAlwaysCentered = alwaysCentered
 }
 
+// SetJumpToLetter sets a flag which determines whether typing a letter
+// selects the next item whose text starts with that letter. Typing the same
+// letter again cycles through all matching items. Letters which do not match
+// any item are handled as usual, e.g. as navigation keys.
+func (l *List) SetJumpToLetter(jump bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.jumpToLetter = jump
+}
+
+// SetJumpToLetterIgnoreCase sets a flag which determines whether jumping to a
+// letter ignores the case of item texts. This is enabled by default.
+func (l *List) SetJumpToLetterIgnoreCase(ignoreCase bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.jumpToLetterIgnoreCase = ignoreCase
+}
+
+// SetJumpToLetterSecondaryText sets a flag which determines whether jumping
+// to a letter matches the secondary texts of items instead of their main
+// texts.
+func (l *List) SetJumpToLetterSecondaryText(secondary bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.jumpToLetterSecondary = secondary
+}
+
 // SetHighlightFullLine sets a flag which determines whether the colored
 // background of selected items spans the entire width of the view. If set to
 // true, the highlight spans the entire view. If set to false, only the text of
@@ -450,6 +772,56 @@ func (l *List) ShowSecondaryText(show bool) {
 	return
 }
 
+// SetExpandCurrentItem sets a flag which determines whether the secondary text
+// is shown for the current item only. When enabled, every item occupies a
+// single line except the current item, which expands to two lines when it has
+// secondary text. This keeps long lists compact while still showing the
+// details of the current item. This setting takes precedence over
+// ShowSecondaryText.
+func (l *List) SetExpandCurrentItem(expand bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.expandCurrentItem = expand
+}
+
+// itemHeight returns the number of lines occupied by the item at the provided
+// index.
+func (l *List) itemHeight(index int) int {
+	if l.expandCurrentItem {
+		item := l.items[index]
+		if index == l.currentItem && len(item.secondaryText) > 0 && !item.isDivider() && !item.disabled {
+			return 2
+		}
+		return 1
+	}
+	if l.showSecondaryText {
+		return 2
+	}
+	return 1
+}
+
+// expandedLines returns the number of additional lines occupied by the
+// expanded current item.
+func (l *List) expandedLines() int {
+	if !l.expandCurrentItem || l.currentItem < 0 || l.currentItem >= len(l.items) {
+		return 0
+	}
+	return l.itemHeight(l.currentItem) - 1
+}
+
+// indexAtRow returns the index of the list item drawn at the provided row,
+// relative to the top of the list, while the current item is expanded.
+func (l *List) indexAtRow(row int) int {
+	for index := l.itemOffset; index < len(l.items); index++ {
+		row -= l.itemHeight(index)
+		if row < 0 {
+			return index
+		}
+	}
+	return len(l.items)
+}
+
 // SetScrollBarVisibility specifies the display of the scroll bar.
 func (l *List) SetScrollBarVisibility(visibility ScrollBarVisibility) {
 	l.Lock()
@@ -458,6 +830,191 @@ func (l *List) SetScrollBarVisibility(visibility ScrollBarVisibility) {
 	l.scrollBarVisibility = visibility
 }
 
+// SetScrollWheelStep sets the number of items scrolled by each mouse wheel
+// event. tcell reports one event for each notch the wheel is turned, so the
+// list is scrolled by this number of items per notch. The default is 1.
+func (l *List) SetScrollWheelStep(step int) {
+	l.Lock()
+	defer l.Unlock()
+
+	if step < 1 {
+		step = 1
+	}
+	l.scrollWheelStep = step
+}
+
+// SetScrollWheelKinetic sets a flag which determines whether turning the
+// mouse wheel quickly scrolls by an increasing number of items. Each mouse
+// wheel event in the same direction which follows the previous event in quick
+// succession scrolls further than the previous event, up to eight times the
+// scroll wheel step.
+func (l *List) SetScrollWheelKinetic(kinetic bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.scrollWheelKinetic = kinetic
+}
+
+// SetScrollWheelPage sets a flag which determines whether the mouse wheel
+// scrolls by page while the Shift key is held.
+func (l *List) SetScrollWheelPage(page bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.scrollWheelPage = page
+}
+
+// SetHighlightedIndices highlights the items at the provided indices, e.g.
+// the indices returned by FindItems. Highlighted items are drawn using the
+// highlight colors, while the selected item is drawn using the selection
+// colors. Provide nil to remove all highlights.
+//
+// Highlights refer to item indices and are not updated when items are added
+// or removed. They are removed when the list is cleared.
+func (l *List) SetHighlightedIndices(indices []int) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.highlighted = nil
+	if len(indices) == 0 {
+		return
+	}
+
+	l.highlighted = make(map[int]bool, len(indices))
+	for _, index := range indices {
+		l.highlighted[index] = true
+	}
+}
+
+// GetHighlightedIndices returns the indices of highlighted items in ascending
+// order.
+func (l *List) GetHighlightedIndices() []int {
+	l.RLock()
+	defer l.RUnlock()
+
+	return l.highlightedIndices()
+}
+
+func (l *List) highlightedIndices() []int {
+	if len(l.highlighted) == 0 {
+		return nil
+	}
+
+	indices := make([]int, 0, len(l.highlighted))
+	for index := range l.highlighted {
+		indices = append(indices, index)
+	}
+	sort.Ints(indices)
+	return indices
+}
+
+// SetHighlightColor sets the text and background color of highlighted items.
+func (l *List) SetHighlightColor(textColor, backgroundColor tcell.Color) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.highlightTextColor = textColor
+	l.highlightBackgroundColor = backgroundColor
+}
+
+// NextHighlight selects the next highlighted item after the currently
+// selected item, wrapping around to the first highlighted item.
+func (l *List) NextHighlight() {
+	l.moveHighlight(false)
+}
+
+// PreviousHighlight selects the previous highlighted item before the
+// currently selected item, wrapping around to the last highlighted item.
+func (l *List) PreviousHighlight() {
+	l.moveHighlight(true)
+}
+
+func (l *List) moveHighlight(previous bool) {
+	var calls callbacks
+	defer calls.run()
+
+	l.Lock()
+	defer l.Unlock()
+
+	var indices []int
+	for _, index := range l.highlightedIndices() {
+		if index >= 0 && index < len(l.items) {
+			indices = append(indices, index)
+		}
+	}
+	if len(indices) == 0 {
+		return
+	}
+
+	next := indices[0]
+	if previous {
+		next = indices[len(indices)-1]
+		for i := len(indices) - 1; i >= 0; i-- {
+			if indices[i] < l.currentItem {
+				next = indices[i]
+				break
+			}
+		}
+	} else {
+		for _, index := range indices {
+			if index > l.currentItem {
+				next = index
+				break
+			}
+		}
+	}
+
+	previousItem := l.currentItem
+	l.currentItem = next
+	l.updateOffset()
+
+	if next != previousItem {
+		l.currentItemChanged(&calls)
+	}
+}
+
+// SetDividerRune sets the rune used to draw dividers.
+func (l *List) SetDividerRune(r rune) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.dividerRune = r
+}
+
+// SetDividerColor sets the color of dividers and their captions. By default,
+// dividers are drawn using the main text color.
+func (l *List) SetDividerColor(color tcell.Color) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.dividerColor = color
+}
+
+// SetPlaceholder sets the text shown centered within the list when it
+// contains no items.
+func (l *List) SetPlaceholder(text string) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.placeholder = []byte(text)
+}
+
+// GetPlaceholder returns the text shown when the list contains no items.
+func (l *List) GetPlaceholder() string {
+	l.RLock()
+	defer l.RUnlock()
+
+	return string(l.placeholder)
+}
+
+// SetPlaceholderTextColor sets the text color of the placeholder.
+func (l *List) SetPlaceholderTextColor(color tcell.Color) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.placeholderTextColor = color
+}
+
 // SetScrollBarColor sets the color of the scroll bar.
 func (l *List) SetScrollBarColor(color tcell.Color) {
 	l.Lock()
@@ -475,6 +1032,63 @@ func (l *List) SetHover(hover bool) {
 	l.hover = hover
 }
 
+// SetHoverFunc sets a handler which is called when the mouse moves onto a
+// different item. When the mouse leaves the list, the handler is called with
+// an index of -1 and a nil item. Mouse support must be enabled via
+// Application.EnableMouse.
+func (l *List) SetHoverFunc(handler func(index int, item *ListItem)) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.hoverFunc = handler
+}
+
+// SetTooltipDelay sets the time the mouse must rest on an item before its
+// tooltip is shown. See ListItem.SetTooltip and SetTooltipShownFunc.
+func (l *List) SetTooltipDelay(delay time.Duration) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.tooltipDelay = delay
+}
+
+// SetShowTruncatedText sets whether the full main and secondary text of an
+// item whose text is truncated is shown in a tooltip, so that long items may
+// be read without resizing the list. The tooltip is shown when the mouse rests
+// on the item (see SetTooltipDelay and SetTooltipShownFunc) or when
+// Keys.ShowTooltip is pressed while the item is selected. Tooltips set via
+// ListItem.SetTooltip take precedence.
+func (l *List) SetShowTruncatedText(show bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.showTruncatedText = show
+}
+
+// SetTooltipColor sets the text and background colors of tooltips.
+func (l *List) SetTooltipColor(textColor, backgroundColor tcell.Color) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.tooltipTextColor = textColor
+	l.tooltipBackgroundColor = backgroundColor
+}
+
+// SetTooltipShownFunc sets a handler which is called once the mouse has rested
+// on an item long enough for its tooltip to be shown (see SetTooltipDelay).
+// The tooltip is drawn the next time the list is drawn. The handler is called
+// from a separate goroutine, so it should typically queue a redraw:
+//
+//   list.SetTooltipShownFunc(func(index int, item *cview.ListItem) {
+//       app.QueueUpdateDraw(func() {}, list)
+//   })
+func (l *List) SetTooltipShownFunc(handler func(index int, item *ListItem)) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.tooltipShown = handler
+}
+
 // SetWrapAround sets the flag that determines whether navigating the list will
 // wrap around. That is, navigating downwards on the last item will move the
 // selection to the first item (similarly in the other direction). If set to
@@ -519,11 +1133,217 @@ func (l *List) SetDoneFunc(handler func()) {
 	l.done = handler
 }
 
+// SetItemAddedFunc sets a function which is called after an item is added to
+// the list. The function is provided with the index and the item added.
+func (l *List) SetItemAddedFunc(handler func(index int, item *ListItem)) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.itemAdded = handler
+}
+
+// SetItemRemovedFunc sets a function which is called after an item is removed
+// from the list. The function is provided with the index the item had before
+// it was removed and the item itself. When the list is cleared, the function
+// is called for each item, starting with the last item.
+func (l *List) SetItemRemovedFunc(handler func(index int, item *ListItem)) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.itemRemoved = handler
+}
+
+// SetItemsDeletable sets a flag which determines whether the user may delete
+// the current item by pressing Keys.DeleteItem (Delete by default). See
+// SetItemDeletedFunc.
+func (l *List) SetItemsDeletable(deletable bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.itemsDeletable = deletable
+}
+
+// SetItemsDuplicable sets a flag which determines whether the user may
+// duplicate the current item by pressing Keys.DuplicateItem (Ctrl+D by
+// default). The duplicate is inserted after the item and becomes the current
+// item. Shortcuts are not duplicated. See SetItemDuplicatedFunc.
+func (l *List) SetItemsDuplicable(duplicable bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.itemsDuplicable = duplicable
+}
+
+// SetItemsEditable sets a flag which determines whether the user may edit the
+// main text of the current item in place by pressing Keys.EditItem (F2 by
+// default). See EditItem.
+func (l *List) SetItemsEditable(editable bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.itemsEditable = editable
+	if !editable {
+		l.editor, l.editItem = nil, nil
+	}
+}
+
+// SetItemDeletedFunc sets a function which is called when the user deletes an
+// item, before the item is removed. The function is provided with the index
+// and the item, and returns whether the item is removed. To ask the user for
+// confirmation, return false and call RemoveListItem once the user confirms.
+// The handler set via SetItemRemovedFunc is called after the item is removed.
+func (l *List) SetItemDeletedFunc(handler func(index int, item *ListItem) bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.itemDeleted = handler
+}
+
+// SetItemDuplicatedFunc sets a function which is called when the user
+// duplicates an item, before the duplicate is inserted. The function is
+// provided with the index of the item, the item and its duplicate, which may
+// be modified, and returns whether the duplicate is inserted.
+func (l *List) SetItemDuplicatedFunc(handler func(index int, item, duplicate *ListItem) bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.itemDuplicated = handler
+}
+
+// SetItemEditedFunc sets a function which is called when the user finishes
+// editing the main text of an item, before the text is changed. The function
+// is provided with the index of the item, the item and the new text, and
+// returns whether the main text of the item is set to the new text.
+func (l *List) SetItemEditedFunc(handler func(index int, item *ListItem, text string) bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.itemEdited = handler
+}
+
+// EditItem starts editing the main text of the item at the provided index in
+// place, regardless of whether items are editable. If a negative index is
+// provided, items are referred to from the back. Enter applies the new text,
+// as do Tab and the Up and Down keys, while Escape or a click outside of the
+// item cancels editing. Disabled items and dividers may not be edited.
+func (l *List) EditItem(index int) {
+	var calls callbacks
+	defer calls.run()
+
+	l.Lock()
+	defer l.Unlock()
+
+	if index < 0 {
+		index = len(l.items) + index
+	}
+	if index < 0 || index >= len(l.items) || l.items[index].disabled {
+		return
+	}
+	l.editItemAt(&calls, index)
+}
+
+// IsEditingItem returns true while the main text of an item is being edited.
+func (l *List) IsEditingItem() bool {
+	l.RLock()
+	defer l.RUnlock()
+
+	return l.editItem != nil
+}
+
+// editItemAt makes the item at the provided index the current item and starts
+// editing it, adding the changed handler to the provided callbacks if the
+// current item changed.
+func (l *List) editItemAt(calls *callbacks, index int) {
+	item := l.items[index]
+
+	editor := NewInputField()
+	editor.SetText(string(item.GetMainBytes()))
+	editor.SetDoneFunc(func(key tcell.Key) {
+		l.finishEditing(key != tcell.KeyEscape)
+	})
+	l.editor, l.editItem = editor, item
+
+	if index != l.currentItem {
+		l.currentItem = index
+		l.currentItemChanged(calls)
+	}
+	l.updateOffset()
+}
+
+// finishEditing stops editing the main text of an item and sets the new text
+// if apply is true and the handler set via SetItemEditedFunc allows it.
+func (l *List) finishEditing(apply bool) {
+	l.Lock()
+	editor, item, edited := l.editor, l.editItem, l.itemEdited
+	l.editor, l.editItem = nil, nil
+	index := l.indexOfItem(item)
+	l.Unlock()
+
+	if !apply || editor == nil || index < 0 {
+		return
+	}
+	text := editor.GetText()
+	if edited == nil || edited(index, item, text) {
+		item.SetMainText(text)
+	}
+}
+
+// deleteItem adds deleting the item at the provided index, subject to the
+// handler set via SetItemDeletedFunc, to the provided callbacks.
+func (l *List) deleteItem(calls *callbacks, index int) {
+	item, deleted := l.items[index], l.itemDeleted
+	calls.add(func() {
+		if deleted == nil || deleted(index, item) {
+			l.RemoveListItem(item)
+		}
+	})
+}
+
+// duplicateItem adds inserting a duplicate of the item at the provided index
+// after it, subject to the handler set via SetItemDuplicatedFunc, to the
+// provided callbacks.
+func (l *List) duplicateItem(calls *callbacks, index int) {
+	item, duplicated := l.items[index], l.itemDuplicated
+
+	item.RLock()
+	duplicate := &ListItem{
+		mainText:      append([]byte(nil), item.mainText...),
+		secondaryText: append([]byte(nil), item.secondaryText...),
+		badge:         append([]byte(nil), item.badge...),
+		badgeColor:    item.badgeColor,
+		tooltip:       append([]byte(nil), item.tooltip...),
+		selected:      item.selected,
+		reference:     item.reference,
+	}
+	item.RUnlock()
+
+	calls.add(func() {
+		if duplicated != nil && !duplicated(index, item, duplicate) {
+			return
+		}
+		index := l.GetItemIndex(item)
+		if index < 0 {
+			return
+		}
+		l.InsertItem(index+1, duplicate)
+		l.SetCurrentListItem(duplicate)
+	})
+}
+
 // AddItem calls InsertItem() with an index of -1.
 func (l *List) AddItem(item *ListItem) {
 	l.InsertItem(-1, item)
 }
 
+// AddDivider adds a divider to the end of the list. When a label is provided,
+// it is shown centered within the divider. Dividers may not be selected.
+func (l *List) AddDivider(label string) {
+	item := NewListItem(label)
+	item.divider = true
+	item.disabled = true
+	l.InsertItem(-1, item)
+}
+
 // InsertItem adds a new item to the list at the specified index. An index of 0
 // will insert the item at the beginning, an index of 1 before the second item,
 // and so on. An index of GetItemCount() or higher will insert the item at the
@@ -537,7 +1357,8 @@ func (l *List) AddItem(item *ListItem) {
 // visible) but which may remain empty.
 //
 // The shortcut is a key binding. If the specified rune is entered, the item
-// is selected immediately. Set to 0 for no binding.
+// is selected immediately. Set to 0 for no binding. Named keys may be bound
+// via ListItem.SetShortcutKey.
 //
 // The "selected" callback will be invoked when the user selects the item. You
 // may provide nil if no such callback is needed or if all events are handled
@@ -547,7 +1368,11 @@ func (l *List) AddItem(item *ListItem) {
 // was previously empty, a "changed" event is fired because the new item becomes
 // selected.
 func (l *List) InsertItem(index int, item *ListItem) {
+	var calls callbacks
+	defer calls.run()
+
 	l.Lock()
+	defer l.Unlock()
 
 	// Shift index to range.
 	if index < 0 {
@@ -571,25 +1396,79 @@ func (l *List) InsertItem(index int, item *ListItem) {
 	}
 	l.items[index] = item
 
+	if itemAdded := l.itemAdded; itemAdded != nil {
+		// Called after the changed handler.
+		defer calls.add(func() {
+			itemAdded(index, item)
+		})
+	}
+
 	// Fire a "change" event for the first item in the list.
-	if len(l.items) == 1 && l.changed != nil {
-		item := l.items[0]
-		l.Unlock()
-		l.changed(0, item)
-	} else {
-		l.Unlock()
+	if len(l.items) == 1 {
+		l.currentItemChanged(&calls)
 	}
 }
 
-// GetItem returns the ListItem at the given index.
-// Returns nil when index is out of bounds.
+// GetItem returns the ListItem at the given index. If a negative index is
+// provided, items are referred to from the back (-1 = last item, -2 =
+// second-to-last item, and so on). Returns nil when index is out of bounds.
 func (l *List) GetItem(index int) *ListItem {
-	if index > len(l.items)-1 {
+	l.RLock()
+	defer l.RUnlock()
+
+	if index < 0 {
+		index = len(l.items) + index
+	}
+	if index < 0 || index >= len(l.items) {
 		return nil
 	}
 	return l.items[index]
 }
 
+// GetItemIndex returns the current index of the provided item, or -1 if the
+// item is not in the list.
+func (l *List) GetItemIndex(item *ListItem) int {
+	l.RLock()
+	defer l.RUnlock()
+
+	return l.indexOfItem(item)
+}
+
+func (l *List) indexOfItem(item *ListItem) int {
+	for index, listItem := range l.items {
+		if listItem == item {
+			return index
+		}
+	}
+	return -1
+}
+
+// RemoveListItem removes the provided item from the list. Nothing happens if
+// the item is not in the list. See RemoveItem for details.
+func (l *List) RemoveListItem(item *ListItem) {
+	l.RLock()
+	index := l.indexOfItem(item)
+	l.RUnlock()
+
+	if index < 0 {
+		return
+	}
+	l.RemoveItem(index)
+}
+
+// SetCurrentListItem selects the provided item. Nothing happens if the item
+// is not in the list. See SetCurrentItem for details.
+func (l *List) SetCurrentListItem(item *ListItem) {
+	l.RLock()
+	index := l.indexOfItem(item)
+	l.RUnlock()
+
+	if index < 0 {
+		return
+	}
+	l.SetCurrentItem(index)
+}
+
 // GetItemCount returns the number of items in the list.
 func (l *List) GetItemCount() int {
 	l.RLock()
@@ -650,38 +1529,189 @@ func (l *List) FindItems(mainSearch, secondarySearch string, mustContainBoth, ig
 		secondarySearch = strings.ToLower(secondarySearch)
 	}
 
-	mainSearchBytes := []byte(mainSearch)
-	secondarySearchBytes := []byte(secondarySearch)
+	mainSearchBytes := []byte(mainSearch)
+	secondarySearchBytes := []byte(secondarySearch)
+
+	for index, item := range l.items {
+		mainText := item.mainText
+		secondaryText := item.secondaryText
+		if ignoreCase {
+			mainText = bytes.ToLower(mainText)
+			secondaryText = bytes.ToLower(secondaryText)
+		}
+
+		// strings.Contains() always returns true for a "" search.
+		mainContained := bytes.Contains(mainText, mainSearchBytes)
+		secondaryContained := bytes.Contains(secondaryText, secondarySearchBytes)
+		if mustContainBoth && mainContained && secondaryContained ||
+			!mustContainBoth && (len(mainText) > 0 && mainContained || len(secondaryText) > 0 && secondaryContained) {
+			indices = append(indices, index)
+		}
+	}
+
+	return
+}
+
+// Clear removes all items from the list.
+func (l *List) Clear() {
+	l.Lock()
+
+	items := l.items
+	removed := l.itemRemoved
+
+	l.items = nil
+	l.highlighted = nil
+	l.currentItem = 0
+	l.itemOffset = 0
+	l.columnOffset = 0
+
+	l.Unlock()
+
+	if removed != nil {
+		for index := len(items) - 1; index >= 0; index-- {
+			removed(index, items[index])
+		}
+	}
+}
+
+// listItemJSON is the JSON representation of a ListItem.
+type listItemJSON struct {
+	MainText      string          `json:"mainText"`
+	SecondaryText string          `json:"secondaryText,omitempty"`
+	Shortcut      string          `json:"shortcut,omitempty"`
+	ShortcutKey   string          `json:"shortcutKey,omitempty"`
+	Badge         string          `json:"badge,omitempty"`
+	Tooltip       string          `json:"tooltip,omitempty"`
+	Disabled      bool            `json:"disabled,omitempty"`
+	Divider       bool            `json:"divider,omitempty"`
+	Reference     json.RawMessage `json:"reference,omitempty"`
+}
+
+// SetReferenceCodec sets the functions which encode and decode the references
+// of items when the list is marshaled to and unmarshaled from JSON. By
+// default, references are encoded via json.Marshal and decoded via
+// json.Unmarshal into an interface{}, i.e. a JSON object is decoded as a
+// map[string]interface{}. A nil reference is never encoded.
+func (l *List) SetReferenceCodec(encode func(reference interface{}) ([]byte, error), decode func(data []byte) (interface{}, error)) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.encodeReference = encode
+	l.decodeReference = decode
+}
+
+// MarshalJSON returns the items of the list encoded as a JSON array. The main
+// text, secondary text, shortcuts, badge, tooltip, enabled state and reference
+// of each item are included. Selected functions and colors are not included.
+// See SetReferenceCodec.
+func (l *List) MarshalJSON() ([]byte, error) {
+	l.RLock()
+	items := make([]*ListItem, len(l.items))
+	copy(items, l.items)
+	encode := l.encodeReference
+	l.RUnlock()
+
+	if encode == nil {
+		encode = json.Marshal
+	}
+
+	out := make([]*listItemJSON, len(items))
+	for i, item := range items {
+		item.RLock()
+		o := &listItemJSON{
+			MainText:      string(item.mainText),
+			SecondaryText: string(item.secondaryText),
+			ShortcutKey:   item.shortcutKey,
+			Badge:         string(item.badge),
+			Tooltip:       string(item.tooltip),
+			Disabled:      item.disabled && !item.divider,
+			Divider:       item.divider,
+		}
+		if item.shortcut != 0 {
+			o.Shortcut = string(item.shortcut)
+		}
+		reference := item.reference
+		item.RUnlock()
+
+		if reference != nil {
+			data, err := encode(reference)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode reference of item %d: %s", i, err)
+			}
+			o.Reference = data
+		}
+		out[i] = o
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON replaces the items of the list with the items decoded from a
+// JSON array created via MarshalJSON. This allows populating a list from a
+// configuration file:
+//
+//   [
+//       {"mainText": "Open", "shortcut": "o", "reference": "open"},
+//       {"divider": true},
+//       {"mainText": "Quit", "shortcutKey": "Ctrl+Q", "disabled": true}
+//   ]
+//
+// See SetReferenceCodec.
+func (l *List) UnmarshalJSON(data []byte) error {
+	var in []*listItemJSON
+	err := json.Unmarshal(data, &in)
+	if err != nil {
+		return err
+	}
+
+	l.RLock()
+	decode := l.decodeReference
+	l.RUnlock()
+
+	if decode == nil {
+		decode = func(data []byte) (interface{}, error) {
+			var reference interface{}
+			err := json.Unmarshal(data, &reference)
+			return reference, err
+		}
+	}
 
-	for index, item := range l.items {
-		mainText := item.mainText
-		secondaryText := item.secondaryText
-		if ignoreCase {
-			mainText = bytes.ToLower(mainText)
-			secondaryText = bytes.ToLower(secondaryText)
+	items := make([]*ListItem, len(in))
+	for i, o := range in {
+		if o == nil {
+			return fmt.Errorf("failed to decode item %d: item is null", i)
 		}
 
-		// strings.Contains() always returns true for a "" search.
-		mainContained := bytes.Contains(mainText, mainSearchBytes)
-		secondaryContained := bytes.Contains(secondaryText, secondarySearchBytes)
-		if mustContainBoth && mainContained && secondaryContained ||
-			!mustContainBoth && (len(mainText) > 0 && mainContained || len(secondaryText) > 0 && secondaryContained) {
-			indices = append(indices, index)
+		item := NewListItem(o.MainText)
+		item.secondaryText = []byte(o.SecondaryText)
+		item.shortcutKey = normalizeKey(o.ShortcutKey)
+		item.badge = []byte(o.Badge)
+		item.tooltip = []byte(o.Tooltip)
+		item.disabled = o.Disabled || o.Divider
+		item.divider = o.Divider
+
+		if o.Shortcut != "" {
+			runes := []rune(o.Shortcut)
+			if len(runes) != 1 {
+				return fmt.Errorf("failed to decode item %d: invalid shortcut %q", i, o.Shortcut)
+			}
+			item.shortcut = runes[0]
 		}
-	}
 
-	return
-}
+		if len(o.Reference) > 0 && string(o.Reference) != "null" {
+			item.reference, err = decode(o.Reference)
+			if err != nil {
+				return fmt.Errorf("failed to decode reference of item %d: %s", i, err)
+			}
+		}
 
-// Clear removes all items from the list.
-func (l *List) Clear() {
-	l.Lock()
-	defer l.Unlock()
+		items[i] = item
+	}
 
-	l.items = nil
-	l.currentItem = 0
-	l.itemOffset = 0
-	l.columnOffset = 0
+	l.Clear()
+	for _, item := range items {
+		l.AddItem(item)
+	}
+	return nil
 }
 
 // Focus is called by the application when the primitive receives focus.
@@ -705,18 +1735,18 @@ func (l *List) HasFocus() bool {
 
 // Transform modifies the current selection.
 func (l *List) Transform(tr Transformation) {
+	var calls callbacks
+	defer calls.run()
+
 	l.Lock()
+	defer l.Unlock()
 
 	previousItem := l.currentItem
 
 	l.transform(tr)
 
-	if l.currentItem != previousItem && l.currentItem < len(l.items) && l.changed != nil {
-		item := l.items[l.currentItem]
-		l.Unlock()
-		l.changed(l.currentItem, item)
-	} else {
-		l.Unlock()
+	if l.currentItem != previousItem {
+		l.currentItemChanged(&calls)
 	}
 }
 
@@ -724,7 +1754,9 @@ func (l *List) transform(tr Transformation) {
 	var decreasing bool
 
 	pageItems := l.height
-	if l.showSecondaryText {
+	if l.expandCurrentItem {
+		pageItems--
+	} else if l.showSecondaryText {
 		pageItems /= 2
 	}
 	if pageItems < 1 {
@@ -769,7 +1801,7 @@ func (l *List) transform(tr Transformation) {
 		}
 
 		item := l.items[l.currentItem]
-		if !item.disabled && (item.shortcut > 0 || len(item.mainText) > 0 || len(item.secondaryText) > 0) {
+		if !item.disabled && (item.shortcutLabel() != "" || len(item.mainText) > 0 || len(item.secondaryText) > 0) {
 			break
 		}
 
@@ -783,6 +1815,24 @@ func (l *List) transform(tr Transformation) {
 	l.updateOffset()
 }
 
+// shortcutWidth returns whether any shortcuts are shown and the width of the
+// widest shortcut, including parentheses.
+func (l *List) shortcutWidth() (bool, int) {
+	var show bool
+	var width int
+	for _, item := range l.items {
+		label := item.shortcutLabel()
+		if label == "" {
+			continue
+		}
+		show = true
+		if w := TaggedStringWidth(Escape(label)) + 2; w > width {
+			width = w
+		}
+	}
+	return show, width
+}
+
 func (l *List) updateOffset() {
 	_, _, _, l.height = l.GetInnerRect()
 
@@ -793,6 +1843,10 @@ func (l *List) updateOffset() {
 
 	if l.currentItem < l.itemOffset {
 		l.itemOffset = l.currentItem
+	} else if l.expandCurrentItem {
+		if lines := l.currentItem - l.itemOffset + 1 + l.expandedLines(); lines > h {
+			l.itemOffset += lines - h
+		}
 	} else if l.showSecondaryText {
 		if 2*(l.currentItem-l.itemOffset) >= h-1 {
 			l.itemOffset = (2*l.currentItem + 3 - h) / 2
@@ -803,7 +1857,11 @@ func (l *List) updateOffset() {
 		}
 	}
 
-	if l.showSecondaryText {
+	if l.expandCurrentItem {
+		if maxOffset := len(l.items) + l.expandedLines() - l.height; l.itemOffset > maxOffset {
+			l.itemOffset = maxOffset
+		}
+	} else if l.showSecondaryText {
 		if l.itemOffset > len(l.items)-(l.height/2) {
 			l.itemOffset = len(l.items) - l.height/2
 		}
@@ -825,8 +1883,8 @@ func (l *List) updateOffset() {
 		if secondaryWidth > strWidth {
 			strWidth = secondaryWidth
 		}
-		if option.shortcut != 0 {
-			strWidth += 4
+		if label := option.shortcutLabel(); label != "" {
+			strWidth += TaggedStringWidth(label) + 3
 		}
 
 		if strWidth > maxWidth {
@@ -838,8 +1896,9 @@ func (l *List) updateOffset() {
 	addWidth := 0
 	if l.scrollBarVisibility == ScrollBarAlways ||
 		(l.scrollBarVisibility == ScrollBarAuto &&
-			((!l.showSecondaryText && len(l.items) > l.innerHeight) ||
-				(l.showSecondaryText && len(l.items) > l.innerHeight/2))) {
+			((l.expandCurrentItem && len(l.items)+l.expandedLines() > l.innerHeight) ||
+				(!l.expandCurrentItem && !l.showSecondaryText && len(l.items) > l.innerHeight) ||
+				(!l.expandCurrentItem && l.showSecondaryText && len(l.items) > l.innerHeight/2))) {
 		addWidth = 1
 	}
 
@@ -879,26 +1938,33 @@ func (l *List) Draw(screen tcell.Screen) {
 	}
 
 	// Halve scroll bar height when drawing two lines per list item.
-	if l.showSecondaryText {
+	if l.showSecondaryText && !l.expandCurrentItem {
 		scrollBarHeight /= 2
 	}
 
+	l.scrollBarX = -1
+	if l.scrollBarVisibility == ScrollBarAlways || (l.scrollBarVisibility == ScrollBarAuto && len(l.items) > scrollBarHeight) {
+		l.scrollBarX = scrollBarX
+	}
+
 	// Do we show any shortcuts?
-	var showShortcuts bool
-	for _, item := range l.items {
-		if item.shortcut != 0 {
-			showShortcuts = true
-			x += 4
-			width -= 4
-			break
-		}
+	showShortcuts, shortcutWidth := l.shortcutWidth()
+	if showShortcuts {
+		x += shortcutWidth + 1
+		width -= shortcutWidth + 1
 	}
+	l.truncatedItems, l.itemRows, l.itemTextX = make(map[int]bool), make(map[int]int), x
 
 	// Adjust offset to keep the current selection in view.
 	if l.selectedAlwaysVisible || l.selectedAlwaysCentered {
 		l.updateOffset()
 	}
 
+	// Draw the placeholder when there are no items.
+	if len(l.items) == 0 && len(l.placeholder) > 0 && height > 0 {
+		Print(screen, EscapeBytes(l.placeholder), leftEdge, y+(height-1)/2, width, AlignCenter, l.placeholderTextColor)
+	}
+
 	scrollBarCursor := int(float64(len(l.items)) * (float64(l.itemOffset) / float64(len(l.items)-height)))
 
 	// Draw the list items.
@@ -926,22 +1992,41 @@ func (l *List) Draw(screen tcell.Screen) {
 			}
 		}
 
-		if len(item.mainText) == 0 && len(item.secondaryText) == 0 && item.shortcut == 0 { // Divider
-			Print(screen, []byte(string(tcell.RuneLTee)), leftEdge-2, y, 1, AlignLeft, l.mainTextColor)
-			Print(screen, bytes.Repeat([]byte(string(tcell.RuneHLine)), fullWidth), leftEdge-1, y, fullWidth, AlignLeft, l.mainTextColor)
-			Print(screen, []byte(string(tcell.RuneRTee)), leftEdge+fullWidth-1, y, 1, AlignLeft, l.mainTextColor)
+		if item.isDivider() {
+			dividerColor := l.dividerColor
+			if dividerColor == ColorUnset {
+				dividerColor = l.mainTextColor
+			}
+			if l.dividerRune == tcell.RuneHLine {
+				Print(screen, []byte(string(tcell.RuneLTee)), leftEdge-2, y, 1, AlignLeft, dividerColor)
+				Print(screen, []byte(string(tcell.RuneRTee)), leftEdge+fullWidth-1, y, 1, AlignLeft, dividerColor)
+			}
+			Print(screen, bytes.Repeat([]byte(string(l.dividerRune)), fullWidth), leftEdge-1, y, fullWidth, AlignLeft, dividerColor)
+			if len(item.mainText) > 0 {
+				Print(screen, []byte(" "+string(item.mainText)+" "), leftEdge-1, y, fullWidth, AlignCenter, dividerColor)
+			}
 
 			RenderScrollBar(screen, l.scrollBarVisibility, scrollBarX, y, scrollBarHeight, len(l.items), scrollBarCursor, index-l.itemOffset, l.hasFocus, l.scrollBarColor)
 			y++
 			continue
 		} else if item.disabled {
 			// Shortcuts.
-			if showShortcuts && item.shortcut != 0 {
-				Print(screen, []byte(fmt.Sprintf("(%c)", item.shortcut)), x-5, y, 4, AlignRight, tcell.ColorDarkSlateGray.TrueColor())
+			if label := item.shortcutLabel(); showShortcuts && label != "" {
+				Print(screen, []byte(fmt.Sprintf("(%s)", Escape(label))), leftEdge, y, shortcutWidth, AlignRight, tcell.ColorDarkSlateGray.TrueColor())
+			}
+
+			// Badge.
+			mainWidth := width
+			if len(item.badge) > 0 {
+				_, badgeWidth := Print(screen, item.badge, x, y, width, AlignRight, tcell.ColorGray.TrueColor())
+				mainWidth -= badgeWidth + 1
+				if mainWidth < 0 {
+					mainWidth = 0
+				}
 			}
 
 			// Main text.
-			Print(screen, mainText, x, y, width, AlignLeft, tcell.ColorGray.TrueColor())
+			Print(screen, mainText, x, y, mainWidth, AlignLeft, tcell.ColorGray.TrueColor())
 
 			RenderScrollBar(screen, l.scrollBarVisibility, scrollBarX, y, scrollBarHeight, len(l.items), scrollBarCursor, index-l.itemOffset, l.hasFocus, l.scrollBarColor)
 			y++
@@ -949,17 +2034,37 @@ func (l *List) Draw(screen tcell.Screen) {
 		}
 
 		// Shortcuts.
-		if showShortcuts && item.shortcut != 0 {
-			Print(screen, []byte(fmt.Sprintf("(%c)", item.shortcut)), x-5, y, 4, AlignRight, l.shortcutColor)
+		if label := item.shortcutLabel(); showShortcuts && label != "" {
+			Print(screen, []byte(fmt.Sprintf("(%s)", Escape(label))), leftEdge, y, shortcutWidth, AlignRight, l.shortcutColor)
+		}
+
+		// Badge.
+		mainWidth := width
+		if len(item.badge) > 0 {
+			badgeColor := l.badgeColor
+			if item.badgeColor != ColorUnset {
+				badgeColor = item.badgeColor
+			}
+			_, badgeWidth := Print(screen, item.badge, x, y, width, AlignRight, badgeColor)
+			mainWidth -= badgeWidth + 1
+			if mainWidth < 0 {
+				mainWidth = 0
+			}
 		}
 
 		// Main text.
-		Print(screen, mainText, x, y, width, AlignLeft, l.mainTextColor)
+		Print(screen, mainText, x, y, mainWidth, AlignLeft, l.mainTextColor)
+		l.itemRows[index] = y
+		if l.columnOffset > 0 && len(item.mainText) > 0 || TaggedTextWidth(item.mainText) > mainWidth {
+			l.truncatedItems[index] = true
+		}
 
-		// Background color of selected text.
-		if index == l.currentItem && (!l.selectedFocusOnly || hasFocus) {
+		// Background color of selected and highlighted text.
+		selected := index == l.currentItem && (!l.selectedFocusOnly || hasFocus)
+		if selected || l.highlighted[index] {
 			textWidth := width
 			if !l.highlightFullLine {
+				textWidth = mainWidth
 				if w := TaggedTextWidth(mainText); w < textWidth {
 					textWidth = w
 				}
@@ -968,14 +2073,32 @@ func (l *List) Draw(screen tcell.Screen) {
 			for bx := 0; bx < textWidth; bx++ {
 				m, c, style, _ := screen.GetContent(x+bx, y)
 				fg, _, _ := style.Decompose()
-				if fg == l.mainTextColor {
-					fg = l.selectedTextColor
+				if selected {
+					if fg == l.mainTextColor {
+						fg = l.selectedTextColor
+					}
+					style = SetAttributes(style.Background(l.selectedBackgroundColor).Foreground(fg), l.selectedTextAttributes)
+				} else {
+					if fg == l.mainTextColor {
+						fg = l.highlightTextColor
+					}
+					style = style.Background(l.highlightBackgroundColor).Foreground(fg)
 				}
-				style = SetAttributes(style.Background(l.selectedBackgroundColor).Foreground(fg), l.selectedTextAttributes)
 				screen.SetContent(x+bx, y, m, c, style)
 			}
 		}
 
+		// Editor.
+		if l.editor != nil && item == l.editItem {
+			if hasFocus {
+				l.editor.Focus(nil)
+			} else {
+				l.editor.Blur()
+			}
+			l.editor.SetRect(x, y, mainWidth, 1)
+			l.editor.Draw(screen)
+		}
+
 		RenderScrollBar(screen, l.scrollBarVisibility, scrollBarX, y, scrollBarHeight, len(l.items), scrollBarCursor, index-l.itemOffset, l.hasFocus, l.scrollBarColor)
 
 		y++
@@ -985,8 +2108,11 @@ func (l *List) Draw(screen tcell.Screen) {
 		}
 
 		// Secondary text.
-		if l.showSecondaryText {
+		if l.itemHeight(index) > 1 {
 			Print(screen, secondaryText, x, y, width, AlignLeft, l.secondaryTextColor)
+			if l.columnOffset > 0 && len(item.secondaryText) > 0 || TaggedTextWidth(item.secondaryText) > width {
+				l.truncatedItems[index] = true
+			}
 
 			RenderScrollBar(screen, l.scrollBarVisibility, scrollBarX, y, scrollBarHeight, len(l.items), scrollBarCursor, index-l.itemOffset, l.hasFocus, l.scrollBarColor)
 
@@ -1011,8 +2137,8 @@ func (l *List) Draw(screen tcell.Screen) {
 		maxWidth := 0
 		for _, option := range ctx.items {
 			strWidth := TaggedTextWidth(option.mainText)
-			if option.shortcut != 0 {
-				strWidth += 4
+			if label := option.shortcutLabel(); label != "" {
+				strWidth += TaggedStringWidth(label) + 3
 			}
 			if strWidth > maxWidth {
 				maxWidth = strWidth
@@ -1033,10 +2159,10 @@ func (l *List) Draw(screen tcell.Screen) {
 		if cx < 0 || cy < 0 {
 			offsetX := 7
 			if showShortcuts {
-				offsetX += 4
+				offsetX += shortcutWidth + 1
 			}
 			offsetY := l.currentItem
-			if l.showSecondaryText {
+			if l.showSecondaryText && !l.expandCurrentItem {
 				offsetY *= 2
 			}
 			x, y, _, _ := l.GetInnerRect()
@@ -1065,74 +2191,213 @@ func (l *List) Draw(screen tcell.Screen) {
 
 		ctx.SetRect(cx, cy, lwidth, lheight)
 		ctx.Draw(screen)
+	} else if l.tooltipVisible {
+		l.drawTooltip(screen)
+	}
+}
+
+// setHoverItem updates the item the mouse is resting on and schedules its
+// tooltip. It returns whether a visible tooltip was hidden.
+func (l *List) setHoverItem(index, x, y int) (hidden bool) {
+	if index == l.hoverItem {
+		return false
+	}
+
+	if l.tooltipTimer != nil {
+		l.tooltipTimer.Stop()
+		l.tooltipTimer = nil
+	}
+	hidden = l.tooltipVisible
+	l.tooltipVisible = false
+	l.hoverItem = index
+
+	if index >= 0 && len(l.tooltipText(index)) > 0 {
+		l.tooltipX, l.tooltipY = x, y
+		l.tooltipTimer = time.AfterFunc(l.tooltipDelay, func() {
+			l.showTooltip(index)
+		})
+	}
+	return hidden
+}
+
+// showTooltip is called once the mouse has rested on an item long enough.
+// The tooltip is drawn on every following draw until the mouse moves onto a
+// different item. The tooltip shown handler is called to request a redraw.
+func (l *List) showTooltip(index int) {
+	l.Lock()
+	if l.hoverItem != index || index >= len(l.items) {
+		l.Unlock()
+		return
+	}
+	l.tooltipTimer = nil
+	l.tooltipVisible, l.tooltipItem = true, index
+	item, shown := l.items[index], l.tooltipShown
+	l.Unlock()
+
+	if shown != nil {
+		shown(index, item)
+	}
+}
+
+// showCurrentTooltip shows the tooltip of the current item below it.
+func (l *List) showCurrentTooltip() {
+	y, ok := l.itemRows[l.currentItem]
+	if !ok || len(l.tooltipText(l.currentItem)) == 0 {
+		return
+	}
+	if l.tooltipTimer != nil {
+		l.tooltipTimer.Stop()
+		l.tooltipTimer = nil
+	}
+	l.tooltipX, l.tooltipY = l.itemTextX-1, y
+	l.tooltipVisible, l.tooltipItem = true, l.currentItem
+}
+
+// tooltipText returns the text of the tooltip of the item with the provided
+// index, or nil if it has none.
+func (l *List) tooltipText(index int) []byte {
+	if index < 0 || index >= len(l.items) {
+		return nil
+	}
+	item := l.items[index]
+	if len(item.tooltip) > 0 {
+		return item.tooltip
+	} else if !l.showTruncatedText || !l.truncatedItems[index] {
+		return nil
+	}
+
+	text := item.mainText
+	if l.itemHeight(index) > 1 && len(item.secondaryText) > 0 {
+		text = append(append(append([]byte(nil), text...), '\n'), item.secondaryText...)
+	}
+	return text
+}
+
+// drawTooltip draws the visible tooltip below the mouse cursor or item, or
+// above it when there is not enough space below. Lines which are wider than
+// the screen are wrapped.
+func (l *List) drawTooltip(screen tcell.Screen) {
+	tooltip := l.tooltipText(l.tooltipItem)
+	if len(tooltip) == 0 {
+		return
+	}
+
+	screenWidth, screenHeight := screen.Size()
+	if screenWidth < 3 {
+		return
+	}
+	lines := WordWrap(string(tooltip), screenWidth-2)
+	var width int
+	for _, line := range lines {
+		if w := TaggedStringWidth(line) + 2; w > width {
+			width = w
+		}
+	}
+	if width > screenWidth {
+		width = screenWidth
+	}
+	x, y := l.tooltipX+1, l.tooltipY+1
+	if x+width > screenWidth {
+		x = screenWidth - width
+	}
+	if y+len(lines) > screenHeight {
+		y = l.tooltipY - len(lines)
+	}
+	if x < 0 || y < 0 {
+		return
+	}
+
+	style := tcell.StyleDefault.Background(l.tooltipBackgroundColor)
+	for i, line := range lines {
+		for j := 0; j < width; j++ {
+			screen.SetContent(x+j, y+i, ' ', nil, style)
+		}
+		PrintStyle(screen, []byte(line), x+1, y+i, width-2, AlignLeft, style.Foreground(l.tooltipTextColor))
 	}
 }
 
 // InputHandler returns the handler for this primitive.
 func (l *List) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
 	return l.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		var calls callbacks
+		defer calls.run()
+
 		l.Lock()
+		defer l.Unlock()
 
-		if HitShortcut(event, Keys.Cancel) {
-			if l.ContextMenu.open {
-				l.Unlock()
+		// Pass events to the editor.
+		if editor := l.editor; editor != nil {
+			calls.add(func() {
+				editor.InputHandler()(event, setFocus)
+			})
+			return
+		}
 
-				l.ContextMenu.hide(setFocus)
+		// Hide the tooltip on any key.
+		if l.tooltipVisible {
+			l.tooltipVisible = false
+			if HitShortcut(event, Keys.Cancel, Keys.ShowTooltip) {
 				return
 			}
+		}
 
-			if l.done != nil {
-				l.Unlock()
-				l.done()
-			} else {
-				l.Unlock()
+		if HitShortcut(event, Keys.Cancel) {
+			if l.ContextMenu.open {
+				calls.add(func() {
+					l.ContextMenu.hide(setFocus)
+				})
+				return
 			}
+
+			calls.add(l.done)
 			return
 		} else if HitShortcut(event, Keys.Select, Keys.Select2) {
-			if l.currentItem >= 0 && l.currentItem < len(l.items) {
-				item := l.items[l.currentItem]
-				if !item.disabled {
-					if item.selected != nil {
-						l.Unlock()
-						item.selected()
-						l.Lock()
-					}
-					if l.selected != nil {
-						l.Unlock()
-						l.selected(l.currentItem, item)
-						l.Lock()
-					}
-				}
+			if l.currentItem >= 0 && l.currentItem < len(l.items) && !l.items[l.currentItem].disabled {
+				l.selectItem(&calls, l.currentItem)
 			}
 		} else if HitShortcut(event, Keys.ShowContextMenu) {
-			defer l.ContextMenu.show(l.currentItem, -1, -1, setFocus)
+			index := l.currentItem
+			calls.add(func() {
+				l.ContextMenu.show(index, -1, -1, setFocus)
+			})
+		} else if HitShortcut(event, Keys.ShowTooltip) {
+			l.showCurrentTooltip()
+			return
+		} else if HitShortcut(event, Keys.DeleteItem, Keys.DuplicateItem, Keys.EditItem) && l.currentItem >= 0 && l.currentItem < len(l.items) {
+			if l.items[l.currentItem].disabled {
+				return
+			}
+			switch {
+			case l.itemsDeletable && HitShortcut(event, Keys.DeleteItem):
+				l.deleteItem(&calls, l.currentItem)
+				return
+			case l.itemsDuplicable && HitShortcut(event, Keys.DuplicateItem):
+				l.duplicateItem(&calls, l.currentItem)
+				return
+			case l.itemsEditable && HitShortcut(event, Keys.EditItem):
+				l.editItemAt(&calls, l.currentItem)
+				return
+			}
 		} else if len(l.items) == 0 {
-			l.Unlock()
 			return
 		}
 
+		// Is it a named shortcut?
+		for index, item := range l.items {
+			if !item.disabled && item.shortcutKey != "" && HitShortcut(event, []string{item.shortcutKey}) {
+				l.selectItem(&calls, index)
+				return
+			}
+		}
+
 		if event.Key() == tcell.KeyRune {
 			ch := event.Rune()
 			if ch != ' ' {
 				// It's not a space bar. Is it a shortcut?
 				for index, item := range l.items {
-					if !item.disabled && item.shortcut == ch {
+					if !item.disabled && item.shortcutKey == "" && item.shortcut == ch {
 						// We have a shortcut.
-						l.currentItem = index
-
-						item := l.items[l.currentItem]
-						if item.selected != nil {
-							l.Unlock()
-							item.selected()
-							l.Lock()
-						}
-						if l.selected != nil {
-							l.Unlock()
-							l.selected(l.currentItem, item)
-							l.Lock()
-						}
-
-						l.Unlock()
+						l.selectItem(&calls, index)
 						return
 					}
 				}
@@ -1141,7 +2406,10 @@ func (l *List) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 
 		previousItem := l.currentItem
 
-		if HitShortcut(event, Keys.MoveFirst, Keys.MoveFirst2) {
+		if index := l.jumpIndex(event); index >= 0 {
+			l.currentItem = index
+			l.updateOffset()
+		} else if HitShortcut(event, Keys.MoveFirst, Keys.MoveFirst2) {
 			l.transform(TransformFirstItem)
 		} else if HitShortcut(event, Keys.MoveLast, Keys.MoveLast2) {
 			l.transform(TransformLastItem)
@@ -1161,16 +2429,68 @@ func (l *List) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 			l.transform(TransformNextPage)
 		}
 
-		if l.currentItem != previousItem && l.currentItem < len(l.items) && l.changed != nil {
-			item := l.items[l.currentItem]
-			l.Unlock()
-			l.changed(l.currentItem, item)
-		} else {
-			l.Unlock()
+		if l.currentItem != previousItem {
+			l.currentItemChanged(&calls)
 		}
 	})
 }
 
+// selectItem makes the item at the provided index the current item and adds
+// the handlers called when it is selected, along with the changed handler if
+// the current item changed, to the provided callbacks.
+func (l *List) selectItem(calls *callbacks, index int) {
+	item := l.items[index]
+	if index != l.currentItem {
+		l.currentItem = index
+		l.currentItemChanged(calls)
+	}
+
+	calls.add(item.selected)
+	if selected := l.selected; selected != nil {
+		calls.add(func() {
+			selected(index, item)
+		})
+	}
+}
+
+// currentItemChanged adds the changed handler, called with the current item,
+// to the provided callbacks.
+func (l *List) currentItemChanged(calls *callbacks) {
+	changed := l.changed
+	if changed == nil || l.currentItem < 0 || l.currentItem >= len(l.items) {
+		return
+	}
+	index, item := l.currentItem, l.items[l.currentItem]
+	calls.add(func() {
+		changed(index, item)
+	})
+}
+
+// jumpIndex returns the index of the next item after the current item which
+// starts with the letter typed, or -1 when no item matches.
+func (l *List) jumpIndex(event *tcell.EventKey) int {
+	if !l.jumpToLetter {
+		return -1
+	}
+	ch, ok := jumpRune(event)
+	if !ok {
+		return -1
+	}
+
+	for i := 1; i <= len(l.items); i++ {
+		index := (l.currentItem + i) % len(l.items)
+		item := l.items[index]
+		text := item.mainText
+		if l.jumpToLetterSecondary {
+			text = item.secondaryText
+		}
+		if !item.disabled && startsWithLetter(text, ch, l.jumpToLetterIgnoreCase) {
+			return index
+		}
+	}
+	return -1
+}
+
 // indexAtY returns the index of the list item found at the given Y position
 // or a negative value if there is no such list item.
 func (l *List) indexAtY(y int) int {
@@ -1180,10 +2500,13 @@ func (l *List) indexAtY(y int) int {
 	}
 
 	index := y - rectY
-	if l.showSecondaryText {
-		index /= 2
+	if l.expandCurrentItem {
+		index = l.indexAtRow(index)
+	} else if l.showSecondaryText {
+		index = index/2 + l.itemOffset
+	} else {
+		index += l.itemOffset
 	}
-	index += l.itemOffset
 
 	if index >= len(l.items) {
 		return -1
@@ -1191,6 +2514,78 @@ func (l *List) indexAtY(y int) int {
 	return index
 }
 
+// onScrollBar returns whether the given position is on the scroll bar.
+func (l *List) onScrollBar(x, y int) bool {
+	_, rectY, _, height := l.GetInnerRect()
+	return l.scrollBarX >= 0 && x == l.scrollBarX && y >= rectY && y < rectY+height
+}
+
+// visibleItems returns the number of items which fit within the list.
+func (l *List) visibleItems() int {
+	_, _, _, height := l.GetInnerRect()
+	if l.expandCurrentItem {
+		height -= l.expandedLines()
+	} else if l.showSecondaryText {
+		height /= 2
+	}
+	return height
+}
+
+// scrollBy scrolls the list by the given number of items without changing
+// the selection.
+func (l *List) scrollBy(items int) {
+	l.itemOffset += items
+	if maxOffset := len(l.items) - l.visibleItems(); l.itemOffset > maxOffset {
+		l.itemOffset = maxOffset
+	}
+	if l.itemOffset < 0 {
+		l.itemOffset = 0
+	}
+}
+
+// wheelStep returns the number of items to scroll by for the given mouse
+// wheel event.
+func (l *List) wheelStep(action MouseAction, event *tcell.EventMouse) int {
+	if l.scrollWheelPage && event.Modifiers()&tcell.ModShift != 0 {
+		l.wheelRepeat = 0
+		if page := l.visibleItems(); page > 1 {
+			return page
+		}
+		return 1
+	}
+
+	if l.scrollWheelKinetic && action == l.lastWheelAction && event.When().Sub(l.lastWheelTime) < 50*time.Millisecond {
+		if l.wheelRepeat < 8 {
+			l.wheelRepeat++
+		}
+	} else {
+		l.wheelRepeat = 1
+	}
+	l.lastWheelAction, l.lastWheelTime = action, event.When()
+
+	return l.scrollWheelStep * l.wheelRepeat
+}
+
+// scrollToY scrolls the list to the position of the scroll bar at the given
+// row.
+func (l *List) scrollToY(y int) {
+	_, rectY, _, height := l.GetInnerRect()
+
+	maxOffset := len(l.items) - l.visibleItems()
+	if maxOffset <= 0 || height <= 1 {
+		l.itemOffset = 0
+		return
+	}
+
+	row := y - rectY
+	if row < 0 {
+		row = 0
+	} else if row > height-1 {
+		row = height - 1
+	}
+	l.itemOffset = int(math.Round(float64(row) / float64(height-1) * float64(maxOffset)))
+}
+
 // indexAtPoint returns the index of the list item found at the given position
 // or a negative value if there is no such list item.
 func (l *List) indexAtPoint(x, y int) int {
@@ -1200,10 +2595,13 @@ func (l *List) indexAtPoint(x, y int) int {
 	}
 
 	index := y - rectY
-	if l.showSecondaryText {
-		index /= 2
+	if l.expandCurrentItem {
+		index = l.indexAtRow(index)
+	} else if l.showSecondaryText {
+		index = index/2 + l.itemOffset
+	} else {
+		index += l.itemOffset
 	}
-	index += l.itemOffset
 
 	if index >= len(l.items) {
 		return -1
@@ -1214,93 +2612,141 @@ func (l *List) indexAtPoint(x, y int) int {
 // MouseHandler returns the mouse handler for this primitive.
 func (l *List) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return l.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		var calls callbacks
+		defer calls.run()
+
 		l.Lock()
+		defer l.Unlock()
 
 		// Pass events to context menu.
 		if l.ContextMenuVisible() && l.ContextMenuList().InRect(event.Position()) {
-			defer l.ContextMenuList().MouseHandler()(action, event, setFocus)
-			consumed = true
-			l.Unlock()
-			return
+			calls.add(func() {
+				l.ContextMenuList().MouseHandler()(action, event, setFocus)
+			})
+			return true, nil
+		}
+
+		// Pass events to the editor, and finish editing when clicking
+		// elsewhere.
+		if editor := l.editor; editor != nil {
+			if editor.InRect(event.Position()) {
+				calls.add(func() {
+					editor.MouseHandler()(action, event, func(p Primitive) {
+						setFocus(l)
+					})
+				})
+				return true, nil
+			} else if action == MouseLeftDown {
+				calls.add(func() {
+					l.finishEditing(false)
+				})
+			}
+		}
+
+		// Scroll while the scroll bar is dragged.
+		if l.scrollBarDrag {
+			switch action {
+			case MouseMove:
+				_, y := event.Position()
+				l.scrollToY(y)
+				capture = l
+			case MouseLeftUp:
+				l.scrollBarDrag = false
+			default:
+				capture = l
+			}
+			return true, capture
+		} else if x, y := event.Position(); l.onScrollBar(x, y) {
+			switch action {
+			case MouseLeftDown:
+				calls.add(func() {
+					setFocus(l)
+				})
+
+				l.scrollToY(y)
+				l.scrollBarDrag = true
+				capture = l
+				consumed = true
+			case MouseLeftClick, MouseLeftDoubleClick:
+				consumed = true
+			}
+			if consumed {
+				return consumed, capture
+			}
 		}
 
 		if !l.InRect(event.Position()) {
-			l.Unlock()
-			return false, nil
+			// Release the mouse once it leaves the list.
+			if l.hoverItem >= 0 {
+				consumed = l.setHoverItem(-1, 0, 0)
+				if hoverFunc := l.hoverFunc; hoverFunc != nil {
+					calls.add(func() {
+						hoverFunc(-1, nil)
+					})
+				}
+			}
+			return consumed, nil
+		}
+
+		// Hide the tooltip when the mouse is used for anything but hovering.
+		if action != MouseMove && (l.tooltipVisible || l.tooltipTimer != nil) {
+			if l.tooltipTimer != nil {
+				l.tooltipTimer.Stop()
+				l.tooltipTimer = nil
+			}
+			l.tooltipVisible = false
+			consumed = true
 		}
 
 		// Process mouse event.
 		switch action {
 		case MouseLeftClick:
 			if l.ContextMenuVisible() {
-				defer l.ContextMenu.hide(setFocus)
-				consumed = true
-				l.Unlock()
-				return
+				calls.add(func() {
+					l.ContextMenu.hide(setFocus)
+				})
+				return true, nil
 			}
 
-			l.Unlock()
-			setFocus(l)
-			l.Lock()
+			calls.add(func() {
+				setFocus(l)
+			})
 
 			index := l.indexAtPoint(event.Position())
-			if index != -1 {
-				item := l.items[index]
-				if !item.disabled {
-					l.currentItem = index
-					if item.selected != nil {
-						l.Unlock()
-						item.selected()
-						l.Lock()
-					}
-					if l.selected != nil {
-						l.Unlock()
-						l.selected(index, item)
-						l.Lock()
-					}
-					if index != l.currentItem && l.changed != nil {
-						l.Unlock()
-						l.changed(index, item)
-						l.Lock()
-					}
-				}
+			if index != -1 && !l.items[index].disabled {
+				l.selectItem(&calls, index)
 			}
 			consumed = true
 		case MouseMiddleClick:
 			if l.ContextMenu.open {
-				defer l.ContextMenu.hide(setFocus)
-				consumed = true
-				l.Unlock()
-				return
+				calls.add(func() {
+					l.ContextMenu.hide(setFocus)
+				})
+				return true, nil
 			}
 		case MouseRightDown:
 			if len(l.ContextMenuList().items) == 0 {
-				l.Unlock()
 				return
 			}
 
 			x, y := event.Position()
 
 			index := l.indexAtPoint(event.Position())
-			if index != -1 {
-				item := l.items[index]
-				if !item.disabled {
-					l.currentItem = index
-					if index != l.currentItem && l.changed != nil {
-						l.Unlock()
-						l.changed(index, item)
-						l.Lock()
-					}
-				}
+			if index != -1 && !l.items[index].disabled && index != l.currentItem {
+				l.currentItem = index
+				l.currentItemChanged(&calls)
 			}
 
-			defer l.ContextMenu.show(l.currentItem, x, y, setFocus)
+			current := l.currentItem
+			calls.add(func() {
+				l.ContextMenu.show(current, x, y, setFocus)
+			})
 			l.ContextMenu.drag = true
 			consumed = true
 		case MouseMove:
+			x, y := event.Position()
+			index := l.indexAtY(y)
 			if l.hover {
-				_, y := event.Position()
-				index := l.indexAtY(y)
 				if index >= 0 {
 					item := l.items[index]
 					if !item.disabled {
@@ -1310,23 +2756,34 @@ func (l *List) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 
 				consumed = true
 			}
-		case MouseScrollUp:
-			if l.itemOffset > 0 {
-				l.itemOffset--
+
+			if index != l.hoverItem {
+				if l.setHoverItem(index, x, y) {
+					consumed = true
+				}
+				if hoverFunc := l.hoverFunc; hoverFunc != nil {
+					var item *ListItem
+					if index >= 0 {
+						item = l.items[index]
+					}
+					calls.add(func() {
+						hoverFunc(index, item)
+					})
+				}
+			}
+
+			// Capture the mouse to be notified when it leaves the list.
+			if l.hoverItem >= 0 {
+				capture = l
 			}
+		case MouseScrollUp:
+			l.scrollBy(-l.wheelStep(action, event))
 			consumed = true
 		case MouseScrollDown:
-			lines := len(l.items) - l.itemOffset
-			if l.showSecondaryText {
-				lines *= 2
-			}
-			if _, _, _, height := l.GetInnerRect(); lines > height {
-				l.itemOffset++
-			}
+			l.scrollBy(l.wheelStep(action, event))
 			consumed = true
 		}
 
-		l.Unlock()
 		return
 	})
 }
@@ -1,7 +1,13 @@
 package cview
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
 )
 
 const (
@@ -64,12 +70,863 @@ func TestList(t *testing.T) {
 		t.Errorf("failed to update List: expected secondary text %s, got %s", listTextC, secondaryText)
 	}
 
+	// Get items by handle
+
+	if l.GetItem(-1) != itemB {
+		t.Errorf("failed to get List item: expected item 1 at index -1")
+	} else if l.GetItem(2) != nil {
+		t.Errorf("failed to get List item: expected nil for out of range index")
+	} else if index := l.GetItemIndex(itemB); index != 1 {
+		t.Errorf("failed to get List item index: expected 1, got %d", index)
+	}
+
+	l.InsertItem(0, NewListItem(listTextC))
+	if index := l.GetItemIndex(itemB); index != 2 {
+		t.Errorf("failed to get List item index: expected 2, got %d", index)
+	}
+
+	l.RemoveItem(0)
+	if index := l.GetItemIndex(itemB); index != 1 {
+		t.Errorf("failed to get List item index: expected 1, got %d", index)
+	}
+
+	// Set item 0 badge
+
+	itemA.SetBadge("42")
+	if badge := itemA.GetBadge(); badge != "42" {
+		t.Errorf("failed to update List: expected badge 42, got %s", badge)
+	}
+
+	// Draw
+
+	app, err := newTestApp(l)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	l.Draw(app.screen)
+}
+
+func TestListHover(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	l := NewList()
+	l.ShowSecondaryText(false)
+	l.SetRect(0, 0, 20, 5)
+	for _, text := range []string{listTextA, listTextB, listTextC} {
+		l.AddItem(NewListItem(text))
+	}
+
+	hovered := -2
+	l.SetHoverFunc(func(index int, item *ListItem) {
+		hovered = index
+	})
+	move := func(x, y int) {
+		l.MouseHandler()(MouseMove, tcell.NewEventMouse(x, y, tcell.ButtonNone, 0), func(p Primitive) {})
+	}
+
+	// Hover item 1
+
+	move(1, 1)
+	if hovered != 1 {
+		t.Errorf("failed to hover List item: expected index 1, got %d", hovered)
+	}
+
+	// Leave list
+
+	move(30, 1)
+	if hovered != -1 {
+		t.Errorf("failed to hover List item: expected index -1, got %d", hovered)
+	}
+}
+
+func TestListTooltip(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	l := NewList()
+	l.ShowSecondaryText(false)
+	l.SetRect(0, 0, 20, 5)
+	for _, text := range []string{listTextA, listTextB, listTextC} {
+		l.AddItem(NewListItem(text))
+	}
+	l.GetItem(1).SetTooltip("Tip")
+	l.SetTooltipDelay(10 * time.Millisecond)
+
+	shown := make(chan int, 1)
+	l.SetTooltipShownFunc(func(index int, item *ListItem) {
+		shown <- index
+	})
+
+	app, err := newTestApp(l)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	l.Draw(app.screen)
+
+	row := func(y int) string {
+		var line []rune
+		for x := 0; x < 20; x++ {
+			r, _, _, _ := app.screen.GetContent(x, y)
+			line = append(line, r)
+		}
+		return strings.TrimRight(string(line), " ")
+	}
+	move := func(x, y int) {
+		l.MouseHandler()(MouseMove, tcell.NewEventMouse(x, y, tcell.ButtonNone, 0), func(p Primitive) {})
+	}
+
+	// Rest on item without tooltip
+
+	move(1, 0)
+	time.Sleep(20 * time.Millisecond)
+	select {
+	case index := <-shown:
+		t.Errorf("failed to show tooltip: expected no tooltip, got tooltip of item %d", index)
+	default:
+	}
+
+	// Rest on item with tooltip
+
+	move(1, 1)
+	select {
+	case index := <-shown:
+		if index != 1 {
+			t.Errorf("failed to show tooltip: expected index 1, got %d", index)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("failed to show tooltip: expected tooltip shown handler to be called")
+	}
+	l.Draw(app.screen)
+	if r, expected := row(2), "He Tip Dolly!"; r != expected {
+		t.Errorf("failed to draw tooltip: expected %q, got %q", expected, r)
+	}
+
+	// Leave item
+
+	move(1, 2)
+	app.screen.Clear()
+	l.Draw(app.screen)
+	if r, expected := row(2), listTextC; r != expected {
+		t.Errorf("failed to hide tooltip: expected %q, got %q", expected, r)
+	}
+}
+
+func TestListTruncatedText(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	l := NewList()
+	l.ShowSecondaryText(false)
+	l.SetRect(0, 0, 10, 3)
+	l.SetShowTruncatedText(true)
+	l.AddItem(NewListItem("Short"))
+	l.AddItem(NewListItem("A long item"))
+
+	app, err := newTestApp(l)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	l.Draw(app.screen)
+
+	row := func(y int) string {
+		var line []rune
+		for x := 0; x < 20; x++ {
+			r, _, _, _ := app.screen.GetContent(x, y)
+			line = append(line, r)
+		}
+		return strings.TrimRight(string(line), " ")
+	}
+
+	// Item which is not truncated
+
+	l.InputHandler()(tcell.NewEventKey(tcell.KeyRune, 't', tcell.ModAlt), func(p Primitive) {})
+	if l.tooltipVisible {
+		t.Errorf("failed to show truncated text: expected no tooltip for item which is not truncated")
+	}
+
+	// Truncated item
+
+	l.SetCurrentItem(1)
+	l.InputHandler()(tcell.NewEventKey(tcell.KeyRune, 't', tcell.ModAlt), func(p Primitive) {})
+	app.screen.Clear()
+	l.Draw(app.screen)
+	if r, expected := row(2), " A long item"; r != expected {
+		t.Errorf("failed to show truncated text: expected %q, got %q", expected, r)
+	}
+
+	// Hide
+
+	l.InputHandler()(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone), func(p Primitive) {})
+	if l.tooltipVisible {
+		t.Errorf("failed to hide truncated text: tooltip is visible")
+	}
+
+	// Hover truncated item
+
+	shown := make(chan struct{})
+	l.SetTooltipDelay(10 * time.Millisecond)
+	l.SetTooltipShownFunc(func(index int, item *ListItem) {
+		close(shown)
+	})
+	l.MouseHandler()(MouseMove, tcell.NewEventMouse(1, 1, tcell.ButtonNone, 0), func(p Primitive) {})
+	select {
+	case <-shown:
+	case <-time.After(time.Second):
+		t.Fatal("failed to show truncated text on hover: expected tooltip shown handler to be called")
+	}
+	app.screen.Clear()
+	l.Draw(app.screen)
+	if r, expected := row(2), "   A long item"; r != expected {
+		t.Errorf("failed to show truncated text on hover: expected %q, got %q", expected, r)
+	}
+}
+
+func TestListShortcutKey(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	l := NewList()
+	l.ShowSecondaryText(false)
+	l.SetRect(0, 0, 20, 5)
+	for _, text := range []string{listTextA, listTextB, listTextC} {
+		l.AddItem(NewListItem(text))
+	}
+
+	item := l.GetItem(2)
+	item.SetShortcutKey("ctrl-d")
+	if key := item.GetShortcutKey(); key != "Ctrl+D" {
+		t.Errorf("failed to set shortcut key: expected Ctrl+D, got %s", key)
+	}
+
 	// Draw
 
 	app, err := newTestApp(l)
 	if err != nil {
 		t.Errorf("failed to initialize Application: %s", err)
 	}
+	l.Draw(app.screen)
+
+	// Select item 2
+
+	l.InputHandler()(tcell.NewEventKey(tcell.KeyCtrlD, 'd', tcell.ModCtrl), func(p Primitive) {})
+	if index := l.GetCurrentItemIndex(); index != 2 {
+		t.Errorf("failed to select List item via shortcut key: expected index 2, got %d", index)
+	}
+}
+
+func TestListJumpToLetter(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	l := NewList()
+	for _, text := range []string{"apple", "Banana", "avocado", "apricot", "cherry"} {
+		item := NewListItem(text)
+		item.SetSecondaryText("x" + text)
+		l.AddItem(item)
+	}
+	l.SetItemEnabled(3, false)
+	l.SetJumpToLetter(true)
+
+	app, err := newTestApp(l)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	l.Draw(app.screen)
+
+	press := func(ch rune) {
+		l.InputHandler()(tcell.NewEventKey(tcell.KeyRune, ch, tcell.ModNone), func(p Primitive) {})
+	}
+	expectItem := func(expected int) {
+		if index := l.GetCurrentItemIndex(); index != expected {
+			t.Errorf("failed to jump to letter: expected item %d, got %d", expected, index)
+		}
+	}
+
+	// Cycle through matching items, skipping disabled items
+
+	for _, expected := range []int{2, 0, 2} {
+		press('a')
+		expectItem(expected)
+	}
+
+	// Ignore case
+
+	press('b')
+	expectItem(1)
+	press('A')
+	expectItem(2)
+
+	l.SetJumpToLetterIgnoreCase(false)
+	press('b')
+	expectItem(2)
+	press('B')
+	expectItem(1)
+
+	// Letters which do not match are handled as usual
+
+	press('j')
+	expectItem(2)
+
+	// Secondary text
+
+	l.SetJumpToLetterSecondaryText(true)
+	press('c')
+	expectItem(2)
+	press('x')
+	expectItem(4)
+
+	// Disabled
+
+	l.SetJumpToLetter(false)
+	press('a')
+	expectItem(4)
+}
+
+func TestListCallbacks(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	l := NewList()
+	l.ShowSecondaryText(false)
+	l.SetRect(0, 0, 20, 5)
+	for _, text := range []string{listTextA, listTextB, listTextC} {
+		l.AddItem(NewListItem(text))
+	}
+
+	var changed, selected []int
+	l.SetChangedFunc(func(index int, item *ListItem) {
+		// Calling methods of the list from its callbacks must not deadlock.
+		if current := l.GetCurrentItemIndex(); current != index {
+			t.Errorf("failed to call changed handler: expected current index %d, got %d", index, current)
+		}
+		changed = append(changed, index)
+	})
+	l.SetSelectedFunc(func(index int, item *ListItem) {
+		selected = append(selected, index)
+		l.RemoveItem(index)
+	})
+
+	app, err := newTestApp(l)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	l.Draw(app.screen)
+
+	// Move down
+
+	l.InputHandler()(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone), func(p Primitive) {})
+	if len(changed) != 1 || changed[0] != 1 {
+		t.Errorf("failed to call changed handler: expected [1], got %v", changed)
+	}
+
+	// Select and remove item 1
+
+	l.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	if len(selected) != 1 || selected[0] != 1 {
+		t.Errorf("failed to call selected handler: expected [1], got %v", selected)
+	} else if count := l.GetItemCount(); count != 2 {
+		t.Errorf("failed to remove item from selected handler: expected 2 items, got %d", count)
+	}
+
+	// Click item 0
+
+	changed = nil
+	l.MouseHandler()(MouseLeftClick, tcell.NewEventMouse(1, 0, tcell.Button1, tcell.ModNone), func(p Primitive) {})
+	if len(changed) != 1 || changed[0] != 0 {
+		t.Errorf("failed to call changed handler: expected [0], got %v", changed)
+	} else if len(selected) != 2 || selected[1] != 0 {
+		t.Errorf("failed to call selected handler: expected [1 0], got %v", selected)
+	}
+}
+
+func TestListPlaceholder(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	l := NewList()
+	l.SetRect(0, 0, 11, 3)
+	l.SetPlaceholder("Empty")
+	if placeholder := l.GetPlaceholder(); placeholder != "Empty" {
+		t.Errorf("failed to set placeholder: expected Empty, got %s", placeholder)
+	}
 
+	// Draw
+
+	app, err := newTestApp(l)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
 	l.Draw(app.screen)
+
+	var text []rune
+	for x := 3; x < 8; x++ {
+		r, _, _, _ := app.screen.GetContent(x, 1)
+		text = append(text, r)
+	}
+	if string(text) != "Empty" {
+		t.Errorf("failed to draw placeholder: expected Empty, got %s", string(text))
+	}
+}
+
+func TestListDivider(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	l := NewList()
+	l.ShowSecondaryText(false)
+	l.SetRect(0, 0, 20, 5)
+	l.AddItem(NewListItem(listTextA))
+	l.AddDivider("Archived")
+	l.AddItem(NewListItem(listTextB))
+
+	// Draw
+
+	app, err := newTestApp(l)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	l.Draw(app.screen)
+
+	var caption []rune
+	for x := 0; x < 20; x++ {
+		r, _, _, _ := app.screen.GetContent(x, 1)
+		if r != tcell.RuneHLine && r != tcell.RuneRTee && r != ' ' {
+			caption = append(caption, r)
+		}
+	}
+	if string(caption) != "Archived" {
+		t.Errorf("failed to draw divider caption: expected Archived, got %s", string(caption))
+	}
+
+	// Skip divider
+
+	l.InputHandler()(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone), func(p Primitive) {})
+	if index := l.GetCurrentItemIndex(); index != 2 {
+		t.Errorf("failed to skip divider: expected index 2, got %d", index)
+	}
+}
+
+func TestListScrollBar(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	l := NewList()
+	l.ShowSecondaryText(false)
+	l.SetRect(0, 0, 20, 5)
+	for i := 0; i < 20; i++ {
+		l.AddItem(NewListItem(listTextA))
+	}
+
+	// Draw
+
+	app, err := newTestApp(l)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	l.Draw(app.screen)
+
+	mouse := func(action MouseAction, x, y int) {
+		l.MouseHandler()(action, tcell.NewEventMouse(x, y, tcell.ButtonNone, 0), func(p Primitive) {})
+	}
+
+	// Click scroll bar
+
+	mouse(MouseLeftDown, 19, 4)
+	mouse(MouseLeftUp, 19, 4)
+	if offset, _ := l.GetOffset(); offset != 15 {
+		t.Errorf("failed to scroll via scroll bar: expected offset 15, got %d", offset)
+	}
+
+	// Drag scroll bar
+
+	mouse(MouseLeftDown, 19, 4)
+	mouse(MouseMove, 19, 2)
+	if offset, _ := l.GetOffset(); offset != 8 {
+		t.Errorf("failed to drag scroll bar: expected offset 8, got %d", offset)
+	}
+	mouse(MouseMove, 30, -5)
+	mouse(MouseLeftUp, 30, -5)
+	if offset, _ := l.GetOffset(); offset != 0 {
+		t.Errorf("failed to drag scroll bar: expected offset 0, got %d", offset)
+	}
+	if index := l.GetCurrentItemIndex(); index != 0 {
+		t.Errorf("failed to drag scroll bar: expected current item 0, got %d", index)
+	}
+}
+
+func TestListScrollWheel(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	l := NewList()
+	l.ShowSecondaryText(false)
+	l.SetRect(0, 0, 20, 5)
+	for i := 0; i < 50; i++ {
+		l.AddItem(NewListItem(listTextA))
+	}
+
+	wheel := func(action MouseAction, modifiers tcell.ModMask) int {
+		l.MouseHandler()(action, tcell.NewEventMouse(1, 1, tcell.ButtonNone, modifiers), func(p Primitive) {})
+		offset, _ := l.GetOffset()
+		return offset
+	}
+
+	// Scroll by step
+
+	l.SetScrollWheelStep(3)
+	if offset := wheel(MouseScrollDown, 0); offset != 3 {
+		t.Errorf("failed to scroll by step: expected offset 3, got %d", offset)
+	}
+	if offset := wheel(MouseScrollUp, 0); offset != 0 {
+		t.Errorf("failed to scroll by step: expected offset 0, got %d", offset)
+	}
+
+	// Scroll by page
+
+	if offset := wheel(MouseScrollDown, tcell.ModShift); offset != 3 {
+		t.Errorf("failed to scroll by step: expected offset 3, got %d", offset)
+	}
+	l.SetScrollWheelPage(true)
+	if offset := wheel(MouseScrollDown, tcell.ModShift); offset != 8 {
+		t.Errorf("failed to scroll by page: expected offset 8, got %d", offset)
+	}
+	for i := 0; i < 20; i++ {
+		wheel(MouseScrollDown, tcell.ModShift)
+	}
+	if offset, _ := l.GetOffset(); offset != 45 {
+		t.Errorf("failed to scroll by page: expected offset 45, got %d", offset)
+	}
+
+	// Kinetic scrolling
+
+	l.SetOffset(0, 0)
+	l.SetScrollWheelStep(1)
+	l.SetScrollWheelKinetic(true)
+	wheel(MouseScrollDown, 0)
+	if offset := wheel(MouseScrollDown, 0); offset != 3 {
+		t.Errorf("failed to scroll kinetically: expected offset 3, got %d", offset)
+	}
+}
+
+func TestListHighlight(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	l := NewList()
+	for _, text := range []string{listTextA, listTextB, listTextC, listTextA} {
+		l.AddItem(NewListItem(text))
+	}
+
+	l.SetHighlightedIndices(l.FindItems(listTextA, "", false, false))
+	if indices := l.GetHighlightedIndices(); len(indices) != 2 || indices[0] != 0 || indices[1] != 3 {
+		t.Errorf("failed to highlight items: expected [0 3], got %v", indices)
+	}
+
+	// Navigate highlights
+
+	for _, expected := range []int{3, 0, 3} {
+		l.NextHighlight()
+		if index := l.GetCurrentItemIndex(); index != expected {
+			t.Errorf("failed to select next highlight: expected %d, got %d", expected, index)
+		}
+	}
+	l.PreviousHighlight()
+	if index := l.GetCurrentItemIndex(); index != 0 {
+		t.Errorf("failed to select previous highlight: expected 0, got %d", index)
+	}
+}
+
+func TestListItemAddedRemoved(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	l := NewList()
+
+	var added, removed []int
+	l.SetItemAddedFunc(func(index int, item *ListItem) {
+		added = append(added, index)
+	})
+	l.SetItemRemovedFunc(func(index int, item *ListItem) {
+		removed = append(removed, index)
+	})
+
+	// Add and remove items
+
+	l.AddItem(NewListItem(listTextA))
+	l.AddItem(NewListItem(listTextB))
+	l.InsertItem(0, NewListItem(listTextC))
+	if len(added) != 3 || added[0] != 0 || added[1] != 1 || added[2] != 0 {
+		t.Errorf("failed to call added func: expected [0 1 0], got %v", added)
+	}
+
+	l.RemoveItem(1)
+	l.Clear()
+	if len(removed) != 3 || removed[0] != 1 || removed[1] != 1 || removed[2] != 0 {
+		t.Errorf("failed to call removed func: expected [1 1 0], got %v", removed)
+	}
+}
+
+func TestListItemActions(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	l := NewList()
+	l.AddItem(NewListItem(listTextA))
+	l.AddItem(NewListItem(listTextB))
+	l.AddItem(NewListItem(listTextC))
+
+	input := l.InputHandler()
+	key := func(key tcell.Key, ch rune, mod tcell.ModMask) {
+		input(tcell.NewEventKey(key, ch, mod), func(p Primitive) {})
+	}
+
+	// Actions are disabled by default
+
+	key(tcell.KeyDelete, 0, tcell.ModNone)
+	key(tcell.KeyCtrlD, 0, tcell.ModCtrl)
+	key(tcell.KeyF2, 0, tcell.ModNone)
+	if l.GetItemCount() != 3 || l.IsEditingItem() {
+		t.Errorf("failed to ignore disabled item actions: expected 3 items, got %d (editing: %v)", l.GetItemCount(), l.IsEditingItem())
+	}
+
+	// Delete
+
+	l.SetItemsDeletable(true)
+	allow := false
+	var deleted []string
+	l.SetItemDeletedFunc(func(index int, item *ListItem) bool {
+		deleted = append(deleted, item.GetMainText())
+		return allow
+	})
+
+	key(tcell.KeyDelete, 0, tcell.ModNone)
+	if l.GetItemCount() != 3 {
+		t.Errorf("failed to veto deletion: expected 3 items, got %d", l.GetItemCount())
+	}
+
+	allow = true
+	key(tcell.KeyDelete, 0, tcell.ModNone)
+	if l.GetItemCount() != 2 || l.GetItem(0).GetMainText() != listTextB {
+		t.Errorf("failed to delete item: expected 2 items starting with %s, got %d starting with %s", listTextB, l.GetItemCount(), l.GetItem(0).GetMainText())
+	}
+	if len(deleted) != 2 || deleted[0] != listTextA || deleted[1] != listTextA {
+		t.Errorf("failed to call deleted func: expected [%s %s], got %v", listTextA, listTextA, deleted)
+	}
+
+	// Duplicate
+
+	l.SetItemsDuplicable(true)
+	l.SetItemDuplicatedFunc(func(index int, item, duplicate *ListItem) bool {
+		duplicate.SetMainText(item.GetMainText() + " copy")
+		return true
+	})
+
+	key(tcell.KeyCtrlD, 0, tcell.ModCtrl)
+	if l.GetItemCount() != 3 || l.GetCurrentItemIndex() != 1 || l.GetCurrentItem().GetMainText() != listTextB+" copy" {
+		t.Errorf("failed to duplicate item: expected current item 1 (%s copy), got %d (%s)", listTextB, l.GetCurrentItemIndex(), l.GetCurrentItem().GetMainText())
+	}
+
+	// Edit
+
+	l.SetItemsEditable(true)
+	var edited string
+	l.SetItemEditedFunc(func(index int, item *ListItem, text string) bool {
+		edited = text
+		return text != ""
+	})
+
+	key(tcell.KeyF2, 0, tcell.ModNone)
+	if !l.IsEditingItem() {
+		t.Fatal("failed to start editing item")
+	}
+	key(tcell.KeyRune, '!', tcell.ModNone)
+	key(tcell.KeyEnter, 0, tcell.ModNone)
+	if l.IsEditingItem() || edited != listTextB+" copy!" || l.GetItem(1).GetMainText() != listTextB+" copy!" {
+		t.Errorf("failed to edit item: expected %s copy!, got %s (called with %s)", listTextB, l.GetItem(1).GetMainText(), edited)
+	}
+
+	l.EditItem(0)
+	key(tcell.KeyRune, '?', tcell.ModNone)
+	key(tcell.KeyEscape, 0, tcell.ModNone)
+	if l.IsEditingItem() || l.GetCurrentItemIndex() != 0 || l.GetItem(0).GetMainText() != listTextB {
+		t.Errorf("failed to cancel editing item: expected %s, got %s", listTextB, l.GetItem(0).GetMainText())
+	}
+
+	l.EditItem(0)
+	for i := 0; i < len(listTextB); i++ {
+		key(tcell.KeyBackspace2, 0, tcell.ModNone)
+	}
+	key(tcell.KeyEnter, 0, tcell.ModNone)
+	if edited != "" || l.GetItem(0).GetMainText() != listTextB {
+		t.Errorf("failed to veto editing item: expected %s, got %s", listTextB, l.GetItem(0).GetMainText())
+	}
+}
+
+func TestListJSON(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	type action struct {
+		Name string `json:"name"`
+	}
+
+	l := NewList()
+	l.SetReferenceCodec(func(reference interface{}) ([]byte, error) {
+		return json.Marshal(reference)
+	}, func(data []byte) (interface{}, error) {
+		a := &action{}
+		err := json.Unmarshal(data, a)
+		return a, err
+	})
+
+	item := NewListItem(listTextA)
+	item.SetSecondaryText(listTextB)
+	item.SetShortcut('a')
+	item.SetReference(&action{Name: "a"})
+	l.AddItem(item)
+	l.AddDivider("")
+	item = NewListItem(listTextC)
+	item.SetShortcutKey("ctrl+c")
+	item.SetEnabled(false)
+	l.AddItem(item)
+
+	// Marshal
+
+	data, err := json.Marshal(l)
+	if err != nil {
+		t.Errorf("failed to marshal List: %s", err)
+		return
+	}
+
+	// Unmarshal
+
+	l2 := NewList()
+	l2.SetReferenceCodec(nil, func(data []byte) (interface{}, error) {
+		a := &action{}
+		err := json.Unmarshal(data, a)
+		return a, err
+	})
+	l2.AddItem(NewListItem(listTextC))
+	err = json.Unmarshal(data, l2)
+	if err != nil {
+		t.Errorf("failed to unmarshal List: %s", err)
+		return
+	}
+
+	if count := l2.GetItemCount(); count != 3 {
+		t.Errorf("failed to unmarshal List: expected 3 items, got %d", count)
+		return
+	}
+	item = l2.GetItem(0)
+	if item.GetMainText() != listTextA || item.GetSecondaryText() != listTextB || item.GetShortcut() != 'a' {
+		t.Errorf("failed to unmarshal item: got %s, %s, %c", item.GetMainText(), item.GetSecondaryText(), item.GetShortcut())
+	}
+	if a, ok := item.GetReference().(*action); !ok || a.Name != "a" {
+		t.Errorf("failed to unmarshal reference: got %v", item.GetReference())
+	}
+	if !l2.GetItem(1).isDivider() {
+		t.Errorf("failed to unmarshal divider")
+	}
+	item = l2.GetItem(2)
+	if item.IsEnabled() || item.GetShortcutKey() != "Ctrl+C" || item.GetReference() != nil {
+		t.Errorf("failed to unmarshal item: got enabled %v, shortcut key %s, reference %v", item.IsEnabled(), item.GetShortcutKey(), item.GetReference())
+	}
+
+	// Invalid shortcut
+
+	err = json.Unmarshal([]byte(`[{"mainText": "a", "shortcut": "ab"}]`), l2)
+	if err == nil {
+		t.Errorf("failed to reject invalid shortcut")
+	} else if count := l2.GetItemCount(); count != 3 {
+		t.Errorf("failed to keep items after error: expected 3 items, got %d", count)
+	}
+}
+
+func TestListExpandCurrentItem(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	l := NewList()
+	l.SetExpandCurrentItem(true)
+	l.SetRect(0, 0, 20, 5)
+	for i := 0; i < 10; i++ {
+		item := NewListItem(fmt.Sprintf("Main %d", i))
+		item.SetSecondaryText(fmt.Sprintf("Second %d", i))
+		l.AddItem(item)
+	}
+
+	app, err := newTestApp(l)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+
+	lines := func() []string {
+		var lines []string
+		for y := 0; y < 5; y++ {
+			var line []rune
+			for x := 0; x < 8; x++ {
+				r, _, _, _ := app.screen.GetContent(x, y)
+				line = append(line, r)
+			}
+			lines = append(lines, strings.TrimSpace(string(line)))
+		}
+		return lines
+	}
+
+	// Draw
+
+	l.Draw(app.screen)
+	expected := "Main 0,Second 0,Main 1,Main 2,Main 3"
+	if got := strings.Join(lines(), ","); got != expected {
+		t.Errorf("failed to expand current item: expected %s, got %s", expected, got)
+	}
+
+	l.SetCurrentItem(6)
+	l.Draw(app.screen)
+	expected = "Main 3,Main 4,Main 5,Main 6,Second 6"
+	if got := strings.Join(lines(), ","); got != expected {
+		t.Errorf("failed to expand current item: expected %s, got %s", expected, got)
+	}
+
+	// Click
+
+	if index := l.indexAtPoint(1, 4); index != 6 {
+		t.Errorf("failed to find item at point: expected 6, got %d", index)
+	}
+	if index := l.indexAtPoint(1, 2); index != 5 {
+		t.Errorf("failed to find item at point: expected 5, got %d", index)
+	}
 }
@@ -0,0 +1,283 @@
+package cview
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	// DefaultLogTimeFormat is the default layout of the timestamps written by
+	// a LogWriter.
+	DefaultLogTimeFormat = "15:04:05"
+
+	// The interval at which pending log lines are written to a TextView.
+	logWriterInterval = 50 * time.Millisecond
+)
+
+// LogWriter writes log lines to a TextView, prefixing each line with a
+// timestamp and coloring it by its level. It implements io.Writer, so that the
+// standard library logger may target a TextView directly:
+//
+//   logWriter := cview.NewLogWriter(textView)
+//   logWriter.SetApplication(app)
+//   log.SetOutput(logWriter)
+//   log.SetFlags(0)
+//
+// When built with Go 1.21 or later, LogWriter also implements slog.Handler:
+//
+//   logger := slog.New(logWriter)
+//
+// The level of lines written via Write is detected from their first words
+// ("DEBUG", "INFO", "WARN", "WARNING" or "ERROR", optionally surrounded by
+// brackets or followed by a colon), defaulting to LogInfo. Lines are written
+// as they are completed by a newline.
+//
+// Lines are written to the text view in batches via
+// Application.QueueUpdateDraw at most every 50 milliseconds once an
+// application is set, so that logging many lines does not flood the event
+// loop. The maximum number of lines of the text view (see
+// TextView.SetMaxLines) applies, and pending lines exceeding it are discarded
+// before they are written. LogWriter may be used from any goroutine.
+type LogWriter struct {
+	*logOutput
+
+	// Attributes added to each line written via the slog.Handler interface,
+	// formatted as " key=value" pairs.
+	attrs string
+
+	// The group the keys of attributes are qualified with, followed by a
+	// period, if any.
+	group string
+}
+
+// logOutput is the state shared by a LogWriter and the writers derived from it
+// via the slog.Handler interface.
+type logOutput struct {
+	// The text view lines are written to.
+	textView *TextView
+
+	// The application which draws the text view, if any.
+	app *Application
+
+	// The minimum level of lines which are written.
+	level LogLevel
+
+	// The layout of timestamps.
+	timeFormat string
+
+	// The colors of lines by level.
+	colors map[LogLevel]tcell.Color
+
+	// The last line written via Write, which has not been terminated by a
+	// newline yet.
+	partial []byte
+
+	// The lines which have not been written to the text view yet.
+	pending [][]byte
+
+	// Whether pending lines are scheduled to be written.
+	scheduled bool
+
+	sync.Mutex
+}
+
+// NewLogWriter returns a new log writer which writes to the provided text
+// view. Dynamic colors are enabled for the text view.
+func NewLogWriter(textView *TextView) *LogWriter {
+	textView.SetDynamicColors(true)
+	return &LogWriter{
+		logOutput: &logOutput{
+			textView:   textView,
+			timeFormat: DefaultLogTimeFormat,
+			colors: map[LogLevel]tcell.Color{
+				LogDebug:   tcell.ColorGray,
+				LogWarning: tcell.ColorYellow,
+				LogError:   tcell.ColorRed,
+			},
+		},
+	}
+}
+
+// SetApplication sets the application which draws the text view. Lines are
+// written in batches and the screen is redrawn via Application.QueueUpdateDraw.
+// When the update queue of the application is full, for example because the
+// application is not running, lines are written without redrawing the screen.
+// When no application is set, lines are written to the text view immediately
+// and the screen is not redrawn.
+func (l *LogWriter) SetApplication(app *Application) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.app = app
+}
+
+// SetLevel sets the minimum level of lines which are written. Lines with a
+// lower level are discarded. The default is LogDebug.
+func (l *LogWriter) SetLevel(level LogLevel) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.level = level
+}
+
+// SetTimeFormat sets the layout of the timestamps lines are prefixed with (see
+// time.Time.Format). An empty layout disables timestamps, which is useful when
+// the logger adds its own. The default is DefaultLogTimeFormat.
+func (l *LogWriter) SetTimeFormat(layout string) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.timeFormat = layout
+}
+
+// SetLevelColor sets the color of lines with the provided level. By default,
+// debug lines are gray, warnings are yellow and errors are red, while other
+// lines use the default text color of the text view. tcell.ColorDefault resets
+// the color of a level.
+func (l *LogWriter) SetLevelColor(level LogLevel, color tcell.Color) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.colors[level] = color
+}
+
+// Write writes log lines to the text view. A "\n" or "\r\n" terminates a line.
+// Incomplete lines are kept until they are terminated.
+func (l *LogWriter) Write(p []byte) (n int, err error) {
+	now := time.Now()
+
+	l.Lock()
+	text := p
+	var lines [][]byte
+	for {
+		i := bytes.IndexByte(text, '\n')
+		if i < 0 {
+			l.partial = append(l.partial, text...)
+			break
+		}
+		line := append(l.partial, text[:i]...)
+		l.partial = nil
+		lines = append(lines, bytes.TrimSuffix(line, []byte("\r")))
+		text = text[i+1:]
+	}
+	l.Unlock()
+
+	for _, line := range lines {
+		l.log(now, parseLogLevel(string(line)), string(line))
+	}
+	return len(p), nil
+}
+
+// Flush writes pending lines to the text view immediately, including a line
+// which has not been terminated yet. The screen is not redrawn.
+func (l *LogWriter) Flush() {
+	l.Lock()
+	if len(l.partial) > 0 {
+		partial := string(l.partial)
+		l.partial = nil
+		l.Unlock()
+
+		l.log(time.Now(), parseLogLevel(partial), partial)
+		l.Lock()
+	}
+	pending := l.takePending()
+	l.Unlock()
+
+	if len(pending) > 0 {
+		l.textView.Write(bytes.Join(pending, nil))
+	}
+}
+
+// log formats a line and writes it to the text view, or schedules writing it.
+func (l *LogWriter) log(t time.Time, level LogLevel, text string) {
+	l.Lock()
+	if level < l.level {
+		l.Unlock()
+		return
+	}
+
+	var b bytes.Buffer
+	color := ColorHex(l.colors[level])
+	if color != "" {
+		b.WriteString("[" + color + "]")
+	}
+	if l.timeFormat != "" {
+		b.WriteString(Escape(t.Format(l.timeFormat)) + " ")
+	}
+	b.WriteString(Escape(text))
+	if color != "" {
+		b.WriteString("[-]")
+	}
+	b.WriteByte('\n')
+
+	app := l.app
+	if app == nil {
+		l.Unlock()
+
+		l.textView.Write(b.Bytes())
+		return
+	}
+
+	l.pending = append(l.pending, b.Bytes())
+	if maxLines := l.textView.GetMaxLines(); maxLines > 0 && len(l.pending) > maxLines {
+		l.pending = l.pending[len(l.pending)-maxLines:]
+	}
+	scheduled := l.scheduled
+	l.scheduled = true
+	l.Unlock()
+
+	if scheduled {
+		return
+	}
+	time.AfterFunc(logWriterInterval, func() {
+		write := func() {
+			l.Lock()
+			pending := l.takePending()
+			l.Unlock()
+
+			l.textView.Write(bytes.Join(pending, nil))
+		}
+
+		// Write the lines without redrawing when the update queue is full,
+		// so that the timer never blocks.
+		if !app.tryQueueUpdate(func() {
+			write()
+			app.draw()
+		}) {
+			write()
+		}
+	})
+}
+
+// takePending returns and removes the pending lines. The lock must be held.
+func (o *logOutput) takePending() [][]byte {
+	pending := o.pending
+	o.pending = nil
+	o.scheduled = false
+	return pending
+}
+
+// parseLogLevel returns the level of a log line, detected from its first
+// words, or LogInfo if the level may not be detected.
+func parseLogLevel(line string) LogLevel {
+	for i, word := range strings.Fields(line) {
+		if i == 4 {
+			break
+		}
+		switch strings.ToUpper(strings.Trim(word, "[]:")) {
+		case "DEBUG":
+			return LogDebug
+		case "INFO":
+			return LogInfo
+		case "WARN", "WARNING":
+			return LogWarning
+		case "ERROR":
+			return LogError
+		}
+	}
+	return LogInfo
+}
@@ -0,0 +1,106 @@
+//go:build go1.21
+// +build go1.21
+
+package cview
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// Enabled returns whether records with the provided level are written,
+// implementing the slog.Handler interface. slog levels below slog.LevelInfo
+// map to LogDebug, levels below slog.LevelWarn to LogInfo, levels below
+// slog.LevelError to LogWarning and all other levels to LogError.
+func (l *LogWriter) Enabled(ctx context.Context, level slog.Level) bool {
+	l.Lock()
+	defer l.Unlock()
+
+	return slogLevel(level) >= l.level
+}
+
+// Handle writes a record to the text view, implementing the slog.Handler
+// interface. The attributes of the record follow its message as " key=value"
+// pairs.
+func (l *LogWriter) Handle(ctx context.Context, record slog.Record) error {
+	var b strings.Builder
+	b.WriteString(record.Message)
+	b.WriteString(l.attrs)
+	record.Attrs(func(attr slog.Attr) bool {
+		writeSlogAttr(&b, l.group, attr)
+		return true
+	})
+
+	t := record.Time
+	if t.IsZero() {
+		t = time.Now()
+	}
+	l.log(t, slogLevel(record.Level), b.String())
+	return nil
+}
+
+// WithAttrs returns a handler which writes to the same text view and adds the
+// provided attributes to each record, implementing the slog.Handler interface.
+func (l *LogWriter) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var b strings.Builder
+	b.WriteString(l.attrs)
+	for _, attr := range attrs {
+		writeSlogAttr(&b, l.group, attr)
+	}
+	return &LogWriter{
+		logOutput: l.logOutput,
+		attrs:     b.String(),
+		group:     l.group,
+	}
+}
+
+// WithGroup returns a handler which writes to the same text view and qualifies
+// the keys of the attributes added afterwards with the provided group,
+// implementing the slog.Handler interface.
+func (l *LogWriter) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return l
+	}
+	return &LogWriter{
+		logOutput: l.logOutput,
+		attrs:     l.attrs,
+		group:     l.group + name + ".",
+	}
+}
+
+// slogLevel returns the log level corresponding to a slog level.
+func slogLevel(level slog.Level) LogLevel {
+	switch {
+	case level < slog.LevelInfo:
+		return LogDebug
+	case level < slog.LevelWarn:
+		return LogInfo
+	case level < slog.LevelError:
+		return LogWarning
+	default:
+		return LogError
+	}
+}
+
+// writeSlogAttr writes an attribute as a " key=value" pair. The keys of the
+// attributes of groups are qualified with the group.
+func writeSlogAttr(b *strings.Builder, group string, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		if attr.Key != "" {
+			group += attr.Key + "."
+		}
+		for _, groupAttr := range attr.Value.Group() {
+			writeSlogAttr(b, group, groupAttr)
+		}
+		return
+	}
+	fmt.Fprintf(b, " %s%s=%v", group, attr.Key, attr.Value)
+}
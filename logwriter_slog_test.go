@@ -0,0 +1,36 @@
+//go:build go1.21
+// +build go1.21
+
+package cview
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLogWriterSlog(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tv := NewTextView()
+	w := NewLogWriter(tv)
+	w.SetTimeFormat("")
+	w.SetLevel(LogInfo)
+
+	logger := slog.New(w).With("host", "example.com").WithGroup("request")
+
+	// Log
+
+	logger.Debug("ignored")
+	logger.Warn("slow", "ms", 250, slog.Group("user", "id", 7))
+	if text := tv.GetText(false); text != "[#ffff00]slow host=example.com request.ms=250 request.user.id=7[-]\n" {
+		t.Errorf("failed to handle record: got %q", text)
+	}
+
+	logger.Info("done")
+	if text := tv.GetText(true); !strings.HasSuffix(text, "\ndone host=example.com\n") {
+		t.Errorf("failed to handle record: got %q", text)
+	}
+}
@@ -0,0 +1,103 @@
+package cview
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestLogWriter(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tv := NewTextView()
+	w := NewLogWriter(tv)
+	w.SetTimeFormat("")
+	w.SetLevelColor(LogInfo, tcell.ColorGreen)
+
+	// Write
+
+	logger := log.New(w, "", 0)
+	logger.Print("INFO: connected [a]")
+	logger.Print("ERROR: failed")
+	fmt.Fprint(w, "[DEBUG] part")
+	if text := tv.GetText(false); text != "[#008000]INFO: connected [a[][-]\n[#ff0000]ERROR: failed[-]\n" {
+		t.Errorf("failed to write lines: got %q", text)
+	}
+
+	w.Flush()
+	if text := tv.GetText(true); !strings.HasSuffix(text, "\n[DEBUG] part\n") {
+		t.Errorf("failed to flush partial line: got %q", text)
+	}
+
+	// Level and timestamp
+
+	tv.Clear()
+	w.SetLevel(LogWarning)
+	w.SetTimeFormat(DefaultLogTimeFormat)
+	fmt.Fprintln(w, "info line")
+	fmt.Fprintln(w, "warn: warning line")
+	if text := tv.GetText(true); !strings.HasSuffix(text, " warn: warning line\n") || strings.Contains(text, "info line") || len(text) != len("15:04:05 warn: warning line\n") {
+		t.Errorf("failed to filter lines: got %q", text)
+	}
+
+	// Batch
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+
+	tv.Clear()
+	tv.SetMaxLines(2)
+	w.SetApplication(app)
+	w.SetTimeFormat("")
+	for i := 0; i < 5; i++ {
+		fmt.Fprintf(w, "error %d\n", i)
+	}
+	if text := tv.GetText(true); text != "" {
+		t.Errorf("failed to batch lines: expected no text before update, got %q", text)
+	}
+
+	timeout := time.After(time.Second)
+	for tv.GetText(true) == "" {
+		select {
+		case update := <-app.updates:
+			update()
+		case <-timeout:
+			t.Fatalf("failed to batch lines: expected queued update")
+		}
+	}
+	if text := tv.GetText(true); text != "error 4\n" {
+		t.Errorf("failed to batch lines: expected last line, got %q", text)
+	}
+
+	// Full update queue
+
+	for len(app.updates) < cap(app.updates) {
+		app.updates <- func() {}
+	}
+	tv.Clear()
+	fmt.Fprintln(w, "error 5")
+	timeout = time.After(time.Second)
+	for tv.GetText(true) == "" {
+		select {
+		case <-time.After(10 * time.Millisecond):
+		case <-timeout:
+			t.Fatalf("failed to write lines: expected lines to be written while the update queue is full")
+		}
+	}
+	if text := tv.GetText(true); text != "error 5\n" {
+		t.Errorf("failed to write lines: expected line, got %q", text)
+	}
+}
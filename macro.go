@@ -0,0 +1,384 @@
+package cview
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// MacroEvent is a key or mouse event recorded in a Macro.
+type MacroEvent struct {
+	// The time elapsed since the previous event was recorded.
+	Delay time.Duration
+
+	// The event, either a *tcell.EventKey or a *tcell.EventMouse.
+	Event tcell.Event
+}
+
+// Macro is a sequence of recorded key and mouse events which may be replayed
+// via Application.PlayMacro.
+type Macro struct {
+	Events []*MacroEvent
+}
+
+// StartRecording starts recording the key and mouse events received by the
+// application. Any previous recording is discarded. Events replayed by a
+// MacroPlayer are not recorded.
+func (a *Application) StartRecording() {
+	a.Lock()
+	defer a.Unlock()
+
+	a.recording = &Macro{}
+	a.lastRecorded = time.Now()
+}
+
+// StopRecording stops recording events and returns the recorded macro, or nil
+// if no recording was in progress.
+func (a *Application) StopRecording() *Macro {
+	a.Lock()
+	defer a.Unlock()
+
+	m := a.recording
+	a.recording = nil
+	return m
+}
+
+// IsRecording returns true when events are being recorded.
+func (a *Application) IsRecording() bool {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.recording != nil
+}
+
+// recordEvent records an event when a recording is in progress.
+func (a *Application) recordEvent(event tcell.Event) {
+	switch event.(type) {
+	case *tcell.EventKey, *tcell.EventMouse:
+	default:
+		return
+	}
+
+	a.Lock()
+	defer a.Unlock()
+
+	if a.recording == nil || a.macroPlayer != nil {
+		return
+	}
+	now := time.Now()
+	a.recording.Events = append(a.recording.Events, &MacroEvent{
+		Delay: now.Sub(a.lastRecorded),
+		Event: event,
+	})
+	a.lastRecorded = now
+}
+
+// copyEvent returns a copy of a recorded event with the current time.
+func copyEvent(event tcell.Event) tcell.Event {
+	switch event := event.(type) {
+	case *tcell.EventKey:
+		return tcell.NewEventKey(event.Key(), event.Rune(), event.Modifiers())
+	case *tcell.EventMouse:
+		x, y := event.Position()
+		return tcell.NewEventMouse(x, y, event.Buttons(), event.Modifiers())
+	}
+	return event
+}
+
+// MacroPlayer replays a Macro. Playback speed may be adjusted, and playback
+// may be paused and stepped through one event at a time, which allows recorded
+// interactions to drive live demonstrations and presentations. While a macro
+// is playing, an indicator showing its state and progress is drawn in the
+// top-right corner of the screen.
+type MacroPlayer struct {
+	app   *Application
+	macro *Macro
+
+	// The index of the next event to play.
+	position int
+
+	// The playback speed, where 1 is the recorded speed.
+	speed float64
+
+	// Whether playback is paused.
+	paused bool
+
+	// Whether playback has finished or was stopped.
+	done bool
+
+	// Whether the indicator is shown.
+	indicator bool
+
+	// Signaled when the state of the player changes.
+	wake chan struct{}
+
+	// Called when playback finishes or is stopped.
+	finished func()
+
+	sync.RWMutex
+}
+
+// PlayMacro starts replaying the provided macro and returns its player. Events
+// are queued via QueueEvent with the recorded delays between them. Any macro
+// which is already playing is stopped.
+func (a *Application) PlayMacro(m *Macro) *MacroPlayer {
+	p := &MacroPlayer{
+		app:       a,
+		macro:     m,
+		speed:     1,
+		indicator: true,
+		wake:      make(chan struct{}, 1),
+	}
+
+	a.Lock()
+	previous := a.macroPlayer
+	a.macroPlayer = p
+	a.Unlock()
+
+	if previous != nil {
+		previous.Stop()
+	}
+
+	go p.run()
+	return p
+}
+
+// GetMacroPlayer returns the player of the macro which is currently playing,
+// or nil if no macro is playing.
+func (a *Application) GetMacroPlayer() *MacroPlayer {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.macroPlayer
+}
+
+// SetSpeed sets the playback speed. A speed of 1 replays events with their
+// recorded delays, 2 replays them twice as fast and 0.5 half as fast. Speeds
+// less than or equal to zero are ignored.
+func (p *MacroPlayer) SetSpeed(speed float64) {
+	if speed <= 0 {
+		return
+	}
+
+	p.Lock()
+	p.speed = speed
+	p.Unlock()
+
+	p.changed()
+}
+
+// GetSpeed returns the playback speed.
+func (p *MacroPlayer) GetSpeed() float64 {
+	p.RLock()
+	defer p.RUnlock()
+
+	return p.speed
+}
+
+// SetIndicatorVisible sets whether the playback indicator is drawn.
+func (p *MacroPlayer) SetIndicatorVisible(visible bool) {
+	p.Lock()
+	p.indicator = visible
+	p.Unlock()
+
+	p.changed()
+}
+
+// SetFinishedFunc sets a handler which is called when playback finishes or is
+// stopped.
+func (p *MacroPlayer) SetFinishedFunc(handler func()) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.finished = handler
+}
+
+// Pause pauses playback.
+func (p *MacroPlayer) Pause() {
+	p.Lock()
+	p.paused = true
+	p.Unlock()
+
+	p.changed()
+}
+
+// Resume resumes playback.
+func (p *MacroPlayer) Resume() {
+	p.Lock()
+	p.paused = false
+	p.Unlock()
+
+	p.changed()
+}
+
+// IsPaused returns true when playback is paused.
+func (p *MacroPlayer) IsPaused() bool {
+	p.RLock()
+	defer p.RUnlock()
+
+	return p.paused
+}
+
+// Step plays the next event immediately while playback is paused. Returns
+// false when playback is not paused or no events remain.
+func (p *MacroPlayer) Step() bool {
+	p.Lock()
+	if !p.paused || p.done || p.position >= len(p.macro.Events) {
+		p.Unlock()
+		return false
+	}
+	event := p.macro.Events[p.position]
+	p.position++
+	p.Unlock()
+
+	p.app.QueueEvent(copyEvent(event.Event))
+	p.changed()
+	return true
+}
+
+// Stop stops playback. Playback may not be resumed after it is stopped.
+func (p *MacroPlayer) Stop() {
+	p.finish()
+}
+
+// IsDone returns true when playback has finished or was stopped.
+func (p *MacroPlayer) IsDone() bool {
+	p.RLock()
+	defer p.RUnlock()
+
+	return p.done
+}
+
+// GetPosition returns the number of events played and the total number of
+// events in the macro.
+func (p *MacroPlayer) GetPosition() (played, total int) {
+	p.RLock()
+	defer p.RUnlock()
+
+	return p.position, len(p.macro.Events)
+}
+
+// changed wakes the playback goroutine and redraws the indicator.
+func (p *MacroPlayer) changed() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+	p.app.queueIndicatorUpdate()
+}
+
+// finish marks playback as done and calls the finished handler.
+func (p *MacroPlayer) finish() {
+	p.Lock()
+	if p.done {
+		p.Unlock()
+		return
+	}
+	p.done = true
+	finished := p.finished
+	p.Unlock()
+
+	p.changed()
+
+	p.app.Lock()
+	if p.app.macroPlayer == p {
+		p.app.macroPlayer = nil
+	}
+	p.app.Unlock()
+
+	if finished != nil {
+		finished()
+	}
+}
+
+// run replays the events of the macro.
+func (p *MacroPlayer) run() {
+	for {
+		p.RLock()
+		if p.done {
+			p.RUnlock()
+			return
+		} else if p.position >= len(p.macro.Events) {
+			p.RUnlock()
+			p.finish()
+			return
+		}
+		paused := p.paused
+		delay := time.Duration(float64(p.macro.Events[p.position].Delay) / p.speed)
+		p.RUnlock()
+
+		if paused {
+			<-p.wake
+			continue
+		}
+
+		// Restart the delay when the state of the player changes, so that
+		// speed changes apply to the event currently being waited on.
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-p.wake:
+			timer.Stop()
+			continue
+		}
+
+		p.Lock()
+		if p.done || p.paused || p.position >= len(p.macro.Events) {
+			p.Unlock()
+			continue
+		}
+		event := p.macro.Events[p.position]
+		p.position++
+		p.Unlock()
+
+		p.app.QueueEvent(copyEvent(event.Event))
+	}
+}
+
+// indicatorText returns the text of the playback indicator, or an empty
+// string if the indicator is hidden.
+func (p *MacroPlayer) indicatorText() string {
+	p.RLock()
+	defer p.RUnlock()
+
+	if !p.indicator || p.done {
+		return ""
+	}
+	state := "▶"
+	if p.paused {
+		state = "❚❚"
+	}
+	return fmt.Sprintf(" %s %gx %d/%d ", state, p.speed, p.position, len(p.macro.Events))
+}
+
+// queueIndicatorUpdate redraws the screen when the application is running so
+// that the playback indicator is updated.
+func (a *Application) queueIndicatorUpdate() {
+	a.RLock()
+	running := a.screen != nil
+	a.RUnlock()
+
+	if !running {
+		return
+	}
+	select {
+	case a.updates <- a.draw:
+	default:
+	}
+}
+
+// drawMacroIndicator draws the playback indicator of the macro which is
+// currently playing.
+func (a *Application) drawMacroIndicator(screen tcell.Screen) {
+	a.RLock()
+	player := a.macroPlayer
+	width := a.width
+	a.RUnlock()
+
+	if player == nil {
+		return
+	}
+	a.drawIndicator(screen, width, player.indicatorText())
+}
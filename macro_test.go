@@ -0,0 +1,75 @@
+package cview
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestMacro(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	app, err := newTestApp(NewBox())
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+
+	// Record
+
+	app.StartRecording()
+	app.recordEvent(tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone))
+	app.recordEvent(tcell.NewEventResize(80, 24))
+	app.recordEvent(tcell.NewEventKey(tcell.KeyRune, 'b', tcell.ModNone))
+	m := app.StopRecording()
+	if m == nil || len(m.Events) != 2 {
+		t.Errorf("failed to record events: expected 2 events, got %v", m)
+		return
+	}
+	m.Events[0].Delay = time.Hour
+	m.Events[1].Delay = time.Millisecond
+
+	// Step
+
+	p := app.PlayMacro(m)
+	p.Pause()
+	p.SetSpeed(2)
+	if text := p.indicatorText(); text != " ❚❚ 2x 0/2 " {
+		t.Errorf("failed to draw indicator: expected \" ❚❚ 2x 0/2 \", got %q", text)
+	}
+	if !p.Step() {
+		t.Errorf("failed to step: expected true, got false")
+	}
+	select {
+	case event := <-app.events:
+		if key, ok := event.(*tcell.EventKey); !ok || key.Rune() != 'a' {
+			t.Errorf("failed to step: expected key a, got %v", event)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("failed to step: no event was queued")
+	}
+	if played, total := p.GetPosition(); played != 1 || total != 2 {
+		t.Errorf("failed to step: expected position 1/2, got %d/%d", played, total)
+	}
+
+	// Resume
+
+	p.Resume()
+	select {
+	case event := <-app.events:
+		if key, ok := event.(*tcell.EventKey); !ok || key.Rune() != 'b' {
+			t.Errorf("failed to resume: expected key b, got %v", event)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("failed to resume: no event was queued")
+	}
+	for i := 0; i < 100 && !p.IsDone(); i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !p.IsDone() || app.GetMacroPlayer() != nil {
+		t.Errorf("failed to finish playback")
+	}
+}
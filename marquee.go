@@ -0,0 +1,324 @@
+package cview
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Marquee displays a single line of text. Text which is wider than the
+// primitive scrolls horizontally at a configurable speed. Scrolling pauses
+// while the marquee has focus or the mouse hovers over it.
+//
+// Color tags are not supported. Text is displayed using a single color.
+type Marquee struct {
+	*Box
+
+	// The text to display.
+	text string
+
+	// The text color.
+	textColor tcell.Color
+
+	// The text alignment when the text is not scrolling, one of AlignLeft,
+	// AlignCenter, or AlignRight.
+	align int
+
+	// The number of blank columns drawn between repetitions of the text.
+	gap int
+
+	// The scroll offset in screen columns.
+	offset int
+
+	// The interval at which the text scrolls by one column.
+	speed time.Duration
+
+	// Whether scrolling pauses while the marquee has focus.
+	pauseOnFocus bool
+
+	// Whether scrolling pauses while the mouse hovers over the marquee.
+	pauseOnHover bool
+
+	// Whether the mouse is hovering over the marquee.
+	hovered bool
+
+	// Whether the marquee is scrolling.
+	running bool
+
+	// Closed to stop the update goroutine.
+	stop chan struct{}
+
+	// An optional function which is called each time the text scrolls.
+	changed func()
+
+	sync.RWMutex
+}
+
+// NewMarquee returns a new marquee.
+func NewMarquee() *Marquee {
+	return &Marquee{
+		Box:          NewBox(),
+		textColor:    Styles.PrimaryTextColor,
+		align:        AlignLeft,
+		gap:          4,
+		speed:        150 * time.Millisecond,
+		pauseOnFocus: true,
+		pauseOnHover: true,
+	}
+}
+
+// SetText sets the text of the marquee and resets the scroll offset.
+func (m *Marquee) SetText(text string) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.text = text
+	m.offset = 0
+}
+
+// GetText returns the text of the marquee.
+func (m *Marquee) GetText() string {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.text
+}
+
+// SetTextColor sets the color of the text.
+func (m *Marquee) SetTextColor(color tcell.Color) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.textColor = color
+	m.textColorSet = true
+}
+
+// inheritColors applies the colors inherited from the parent container.
+func (m *Marquee) inheritColors(background, text tcell.Color) {
+	m.Box.inheritColors(background, text)
+
+	m.Lock()
+	defer m.Unlock()
+
+	m.inheritTextColor(&m.textColor, text)
+}
+
+// primaryTextColor returns the color of the primary text.
+func (m *Marquee) primaryTextColor() tcell.Color {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.textColor
+}
+
+// SetTextAlign sets the alignment of text which fits within the marquee.
+// This must be either AlignLeft, AlignCenter, or AlignRight.
+func (m *Marquee) SetTextAlign(align int) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.align = align
+}
+
+// SetGap sets the number of blank columns drawn between repetitions of the
+// text while scrolling.
+func (m *Marquee) SetGap(gap int) {
+	m.Lock()
+	defer m.Unlock()
+
+	if gap < 0 {
+		gap = 0
+	}
+	m.gap = gap
+}
+
+// SetSpeed sets the interval at which the text scrolls by one column. If the
+// marquee is already scrolling, the new speed takes effect after it is
+// restarted.
+func (m *Marquee) SetSpeed(interval time.Duration) {
+	m.Lock()
+	defer m.Unlock()
+
+	if interval <= 0 {
+		interval = 150 * time.Millisecond
+	}
+	m.speed = interval
+}
+
+// SetPauseOnFocus sets a flag which determines whether scrolling pauses while
+// the marquee has focus.
+func (m *Marquee) SetPauseOnFocus(pause bool) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.pauseOnFocus = pause
+}
+
+// SetPauseOnHover sets a flag which determines whether scrolling pauses while
+// the mouse hovers over the marquee.
+func (m *Marquee) SetPauseOnHover(pause bool) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.pauseOnHover = pause
+}
+
+// SetChangedFunc sets a handler which is called each time the text scrolls.
+// The handler is called from a separate goroutine, so it should typically
+// queue a redraw:
+//
+//   marquee.SetChangedFunc(func() {
+//       app.QueueUpdateDraw(func() {}, marquee)
+//   })
+func (m *Marquee) SetChangedFunc(handler func()) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.changed = handler
+}
+
+// Start starts scrolling the text.
+func (m *Marquee) Start() {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.running {
+		return
+	}
+
+	m.running = true
+	m.stop = make(chan struct{})
+	go m.run(m.speed, m.stop)
+}
+
+// Stop stops scrolling the text.
+func (m *Marquee) Stop() {
+	m.Lock()
+	defer m.Unlock()
+
+	if !m.running {
+		return
+	}
+
+	m.running = false
+	close(m.stop)
+	m.stop = nil
+}
+
+// IsRunning returns whether the marquee is scrolling.
+func (m *Marquee) IsRunning() bool {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.running
+}
+
+// run scrolls the text until the marquee is stopped.
+func (m *Marquee) run(interval time.Duration, stop chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			m.tick()
+		}
+	}
+}
+
+// tick advances the scroll offset by one column unless scrolling is paused
+// or the text fits within the marquee.
+func (m *Marquee) tick() {
+	hasFocus := m.GetFocusable().HasFocus()
+	_, _, width, _ := m.GetInnerRect()
+
+	m.Lock()
+	if (m.pauseOnFocus && hasFocus) || (m.pauseOnHover && m.hovered) {
+		m.Unlock()
+		return
+	}
+
+	cycle := stringWidth(m.text) + m.gap
+	if cycle-m.gap <= width {
+		m.offset = 0
+		m.Unlock()
+		return
+	}
+	m.offset = (m.offset + 1) % cycle
+
+	changed := m.changed
+	m.Unlock()
+
+	if changed != nil {
+		changed()
+	}
+}
+
+// Draw draws this primitive onto the screen.
+func (m *Marquee) Draw(screen tcell.Screen) {
+	if !m.GetVisible() {
+		return
+	}
+
+	m.Box.Draw(screen)
+
+	backgroundColor := m.GetBackgroundColor()
+
+	m.RLock()
+	defer m.RUnlock()
+
+	x, y, width, height := m.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	textWidth := stringWidth(m.text)
+	if textWidth <= width {
+		Print(screen, []byte(Escape(m.text)), x, y, width, m.align, m.textColor)
+		return
+	}
+
+	style := tcell.StyleDefault.Foreground(m.textColor).Background(backgroundColor)
+	cycle := textWidth + m.gap
+	start := m.offset
+	iterateString(m.text, func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
+		// Draw each rune wherever it appears within the visible repetitions.
+		for rx := screenPos - start; rx < width; rx += cycle {
+			if rx < 0 || rx+screenWidth > width {
+				continue
+			}
+			screen.SetContent(x+rx, y, main, comb, style)
+			for i := 1; i < screenWidth; i++ {
+				screen.SetContent(x+rx+i, y, 0, nil, style)
+			}
+		}
+		return false
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (m *Marquee) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return m.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		inRect := m.InRect(event.Position())
+
+		m.Lock()
+		m.hovered = inRect
+		m.Unlock()
+
+		if !inRect {
+			return false, nil
+		}
+
+		// Capture the mouse while hovering to be notified when it leaves.
+		capture = m
+
+		if action == MouseLeftClick {
+			setFocus(m)
+			consumed = true
+		}
+		return
+	})
+}
@@ -0,0 +1,127 @@
+package cview
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestMarquee(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	m := NewMarquee()
+	m.SetText("Hello, world!")
+	m.SetBackgroundColor(tcell.ColorNavy)
+	m.SetPauseOnFocus(false)
+	m.SetPauseOnHover(false)
+	m.SetRect(0, 0, 5, 1)
+
+	app, err := newTestApp(m)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+
+	line := func() string {
+		m.Draw(app.screen)
+
+		var b strings.Builder
+		for x := 0; x < 5; x++ {
+			r, _, _, _ := app.screen.GetContent(x, 0)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+
+	if l := line(); l != "Hello" {
+		t.Errorf("failed to draw marquee: expected Hello, got %s", l)
+	}
+	_, _, style, _ := app.screen.GetContent(0, 0)
+	if _, bg, _ := style.Decompose(); bg != tcell.ColorNavy {
+		t.Errorf("failed to draw marquee: expected navy background, got %v", bg)
+	}
+
+	// Scroll
+
+	var changed int
+	m.SetChangedFunc(func() {
+		changed++
+	})
+	m.tick()
+	if l := line(); l != "ello," {
+		t.Errorf("failed to scroll marquee: expected ello,, got %s", l)
+	} else if changed != 1 {
+		t.Errorf("failed to call changed handler: expected 1 call, got %d", changed)
+	}
+	for i := 0; i < 12; i++ {
+		m.tick()
+	}
+	if l := line(); l != "    H" {
+		t.Errorf("failed to repeat marquee text: expected \"    H\", got %q", l)
+	}
+
+	// Pause on focus
+
+	m.SetPauseOnFocus(true)
+	m.Focus(nil)
+	m.tick()
+	if l := line(); l != "    H" {
+		t.Errorf("failed to pause marquee: expected \"    H\", got %q", l)
+	}
+	m.Blur()
+
+	// Text which fits
+
+	m.SetText("Hi")
+	m.tick()
+	if l := line(); l != "Hi   " {
+		t.Errorf("failed to draw marquee: expected \"Hi   \", got %q", l)
+	}
+}
+
+func TestMarqueeStart(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	m := NewMarquee()
+	m.SetText("Hello, world!")
+	m.SetRect(0, 0, 5, 1)
+	m.SetSpeed(time.Millisecond)
+
+	changed := make(chan struct{}, 1)
+	m.SetChangedFunc(func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	})
+
+	// Start
+
+	m.Start()
+	if !m.IsRunning() {
+		t.Errorf("failed to start marquee: expected running")
+	}
+	select {
+	case <-changed:
+	case <-time.After(time.Second):
+		t.Fatal("failed to scroll marquee: expected changed handler to be called")
+	}
+
+	// Stop
+
+	m.Stop()
+	if m.IsRunning() {
+		t.Errorf("failed to stop marquee: expected not running")
+	}
+	m.Stop()
+}
@@ -0,0 +1,236 @@
+package cview
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogLevel represents the severity of a LogMessage.
+type LogLevel int
+
+// Log levels.
+const (
+	LogDebug LogLevel = iota
+	LogInfo
+	LogWarning
+	LogError
+)
+
+// String returns the name of the log level.
+func (l LogLevel) String() string {
+	switch l {
+	case LogDebug:
+		return "DEBUG"
+	case LogInfo:
+		return "INFO"
+	case LogWarning:
+		return "WARNING"
+	case LogError:
+		return "ERROR"
+	default:
+		return fmt.Sprintf("LEVEL%d", int(l))
+	}
+}
+
+// LogMessage is a message stored in a MessageLog.
+type LogMessage struct {
+	// The time the message was logged.
+	Time time.Time
+
+	// The severity of the message.
+	Level LogLevel
+
+	// The text of the message.
+	Text string
+
+	// Optional fields providing additional information.
+	Fields map[string]interface{}
+}
+
+// String returns the message formatted as a single line, e.g.
+// "15:04:05 INFO Connected host=example.com port=22". Fields are sorted by
+// their names.
+func (m *LogMessage) String() string {
+	var b strings.Builder
+	b.WriteString(m.Time.Format("15:04:05"))
+	b.WriteByte(' ')
+	b.WriteString(m.Level.String())
+	b.WriteByte(' ')
+	b.WriteString(m.Text)
+
+	names := make([]string, 0, len(m.Fields))
+	for name := range m.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&b, " %s=%v", name, m.Fields[name])
+	}
+	return b.String()
+}
+
+// MessageLog is a thread-safe log of messages which is shared by any number of
+// widgets. Producers log messages without knowing which widgets display them,
+// while widgets subscribe to be notified of new messages. Only the most recent
+// messages are kept, up to the capacity of the log.
+//
+// Subscribers are called from the goroutine which logged the message, so they
+// should typically queue an update:
+//
+//   messages.Subscribe(func(m *cview.LogMessage) {
+//       app.QueueUpdateDraw(func() {
+//           fmt.Fprintln(textView, m)
+//       })
+//   })
+type MessageLog struct {
+	// The messages, stored in a ring buffer.
+	messages []*LogMessage
+
+	// The index of the oldest message.
+	start int
+
+	// The number of messages stored.
+	count int
+
+	// The minimum level of messages which are logged.
+	level LogLevel
+
+	// The functions called when a message is logged.
+	subscribers map[int]func(message *LogMessage)
+
+	// The ID of the next subscriber.
+	nextSubscriber int
+
+	sync.RWMutex
+}
+
+// NewMessageLog returns a new message log which keeps up to the provided
+// number of messages.
+func NewMessageLog(capacity int) *MessageLog {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &MessageLog{
+		messages:    make([]*LogMessage, capacity),
+		subscribers: make(map[int]func(message *LogMessage)),
+	}
+}
+
+// SetLevel sets the minimum level of messages which are logged. Messages with
+// a lower level are discarded. The default is LogDebug.
+func (l *MessageLog) SetLevel(level LogLevel) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.level = level
+}
+
+// Log logs a message with the provided level, text and optional fields, and
+// notifies all subscribers.
+func (l *MessageLog) Log(level LogLevel, text string, fields map[string]interface{}) {
+	l.Lock()
+	if level < l.level {
+		l.Unlock()
+		return
+	}
+
+	message := &LogMessage{
+		Time:   time.Now(),
+		Level:  level,
+		Text:   text,
+		Fields: fields,
+	}
+
+	capacity := len(l.messages)
+	if l.count < capacity {
+		l.messages[(l.start+l.count)%capacity] = message
+		l.count++
+	} else {
+		l.messages[l.start] = message
+		l.start = (l.start + 1) % capacity
+	}
+
+	subscribers := make([]func(message *LogMessage), 0, len(l.subscribers))
+	for id := 0; id < l.nextSubscriber; id++ {
+		if handler, ok := l.subscribers[id]; ok {
+			subscribers = append(subscribers, handler)
+		}
+	}
+	l.Unlock()
+
+	for _, handler := range subscribers {
+		handler(message)
+	}
+}
+
+// Logf logs a message with the provided level and formatted text.
+func (l *MessageLog) Logf(level LogLevel, format string, a ...interface{}) {
+	l.Log(level, fmt.Sprintf(format, a...), nil)
+}
+
+// Subscribe sets a handler which is called each time a message is logged.
+// Handlers are called in the order they subscribed. The returned function
+// unsubscribes the handler.
+func (l *MessageLog) Subscribe(handler func(message *LogMessage)) (unsubscribe func()) {
+	l.Lock()
+	defer l.Unlock()
+
+	id := l.nextSubscriber
+	l.nextSubscriber++
+	l.subscribers[id] = handler
+
+	return func() {
+		l.Lock()
+		defer l.Unlock()
+
+		delete(l.subscribers, id)
+	}
+}
+
+// GetMessages returns the stored messages, from oldest to newest.
+func (l *MessageLog) GetMessages() []*LogMessage {
+	l.RLock()
+	defer l.RUnlock()
+
+	messages := make([]*LogMessage, l.count)
+	for i := range messages {
+		messages[i] = l.messages[(l.start+i)%len(l.messages)]
+	}
+	return messages
+}
+
+// GetMessageCount returns the number of stored messages.
+func (l *MessageLog) GetMessageCount() int {
+	l.RLock()
+	defer l.RUnlock()
+
+	return l.count
+}
+
+// CountLevel returns the number of stored messages with the provided level.
+func (l *MessageLog) CountLevel(level LogLevel) int {
+	l.RLock()
+	defer l.RUnlock()
+
+	var count int
+	for i := 0; i < l.count; i++ {
+		if l.messages[(l.start+i)%len(l.messages)].Level == level {
+			count++
+		}
+	}
+	return count
+}
+
+// Clear removes all stored messages.
+func (l *MessageLog) Clear() {
+	l.Lock()
+	defer l.Unlock()
+
+	for i := range l.messages {
+		l.messages[i] = nil
+	}
+	l.start, l.count = 0, 0
+}
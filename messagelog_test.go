@@ -0,0 +1,63 @@
+package cview
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMessageLog(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	l := NewMessageLog(3)
+
+	var received []string
+	unsubscribe := l.Subscribe(func(message *LogMessage) {
+		received = append(received, message.Text)
+	})
+
+	// Log
+
+	l.Log(LogInfo, "a", nil)
+	l.Log(LogError, "b", map[string]interface{}{"port": 22, "host": "example.com"})
+	l.Logf(LogWarning, "%s", "c")
+	l.Log(LogError, "d", nil)
+
+	messages := l.GetMessages()
+	if len(messages) != 3 {
+		t.Errorf("failed to log messages: expected 3 messages, got %d", len(messages))
+	} else if messages[0].Text != "b" || messages[2].Text != "d" {
+		t.Errorf("failed to log messages: expected b, c, d, got %s, %s, %s", messages[0].Text, messages[1].Text, messages[2].Text)
+	}
+	if count := l.CountLevel(LogError); count != 2 {
+		t.Errorf("failed to count messages: expected 2, got %d", count)
+	}
+	if s := messages[0].String(); !strings.HasSuffix(s, " ERROR b host=example.com port=22") {
+		t.Errorf("failed to format message: got %s", s)
+	}
+
+	// Subscribe
+
+	if strings.Join(received, "") != "abcd" {
+		t.Errorf("failed to notify subscriber: expected abcd, got %s", strings.Join(received, ""))
+	}
+
+	unsubscribe()
+	l.SetLevel(LogWarning)
+	l.Log(LogInfo, "e", nil)
+	l.Log(LogWarning, "f", nil)
+	if len(received) != 4 {
+		t.Errorf("failed to unsubscribe: expected 4 messages, got %d", len(received))
+	}
+	if messages := l.GetMessages(); messages[2].Text != "f" {
+		t.Errorf("failed to filter messages: expected f, got %s", messages[2].Text)
+	}
+
+	// Clear
+
+	l.Clear()
+	if count := l.GetMessageCount(); count != 0 {
+		t.Errorf("failed to clear messages: expected 0 messages, got %d", count)
+	}
+}
@@ -0,0 +1,171 @@
+package cview
+
+import (
+	"bytes"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SetMnemonicMarker sets the rune which marks the mnemonic of a Button or
+// CheckBox label. The rune following the marker is underlined, and pressing
+// Alt and that rune activates the widget while any primitive within the same
+// Form has focus. For example, when set to '&', the label "&Verbose" is
+// displayed as "Verbose" and is activated via Alt+V. The marker is displayed
+// literally when it is repeated ("&&") or when it is not followed by a letter
+// or digit.
+//
+// Mnemonics are disabled by default (0), as enabling them changes how existing
+// labels which contain the marker are displayed and returned. The marker
+// applies to the widgets contained in the root primitive, which parse their
+// labels again when the application is drawn or handles a key event.
+func (a *Application) SetMnemonicMarker(marker rune) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.mnemonicMarker = marker
+}
+
+// GetMnemonicMarker returns the rune set via SetMnemonicMarker.
+func (a *Application) GetMnemonicMarker() rune {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.mnemonicMarker
+}
+
+// mnemonicActivator is implemented by primitives which may be activated via
+// a mnemonic.
+type mnemonicActivator interface {
+	// setMnemonicMarker parses the mnemonic of the label using the provided
+	// marker.
+	setMnemonicMarker(marker rune)
+
+	activateMnemonic(r rune, setFocus func(p Primitive)) bool
+}
+
+// parseMnemonic removes the provided mnemonic marker from the provided text.
+// The mnemonic rune (in lower case) and its byte index within the returned
+// text are also returned. If the text has no mnemonic, the rune is 0.
+func parseMnemonic(text []byte, marker rune) (stripped []byte, mnemonic rune, index int) {
+	if marker == 0 || !bytes.ContainsRune(text, marker) {
+		return text, 0, 0
+	}
+
+	stripped = make([]byte, 0, len(text))
+	for len(text) > 0 {
+		r, size := utf8.DecodeRune(text)
+		text = text[size:]
+		if r != marker {
+			stripped = append(stripped, string(r)...)
+			continue
+		}
+
+		next, nextSize := utf8.DecodeRune(text)
+		if next == marker {
+			// Escaped marker.
+			stripped = append(stripped, string(r)...)
+			text = text[nextSize:]
+		} else if mnemonic == 0 && (unicode.IsLetter(next) || unicode.IsDigit(next)) {
+			mnemonic = unicode.ToLower(next)
+			index = len(stripped)
+		} else {
+			stripped = append(stripped, string(r)...)
+		}
+	}
+	return stripped, mnemonic, index
+}
+
+// underlineMnemonic returns the provided text with the rune at the provided
+// byte index wrapped in style tags which underline it.
+func underlineMnemonic(text []byte, index int) []byte {
+	if index < 0 || index >= len(text) {
+		return text
+	}
+	_, size := utf8.DecodeRune(text[index:])
+
+	marked := make([]byte, 0, len(text)+10)
+	marked = append(marked, text[:index]...)
+	marked = append(marked, "[::u]"...)
+	marked = append(marked, text[index:index+size]...)
+	marked = append(marked, "[::-]"...)
+	marked = append(marked, text[index+size:]...)
+	return marked
+}
+
+// matchMnemonic returns whether the provided rune matches a mnemonic.
+func matchMnemonic(mnemonic, r rune) bool {
+	return mnemonic != 0 && unicode.ToLower(r) == mnemonic
+}
+
+// applyMnemonicMarker passes the mnemonic marker of the application to the
+// widgets contained in the provided primitive.
+func (a *Application) applyMnemonicMarker(p Primitive) {
+	a.RLock()
+	marker, applied := a.mnemonicMarker, a.mnemonicMarkerApplied
+	a.RUnlock()
+
+	if marker == 0 && applied == 0 {
+		return // Labels were never parsed.
+	}
+	setMnemonicMarker(p, marker)
+
+	a.Lock()
+	a.mnemonicMarkerApplied = marker
+	a.Unlock()
+}
+
+// setMnemonicMarker passes the provided mnemonic marker to the widgets
+// contained in the provided primitive.
+func setMnemonicMarker(p Primitive, marker rune) {
+	if p == nil {
+		return
+	}
+	if m, ok := p.(mnemonicActivator); ok {
+		m.setMnemonicMarker(marker)
+	}
+	if c, ok := p.(containerPrimitive); ok {
+		for _, child := range c.children() {
+			setMnemonicMarker(child, marker)
+		}
+	}
+}
+
+// activateMnemonic activates the widget with the provided mnemonic within the
+// innermost focused Form. Returns whether a widget was activated.
+func (a *Application) activateMnemonic(p Primitive, r rune) bool {
+	if p == nil || !p.GetVisible() || !p.GetFocusable().HasFocus() {
+		return false
+	}
+
+	if c, ok := p.(containerPrimitive); ok {
+		for _, child := range c.children() {
+			if a.activateMnemonic(child, r) {
+				return true
+			}
+		}
+	}
+
+	if f, ok := p.(*Form); ok {
+		return findMnemonic(f, r, a.SetFocus)
+	}
+	return false
+}
+
+// findMnemonic activates the first visible primitive with the provided
+// mnemonic contained in the provided primitive.
+func findMnemonic(p Primitive, r rune, setFocus func(p Primitive)) bool {
+	if !p.GetVisible() {
+		return false
+	}
+	if m, ok := p.(mnemonicActivator); ok && m.activateMnemonic(r, setFocus) {
+		return true
+	}
+	if c, ok := p.(containerPrimitive); ok {
+		for _, child := range c.children() {
+			if findMnemonic(child, r, setFocus) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package cview
+
+import (
+	"testing"
+)
+
+func TestParseMnemonic(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		label    string
+		stripped string
+		mnemonic rune
+		index    int
+	}{
+		{"Verbose", "Verbose", 0, 0},
+		{"&Verbose", "Verbose", 'v', 0},
+		{"Save &As", "Save As", 'a', 5},
+		{"Save && Quit", "Save & Quit", 0, 0},
+		{"Save & &Quit", "Save & Quit", 'q', 7},
+		{"&Über", "Über", 'ü', 0},
+	} {
+		stripped, mnemonic, index := parseMnemonic([]byte(test.label), '&')
+		if string(stripped) != test.stripped || mnemonic != test.mnemonic || index != test.index {
+			t.Errorf("failed to parse mnemonic of %q: expected %q, %q, %d, got %q, %q, %d", test.label, test.stripped, test.mnemonic, test.index, stripped, mnemonic, index)
+		}
+	}
+}
+
+func TestFormMnemonic(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	var saved bool
+	f := NewForm()
+	f.AddInputField("Name", "", 20, nil, nil)
+	f.AddCheckBox("", "&Verbose", false, nil)
+	f.AddButton("&Save", func() {
+		saved = true
+	})
+
+	app, err := newTestApp(f)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	app.SetMnemonicMarker('&')
+	app.SetFocus(f)
+
+	checkBox := f.GetFormItem(1).(*CheckBox)
+	if checkBox.GetMessage() != "&Verbose" {
+		t.Errorf("failed to keep label before draw: expected &Verbose, got %s", checkBox.GetMessage())
+	}
+
+	app.draw()
+	if checkBox.GetMessage() != "Verbose" {
+		t.Errorf("failed to strip mnemonic marker: expected Verbose, got %s", checkBox.GetMessage())
+	}
+
+	// Activate
+
+	if !app.activateMnemonic(f, 'V') {
+		t.Errorf("failed to activate CheckBox mnemonic")
+	} else if !checkBox.IsChecked() || !checkBox.HasFocus() {
+		t.Errorf("failed to activate CheckBox mnemonic: expected checked and focused CheckBox")
+	}
+
+	if !app.activateMnemonic(f, 's') || !saved {
+		t.Errorf("failed to activate Button mnemonic")
+	}
+
+	if app.activateMnemonic(f, 'x') {
+		t.Errorf("failed to ignore unknown mnemonic")
+	}
+
+	// Disable
+
+	app.SetMnemonicMarker(0)
+	app.draw()
+	if checkBox.GetMessage() != "&Verbose" {
+		t.Errorf("failed to restore label: expected &Verbose, got %s", checkBox.GetMessage())
+	} else if app.activateMnemonic(f, 'v') {
+		t.Errorf("failed to disable mnemonics")
+	}
+}
+
+func TestFormMnemonicDisabled(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	f := NewForm()
+	f.AddCheckBox("", "&Verbose", false, nil)
+	f.AddButton("&Save", nil)
+
+	// Labels are unchanged
+
+	if message := f.GetFormItem(0).(*CheckBox).GetMessage(); message != "&Verbose" {
+		t.Errorf("failed to keep label: expected &Verbose, got %s", message)
+	} else if index := f.GetButtonIndex("&Save"); index != 0 {
+		t.Errorf("failed to get button index: expected 0, got %d", index)
+	}
+}
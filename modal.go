@@ -205,6 +205,11 @@ func (m *Modal) HasFocus() bool {
 	return m.GetForm().HasFocus()
 }
 
+// children returns the primitives contained in this primitive.
+func (m *Modal) children() []Primitive {
+	return []Primitive{m.frame}
+}
+
 // Draw draws this primitive onto the screen.
 func (m *Modal) Draw(screen tcell.Screen) {
 	if !m.GetVisible() {
@@ -245,7 +250,7 @@ func (m *Modal) Draw(screen tcell.Screen) {
 
 	// Draw the frame.
 	m.frame.SetRect(x, y, width, height)
-	m.frame.Draw(screen)
+	drawPrimitive(m.frame, screen)
 }
 
 // MouseHandler returns the mouse handler for this primitive.
@@ -24,6 +24,25 @@ const (
 	MouseScrollDown
 	MouseScrollLeft
 	MouseScrollRight
+
+	// Gestures. A drag starts when the mouse is moved by at least the drag
+	// threshold while the left button is held. A swipe is a drag which ends
+	// at least the swipe threshold away from where it started, mostly along
+	// a single axis. See Application.SetDragThreshold and
+	// Application.SetSwipeThreshold.
+	MouseDragStart
+	MouseDrag
+	MouseDragEnd
+	MouseSwipeLeft
+	MouseSwipeRight
+	MouseSwipeUp
+	MouseSwipeDown
+)
+
+// Default gesture thresholds, in screen cells.
+const (
+	DefaultDragThreshold  = 2
+	DefaultSwipeThreshold = 6
 )
 
 // StandardDoubleClick is a commonly used double click interval.
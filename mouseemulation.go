@@ -0,0 +1,211 @@
+package cview
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// The number of cells the virtual mouse cursor is moved by when an arrow key
+// is pressed with Shift.
+const mouseEmulationFastStep = 8
+
+// EnableMouseEmulation sets whether the mouse emulation mode may be used. It
+// is disabled by default, so that Keys.MouseEmulation (F9 by default) is
+// passed to the focused primitive. See SetMouseEmulation.
+func (a *Application) EnableMouseEmulation(enable bool) {
+	a.Lock()
+	a.mouseEmulationEnabled = enable
+	a.Unlock()
+
+	if !enable {
+		a.SetMouseEmulation(false)
+	}
+}
+
+// SetMouseEmulation enables or disables the mouse emulation mode of the
+// application. It has no effect unless mouse emulation was enabled via
+// EnableMouseEmulation. While the mouse emulation mode is enabled, a virtual mouse
+// cursor is moved via the keyboard and mouse events are generated for it,
+// allowing features which are only available via the mouse, such as dragging
+// scroll bars and opening context menus, to be used on terminals without mouse
+// support. Key events are not passed to the focused primitive.
+//
+// The arrow keys move the cursor one cell, or eight cells while Shift is held.
+// Keys.Select and Keys.Select2 (Enter and Space) click the left button, and
+// Keys.ShowContextMenu (Alt+Enter) clicks the right button. Keys.MouseDrag
+// (d) presses the left button until it is pressed again or a click is
+// emulated, so that the cursor may be dragged. Keys.MovePreviousPage and
+// Keys.MoveNextPage scroll the mouse wheel. Escape disables the mouse
+// emulation mode.
+//
+// The cursor starts at the center of the focused primitive. The mouse
+// emulation mode may also be toggled via Keys.MouseEmulation.
+func (a *Application) SetMouseEmulation(emulate bool) {
+	a.Lock()
+	if emulate && !a.mouseEmulationEnabled {
+		a.Unlock()
+		return
+	}
+	if emulate && !a.mouseEmulation {
+		a.emulatedMouseX, a.emulatedMouseY = a.width/2, a.height/2
+		if a.focus != nil {
+			x, y, width, height := a.focus.GetRect()
+			a.emulatedMouseX, a.emulatedMouseY = x+width/2, y+height/2
+		}
+	}
+	dragging := a.emulatedDrag && !emulate
+	if dragging {
+		a.emulatedDrag = false
+	}
+	a.mouseEmulation = emulate
+	a.Unlock()
+
+	if dragging {
+		// Release the left button on the main goroutine.
+		a.Defer(func() {
+			a.emulateMouse(0, 0, 0)
+		})
+	}
+	a.queueIndicatorUpdate()
+}
+
+// GetMouseEmulation returns whether the mouse emulation mode of the
+// application is enabled.
+func (a *Application) GetMouseEmulation() bool {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.mouseEmulation
+}
+
+// GetEmulatedMousePosition returns the position of the virtual mouse cursor of
+// the mouse emulation mode.
+func (a *Application) GetEmulatedMousePosition() (x, y int) {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.emulatedMouseX, a.emulatedMouseY
+}
+
+// handleMouseEmulation handles a key event while the mouse emulation mode is
+// enabled, or enables the mouse emulation mode. Returns whether the event was
+// handled.
+func (a *Application) handleMouseEmulation(event *tcell.EventKey) bool {
+	a.RLock()
+	enabled := a.mouseEmulationEnabled
+	a.RUnlock()
+
+	if !enabled {
+		return false
+	} else if !a.GetMouseEmulation() {
+		if !HitShortcut(event, Keys.MouseEmulation) {
+			return false
+		}
+		a.SetMouseEmulation(true)
+		return true
+	}
+
+	step := 1
+	if event.Modifiers()&tcell.ModShift != 0 {
+		step = mouseEmulationFastStep
+	}
+
+	switch {
+	case HitShortcut(event, Keys.Cancel, Keys.MouseEmulation):
+		a.SetMouseEmulation(false)
+	case HitShortcut(event, Keys.ShowContextMenu):
+		a.emulateClick(tcell.ButtonSecondary)
+	case HitShortcut(event, Keys.Select, Keys.Select2):
+		a.emulateClick(tcell.ButtonPrimary)
+	case HitShortcut(event, Keys.MouseDrag):
+		a.Lock()
+		a.emulatedDrag = !a.emulatedDrag
+		a.Unlock()
+
+		a.emulateMouse(0, 0, 0)
+	case HitShortcut(event, Keys.MovePreviousPage):
+		a.emulateMouse(0, 0, tcell.WheelUp)
+	case HitShortcut(event, Keys.MoveNextPage):
+		a.emulateMouse(0, 0, tcell.WheelDown)
+	case event.Key() == tcell.KeyUp || HitShortcut(event, Keys.MoveUp2):
+		a.emulateMouse(0, -step, 0)
+	case event.Key() == tcell.KeyDown || HitShortcut(event, Keys.MoveDown2):
+		a.emulateMouse(0, step, 0)
+	case event.Key() == tcell.KeyLeft || HitShortcut(event, Keys.MoveLeft2):
+		a.emulateMouse(-step, 0, 0)
+	case event.Key() == tcell.KeyRight || HitShortcut(event, Keys.MoveRight2):
+		a.emulateMouse(step, 0, 0)
+	}
+	return true // Don't pass keys to the focused primitive while emulating.
+}
+
+// emulateClick emulates pressing and releasing a mouse button at the position
+// of the virtual mouse cursor, releasing the left button first if it is held.
+func (a *Application) emulateClick(button tcell.ButtonMask) {
+	a.Lock()
+	dragging := a.emulatedDrag
+	a.emulatedDrag = false
+	a.Unlock()
+
+	if dragging {
+		a.emulateMouse(0, 0, 0)
+		if button == tcell.ButtonPrimary {
+			return // Releasing the button completes the click.
+		}
+	}
+	a.emulateMouse(0, 0, button)
+	a.emulateMouse(0, 0, 0)
+}
+
+// emulateMouse moves the virtual mouse cursor by the provided distance and
+// handles a mouse event at its position with the provided buttons pressed,
+// in addition to the left button while it is held.
+func (a *Application) emulateMouse(dx, dy int, buttons tcell.ButtonMask) {
+	a.Lock()
+	a.emulatedMouseX, a.emulatedMouseY = a.emulatedMouseX+dx, a.emulatedMouseY+dy
+	if a.emulatedMouseX >= a.width {
+		a.emulatedMouseX = a.width - 1
+	}
+	if a.emulatedMouseX < 0 {
+		a.emulatedMouseX = 0
+	}
+	if a.emulatedMouseY >= a.height {
+		a.emulatedMouseY = a.height - 1
+	}
+	if a.emulatedMouseY < 0 {
+		a.emulatedMouseY = 0
+	}
+	x, y := a.emulatedMouseX, a.emulatedMouseY
+	if a.emulatedDrag {
+		buttons |= tcell.ButtonPrimary
+	}
+	a.Unlock()
+
+	a.handleMouse(tcell.NewEventMouse(x, y, buttons, tcell.ModNone))
+}
+
+// drawMouseEmulation draws the virtual mouse cursor and an indicator while
+// the mouse emulation mode is enabled.
+func (a *Application) drawMouseEmulation(screen tcell.Screen) {
+	a.RLock()
+	emulate := a.mouseEmulation
+	x, y := a.emulatedMouseX, a.emulatedMouseY
+	dragging := a.emulatedDrag
+	width := a.width
+	a.RUnlock()
+
+	if !emulate {
+		return
+	}
+
+	m, c, style, _ := screen.GetContent(x, y)
+	_, _, attributes := style.Decompose()
+	screen.SetContent(x, y, m, c, style.Reverse(attributes&tcell.AttrReverse == 0))
+
+	action := "Drag: d"
+	if dragging {
+		action = "Drop: d"
+	}
+	a.drawIndicator(screen, width, fmt.Sprintf(" Mouse %d,%d  Click: Enter  %s  Done: Escape ", x, y, action))
+}
@@ -0,0 +1,122 @@
+package cview
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestMouseEmulation(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	var actions []string
+	box := NewBox()
+	box.SetMouseCapture(func(action MouseAction, event *tcell.EventMouse) (MouseAction, *tcell.EventMouse) {
+		x, y := event.Position()
+		switch action {
+		case MouseLeftClick, MouseRightClick, MouseDragStart, MouseDragEnd, MouseScrollDown:
+			actions = append(actions, fmt.Sprintf("%d:%d,%d", action, x, y))
+		}
+		return action, event
+	})
+
+	app, err := newTestApp(box)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	app.screen.(tcell.SimulationScreen).SetSize(80, 24)
+	app.width, app.height = app.screen.Size()
+	box.SetRect(0, 0, 20, 10)
+	app.SetFocus(box)
+
+	key := func(k tcell.Key, r rune, mod tcell.ModMask) bool {
+		return app.handleMouseEmulation(tcell.NewEventKey(k, r, mod))
+	}
+
+	// Disabled by default
+
+	if key(tcell.KeyF9, 0, tcell.ModNone) || app.GetMouseEmulation() {
+		t.Errorf("failed to pass key: expected mouse emulation to be disabled by default")
+	}
+	app.SetMouseEmulation(true)
+	if app.GetMouseEmulation() {
+		t.Errorf("failed to ignore mouse emulation: expected mouse emulation to be disabled by default")
+	}
+
+	// Enable
+
+	app.EnableMouseEmulation(true)
+	if !key(tcell.KeyF9, 0, tcell.ModNone) || !app.GetMouseEmulation() {
+		t.Errorf("failed to enable mouse emulation")
+	}
+	if x, y := app.GetEmulatedMousePosition(); x != 10 || y != 5 {
+		t.Errorf("failed to position cursor: expected 10,5, got %d,%d", x, y)
+	}
+
+	// Move
+
+	key(tcell.KeyLeft, 0, tcell.ModShift)
+	key(tcell.KeyUp, 0, tcell.ModNone)
+	key(tcell.KeyRune, 'j', tcell.ModNone)
+	key(tcell.KeyRune, 'j', tcell.ModNone)
+	if x, y := app.GetEmulatedMousePosition(); x != 2 || y != 6 {
+		t.Errorf("failed to move cursor: expected 2,6, got %d,%d", x, y)
+	}
+	key(tcell.KeyLeft, 0, tcell.ModShift)
+	if x, _ := app.GetEmulatedMousePosition(); x != 0 {
+		t.Errorf("failed to clamp cursor: expected 0, got %d", x)
+	}
+
+	// Click
+
+	key(tcell.KeyEnter, 0, tcell.ModNone)
+	key(tcell.KeyEnter, 0, tcell.ModAlt)
+	key(tcell.KeyPgDn, 0, tcell.ModNone)
+	expected := fmt.Sprintf("%d:0,6 %d:0,6 %d:0,6", MouseLeftClick, MouseRightClick, MouseScrollDown)
+	if got := strings.Join(actions, " "); got != expected {
+		t.Errorf("failed to emulate clicks: expected %s, got %s", expected, got)
+	}
+
+	// Drag
+
+	actions = nil
+	key(tcell.KeyRune, 'd', tcell.ModNone)
+	key(tcell.KeyRight, 0, tcell.ModShift)
+	key(tcell.KeyRune, 'd', tcell.ModNone)
+	expected = fmt.Sprintf("%d:8,6 %d:8,6", MouseDragStart, MouseDragEnd)
+	if got := strings.Join(actions, " "); got != expected {
+		t.Errorf("failed to emulate drag: expected %s, got %s", expected, got)
+	}
+
+	// Draw
+
+	app.draw()
+	_, _, style, _ := app.screen.GetContent(8, 6)
+	if _, _, attributes := style.Decompose(); attributes&tcell.AttrReverse == 0 {
+		t.Errorf("failed to draw cursor: expected reversed cell")
+	}
+
+	// Disable
+
+	key(tcell.KeyRune, 'd', tcell.ModNone)
+	key(tcell.KeyEscape, 0, tcell.ModNone)
+	app.runDeferred()
+	if app.GetMouseEmulation() {
+		t.Errorf("failed to disable mouse emulation")
+	}
+	if app.lastMouseButtons != 0 {
+		t.Errorf("failed to release button: expected no buttons, got %d", app.lastMouseButtons)
+	}
+	if key(tcell.KeyEnter, 0, tcell.ModNone) {
+		t.Errorf("failed to pass key: expected false while mouse emulation is disabled")
+	}
+}
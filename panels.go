@@ -313,6 +313,18 @@ func (p *Panels) HasFocus() bool {
 	return false
 }
 
+// children returns the primitives contained in this primitive.
+func (p *Panels) children() []Primitive {
+	p.RLock()
+	defer p.RUnlock()
+
+	children := make([]Primitive, len(p.panels))
+	for i, panel := range p.panels {
+		children[i] = panel.Item
+	}
+	return children
+}
+
 // Focus is called by the application when the primitive receives focus.
 func (p *Panels) Focus(delegate func(p Primitive)) {
 	p.Lock()
@@ -355,7 +367,8 @@ func (p *Panels) Draw(screen tcell.Screen) {
 		if panel.Resize {
 			panel.Item.SetRect(x, y, width, height)
 		}
-		panel.Item.Draw(screen)
+		p.passColors(panel.Item)
+		drawPrimitive(panel.Item, screen)
 	}
 }
 
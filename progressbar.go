@@ -1,13 +1,25 @@
 package cview
 
 import (
+	"fmt"
 	"math"
 	"sync"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 )
 
+// progressSample is the progress of a ProgressBar at a point in time.
+type progressSample struct {
+	time     time.Time
+	progress int
+}
+
 // ProgressBar indicates the progress of an operation.
+//
+// When rate tracking is enabled via SetShowRate, the time of each progress
+// update is recorded and the throughput and estimated time remaining are
+// displayed after the bar.
 type ProgressBar struct {
 	*Box
 
@@ -33,6 +45,23 @@ type ProgressBar struct {
 	// Progress required to fill the bar.
 	max int
 
+	// Whether progress updates are timestamped and the rate and estimated
+	// time remaining are displayed.
+	showRate bool
+
+	// The recorded progress updates within the rate window.
+	samples []progressSample
+
+	// The duration of progress updates considered when calculating the rate.
+	rateWindow time.Duration
+
+	// The color of the rate and estimated time remaining.
+	rateColor tcell.Color
+
+	// An optional function which formats the rate and estimated time
+	// remaining.
+	rateFormat func(rate float64, eta time.Duration) string
+
 	sync.RWMutex
 }
 
@@ -45,6 +74,8 @@ func NewProgressBar() *ProgressBar {
 		filledRune:  tcell.RuneBlock,
 		filledColor: Styles.PrimaryTextColor,
 		max:         100,
+		rateWindow:  10 * time.Second,
+		rateColor:   Styles.SecondaryTextColor,
 	}
 	p.SetBackgroundColor(Styles.PrimitiveBackgroundColor)
 	return p
@@ -106,6 +137,132 @@ func (p *ProgressBar) GetMax() int {
 	return p.max
 }
 
+// SetShowRate sets whether progress updates are timestamped and the
+// throughput and estimated time remaining are displayed after a horizontal
+// progress bar.
+func (p *ProgressBar) SetShowRate(show bool) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.showRate = show
+	p.samples = nil
+	if show {
+		p.samples = append(p.samples, progressSample{time.Now(), p.progress})
+	}
+}
+
+// SetRateWindow sets the duration of the progress updates which are considered
+// when calculating the rate. Shorter windows respond to changes in throughput
+// more quickly. The default window is 10 seconds.
+func (p *ProgressBar) SetRateWindow(window time.Duration) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.rateWindow = window
+}
+
+// SetRateColor sets the color of the rate and estimated time remaining.
+func (p *ProgressBar) SetRateColor(color tcell.Color) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.rateColor = color
+}
+
+// SetRateFormatFunc sets a handler which formats the rate (in progress per
+// second) and the estimated time remaining displayed after the bar, such as
+// to display the rate of a file transfer in bytes. The estimated time
+// remaining is negative when it is unknown.
+func (p *ProgressBar) SetRateFormatFunc(handler func(rate float64, eta time.Duration) string) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.rateFormat = handler
+}
+
+// GetRate returns the progress per second over the rate window. Rate tracking
+// must be enabled via SetShowRate.
+func (p *ProgressBar) GetRate() float64 {
+	p.RLock()
+	defer p.RUnlock()
+
+	return p.rate()
+}
+
+// GetETA returns the estimated time remaining until the bar is filled, or a
+// negative duration when it is unknown. Rate tracking must be enabled via
+// SetShowRate.
+func (p *ProgressBar) GetETA() time.Duration {
+	p.RLock()
+	defer p.RUnlock()
+
+	return p.eta()
+}
+
+// rate returns the progress per second over the rate window.
+func (p *ProgressBar) rate() float64 {
+	if len(p.samples) < 2 {
+		return 0
+	}
+	first, last := p.samples[0], p.samples[len(p.samples)-1]
+	elapsed := last.time.Sub(first.time).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(last.progress-first.progress) / elapsed
+}
+
+// eta returns the estimated time remaining, or -1 when it is unknown.
+func (p *ProgressBar) eta() time.Duration {
+	if p.progress >= p.max {
+		return 0
+	}
+	rate := p.rate()
+	if rate <= 0 {
+		return -1
+	}
+	return time.Duration(float64(p.max-p.progress) / rate * float64(time.Second))
+}
+
+// recordProgress records the current progress when rate tracking is enabled.
+// Samples outside of the rate window are discarded, keeping at least one
+// sample before the new one.
+func (p *ProgressBar) recordProgress(now time.Time) {
+	if !p.showRate {
+		return
+	}
+
+	// Start over when progress was reset.
+	if len(p.samples) > 0 && p.progress < p.samples[len(p.samples)-1].progress {
+		p.samples = p.samples[:0]
+	}
+
+	p.samples = append(p.samples, progressSample{now, p.progress})
+
+	var discard int
+	for discard < len(p.samples)-2 && now.Sub(p.samples[discard+1].time) >= p.rateWindow {
+		discard++
+	}
+	if discard > 0 {
+		p.samples = append(p.samples[:0], p.samples[discard:]...)
+	}
+}
+
+// rateText returns the rate and estimated time remaining as displayed after
+// the bar.
+func (p *ProgressBar) rateText() string {
+	rate, eta := p.rate(), p.eta()
+	if p.rateFormat != nil {
+		return p.rateFormat(rate, eta)
+	}
+
+	etaText := "--"
+	if eta >= 0 {
+		etaText = eta.Round(time.Second).String()
+	}
+	return fmt.Sprintf("%.1f/s ETA %s", rate, etaText)
+}
+
 // AddProgress adds to the current progress.
 func (p *ProgressBar) AddProgress(progress int) {
 	p.Lock()
@@ -117,6 +274,7 @@ func (p *ProgressBar) AddProgress(progress int) {
 	} else if p.progress > p.max {
 		p.progress = p.max
 	}
+	p.recordProgress(time.Now())
 }
 
 // SetProgress sets the current progress.
@@ -130,6 +288,7 @@ func (p *ProgressBar) SetProgress(progress int) {
 	} else if p.progress > p.max {
 		p.progress = p.max
 	}
+	p.recordProgress(time.Now())
 }
 
 // GetProgress gets the current progress.
@@ -161,6 +320,16 @@ func (p *ProgressBar) Draw(screen tcell.Screen) {
 
 	x, y, width, height := p.GetInnerRect()
 
+	// Draw the rate and estimated time remaining.
+	if p.showRate && !p.vertical && height > 0 {
+		text := p.rateText()
+		textWidth := TaggedStringWidth(text)
+		if textWidth > 0 && textWidth < width {
+			Print(screen, []byte(text), x+width-textWidth, y+height/2, textWidth, AlignLeft, p.rateColor)
+			width -= textWidth + 1
+		}
+	}
+
 	barSize := height
 	maxLength := width
 	if p.vertical {
@@ -2,6 +2,7 @@ package cview
 
 import (
 	"testing"
+	"time"
 )
 
 func TestProgressBar(t *testing.T) {
@@ -66,3 +67,71 @@ func TestProgressBar(t *testing.T) {
 
 	p.Draw(app.screen)
 }
+
+func TestProgressBarRate(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	p := NewProgressBar()
+	p.SetShowRate(true)
+	p.SetRateWindow(10 * time.Second)
+	if eta := p.GetETA(); eta >= 0 {
+		t.Errorf("failed to initialize ProgressBar: expected unknown ETA, got %s", eta)
+	}
+
+	// Record progress
+
+	start := time.Now()
+	p.samples = []progressSample{{start, 0}}
+	for i := 1; i <= 4; i++ {
+		p.progress = i * 10
+		p.recordProgress(start.Add(time.Duration(i) * time.Second))
+	}
+	if rate := p.GetRate(); rate != 10 {
+		t.Errorf("failed to calculate rate: expected 10, got %f", rate)
+	}
+	if eta := p.GetETA(); eta != 6*time.Second {
+		t.Errorf("failed to calculate ETA: expected 6s, got %s", eta)
+	}
+	if text := p.rateText(); text != "10.0/s ETA 6s" {
+		t.Errorf("failed to format rate: expected \"10.0/s ETA 6s\", got %q", text)
+	}
+
+	// Discard old samples
+
+	p.progress = 60
+	p.recordProgress(start.Add(20 * time.Second))
+	if len(p.samples) != 2 {
+		t.Errorf("failed to discard old samples: expected 2 samples, got %d", len(p.samples))
+	}
+	if rate := p.GetRate(); rate != 1.25 {
+		t.Errorf("failed to calculate rate: expected 1.25, got %f", rate)
+	}
+
+	// Draw
+
+	app, err := newTestApp(p)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	p.SetRateFormatFunc(func(rate float64, eta time.Duration) string {
+		return "RATE"
+	})
+	p.SetRect(0, 0, 20, 1)
+	p.Draw(app.screen)
+
+	var line []rune
+	for x := 15; x < 20; x++ {
+		r, _, _, _ := app.screen.GetContent(x, 0)
+		line = append(line, r)
+	}
+	if got := string(line); got != " RATE" {
+		t.Errorf("failed to draw rate: expected \" RATE\", got %q", got)
+	}
+}
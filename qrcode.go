@@ -0,0 +1,684 @@
+package cview
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// QRErrorCorrectionLevel is the level of error correction used when encoding
+// a QR code. Higher levels allow more of the code to be damaged or obscured
+// while still being scannable, at the cost of a larger code.
+type QRErrorCorrectionLevel int
+
+// Available QR code error correction levels.
+const (
+	QRErrorCorrectionLow      QRErrorCorrectionLevel = iota // Recovers 7% of data.
+	QRErrorCorrectionMedium                                 // Recovers 15% of data.
+	QRErrorCorrectionQuartile                               // Recovers 25% of data.
+	QRErrorCorrectionHigh                                   // Recovers 30% of data.
+)
+
+// ErrQRCodeTooLong is returned when a payload does not fit within the largest
+// QR code at the selected error correction level.
+var ErrQRCodeTooLong = errors.New("payload too long for QR code")
+
+// QRCode displays a payload as a scannable QR code. Two modules are drawn
+// per screen cell using half block characters. The code is centered within
+// the primitive and scaled up when space allows.
+type QRCode struct {
+	*Box
+
+	// The encoded payload.
+	text string
+
+	// The error correction level.
+	level QRErrorCorrectionLevel
+
+	// The encoded modules, true for dark modules. Nil when the payload is
+	// empty.
+	modules [][]bool
+
+	// The width of the quiet zone, in modules.
+	quietZone int
+
+	// The color of dark modules.
+	darkColor tcell.Color
+
+	// The color of light modules, including the quiet zone.
+	lightColor tcell.Color
+
+	sync.RWMutex
+}
+
+// NewQRCode returns a new QR code.
+func NewQRCode() *QRCode {
+	return &QRCode{
+		Box:        NewBox(),
+		level:      QRErrorCorrectionMedium,
+		quietZone:  4,
+		darkColor:  tcell.ColorBlack,
+		lightColor: tcell.ColorWhite,
+	}
+}
+
+// SetText encodes the provided payload. ErrQRCodeTooLong is returned when the
+// payload does not fit within a QR code at the current error correction
+// level, in which case the previous payload remains displayed.
+func (q *QRCode) SetText(text string) error {
+	q.Lock()
+	defer q.Unlock()
+
+	return q.encode(text, q.level)
+}
+
+// GetText returns the encoded payload.
+func (q *QRCode) GetText() string {
+	q.RLock()
+	defer q.RUnlock()
+
+	return q.text
+}
+
+// SetErrorCorrectionLevel sets the error correction level and re-encodes the
+// payload. ErrQRCodeTooLong is returned when the payload does not fit within
+// a QR code at the provided level, in which case the level is not changed.
+func (q *QRCode) SetErrorCorrectionLevel(level QRErrorCorrectionLevel) error {
+	q.Lock()
+	defer q.Unlock()
+
+	return q.encode(q.text, level)
+}
+
+// SetQuietZone sets the width of the blank border drawn around the code, in
+// modules. Scanners expect a quiet zone of four modules. The quiet zone is
+// narrowed automatically when the code would not fit otherwise.
+func (q *QRCode) SetQuietZone(modules int) {
+	q.Lock()
+	defer q.Unlock()
+
+	if modules < 0 {
+		modules = 0
+	}
+	q.quietZone = modules
+}
+
+// SetColors sets the colors of the dark and light modules. Most scanners
+// require dark modules on a light background.
+func (q *QRCode) SetColors(dark, light tcell.Color) {
+	q.Lock()
+	defer q.Unlock()
+
+	q.darkColor = dark
+	q.lightColor = light
+}
+
+// GetSize returns the number of modules along each side of the code,
+// excluding the quiet zone. Zero is returned when the payload is empty.
+func (q *QRCode) GetSize() int {
+	q.RLock()
+	defer q.RUnlock()
+
+	return len(q.modules)
+}
+
+func (q *QRCode) encode(text string, level QRErrorCorrectionLevel) error {
+	if text == "" {
+		q.text, q.level, q.modules = text, level, nil
+		return nil
+	}
+
+	modules, err := qrEncode([]byte(text), level, -1)
+	if err != nil {
+		return err
+	}
+	q.text, q.level, q.modules = text, level, modules
+	return nil
+}
+
+// Draw draws this primitive onto the screen.
+func (q *QRCode) Draw(screen tcell.Screen) {
+	if !q.GetVisible() {
+		return
+	}
+
+	q.Box.Draw(screen)
+
+	q.Lock()
+	defer q.Unlock()
+
+	x, y, width, height := q.GetInnerRect()
+	size := len(q.modules)
+	if size == 0 || width <= 0 || height <= 0 {
+		return
+	}
+
+	// Narrow the quiet zone when necessary.
+	quietZone := q.quietZone
+	for quietZone > 0 && (size+2*quietZone > width || size+2*quietZone > height*2) {
+		quietZone--
+	}
+	total := size + 2*quietZone
+	if total > width || total > height*2 {
+		Print(screen, []byte("Too small to display QR code"), x, y, width, AlignCenter, Styles.PrimaryTextColor)
+		return
+	}
+
+	// Scale up while space allows. Each module is drawn as one column and
+	// half a row, which is roughly square on most terminals.
+	scale := 1
+	for total*(scale+1) <= width && total*(scale+1) <= height*2 {
+		scale++
+	}
+
+	isDark := func(row, col int) bool {
+		row, col = row/scale-quietZone, col/scale-quietZone
+		if row < 0 || col < 0 || row >= size || col >= size {
+			return false
+		}
+		return q.modules[row][col]
+	}
+	color := func(dark bool) tcell.Color {
+		if dark {
+			return q.darkColor
+		}
+		return q.lightColor
+	}
+
+	drawnWidth := total * scale
+	drawnHeight := (total*scale + 1) / 2
+	offsetX := x + (width-drawnWidth)/2
+	offsetY := y + (height-drawnHeight)/2
+	for row := 0; row < drawnHeight; row++ {
+		for col := 0; col < drawnWidth; col++ {
+			top := isDark(row*2, col)
+			bottom := isDark(row*2+1, col)
+			style := tcell.StyleDefault.Foreground(color(top)).Background(color(bottom))
+			screen.SetContent(offsetX+col, offsetY+row, '▀', nil, style)
+		}
+	}
+}
+
+// The number of error correction codewords per block, indexed by error
+// correction level and version.
+var qrECCCodewordsPerBlock = [4][41]int{
+	{-1, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+	{-1, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+}
+
+// The number of error correction blocks, indexed by error correction level
+// and version.
+var qrECCBlocks = [4][41]int{
+	{-1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+	{-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+	{-1, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+	{-1, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+}
+
+// The format information bits of each error correction level.
+var qrFormatBits = [4]int{1, 0, 3, 2}
+
+// qrMatrix holds the modules of a QR code while it is being encoded.
+type qrMatrix struct {
+	size       int
+	modules    [][]bool
+	isFunction [][]bool
+}
+
+// qrEncode encodes data in byte mode using the smallest version which fits.
+// When mask is negative, the mask resulting in the lowest penalty is chosen.
+func qrEncode(data []byte, level QRErrorCorrectionLevel, mask int) ([][]bool, error) {
+	// Find the smallest version which fits.
+	version := 1
+	for ; ; version++ {
+		if version > 40 {
+			return nil, ErrQRCodeTooLong
+		}
+		if qrDataBits(data, version) <= qrDataCodewords(version, level)*8 {
+			break
+		}
+	}
+
+	// Build the bit stream.
+	var bits []bool
+	appendBits := func(value, length int) {
+		for i := length - 1; i >= 0; i-- {
+			bits = append(bits, (value>>uint(i))&1 != 0)
+		}
+	}
+	appendBits(0x4, 4) // Byte mode.
+	appendBits(len(data), qrCharCountBits(version))
+	for _, b := range data {
+		appendBits(int(b), 8)
+	}
+
+	// Add the terminator and padding.
+	capacity := qrDataCodewords(version, level) * 8
+	terminator := capacity - len(bits)
+	if terminator > 4 {
+		terminator = 4
+	}
+	appendBits(0, terminator)
+	appendBits(0, (8-len(bits)%8)%8)
+	for pad := 0xEC; len(bits) < capacity; pad ^= 0xEC ^ 0x11 {
+		appendBits(pad, 8)
+	}
+
+	codewords := make([]byte, len(bits)/8)
+	for i, bit := range bits {
+		if bit {
+			codewords[i>>3] |= 1 << uint(7-i&7)
+		}
+	}
+
+	m := newQRMatrix(version)
+	m.drawFunctionPatterns(version, level)
+	m.drawCodewords(qrAddECC(codewords, version, level))
+
+	if mask < 0 {
+		minPenalty := -1
+		for i := 0; i < 8; i++ {
+			m.applyMask(i)
+			m.drawFormatBits(level, i)
+			penalty := m.penalty()
+			if minPenalty < 0 || penalty < minPenalty {
+				mask = i
+				minPenalty = penalty
+			}
+			m.applyMask(i) // Undo the mask.
+		}
+	}
+	m.applyMask(mask)
+	m.drawFormatBits(level, mask)
+
+	return m.modules, nil
+}
+
+// qrCharCountBits returns the length of the character count indicator.
+func qrCharCountBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+	return 16
+}
+
+// qrDataBits returns the number of bits required to encode data.
+func qrDataBits(data []byte, version int) int {
+	if len(data) >= 1<<uint(qrCharCountBits(version)) {
+		return 1 << 30
+	}
+	return 4 + qrCharCountBits(version) + len(data)*8
+}
+
+// qrRawDataModules returns the number of modules available for data and error
+// correction codewords.
+func qrRawDataModules(version int) int {
+	result := (16*version+128)*version + 64
+	if version >= 2 {
+		numAlign := version/7 + 2
+		result -= (25*numAlign-10)*numAlign - 55
+		if version >= 7 {
+			result -= 36
+		}
+	}
+	return result
+}
+
+// qrDataCodewords returns the number of data codewords available.
+func qrDataCodewords(version int, level QRErrorCorrectionLevel) int {
+	return qrRawDataModules(version)/8 - qrECCCodewordsPerBlock[level][version]*qrECCBlocks[level][version]
+}
+
+// qrAlignmentPositions returns the positions of the alignment patterns along
+// each axis.
+func qrAlignmentPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+	numAlign := version/7 + 2
+	step := (version*8 + numAlign*3 + 5) / (numAlign*4 - 4) * 2
+	result := make([]int, numAlign)
+	result[0] = 6
+	for i, pos := numAlign-1, version*4+10; i > 0; i, pos = i-1, pos-step {
+		result[i] = pos
+	}
+	return result
+}
+
+// qrAddECC splits data into blocks, appends error correction codewords to
+// each block and interleaves the result.
+func qrAddECC(data []byte, version int, level QRErrorCorrectionLevel) []byte {
+	numBlocks := qrECCBlocks[level][version]
+	eccLen := qrECCCodewordsPerBlock[level][version]
+	rawCodewords := qrRawDataModules(version) / 8
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLen := rawCodewords / numBlocks
+
+	divisor := qrReedSolomonDivisor(eccLen)
+	blocks := make([][]byte, numBlocks)
+	for i, k := 0, 0; i < numBlocks; i++ {
+		dataLen := shortBlockLen - eccLen
+		if i >= numShortBlocks {
+			dataLen++
+		}
+		block := make([]byte, 0, shortBlockLen+1)
+		block = append(block, data[k:k+dataLen]...)
+		k += dataLen
+		ecc := qrReedSolomonRemainder(block, divisor)
+		if i < numShortBlocks {
+			block = append(block, 0)
+		}
+		blocks[i] = append(block, ecc...)
+	}
+
+	result := make([]byte, 0, rawCodewords)
+	for i := range blocks[0] {
+		for j, block := range blocks {
+			// Skip the padding byte in short blocks.
+			if i != shortBlockLen-eccLen || j >= numShortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+	return result
+}
+
+// qrMultiply multiplies two elements of GF(2^8).
+func qrMultiply(x, y byte) byte {
+	var z int
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>uint(i))&1) * int(x)
+	}
+	return byte(z)
+}
+
+// qrReedSolomonDivisor returns the generator polynomial of the given degree.
+func qrReedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = qrMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = qrMultiply(root, 0x02)
+	}
+	return result
+}
+
+// qrReedSolomonRemainder returns the error correction codewords of data.
+func qrReedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, d := range divisor {
+			result[i] ^= qrMultiply(d, factor)
+		}
+	}
+	return result
+}
+
+func newQRMatrix(version int) *qrMatrix {
+	size := version*4 + 17
+	m := &qrMatrix{
+		size:       size,
+		modules:    make([][]bool, size),
+		isFunction: make([][]bool, size),
+	}
+	for i := 0; i < size; i++ {
+		m.modules[i] = make([]bool, size)
+		m.isFunction[i] = make([]bool, size)
+	}
+	return m
+}
+
+func (m *qrMatrix) setFunction(x, y int, dark bool) {
+	m.modules[y][x] = dark
+	m.isFunction[y][x] = true
+}
+
+// drawFunctionPatterns draws the finder, alignment and timing patterns as
+// well as reserving space for the format and version information.
+func (m *qrMatrix) drawFunctionPatterns(version int, level QRErrorCorrectionLevel) {
+	// Timing patterns.
+	for i := 0; i < m.size; i++ {
+		m.setFunction(6, i, i%2 == 0)
+		m.setFunction(i, 6, i%2 == 0)
+	}
+
+	// Finder patterns and separators.
+	for _, center := range [][2]int{{3, 3}, {m.size - 4, 3}, {3, m.size - 4}} {
+		for dy := -4; dy <= 4; dy++ {
+			for dx := -4; dx <= 4; dx++ {
+				x, y := center[0]+dx, center[1]+dy
+				if x < 0 || y < 0 || x >= m.size || y >= m.size {
+					continue
+				}
+				dist := qrMax(qrAbs(dx), qrAbs(dy))
+				m.setFunction(x, y, dist != 2 && dist != 4)
+			}
+		}
+	}
+
+	// Alignment patterns, skipping those overlapping finder patterns.
+	positions := qrAlignmentPositions(version)
+	last := len(positions) - 1
+	for i, cy := range positions {
+		for j, cx := range positions {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					m.setFunction(cx+dx, cy+dy, qrMax(qrAbs(dx), qrAbs(dy)) != 1)
+				}
+			}
+		}
+	}
+
+	// Reserve format information, which is drawn after choosing a mask.
+	m.drawFormatBits(level, 0)
+
+	// Version information.
+	if version >= 7 {
+		rem := version
+		for i := 0; i < 12; i++ {
+			rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+		}
+		bits := version<<12 | rem
+		for i := 0; i < 18; i++ {
+			dark := (bits>>uint(i))&1 != 0
+			a, b := m.size-11+i%3, i/3
+			m.setFunction(a, b, dark)
+			m.setFunction(b, a, dark)
+		}
+	}
+}
+
+// drawFormatBits draws both copies of the format information.
+func (m *qrMatrix) drawFormatBits(level QRErrorCorrectionLevel, mask int) {
+	data := qrFormatBits[level]<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+	bit := func(i int) bool {
+		return (bits>>uint(i))&1 != 0
+	}
+
+	// First copy, around the top left finder pattern.
+	for i := 0; i <= 5; i++ {
+		m.setFunction(8, i, bit(i))
+	}
+	m.setFunction(8, 7, bit(6))
+	m.setFunction(8, 8, bit(7))
+	m.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		m.setFunction(14-i, 8, bit(i))
+	}
+
+	// Second copy, split between the other finder patterns.
+	for i := 0; i < 8; i++ {
+		m.setFunction(m.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		m.setFunction(8, m.size-15+i, bit(i))
+	}
+	m.setFunction(8, m.size-8, true) // Always dark.
+}
+
+// drawCodewords draws the data and error correction codewords in a zigzag
+// pattern, starting at the bottom right corner.
+func (m *qrMatrix) drawCodewords(data []byte) {
+	i := 0
+	for right := m.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // Skip the vertical timing pattern.
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < m.size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if upward {
+					y = m.size - 1 - vert
+				}
+				if m.isFunction[y][x] || i >= len(data)*8 {
+					continue
+				}
+				m.modules[y][x] = (data[i>>3]>>uint(7-i&7))&1 != 0
+				i++
+			}
+		}
+	}
+}
+
+// applyMask inverts the data modules selected by the mask. Applying the same
+// mask twice undoes it.
+func (m *qrMatrix) applyMask(mask int) {
+	for y := 0; y < m.size; y++ {
+		for x := 0; x < m.size; x++ {
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert && !m.isFunction[y][x] {
+				m.modules[y][x] = !m.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty returns the penalty score of the current modules. Lower scores
+// indicate codes which are easier to scan.
+func (m *qrMatrix) penalty() int {
+	var result int
+	at := func(x, y int, transpose bool) bool {
+		if transpose {
+			return m.modules[x][y]
+		}
+		return m.modules[y][x]
+	}
+
+	finderLike := []bool{true, false, true, true, true, false, true, false, false, false, false}
+	for _, transpose := range []bool{false, true} {
+		for y := 0; y < m.size; y++ {
+			// Runs of five or more modules of the same color.
+			run := 1
+			for x := 1; x < m.size; x++ {
+				if at(x, y, transpose) == at(x-1, y, transpose) {
+					run++
+					if run == 5 {
+						result += 3
+					} else if run > 5 {
+						result++
+					}
+				} else {
+					run = 1
+				}
+			}
+
+			// Patterns resembling finder patterns.
+			for x := 0; x+len(finderLike) <= m.size; x++ {
+				forward, backward := true, true
+				for i, dark := range finderLike {
+					if at(x+i, y, transpose) != dark {
+						forward = false
+					}
+					if at(x+len(finderLike)-1-i, y, transpose) != dark {
+						backward = false
+					}
+				}
+				if forward {
+					result += 40
+				}
+				if backward {
+					result += 40
+				}
+			}
+		}
+	}
+
+	// Blocks of 2x2 modules of the same color.
+	var dark int
+	for y := 0; y < m.size; y++ {
+		for x := 0; x < m.size; x++ {
+			if m.modules[y][x] {
+				dark++
+			}
+			if x > 0 && y > 0 {
+				c := m.modules[y][x]
+				if c == m.modules[y-1][x] && c == m.modules[y][x-1] && c == m.modules[y-1][x-1] {
+					result += 3
+				}
+			}
+		}
+	}
+
+	// Balance of dark and light modules.
+	total := m.size * m.size
+	k := (qrAbs(dark*20-total*10)+total-1)/total - 1
+	if k > 0 {
+		result += k * 10
+	}
+	return result
+}
+
+func qrAbs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func qrMax(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,74 @@
+package cview
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestQRCode(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	q := NewQRCode()
+	if q.GetSize() != 0 {
+		t.Errorf("failed to initialize QRCode: expected size 0, got %d", q.GetSize())
+	}
+
+	// Set text
+
+	err := q.SetText("https://code.rocketnine.space/tslocum/cview")
+	if err != nil {
+		t.Errorf("failed to update QRCode: %s", err)
+	} else if q.GetSize() != 33 {
+		t.Errorf("failed to update QRCode: expected size 33, got %d", q.GetSize())
+	}
+
+	// Set error correction level
+
+	err = q.SetErrorCorrectionLevel(QRErrorCorrectionHigh)
+	if err != nil {
+		t.Errorf("failed to update QRCode: %s", err)
+	} else if q.GetSize() != 37 {
+		t.Errorf("failed to update QRCode: expected size 37, got %d", q.GetSize())
+	}
+
+	// Set text which is too long
+
+	err = q.SetText(strings.Repeat("A", 1274))
+	if err != ErrQRCodeTooLong {
+		t.Errorf("failed to update QRCode: expected ErrQRCodeTooLong, got %v", err)
+	} else if q.GetText() != "https://code.rocketnine.space/tslocum/cview" {
+		t.Errorf("failed to update QRCode: expected previous text to be retained, got %s", q.GetText())
+	}
+
+	// Draw
+
+	app, err := newTestApp(q)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	q.Draw(app.screen)
+}
+
+func TestQRCapacity(t *testing.T) {
+	t.Parallel()
+
+	// Maximum number of bytes per version and error correction level.
+	capacities := map[int][4]int{
+		1:  {17, 14, 11, 7},
+		10: {271, 213, 151, 119},
+		40: {2953, 2331, 1663, 1273},
+	}
+	for version, expected := range capacities {
+		for level, capacity := range expected {
+			l := QRErrorCorrectionLevel(level)
+			if qrDataBits(make([]byte, capacity), version) > qrDataCodewords(version, l)*8 {
+				t.Errorf("failed to encode %d bytes in version %d at level %d", capacity, version, level)
+			} else if qrDataBits(make([]byte, capacity+1), version) <= qrDataCodewords(version, l)*8 {
+				t.Errorf("unexpectedly encoded %d bytes in version %d at level %d", capacity+1, version, level)
+			}
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package cview
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// EnableResizeMode sets whether the resize mode may be used. It is disabled by
+// default, so that Keys.ResizeMode (Alt+r by default) and the arrow keys are
+// passed to the focused primitive. See SetResizeMode.
+func (a *Application) EnableResizeMode(enable bool) {
+	a.Lock()
+	a.resizeModeEnabled = enable
+	a.Unlock()
+
+	if !enable {
+		a.SetResizeMode(false)
+	}
+}
+
+// SetResizeMode enables or disables the resize mode of the application. It has
+// no effect unless the resize mode was enabled via EnableResizeMode. While
+// the resize mode is enabled, the arrow keys resize the focused pane within
+// its enclosing Flex or Grid instead of being passed to the focused primitive,
+// and Enter or Escape disables the resize mode. Resized panes are given a
+// fixed size.
+//
+// The resize mode may also be enabled via Keys.ResizeMode.
+func (a *Application) SetResizeMode(resize bool) {
+	a.Lock()
+	if resize && !a.resizeModeEnabled {
+		a.Unlock()
+		return
+	}
+	a.resizeMode = resize
+	a.Unlock()
+
+	a.queueIndicatorUpdate()
+}
+
+// GetResizeMode returns whether the resize mode of the application is enabled.
+func (a *Application) GetResizeMode() bool {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.resizeMode
+}
+
+// handleResizeMode handles a key event while the resize mode is enabled, or
+// enables the resize mode. Returns whether the event was handled.
+func (a *Application) handleResizeMode(event *tcell.EventKey, root, focused Primitive) bool {
+	a.RLock()
+	enabled := a.resizeModeEnabled
+	a.RUnlock()
+
+	if !enabled {
+		return false
+	} else if !a.GetResizeMode() {
+		if !HitShortcut(event, Keys.ResizeMode) || panePath(root, focused) == nil {
+			return false
+		}
+		a.SetResizeMode(true)
+		return true
+	}
+
+	switch {
+	case HitShortcut(event, Keys.Cancel, Keys.Select, Keys.ResizeMode):
+		a.SetResizeMode(false)
+	case HitShortcut(event, Keys.MoveLeft, Keys.MoveLeft2):
+		resizePane(root, focused, true, -1)
+	case HitShortcut(event, Keys.MoveRight, Keys.MoveRight2):
+		resizePane(root, focused, true, 1)
+	case HitShortcut(event, Keys.MoveUp, Keys.MoveUp2):
+		resizePane(root, focused, false, -1)
+	case HitShortcut(event, Keys.MoveDown, Keys.MoveDown2):
+		resizePane(root, focused, false, 1)
+	}
+	return true // Don't pass keys to the focused primitive while resizing.
+}
+
+// panePath returns the primitives from the root to the focused primitive, or
+// nil if the focused primitive is not contained in a Flex or Grid.
+func panePath(root, focused Primitive) []Primitive {
+	path := findPath(root, focused)
+	for i := 0; i < len(path)-1; i++ {
+		switch path[i].(type) {
+		case *Flex, *Grid:
+			return path
+		}
+	}
+	return nil
+}
+
+// findPath returns the primitives from the provided primitive to the target
+// primitive, or nil if the target is not contained in the primitive.
+func findPath(p, target Primitive) []Primitive {
+	if p == nil || target == nil {
+		return nil
+	}
+	if p == target {
+		return []Primitive{p}
+	}
+	if c, ok := p.(containerPrimitive); ok {
+		for _, child := range c.children() {
+			if path := findPath(child, target); path != nil {
+				return append([]Primitive{p}, path...)
+			}
+		}
+	}
+	return nil
+}
+
+// resizePane resizes the pane containing the focused primitive within the
+// innermost Flex or Grid which may be resized in the provided direction.
+func resizePane(root, focused Primitive, horizontal bool, delta int) {
+	path := panePath(root, focused)
+	for i := len(path) - 2; i >= 0; i-- {
+		var resized bool
+		switch c := path[i].(type) {
+		case *Flex:
+			resized = c.resizePane(path[i+1], horizontal, delta)
+		case *Grid:
+			resized = c.resizePane(path[i+1], horizontal, delta)
+		}
+		if resized {
+			return
+		}
+	}
+}
+
+// drawResizeModeIndicator draws an indicator while the resize mode is
+// enabled.
+func (a *Application) drawResizeModeIndicator(screen tcell.Screen) {
+	a.RLock()
+	resize := a.resizeMode
+	width := a.width
+	a.RUnlock()
+
+	if !resize {
+		return
+	}
+	a.drawIndicator(screen, width, " Resize: arrow keys  Done: Enter ")
+}
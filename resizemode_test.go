@@ -0,0 +1,88 @@
+package cview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestResizeMode(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	left, right, bottom := NewBox(), NewBox(), NewBox()
+
+	columns := NewFlex()
+	columns.AddItem(left, 0, 1, true)
+	columns.AddItem(right, 0, 1, false)
+
+	grid := NewGrid()
+	grid.SetColumns(-1, -1)
+	grid.AddItem(bottom, 0, 0, 1, 1, 0, 0, false)
+
+	rows := NewFlex()
+	rows.SetDirection(FlexRow)
+	rows.AddItem(columns, 0, 1, true)
+	rows.AddItem(grid, 0, 1, false)
+
+	app, err := newTestApp(rows)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	rows.SetRect(0, 0, 20, 10)
+	rows.Draw(app.screen)
+
+	key := func(focused Primitive, k tcell.Key, r rune, mod tcell.ModMask) bool {
+		return app.handleResizeMode(tcell.NewEventKey(k, r, mod), rows, focused)
+	}
+
+	// Disabled by default
+
+	if key(left, tcell.KeyRune, 'r', tcell.ModAlt) || app.GetResizeMode() {
+		t.Errorf("failed to pass key: expected resize mode to be disabled by default")
+	}
+	app.SetResizeMode(true)
+	if app.GetResizeMode() {
+		t.Errorf("failed to ignore resize mode: expected resize mode to be disabled by default")
+	}
+
+	// Enable
+
+	app.EnableResizeMode(true)
+	if key(left, tcell.KeyRight, 0, tcell.ModNone) {
+		t.Errorf("failed to pass key: expected false while resize mode is disabled")
+	}
+	if !key(left, tcell.KeyRune, 'r', tcell.ModAlt) || !app.GetResizeMode() {
+		t.Errorf("failed to enable resize mode")
+	}
+
+	// Resize
+
+	key(left, tcell.KeyRight, 0, tcell.ModNone)
+	key(left, tcell.KeyRight, 0, tcell.ModNone)
+	key(left, tcell.KeyDown, 0, tcell.ModNone)
+	rows.Draw(app.screen)
+
+	if _, _, width, height := left.GetRect(); width != 12 || height != 6 {
+		t.Errorf("failed to resize pane: expected 12x6, got %dx%d", width, height)
+	}
+
+	key(bottom, tcell.KeyLeft, 0, tcell.ModNone)
+	rows.Draw(app.screen)
+
+	if _, _, width, _ := bottom.GetRect(); width != 9 {
+		t.Errorf("failed to resize grid column: expected width 9, got %d", width)
+	}
+
+	// Disable
+
+	if !key(left, tcell.KeyEscape, 0, tcell.ModNone) || app.GetResizeMode() {
+		t.Errorf("failed to disable resize mode")
+	}
+}
@@ -0,0 +1,194 @@
+package cview
+
+import (
+	"sync"
+	"time"
+)
+
+// Schedule is a handle to a function which is called repeatedly on the main
+// goroutine of an application. Schedules are created via Application.Every.
+type Schedule struct {
+	app *Application
+
+	// The function to call.
+	f func()
+
+	// Whether the schedule is paused via Pause.
+	paused bool
+
+	// Whether the schedule is paused while a Modal has focus.
+	pauseOnModal bool
+
+	// Whether a call is queued but has not yet been executed.
+	pending bool
+
+	// Whether the schedule is stopped.
+	stopped bool
+
+	// Closed to stop the update goroutine, and closed by the update goroutine
+	// when it exits.
+	stop, done chan struct{}
+
+	sync.RWMutex
+}
+
+// Every calls the provided function repeatedly at the given interval until
+// the returned Schedule is stopped or the application exits. The function is
+// called on the main goroutine of the application, like functions queued via
+// QueueUpdate, and the screen is redrawn after each call. This is typically
+// used to refresh the data shown by the application:
+//
+//   app.Every(5*time.Second, func() {
+//       table.SetCell(0, 0, cview.NewTableCell(fetchStatus()))
+//   })
+//
+// Calls are skipped while the application is suspended and while a previous
+// call has not yet been executed.
+func (a *Application) Every(interval time.Duration, f func()) *Schedule {
+	s := &Schedule{
+		app:  a,
+		f:    f,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+
+	a.Lock()
+	a.schedules = append(a.schedules, s)
+	a.Unlock()
+
+	go s.run(interval)
+	return s
+}
+
+// run queues calls until the schedule is stopped. Queueing a call is
+// abandoned when the schedule is stopped while the update queue is full, for
+// example because the application exited.
+func (s *Schedule) run(interval time.Duration) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			if s.skip() {
+				continue
+			}
+			select {
+			case s.app.updates <- func() {
+				s.execute()
+				s.app.draw()
+			}:
+			case <-s.stop:
+				return
+			}
+		}
+	}
+}
+
+// skip returns whether the next call should be skipped. Otherwise, the call
+// is marked as pending.
+func (s *Schedule) skip() bool {
+	s.app.RLock()
+	suspended := s.app.suspended
+	modal := s.app.modal
+	s.app.RUnlock()
+
+	s.Lock()
+	defer s.Unlock()
+
+	if s.stopped || s.paused || s.pending || suspended {
+		return true
+	}
+	if s.pauseOnModal && modal != nil && modal.GetVisible() && modal.HasFocus() {
+		return true
+	}
+
+	s.pending = true
+	return false
+}
+
+// execute calls the scheduled function.
+func (s *Schedule) execute() {
+	s.Lock()
+	s.pending = false
+	if s.stopped {
+		s.Unlock()
+		return
+	}
+	s.Unlock()
+
+	s.f()
+}
+
+// Pause temporarily stops calling the scheduled function.
+func (s *Schedule) Pause() {
+	s.Lock()
+	defer s.Unlock()
+
+	s.paused = true
+}
+
+// Resume resumes calling the scheduled function after it was paused.
+func (s *Schedule) Resume() {
+	s.Lock()
+	defer s.Unlock()
+
+	s.paused = false
+}
+
+// IsPaused returns whether the schedule is paused.
+func (s *Schedule) IsPaused() bool {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.paused
+}
+
+// SetPauseOnModal sets a flag which determines whether calls are skipped
+// while a Modal has focus.
+func (s *Schedule) SetPauseOnModal(pause bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.pauseOnModal = pause
+}
+
+// Stop stops calling the scheduled function. A stopped schedule may not be
+// resumed.
+func (s *Schedule) Stop() {
+	s.Lock()
+	if s.stopped {
+		s.Unlock()
+		return
+	}
+	s.stopped = true
+	close(s.stop)
+	s.Unlock()
+
+	a := s.app
+	a.Lock()
+	defer a.Unlock()
+
+	for i, schedule := range a.schedules {
+		if schedule == s {
+			a.schedules = append(a.schedules[:i], a.schedules[i+1:]...)
+			break
+		}
+	}
+}
+
+// stopSchedules stops all schedules of the application.
+func (a *Application) stopSchedules() {
+	a.RLock()
+	schedules := make([]*Schedule, len(a.schedules))
+	copy(schedules, a.schedules)
+	a.RUnlock()
+
+	for _, s := range schedules {
+		s.Stop()
+	}
+}
@@ -0,0 +1,210 @@
+package cview
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSchedule(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	app, err := newTestApp(NewBox())
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+
+	// Run the draw queued by SetRoot.
+	for len(app.updates) > 0 {
+		(<-app.updates)()
+	}
+
+	var calls []int
+	s := app.Every(10*time.Millisecond, func() {
+		calls = append(calls, len(calls))
+	})
+
+	next := func() func() {
+		select {
+		case update := <-app.updates:
+			return update
+		case <-time.After(time.Second):
+			t.Fatal("failed to schedule call: expected queued update")
+		}
+		return nil
+	}
+	expectNone := func(reason string) {
+		time.Sleep(50 * time.Millisecond)
+		select {
+		case <-app.updates:
+			t.Errorf("failed to skip call: expected no update %s", reason)
+		default:
+		}
+	}
+
+	// Call in order
+
+	for i := 0; i < 3; i++ {
+		next()()
+	}
+	if len(calls) != 3 || calls[0] != 0 || calls[1] != 1 || calls[2] != 2 {
+		t.Errorf("failed to call scheduled function: expected [0 1 2], got %v", calls)
+	}
+
+	// Skip while pending
+
+	update := next()
+	expectNone("while a call is pending")
+	update()
+	if len(calls) != 4 {
+		t.Errorf("failed to call scheduled function: expected 4 calls, got %d", len(calls))
+	}
+
+	// Pause
+
+	s.Pause()
+	if !s.IsPaused() {
+		t.Errorf("failed to pause schedule: expected paused")
+	}
+	select {
+	case update := <-app.updates:
+		// Queued before the schedule was paused.
+		update()
+	case <-time.After(50 * time.Millisecond):
+	}
+	expectNone("while paused")
+
+	// Resume
+
+	s.Resume()
+	if s.IsPaused() {
+		t.Errorf("failed to resume schedule: expected not paused")
+	}
+	count := len(calls)
+	next()()
+	if len(calls) != count+1 {
+		t.Errorf("failed to resume schedule: expected %d calls, got %d", count+1, len(calls))
+	}
+
+	// Stop while pending
+
+	update = next()
+	count = len(calls)
+	s.Stop()
+	update()
+	if len(calls) != count {
+		t.Errorf("failed to stop schedule: expected %d calls, got %d", count, len(calls))
+	}
+	expectNone("after stopping")
+
+	app.RLock()
+	schedules := len(app.schedules)
+	app.RUnlock()
+	if schedules != 0 {
+		t.Errorf("failed to stop schedule: expected no schedules, got %d", schedules)
+	}
+
+	// Stop twice
+
+	s.Stop()
+}
+
+func TestScheduleStopSchedules(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	app, err := newTestApp(NewBox())
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+
+	// Run the draw queued by SetRoot.
+	for len(app.updates) > 0 {
+		(<-app.updates)()
+	}
+
+	var calls int
+	a := app.Every(10*time.Millisecond, func() {
+		calls++
+	})
+	b := app.Every(10*time.Millisecond, func() {
+		calls++
+	})
+
+	// Stop all
+
+	app.stopSchedules()
+	for i, s := range []*Schedule{a, b} {
+		s.RLock()
+		stopped := s.stopped
+		s.RUnlock()
+		if !stopped {
+			t.Errorf("failed to stop schedule %d: expected stopped", i)
+		}
+	}
+
+	// Calls queued before the schedules were stopped are skipped.
+	timeout := time.After(50 * time.Millisecond)
+	for done := false; !done; {
+		select {
+		case update := <-app.updates:
+			update()
+		case <-timeout:
+			done = true
+		}
+	}
+	if calls != 0 {
+		t.Errorf("failed to stop schedules: expected no calls, got %d", calls)
+	}
+}
+
+func TestScheduleStopFullQueue(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	app, err := newTestApp(NewBox())
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+
+	// Fill the update queue, as if the application exited.
+	for len(app.updates) < cap(app.updates) {
+		app.updates <- func() {}
+	}
+
+	s := app.Every(time.Millisecond, func() {})
+
+	// Wait until a call is being queued.
+	for {
+		s.RLock()
+		pending := s.pending
+		s.RUnlock()
+		if pending {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Stop
+
+	s.Stop()
+	select {
+	case <-s.done:
+	case <-time.After(time.Second):
+		t.Fatal("failed to stop schedule: expected goroutine to exit while the update queue is full")
+	}
+}
@@ -0,0 +1,298 @@
+package cview
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"sync"
+)
+
+const (
+	// The number of lines read from the file of a Scrollback at once when
+	// lines which were spilled to disk are requested.
+	scrollbackPageLines = 256
+
+	// The maximum number of pages of lines read from disk which are kept in
+	// memory.
+	scrollbackPages = 16
+)
+
+// Scrollback is a LineProvider which stores the lines written to it, keeping
+// the most recent lines in memory and spilling older lines to a temporary file
+// once the lines kept in memory exceed a threshold. Lines spilled to disk are
+// paged back in transparently when they are scrolled into view. This bounds
+// the memory used by long-running sessions which produce large amounts of
+// output, such as logs:
+//
+//   scrollback := cview.NewScrollback(1<<20, "")
+//   defer scrollback.Close()
+//
+//   textView.SetLineProvider(scrollback)
+//   scrollback.SetChangedFunc(func() {
+//     app.QueueUpdateDraw(func() {})
+//   })
+//
+//   fmt.Fprintln(scrollback, "Hello, world!")
+//
+// Text is split into lines like text written to a TextView. Only the offsets
+// of spilled lines are kept in memory, which take up 8 bytes per line. Close
+// removes the file.
+type Scrollback struct {
+	// The maximum number of bytes of the lines kept in memory.
+	threshold int
+
+	// The directory the file is created in.
+	dir string
+
+	// The file spilled lines are written to, created when the first line is
+	// spilled.
+	file *os.File
+
+	// The offsets of the spilled lines within the file, followed by the
+	// offset of the end of the last spilled line.
+	offsets []int64
+
+	// The lines kept in memory, following the spilled lines.
+	lines [][]byte
+
+	// The number of bytes of the lines kept in memory.
+	size int
+
+	// The last line, which has not been terminated by a newline yet.
+	partial []byte
+
+	// Pages of spilled lines read from the file, by page number, and the
+	// page numbers in the order they were read.
+	pages     map[int][][]byte
+	pageOrder []int
+
+	// An optional function which is called after text is written.
+	changed func()
+
+	sync.RWMutex
+}
+
+// NewScrollback returns a new Scrollback which keeps up to threshold bytes of
+// lines in memory and spills older lines to a temporary file created in the
+// provided directory. If dir is the empty string, the default directory for
+// temporary files is used (see os.TempDir).
+func NewScrollback(threshold int, dir string) *Scrollback {
+	return &Scrollback{
+		threshold: threshold,
+		dir:       dir,
+		offsets:   []int64{0},
+		pages:     make(map[int][][]byte),
+	}
+}
+
+// SetChangedFunc sets a handler which is called after text is written to the
+// scrollback. It is called from the goroutine which wrote the text, so it
+// should queue drawing the screen via Application.QueueUpdateDraw.
+func (s *Scrollback) SetChangedFunc(handler func()) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.changed = handler
+}
+
+// Write writes text to the scrollback. A "\n" or "\r\n" is interpreted as a
+// new line. Returns an error if spilling lines to disk fails, in which case
+// the lines are kept in memory.
+func (s *Scrollback) Write(p []byte) (n int, err error) {
+	s.Lock()
+	changed := s.changed
+	if changed != nil {
+		// Notify at the end.
+		defer changed()
+	}
+	defer s.Unlock()
+
+	text := p
+	for {
+		i := bytes.IndexByte(text, '\n')
+		if i < 0 {
+			s.partial = append(s.partial, text...)
+			break
+		}
+		line := append(s.partial, text[:i]...)
+		s.partial = nil
+		if len(line) > 0 && line[len(line)-1] == '\r' {
+			line = line[:len(line)-1]
+		}
+		s.lines = append(s.lines, line)
+		s.size += len(line)
+		text = text[i+1:]
+	}
+
+	if err := s.spill(); err != nil {
+		return len(p), err
+	}
+	return len(p), nil
+}
+
+// spill writes the oldest lines kept in memory to the file until the lines
+// kept in memory no longer exceed the threshold.
+func (s *Scrollback) spill() error {
+	if s.size <= s.threshold {
+		return nil
+	}
+
+	if s.file == nil {
+		file, err := ioutil.TempFile(s.dir, "cview-scrollback-")
+		if err != nil {
+			return err
+		}
+		s.file = file
+	}
+
+	var (
+		buf    bytes.Buffer
+		spill  int
+		start  = s.offsets[len(s.offsets)-1]
+		offset = start
+	)
+	offsets := s.offsets[:len(s.offsets)-1]
+	size := s.size
+	for spill < len(s.lines) && size > s.threshold {
+		line := s.lines[spill]
+		offsets = append(offsets, offset)
+		buf.Write(line)
+		buf.WriteByte('\n')
+		offset += int64(len(line) + 1)
+		size -= len(line)
+		spill++
+	}
+
+	if _, err := s.file.WriteAt(buf.Bytes(), start); err != nil {
+		return err
+	}
+	s.offsets = append(offsets, offset)
+	for i := range s.lines[:spill] {
+		s.lines[i] = nil
+	}
+	s.lines = s.lines[spill:]
+	s.size = size
+	return nil
+}
+
+// LineCount returns the number of lines.
+func (s *Scrollback) LineCount() int {
+	s.RLock()
+	defer s.RUnlock()
+
+	count := len(s.offsets) - 1 + len(s.lines)
+	if len(s.partial) > 0 {
+		count++
+	}
+	return count
+}
+
+// GetSpilledLineCount returns the number of lines which were spilled to disk.
+func (s *Scrollback) GetSpilledLineCount() int {
+	s.RLock()
+	defer s.RUnlock()
+
+	return len(s.offsets) - 1
+}
+
+// Line returns the line with the given index, starting at 0. Lines which were
+// spilled to disk are read from the file. Returns nil if the index is out of
+// range or the line may not be read.
+func (s *Scrollback) Line(n int) []byte {
+	s.Lock()
+	defer s.Unlock()
+
+	spilled := len(s.offsets) - 1
+	switch {
+	case n < 0:
+		return nil
+	case n < spilled:
+		page := s.page(n / scrollbackPageLines)
+		if i := n % scrollbackPageLines; i < len(page) {
+			return page[i]
+		}
+		return nil
+	case n < spilled+len(s.lines):
+		return s.lines[n-spilled]
+	case n == spilled+len(s.lines) && len(s.partial) > 0:
+		return s.partial
+	}
+	return nil
+}
+
+// page returns the spilled lines of the page with the provided number, reading
+// them from the file if necessary.
+func (s *Scrollback) page(number int) [][]byte {
+	if page, ok := s.pages[number]; ok {
+		return page
+	}
+
+	first := number * scrollbackPageLines
+	last := first + scrollbackPageLines
+	if last > len(s.offsets)-1 {
+		last = len(s.offsets) - 1
+	}
+	buf := make([]byte, s.offsets[last]-s.offsets[first])
+	if _, err := s.file.ReadAt(buf, s.offsets[first]); err != nil {
+		return nil
+	}
+
+	page := make([][]byte, 0, last-first)
+	for n := first; n < last; n++ {
+		start, end := s.offsets[n]-s.offsets[first], s.offsets[n+1]-s.offsets[first]-1
+		page = append(page, buf[start:end:end])
+	}
+
+	// Only keep complete pages, as incomplete pages grow as lines are spilled.
+	if last-first == scrollbackPageLines {
+		if len(s.pageOrder) >= scrollbackPages {
+			delete(s.pages, s.pageOrder[0])
+			s.pageOrder = s.pageOrder[1:]
+		}
+		s.pages[number] = page
+		s.pageOrder = append(s.pageOrder, number)
+	}
+	return page
+}
+
+// Clear removes all lines from the scrollback.
+func (s *Scrollback) Clear() error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.clear()
+	if s.file != nil {
+		return s.file.Truncate(0)
+	}
+	return nil
+}
+
+// clear removes all lines from the scrollback without modifying its file.
+func (s *Scrollback) clear() {
+	s.offsets = []int64{0}
+	s.lines = nil
+	s.size = 0
+	s.partial = nil
+	s.pages = make(map[int][][]byte)
+	s.pageOrder = nil
+}
+
+// Close removes all lines from the scrollback and removes its file. The
+// scrollback may still be written to afterwards.
+func (s *Scrollback) Close() error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.clear()
+	if s.file == nil {
+		return nil
+	}
+
+	file := s.file
+	s.file = nil
+	err := file.Close()
+	if removeErr := os.Remove(file.Name()); err == nil {
+		err = removeErr
+	}
+	return err
+}
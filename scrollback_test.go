@@ -0,0 +1,87 @@
+package cview
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestScrollback(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	s := NewScrollback(1024, "")
+	defer s.Close()
+
+	const lines = 1000
+	for i := 0; i < lines; i++ {
+		fmt.Fprintf(s, "Line %d\r\n", i)
+	}
+	fmt.Fprint(s, "Partial")
+
+	if count := s.LineCount(); count != lines+1 {
+		t.Errorf("failed to write lines: expected %d lines, got %d", lines+1, count)
+	}
+	spilled := s.GetSpilledLineCount()
+	if spilled == 0 || spilled >= lines {
+		t.Errorf("failed to spill lines: expected between 1 and %d spilled lines, got %d", lines-1, spilled)
+	}
+	if s.size > 1024 {
+		t.Errorf("failed to spill lines: expected at most 1024 bytes in memory, got %d", s.size)
+	}
+
+	// Read lines
+
+	for _, n := range []int{0, 1, 255, 256, spilled - 1, spilled, lines - 1, 500, 3} {
+		if line, expected := string(s.Line(n)), fmt.Sprintf("Line %d", n); line != expected {
+			t.Errorf("failed to read line %d: expected %q, got %q", n, expected, line)
+		}
+	}
+	if line := string(s.Line(lines)); line != "Partial" {
+		t.Errorf("failed to read partial line: expected %q, got %q", "Partial", line)
+	}
+	if line := s.Line(lines + 1); line != nil {
+		t.Errorf("failed to read line out of range: expected nil, got %q", line)
+	}
+
+	// Display in TextView
+
+	tv := NewTextView()
+	tv.SetLineProvider(s)
+	tv.SetRect(0, 0, 20, 3)
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	tv.ScrollTo(10, 0)
+	tv.Draw(app.screen)
+
+	var row []rune
+	for x := 0; x < 7; x++ {
+		r, _, _, _ := app.screen.GetContent(x, 0)
+		row = append(row, r)
+	}
+	if string(row) != "Line 10" {
+		t.Errorf("failed to display spilled line: expected %q, got %q", "Line 10", string(row))
+	}
+
+	// Close
+
+	name := s.file.Name()
+	if err := s.Close(); err != nil {
+		t.Errorf("failed to close scrollback: %s", err)
+	}
+	if _, err := os.Stat(name); !os.IsNotExist(err) {
+		t.Errorf("failed to remove scrollback file: %v", err)
+	}
+	if count := s.LineCount(); count != 0 {
+		t.Errorf("failed to close scrollback: expected 0 lines, got %d", count)
+	}
+}
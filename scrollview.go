@@ -0,0 +1,430 @@
+package cview
+
+import (
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// clippedScreen wraps a screen and discards any content drawn outside of a
+// rectangle.
+type clippedScreen struct {
+	tcell.Screen
+
+	x, y, width, height int
+}
+
+func (s *clippedScreen) contains(x, y int) bool {
+	return x >= s.x && x < s.x+s.width && y >= s.y && y < s.y+s.height
+}
+
+// SetContent sets the contents of the given cell when it is within the
+// clipping rectangle.
+func (s *clippedScreen) SetContent(x int, y int, mainc rune, combc []rune, style tcell.Style) {
+	if s.contains(x, y) {
+		s.Screen.SetContent(x, y, mainc, combc, style)
+	}
+}
+
+// SetCell sets the contents of the given cell when it is within the clipping
+// rectangle.
+func (s *clippedScreen) SetCell(x int, y int, style tcell.Style, ch ...rune) {
+	if s.contains(x, y) {
+		s.Screen.SetCell(x, y, style, ch...)
+	}
+}
+
+// ShowCursor shows the cursor when it is within the clipping rectangle.
+func (s *clippedScreen) ShowCursor(x int, y int) {
+	if s.contains(x, y) {
+		s.Screen.ShowCursor(x, y)
+	} else {
+		s.Screen.HideCursor()
+	}
+}
+
+// ScrollView is a container which allows scrolling a primitive larger than
+// the available space. The contained primitive is given a fixed size and the
+// visible portion of it is determined by the scroll offset.
+//
+// Scrolling is controlled via the mouse wheel. When the scroll view receives
+// focus, the focus is passed on to the contained primitive, which then
+// receives key events directly. Key events passed to the scroll view itself,
+// for example when it contains no primitive, scroll via the arrow keys,
+// page up/down and home/end, and all other keys are passed to the contained
+// primitive.
+type ScrollView struct {
+	*Box
+
+	// The contained primitive.
+	primitive Primitive
+
+	// The size of the contained primitive. A value of 0 means use the size of
+	// the viewport, i.e. no scrolling in that direction.
+	contentWidth, contentHeight int
+
+	// The number of rows and columns by which the content is scrolled.
+	rowOffset, columnOffset int
+
+	// The size of the viewport the last time the view was drawn.
+	viewportWidth, viewportHeight int
+
+	// Visibility of the scroll bars.
+	scrollBarVisibility ScrollBarVisibility
+
+	// The scroll bar color.
+	scrollBarColor tcell.Color
+
+	sync.RWMutex
+}
+
+// NewScrollView returns a new scroll view containing the given primitive.
+func NewScrollView(primitive Primitive) *ScrollView {
+	s := &ScrollView{
+		Box:                 NewBox(),
+		primitive:           primitive,
+		scrollBarVisibility: ScrollBarAuto,
+		scrollBarColor:      Styles.ScrollBarColor,
+	}
+	s.focus = s
+	return s
+}
+
+// SetPrimitive sets the contained primitive.
+func (s *ScrollView) SetPrimitive(primitive Primitive) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.primitive = primitive
+}
+
+// GetPrimitive returns the contained primitive.
+func (s *ScrollView) GetPrimitive() Primitive {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.primitive
+}
+
+// children returns the primitives contained in this primitive.
+func (s *ScrollView) children() []Primitive {
+	s.RLock()
+	defer s.RUnlock()
+
+	if s.primitive == nil {
+		return nil
+	}
+	return []Primitive{s.primitive}
+}
+
+// SetContentSize sets the size of the contained primitive. A width or height
+// of 0 means use the width or height of the scroll view, i.e. no scrolling
+// in that direction.
+func (s *ScrollView) SetContentSize(width, height int) {
+	s.Lock()
+	defer s.Unlock()
+
+	if width < 0 {
+		width = 0
+	}
+	if height < 0 {
+		height = 0
+	}
+	s.contentWidth, s.contentHeight = width, height
+}
+
+// GetContentSize returns the size of the contained primitive.
+func (s *ScrollView) GetContentSize() (width, height int) {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.contentWidth, s.contentHeight
+}
+
+// SetScrollBarVisibility specifies the display of the scroll bars.
+func (s *ScrollView) SetScrollBarVisibility(visibility ScrollBarVisibility) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.scrollBarVisibility = visibility
+}
+
+// SetScrollBarColor sets the color of the scroll bars.
+func (s *ScrollView) SetScrollBarColor(color tcell.Color) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.scrollBarColor = color
+}
+
+// SetOffset sets the number of rows and columns by which the content is
+// scrolled.
+func (s *ScrollView) SetOffset(row, column int) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.rowOffset, s.columnOffset = row, column
+	s.clampOffset()
+}
+
+// GetOffset returns the number of rows and columns by which the content is
+// scrolled.
+func (s *ScrollView) GetOffset() (row, column int) {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.rowOffset, s.columnOffset
+}
+
+// ScrollToBeginning scrolls to the top left corner of the content.
+func (s *ScrollView) ScrollToBeginning() {
+	s.Lock()
+	defer s.Unlock()
+
+	s.rowOffset, s.columnOffset = 0, 0
+}
+
+// ScrollToEnd scrolls to the bottom of the content.
+func (s *ScrollView) ScrollToEnd() {
+	s.Lock()
+	defer s.Unlock()
+
+	s.rowOffset = s.contentHeight
+	s.clampOffset()
+}
+
+// contentSize returns the effective size of the contained primitive.
+func (s *ScrollView) contentSize() (width, height int) {
+	width, height = s.contentWidth, s.contentHeight
+	if width == 0 {
+		width = s.viewportWidth
+	}
+	if height == 0 {
+		height = s.viewportHeight
+	}
+	return width, height
+}
+
+// clampOffset keeps the scroll offset within the content.
+func (s *ScrollView) clampOffset() {
+	width, height := s.contentSize()
+	if s.rowOffset > height-s.viewportHeight {
+		s.rowOffset = height - s.viewportHeight
+	}
+	if s.rowOffset < 0 {
+		s.rowOffset = 0
+	}
+	if s.columnOffset > width-s.viewportWidth {
+		s.columnOffset = width - s.viewportWidth
+	}
+	if s.columnOffset < 0 {
+		s.columnOffset = 0
+	}
+}
+
+// updateViewport calculates the size of the viewport, which is the inner
+// rect minus the space taken up by the scroll bars. It returns whether the
+// vertical and horizontal scroll bars are shown.
+func (s *ScrollView) updateViewport() (vertical, horizontal bool) {
+	_, _, width, height := s.GetInnerRect()
+
+	show := func(content, available int) bool {
+		return s.scrollBarVisibility == ScrollBarAlways || (s.scrollBarVisibility == ScrollBarAuto && content > available)
+	}
+	vertical = s.contentHeight > 0 && show(s.contentHeight, height)
+	if vertical {
+		width--
+	}
+	horizontal = s.contentWidth > 0 && show(s.contentWidth, width)
+	if horizontal {
+		height--
+		if !vertical && s.contentHeight > 0 && show(s.contentHeight, height) {
+			vertical = true
+			width--
+		}
+	}
+
+	if width < 0 {
+		width = 0
+	}
+	if height < 0 {
+		height = 0
+	}
+	s.viewportWidth, s.viewportHeight = width, height
+	return vertical, horizontal
+}
+
+// scrollViewCursor returns the scroll bar cursor position for the given
+// offset.
+func scrollViewCursor(offset, content, viewport int) int {
+	if content <= viewport {
+		return 0
+	}
+	return int(float64(content-1) * (float64(offset) / float64(content-viewport)))
+}
+
+// Draw draws this primitive onto the screen.
+func (s *ScrollView) Draw(screen tcell.Screen) {
+	if !s.GetVisible() {
+		return
+	}
+
+	s.Box.Draw(screen)
+
+	s.Lock()
+	defer s.Unlock()
+
+	x, y, _, _ := s.GetInnerRect()
+	vertical, horizontal := s.updateViewport()
+	s.clampOffset()
+	if s.viewportWidth <= 0 || s.viewportHeight <= 0 || s.primitive == nil {
+		return
+	}
+
+	// Draw the contained primitive, discarding anything outside the viewport.
+	width, height := s.contentSize()
+	s.primitive.SetRect(x-s.columnOffset, y-s.rowOffset, width, height)
+	s.passColors(s.primitive)
+	drawPrimitive(s.primitive, &clippedScreen{
+		Screen: screen,
+		x:      x,
+		y:      y,
+		width:  s.viewportWidth,
+		height: s.viewportHeight,
+	})
+
+	// Draw scroll bars.
+	hasFocus := s.hasFocus || s.primitive.GetFocusable().HasFocus()
+	if vertical {
+		cursor := scrollViewCursor(s.rowOffset, height, s.viewportHeight)
+		for printed := 0; printed < s.viewportHeight; printed++ {
+			RenderScrollBar(screen, ScrollBarAlways, x+s.viewportWidth, y+printed, s.viewportHeight, height, cursor, printed, hasFocus, s.scrollBarColor)
+		}
+	}
+	if horizontal {
+		cursor := scrollViewCursor(s.columnOffset, width, s.viewportWidth)
+		for printed := 0; printed < s.viewportWidth; printed++ {
+			RenderScrollBar(screen, ScrollBarAlways, x+printed, y+s.viewportHeight, s.viewportWidth, width, cursor, printed, hasFocus, s.scrollBarColor)
+		}
+	}
+}
+
+// InputHandler returns the handler for this primitive.
+func (s *ScrollView) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return s.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		s.Lock()
+
+		switch {
+		case HitShortcut(event, Keys.MoveUp):
+			s.rowOffset--
+		case HitShortcut(event, Keys.MoveDown):
+			s.rowOffset++
+		case HitShortcut(event, Keys.MoveLeft):
+			s.columnOffset--
+		case HitShortcut(event, Keys.MoveRight):
+			s.columnOffset++
+		case HitShortcut(event, Keys.MovePreviousPage):
+			s.rowOffset -= s.viewportHeight
+		case HitShortcut(event, Keys.MoveNextPage):
+			s.rowOffset += s.viewportHeight
+		case HitShortcut(event, Keys.MoveFirst):
+			s.rowOffset, s.columnOffset = 0, 0
+		case HitShortcut(event, Keys.MoveLast):
+			_, s.rowOffset = s.contentSize()
+		default:
+			// Pass other keys to the contained primitive.
+			primitive := s.primitive
+			s.Unlock()
+			if primitive != nil {
+				if handler := primitive.InputHandler(); handler != nil {
+					handler(event, setFocus)
+				}
+			}
+			return
+		}
+
+		s.clampOffset()
+		s.Unlock()
+	})
+}
+
+// Focus is called when this primitive receives focus.
+func (s *ScrollView) Focus(delegate func(p Primitive)) {
+	s.RLock()
+	primitive := s.primitive
+	s.RUnlock()
+
+	if primitive == nil {
+		s.Box.Focus(delegate)
+		return
+	}
+	delegate(primitive)
+}
+
+// HasFocus returns whether or not this primitive or its contained primitive
+// has focus.
+func (s *ScrollView) HasFocus() bool {
+	s.RLock()
+	primitive := s.primitive
+	s.RUnlock()
+
+	if primitive != nil && primitive.GetFocusable().HasFocus() {
+		return true
+	}
+	return s.Box.HasFocus()
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (s *ScrollView) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return s.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		x, y := event.Position()
+		if !s.InRect(x, y) {
+			return false, nil
+		}
+
+		s.Lock()
+
+		switch action {
+		case MouseScrollUp, MouseScrollDown, MouseScrollLeft, MouseScrollRight:
+			// Scroll horizontally when shift is held or the content is only
+			// wider than the viewport.
+			_, height := s.contentSize()
+			horizontal := event.Modifiers()&tcell.ModShift != 0 || height <= s.viewportHeight
+			switch {
+			case action == MouseScrollLeft || (horizontal && action == MouseScrollUp):
+				s.columnOffset--
+			case action == MouseScrollRight || (horizontal && action == MouseScrollDown):
+				s.columnOffset++
+			case action == MouseScrollUp:
+				s.rowOffset--
+			default:
+				s.rowOffset++
+			}
+			s.clampOffset()
+			s.Unlock()
+			return true, nil
+		}
+
+		// Pass other events to the contained primitive when they occur within
+		// the viewport.
+		rectX, rectY, _, _ := s.GetInnerRect()
+		inViewport := x >= rectX && x < rectX+s.viewportWidth && y >= rectY && y < rectY+s.viewportHeight
+		primitive := s.primitive
+		s.Unlock()
+
+		if inViewport && primitive != nil {
+			if handler := primitive.MouseHandler(); handler != nil {
+				consumed, capture = handler(action, event, setFocus)
+				if consumed {
+					return
+				}
+			}
+		}
+
+		if action == MouseLeftClick {
+			setFocus(s)
+			consumed = true
+		}
+		return
+	})
+}
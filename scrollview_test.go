@@ -0,0 +1,97 @@
+package cview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestScrollView(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tv := NewTextView()
+	s := NewScrollView(tv)
+	s.SetContentSize(200, 100)
+
+	app, err := newTestApp(s)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	s.SetRect(0, 0, 80, 24)
+	s.Draw(app.screen)
+
+	// Scroll
+
+	s.SetOffset(10, 20)
+	if row, column := s.GetOffset(); row != 10 || column != 20 {
+		t.Errorf("failed to update ScrollView: expected offset 10,20, got %d,%d", row, column)
+	}
+
+	// Scroll past the end
+
+	s.SetOffset(1000, 1000)
+	if row, column := s.GetOffset(); row != 100-23 || column != 200-79 {
+		t.Errorf("failed to update ScrollView: expected offset %d,%d, got %d,%d", 100-23, 200-79, row, column)
+	}
+
+	// Scroll via keyboard
+
+	s.ScrollToBeginning()
+	s.InputHandler()(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone), func(p Primitive) {})
+	if row, _ := s.GetOffset(); row != 1 {
+		t.Errorf("failed to update ScrollView: expected row offset 1, got %d", row)
+	}
+
+	// Draw
+
+	s.Draw(app.screen)
+	if x, y, width, height := tv.GetRect(); x != 0 || y != -1 || width != 200 || height != 100 {
+		t.Errorf("failed to draw ScrollView: unexpected content rect %d,%d %dx%d", x, y, width, height)
+	}
+}
+
+func TestScrollViewFocus(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tv := NewTextView()
+	s := NewScrollView(tv)
+	s.SetContentSize(200, 100)
+
+	app, err := newTestApp(s)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	s.SetRect(0, 0, 80, 24)
+	s.Draw(app.screen)
+
+	// Forward focus
+
+	app.SetFocus(s)
+	if p := app.GetFocus(); p != tv {
+		t.Errorf("failed to forward focus: expected contained primitive to be focused, got %T", p)
+	} else if !s.HasFocus() {
+		t.Errorf("failed to forward focus: expected scroll view to report focus")
+	}
+
+	// Keep focus without a contained primitive
+
+	s.SetPrimitive(nil)
+	app.SetFocus(s)
+	if p := app.GetFocus(); p != s {
+		t.Errorf("failed to focus scroll view: expected scroll view to be focused, got %T", p)
+	} else if !s.HasFocus() {
+		t.Errorf("failed to focus scroll view: expected scroll view to report focus")
+	}
+
+	// Click without a contained primitive
+
+	consumed, _ := s.MouseHandler()(MouseLeftClick, tcell.NewEventMouse(1, 1, tcell.Button1, tcell.ModNone), func(p Primitive) {})
+	if !consumed {
+		t.Errorf("failed to handle click: expected click to be consumed")
+	}
+}
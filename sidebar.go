@@ -0,0 +1,291 @@
+package cview
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// sideBarItem represents a single item of a SideBar.
+type sideBarItem struct {
+	name  string
+	icon  string
+	label string
+}
+
+// SideBar is a navigation container consisting of a menu of items and the
+// content of the current item. Each item has an icon and a label. When the
+// side bar is collapsed, only icons are shown.
+//
+// The menu is collapsed via the left arrow key and expanded via the right
+// arrow key. Selecting an item via the keyboard or the mouse shows its
+// content.
+type SideBar struct {
+	*Flex
+
+	// The menu of items. Its input capture function is used by the side bar,
+	// set one via SetMenuInputCapture instead.
+	Menu *List
+
+	panels *Panels
+
+	items       []*sideBarItem
+	currentItem string
+
+	// Whether labels are shown in addition to icons.
+	expanded bool
+
+	// The text shown before the current item.
+	indicator string
+
+	// The color of the indicator.
+	indicatorColor tcell.Color
+
+	// An optional function which is called when the current item changes.
+	changed func(name string)
+
+	// An optional function which is called with key events of the menu before
+	// they are handled by the side bar.
+	menuInputCapture func(event *tcell.EventKey) *tcell.EventKey
+
+	sync.RWMutex
+}
+
+// NewSideBar returns a new expanded side bar.
+func NewSideBar() *SideBar {
+	s := &SideBar{
+		Flex:           NewFlex(),
+		Menu:           NewList(),
+		panels:         NewPanels(),
+		expanded:       true,
+		indicator:      "▌",
+		indicatorColor: Styles.PrimaryTextColor,
+	}
+
+	m := s.Menu
+	m.ShowSecondaryText(false)
+	m.SetHighlightFullLine(true)
+	m.SetScrollBarVisibility(ScrollBarNever)
+	m.SetBackgroundColor(Styles.ContrastBackgroundColor)
+	m.SetSelectedFunc(func(index int, item *ListItem) {
+		if name, ok := item.GetReference().(string); ok {
+			s.SetCurrentItem(name)
+		}
+	})
+	m.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		s.RLock()
+		capture := s.menuInputCapture
+		s.RUnlock()
+
+		if capture != nil {
+			event = capture(event)
+			if event == nil {
+				return nil
+			}
+		}
+
+		if HitShortcut(event, Keys.MoveLeft) {
+			s.SetExpanded(false)
+			return nil
+		} else if HitShortcut(event, Keys.MoveRight) {
+			s.SetExpanded(true)
+			return nil
+		}
+		return event
+	})
+
+	s.Flex.SetDirection(FlexColumn)
+	s.Flex.AddItem(m, 0, 0, true)
+	s.Flex.AddItem(s.panels, 0, 1, false)
+
+	return s
+}
+
+// AddItem adds a new item. Selecting the item shows the provided primitive.
+// The first item added becomes the current item.
+func (s *SideBar) AddItem(name, icon, label string, item Primitive) {
+	s.Lock()
+
+	s.items = append(s.items, &sideBarItem{
+		name:  name,
+		icon:  icon,
+		label: label,
+	})
+	s.panels.AddPanel(name, item, true, false)
+
+	setCurrent := s.currentItem == ""
+	s.updateMenu()
+
+	s.Unlock()
+
+	if setCurrent {
+		s.SetCurrentItem(name)
+	}
+}
+
+// RemoveItem removes an item.
+func (s *SideBar) RemoveItem(name string) {
+	s.Lock()
+
+	for i, item := range s.items {
+		if item.name == name {
+			s.items = append(s.items[:i], s.items[i+1:]...)
+			break
+		}
+	}
+	s.panels.RemovePanel(name)
+
+	var newCurrent string
+	if s.currentItem == name {
+		s.currentItem = ""
+		if len(s.items) > 0 {
+			newCurrent = s.items[0].name
+		}
+	}
+	s.updateMenu()
+
+	s.Unlock()
+
+	if newCurrent != "" {
+		s.SetCurrentItem(newCurrent)
+	}
+}
+
+// SetCurrentItem sets the current item and shows its content.
+func (s *SideBar) SetCurrentItem(name string) {
+	s.Lock()
+
+	if s.currentItem == name || !s.panels.HasPanel(name) {
+		s.Unlock()
+		return
+	}
+
+	s.currentItem = name
+	s.panels.SetCurrentPanel(name)
+	s.updateMenu()
+
+	changed := s.changed
+	s.Unlock()
+
+	if changed != nil {
+		changed(name)
+	}
+}
+
+// GetCurrentItem returns the name of the current item.
+func (s *SideBar) GetCurrentItem() string {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.currentItem
+}
+
+// GetPanels returns the Panels containing the content of each item.
+func (s *SideBar) GetPanels() *Panels {
+	return s.panels
+}
+
+// SetExpanded sets whether labels are shown in addition to icons.
+func (s *SideBar) SetExpanded(expanded bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.expanded == expanded {
+		return
+	}
+
+	s.expanded = expanded
+	s.updateMenu()
+}
+
+// IsExpanded returns whether labels are shown in addition to icons.
+func (s *SideBar) IsExpanded() bool {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.expanded
+}
+
+// Toggle collapses the side bar when it is expanded and expands it when it is
+// collapsed.
+func (s *SideBar) Toggle() {
+	s.Lock()
+	defer s.Unlock()
+
+	s.expanded = !s.expanded
+	s.updateMenu()
+}
+
+// SetIndicator sets the text shown before the current item. All indicators
+// must have the same width.
+func (s *SideBar) SetIndicator(indicator string) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.indicator = indicator
+	s.updateMenu()
+}
+
+// SetIndicatorColor sets the color of the indicator shown before the current
+// item.
+func (s *SideBar) SetIndicatorColor(color tcell.Color) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.indicatorColor = color
+	s.updateMenu()
+}
+
+// SetMenuInputCapture installs a function which captures key events of the
+// menu before they are handled by the side bar, like Box.SetInputCapture. The
+// function may return the event, a different event or nil to stop further
+// processing. Use this instead of Menu.SetInputCapture, which would replace
+// the function handling the keys which collapse and expand the side bar.
+func (s *SideBar) SetMenuInputCapture(capture func(event *tcell.EventKey) *tcell.EventKey) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.menuInputCapture = capture
+}
+
+// SetChangedFunc sets a handler which is called when the current item
+// changes. The handler is provided with the name of the new current item.
+func (s *SideBar) SetChangedFunc(handler func(name string)) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.changed = handler
+}
+
+// updateMenu rebuilds the menu items and resizes the menu to fit them.
+func (s *SideBar) updateMenu() {
+	indicatorWidth := TaggedStringWidth(s.indicator)
+	blank := strings.Repeat(" ", indicatorWidth)
+
+	currentIndex := s.Menu.GetCurrentItemIndex()
+	s.Menu.Clear()
+
+	var width int
+	for _, item := range s.items {
+		prefix := blank
+		if item.name == s.currentItem {
+			prefix = "[" + ColorHex(s.indicatorColor) + "]" + s.indicator + "[-]"
+		}
+		text := item.icon
+		if s.expanded {
+			text += " " + item.label
+		}
+
+		listItem := NewListItem(prefix + text)
+		listItem.SetReference(item.name)
+		s.Menu.AddItem(listItem)
+
+		if w := indicatorWidth + TaggedStringWidth(text); w > width {
+			width = w
+		}
+	}
+	s.Menu.SetCurrentItem(currentIndex)
+
+	s.Flex.ResizeItem(s.Menu, width+1, 0)
+}
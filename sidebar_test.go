@@ -0,0 +1,99 @@
+package cview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestSideBar(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	s := NewSideBar()
+	s.AddItem("home", "H", "Home", NewBox())
+	s.AddItem("settings", "S", "Settings", NewBox())
+	if s.GetCurrentItem() != "home" {
+		t.Errorf("failed to initialize SideBar: expected current item home, got %s", s.GetCurrentItem())
+	}
+
+	// Select item
+
+	s.Menu.SetCurrentItem(1)
+	s.Menu.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	if s.GetCurrentItem() != "settings" {
+		t.Errorf("failed to update SideBar: expected current item settings, got %s", s.GetCurrentItem())
+	} else if name, _ := s.GetPanels().GetFrontPanel(); name != "settings" {
+		t.Errorf("failed to update SideBar: expected front panel settings, got %s", name)
+	}
+
+	// Collapse
+
+	s.Menu.InputHandler()(tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModNone), func(p Primitive) {})
+	if s.IsExpanded() {
+		t.Errorf("failed to collapse SideBar: expected collapsed, got expanded")
+	}
+
+	// Remove item
+
+	s.RemoveItem("settings")
+	if s.GetCurrentItem() != "home" {
+		t.Errorf("failed to update SideBar: expected current item home, got %s", s.GetCurrentItem())
+	}
+
+	// Draw
+
+	app, err := newTestApp(s)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	s.Draw(app.screen)
+}
+
+func TestSideBarMenuInputCapture(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	s := NewSideBar()
+	s.AddItem("home", "H", "Home", NewBox())
+
+	var captured []tcell.Key
+	s.SetMenuInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		captured = append(captured, event.Key())
+		if event.Key() == tcell.KeyRight {
+			return nil
+		}
+		return event
+	})
+	press := func(key tcell.Key) {
+		s.Menu.InputHandler()(tcell.NewEventKey(key, 0, tcell.ModNone), func(p Primitive) {})
+	}
+
+	// Pass events on
+
+	press(tcell.KeyLeft)
+	if s.IsExpanded() {
+		t.Errorf("failed to collapse SideBar: expected collapsed, got expanded")
+	}
+
+	// Stop processing
+
+	press(tcell.KeyRight)
+	if s.IsExpanded() {
+		t.Errorf("failed to capture key: expected collapsed, got expanded")
+	}
+	if len(captured) != 2 || captured[0] != tcell.KeyLeft || captured[1] != tcell.KeyRight {
+		t.Errorf("failed to capture keys: expected Left and Right, got %v", captured)
+	}
+
+	// Remove capture
+
+	s.SetMenuInputCapture(nil)
+	press(tcell.KeyRight)
+	if !s.IsExpanded() {
+		t.Errorf("failed to expand SideBar: expected expanded, got collapsed")
+	}
+}
@@ -55,6 +55,10 @@ type Slider struct {
 	// this form item.
 	finished func(tcell.Key)
 
+	// The validation state and its message.
+	fieldState        FieldState
+	fieldStateMessage string
+
 	sync.RWMutex
 }
 
@@ -146,6 +150,25 @@ func (s *Slider) SetFieldTextColorFocused(color tcell.Color) {
 	s.fieldTextColorFocused = color
 }
 
+// SetFieldState sets the validation state of the slider and a message
+// describing it. Warnings and errors are drawn using the FieldWarningColor and
+// FieldErrorColor of the theme. Within a Form, the message is shown below the
+// slider.
+func (s *Slider) SetFieldState(state FieldState, message string) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.fieldState, s.fieldStateMessage = state, message
+}
+
+// GetFieldState returns the validation state of the slider and its message.
+func (s *Slider) GetFieldState() (state FieldState, message string) {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.fieldState, s.fieldStateMessage
+}
+
 // GetFieldHeight returns the height of the field.
 func (s *Slider) GetFieldHeight() int {
 	return 1
@@ -202,7 +225,10 @@ func (s *Slider) Draw(screen tcell.Screen) {
 		return
 	}
 
-	s.Box.Draw(screen)
+	s.RLock()
+	fieldState := s.fieldState
+	s.RUnlock()
+	drawFieldStateBox(s.Box, fieldState, screen)
 	hasFocus := s.GetFocusable().HasFocus()
 
 	s.Lock()
@@ -223,6 +249,8 @@ func (s *Slider) Draw(screen tcell.Screen) {
 		}
 	}
 
+	fieldBackgroundColor = fieldStateColor(fieldState, fieldBackgroundColor)
+
 	// Prepare.
 	x, y, width, height := s.GetInnerRect()
 	rightLimit := x + width
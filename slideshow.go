@@ -0,0 +1,482 @@
+package cview
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Slide is a slide of a SlideShow.
+type Slide struct {
+	// The title of the slide.
+	title string
+
+	// The primitive displayed by the slide.
+	content Primitive
+
+	// The speaker notes of the slide.
+	notes string
+
+	// Called when the slide is shown.
+	setup func()
+
+	// Called when the slide is hidden.
+	teardown func()
+
+	sync.RWMutex
+}
+
+// NewSlide returns a new slide with the provided title and content.
+func NewSlide(title string, content Primitive) *Slide {
+	return &Slide{
+		title:   title,
+		content: content,
+	}
+}
+
+// SetTitle sets the title of the slide.
+func (s *Slide) SetTitle(title string) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.title = title
+}
+
+// GetTitle returns the title of the slide.
+func (s *Slide) GetTitle() string {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.title
+}
+
+// SetContent sets the primitive displayed by the slide.
+func (s *Slide) SetContent(content Primitive) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.content = content
+}
+
+// GetContent returns the primitive displayed by the slide.
+func (s *Slide) GetContent() Primitive {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.content
+}
+
+// SetNotes sets the speaker notes of the slide. See SlideShow.SetNotesWriter.
+func (s *Slide) SetNotes(notes string) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.notes = notes
+}
+
+// GetNotes returns the speaker notes of the slide.
+func (s *Slide) GetNotes() string {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.notes
+}
+
+// SetSetupFunc sets a handler which is called each time the slide is shown,
+// before it is drawn. This may be used to reset or start animations.
+func (s *Slide) SetSetupFunc(handler func()) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.setup = handler
+}
+
+// SetTeardownFunc sets a handler which is called each time the slide is
+// hidden. This may be used to stop animations started by the setup handler.
+func (s *Slide) SetTeardownFunc(handler func()) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.teardown = handler
+}
+
+// SlideShow displays one slide at a time, such as when presenting. The current
+// slide may be changed using the keyboard (see Keys.NextSlide and
+// Keys.PreviousSlide) or programmatically. A progress line showing the title
+// of the current slide and the position within the slide show is displayed
+// beneath the slide.
+//
+// Navigation keys are handled by the slide show only when it is focused
+// itself. To navigate while the content of a slide is focused, pass the
+// slide show's HandleNavigation function to Application.SetInputCapture.
+type SlideShow struct {
+	*Box
+
+	// The slides.
+	slides []*Slide
+
+	// The index of the current slide.
+	current int
+
+	// Whether the progress line is shown.
+	showProgress bool
+
+	// The colors of the progress line.
+	progressTextColor       tcell.Color
+	progressBackgroundColor tcell.Color
+	progressFilledColor     tcell.Color
+
+	// The writer to which the speaker notes of the current slide are written.
+	notesWriter io.Writer
+
+	// An optional handler which is called when the current slide changes.
+	changed func(index int, slide *Slide)
+
+	// We keep a reference to the function which allows us to set the focus to
+	// the content of the current slide.
+	setFocus func(p Primitive)
+
+	sync.RWMutex
+}
+
+// NewSlideShow returns a new slide show.
+func NewSlideShow() *SlideShow {
+	s := &SlideShow{
+		Box:                     NewBox(),
+		showProgress:            true,
+		progressTextColor:       Styles.PrimaryTextColor,
+		progressBackgroundColor: Styles.ContrastBackgroundColor,
+		progressFilledColor:     Styles.MoreContrastBackgroundColor,
+	}
+	s.focus = s
+	return s
+}
+
+// AddSlide adds a slide to the end of the slide show. The first slide added
+// becomes the current slide.
+func (s *SlideShow) AddSlide(slide *Slide) {
+	s.Lock()
+	s.slides = append(s.slides, slide)
+	first := len(s.slides) == 1
+	s.Unlock()
+
+	if first {
+		s.showSlide(-1, 0)
+	}
+}
+
+// GetSlideCount returns the number of slides.
+func (s *SlideShow) GetSlideCount() int {
+	s.RLock()
+	defer s.RUnlock()
+
+	return len(s.slides)
+}
+
+// GetSlide returns the slide at the provided index, or nil if the index is out
+// of range.
+func (s *SlideShow) GetSlide(index int) *Slide {
+	s.RLock()
+	defer s.RUnlock()
+
+	if index < 0 || index >= len(s.slides) {
+		return nil
+	}
+	return s.slides[index]
+}
+
+// SetCurrentSlide sets the current slide. The index is clamped to the range of
+// slides.
+func (s *SlideShow) SetCurrentSlide(index int) {
+	s.RLock()
+	previous := s.current
+	count := len(s.slides)
+	s.RUnlock()
+
+	if count == 0 {
+		return
+	}
+	if index < 0 {
+		index = 0
+	} else if index >= count {
+		index = count - 1
+	}
+	if index == previous {
+		return
+	}
+	s.showSlide(previous, index)
+}
+
+// GetCurrentSlide returns the index of the current slide.
+func (s *SlideShow) GetCurrentSlide() int {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.current
+}
+
+// NextSlide shows the next slide, if any.
+func (s *SlideShow) NextSlide() {
+	s.SetCurrentSlide(s.GetCurrentSlide() + 1)
+}
+
+// PreviousSlide shows the previous slide, if any.
+func (s *SlideShow) PreviousSlide() {
+	s.SetCurrentSlide(s.GetCurrentSlide() - 1)
+}
+
+// SetProgressVisible sets whether the progress line is shown. The progress
+// line is shown by default.
+func (s *SlideShow) SetProgressVisible(visible bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.showProgress = visible
+}
+
+// SetProgressTextColor sets the text color of the progress line.
+func (s *SlideShow) SetProgressTextColor(color tcell.Color) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.progressTextColor = color
+}
+
+// SetProgressBackgroundColor sets the background color of the progress line.
+func (s *SlideShow) SetProgressBackgroundColor(color tcell.Color) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.progressBackgroundColor = color
+}
+
+// SetProgressFilledColor sets the background color of the portion of the
+// progress line which represents the slides shown so far.
+func (s *SlideShow) SetProgressFilledColor(color tcell.Color) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.progressFilledColor = color
+}
+
+// SetNotesWriter sets the writer to which the speaker notes of each slide are
+// written when the slide is shown. This may be a file, or a TextView which is
+// displayed on a second screen. Provide nil to disable writing notes.
+func (s *SlideShow) SetNotesWriter(w io.Writer) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.notesWriter = w
+}
+
+// SetChangedFunc sets a handler which is called when the current slide
+// changes.
+func (s *SlideShow) SetChangedFunc(handler func(index int, slide *Slide)) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.changed = handler
+}
+
+// HandleNavigation handles the slide show's navigation keys and returns nil
+// when the provided event was handled. It may be passed to
+// Application.SetInputCapture to navigate while the content of a slide is
+// focused.
+func (s *SlideShow) HandleNavigation(event *tcell.EventKey) *tcell.EventKey {
+	if HitShortcut(event, Keys.NextSlide) {
+		s.NextSlide()
+		return nil
+	} else if HitShortcut(event, Keys.PreviousSlide) {
+		s.PreviousSlide()
+		return nil
+	}
+	return event
+}
+
+// showSlide hides the slide at the previous index, shows the slide at the
+// provided index and calls the corresponding handlers.
+func (s *SlideShow) showSlide(previous, index int) {
+	s.Lock()
+	var previousSlide *Slide
+	if previous >= 0 && previous < len(s.slides) {
+		previousSlide = s.slides[previous]
+	}
+	slide := s.slides[index]
+	s.current = index
+	count := len(s.slides)
+	notesWriter := s.notesWriter
+	changed := s.changed
+	setFocus := s.setFocus
+	s.Unlock()
+
+	var hadFocus bool
+	if previousSlide != nil {
+		if content := previousSlide.GetContent(); content != nil {
+			hadFocus = content.GetFocusable().HasFocus()
+		}
+
+		previousSlide.RLock()
+		teardown := previousSlide.teardown
+		previousSlide.RUnlock()
+		if teardown != nil {
+			teardown()
+		}
+	}
+
+	slide.RLock()
+	title, notes, setup, content := slide.title, slide.notes, slide.setup, slide.content
+	slide.RUnlock()
+
+	if setup != nil {
+		setup()
+	}
+	if notesWriter != nil {
+		fmt.Fprintf(notesWriter, "Slide %d/%d: %s\n", index+1, count, title)
+		if notes != "" {
+			fmt.Fprintf(notesWriter, "\n%s\n", notes)
+		}
+		fmt.Fprintln(notesWriter)
+	}
+	if hadFocus && setFocus != nil && content != nil {
+		setFocus(content)
+	}
+	if changed != nil {
+		changed(index, slide)
+	}
+}
+
+// currentContent returns the content of the current slide.
+func (s *SlideShow) currentContent() Primitive {
+	s.RLock()
+	defer s.RUnlock()
+
+	if s.current >= len(s.slides) {
+		return nil
+	}
+	return s.slides[s.current].GetContent()
+}
+
+// children returns the content of the current slide.
+func (s *SlideShow) children() []Primitive {
+	if content := s.currentContent(); content != nil {
+		return []Primitive{content}
+	}
+	return nil
+}
+
+// Focus is called when this primitive receives focus.
+func (s *SlideShow) Focus(delegate func(p Primitive)) {
+	s.Lock()
+	s.setFocus = delegate
+	s.Unlock()
+
+	if content := s.currentContent(); content != nil && delegate != nil {
+		delegate(content)
+		return
+	}
+	s.Box.Focus(delegate)
+}
+
+// HasFocus returns whether or not this primitive has focus.
+func (s *SlideShow) HasFocus() bool {
+	if content := s.currentContent(); content != nil && content.GetFocusable().HasFocus() {
+		return true
+	}
+	return s.Box.HasFocus()
+}
+
+// Draw draws this primitive onto the screen.
+func (s *SlideShow) Draw(screen tcell.Screen) {
+	if !s.GetVisible() {
+		return
+	}
+
+	s.Box.Draw(screen)
+
+	x, y, width, height := s.GetInnerRect()
+
+	s.RLock()
+	showProgress := s.showProgress && height > 1
+	current, count := s.current, len(s.slides)
+	var title string
+	if current < count {
+		title = s.slides[current].GetTitle()
+	}
+	textColor, backgroundColor, filledColor := s.progressTextColor, s.progressBackgroundColor, s.progressFilledColor
+	s.RUnlock()
+
+	if showProgress {
+		height--
+		progressY := y + height
+
+		var filled int
+		if count > 0 {
+			filled = width * (current + 1) / count
+		}
+		for i := 0; i < width; i++ {
+			color := backgroundColor
+			if i < filled {
+				color = filledColor
+			}
+			screen.SetContent(x+i, progressY, ' ', nil, tcell.StyleDefault.Background(color))
+		}
+
+		position := fmt.Sprintf("%d/%d", current+1, count)
+		if count == 0 {
+			position = "0/0"
+		}
+		positionWidth := len(position)
+		Print(screen, []byte(position), x, progressY, width-1, AlignRight, textColor)
+		Print(screen, []byte(Escape(title)), x+1, progressY, width-positionWidth-3, AlignLeft, textColor)
+	}
+
+	if content := s.currentContent(); content != nil {
+		content.SetRect(x, y, width, height)
+		s.passColors(content)
+		drawPrimitive(content, screen)
+	}
+}
+
+// InputHandler returns the handler for this primitive.
+func (s *SlideShow) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return s.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		if s.HandleNavigation(event) == nil {
+			return
+		}
+
+		if content := s.currentContent(); content != nil && content.GetFocusable().HasFocus() {
+			if handler := content.InputHandler(); handler != nil {
+				handler(event, setFocus)
+			}
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (s *SlideShow) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return s.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		if !s.InRect(event.Position()) {
+			return false, nil
+		}
+
+		if content := s.currentContent(); content != nil {
+			consumed, capture = content.MouseHandler()(action, event, setFocus)
+			if consumed {
+				return
+			}
+		}
+
+		// Clicking the progress line advances to the next slide.
+		_, y, _, height := s.GetInnerRect()
+		s.RLock()
+		showProgress := s.showProgress && height > 1
+		s.RUnlock()
+		if _, mouseY := event.Position(); action == MouseLeftClick && showProgress && mouseY == y+height-1 {
+			s.NextSlide()
+			return true, nil
+		}
+		return
+	})
+}
@@ -0,0 +1,80 @@
+package cview
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestSlideShow(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	s := NewSlideShow()
+
+	var events []string
+	for _, title := range []string{"A", "B", "C"} {
+		title := title // Capture
+		slide := NewSlide(title, NewBox())
+		slide.SetNotes("Notes " + title)
+		slide.SetSetupFunc(func() {
+			events = append(events, "+"+title)
+		})
+		slide.SetTeardownFunc(func() {
+			events = append(events, "-"+title)
+		})
+		s.AddSlide(slide)
+	}
+	if s.GetSlideCount() != 3 || s.GetCurrentSlide() != 0 {
+		t.Errorf("failed to add slides: expected 3 slides at 0, got %d slides at %d", s.GetSlideCount(), s.GetCurrentSlide())
+	}
+
+	var notes bytes.Buffer
+	s.SetNotesWriter(&notes)
+
+	// Navigate
+
+	if s.HandleNavigation(tcell.NewEventKey(tcell.KeyCtrlN, 0, tcell.ModCtrl)) != nil {
+		t.Errorf("failed to handle navigation: expected nil event")
+	}
+	s.NextSlide()
+	s.NextSlide()
+	s.PreviousSlide()
+	if s.GetCurrentSlide() != 1 {
+		t.Errorf("failed to navigate: expected slide 1, got %d", s.GetCurrentSlide())
+	}
+
+	expected := "+A -A +B -B +C -C +B"
+	if got := strings.Join(events, " "); got != expected {
+		t.Errorf("failed to call setup and teardown handlers: expected %s, got %s", expected, got)
+	}
+	if !strings.HasPrefix(notes.String(), "Slide 2/3: B\n\nNotes B\n") {
+		t.Errorf("failed to write notes: got %q", notes.String())
+	}
+
+	// Draw
+
+	app, err := newTestApp(s)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	s.SetRect(0, 0, 20, 5)
+	s.Draw(app.screen)
+
+	var line []rune
+	for x := 0; x < 20; x++ {
+		r, _, _, _ := app.screen.GetContent(x, 4)
+		line = append(line, r)
+	}
+	if got := string(line); got != " B              2/3 " {
+		t.Errorf("failed to draw progress line: expected \" B              2/3 \", got %q", got)
+	}
+}
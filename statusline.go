@@ -0,0 +1,135 @@
+package cview
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// EnableStatusLine sets whether a single-line status area is shown at the
+// bottom of the screen. The status line is drawn on top of the root primitive.
+// When the root primitive is resized to fill the screen, the line it would
+// otherwise occupy is reserved for the status line. See SetStatus.
+func (a *Application) EnableStatusLine(enable bool) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.statusLine = enable
+}
+
+// SetStatus sets the text shown in the status line. The level determines the
+// color of the text. Provide an empty text to clear the status line.
+//
+// This function may be called from any goroutine. The screen is redrawn when
+// the application is running.
+func (a *Application) SetStatus(text string, level LogLevel) {
+	a.Lock()
+	a.status, a.statusLevel = text, level
+	a.Unlock()
+
+	a.queueIndicatorUpdate()
+}
+
+// SetStatusf sets the text shown in the status line according to a format
+// specifier. See SetStatus.
+func (a *Application) SetStatusf(level LogLevel, format string, v ...interface{}) {
+	a.SetStatus(fmt.Sprintf(format, v...), level)
+}
+
+// GetStatus returns the text shown in the status line and its level. While a
+// transient status is shown, the persistent status is returned.
+func (a *Application) GetStatus() (text string, level LogLevel) {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.status, a.statusLevel
+}
+
+// ShowTransientStatus shows the provided text in the status line for the
+// provided duration, after which the status set via SetStatus is shown again.
+// Showing another transient status replaces the current one.
+func (a *Application) ShowTransientStatus(text string, level LogLevel, duration time.Duration) {
+	a.Lock()
+	a.transientStatusID++
+	id := a.transientStatusID
+	a.transientStatus, a.transientStatusLevel = text, level
+	a.Unlock()
+
+	a.queueIndicatorUpdate()
+
+	time.AfterFunc(duration, func() {
+		hide := func() {
+			a.Lock()
+			defer a.Unlock()
+
+			if a.transientStatusID == id {
+				a.transientStatus = ""
+			}
+		}
+
+		// Hide the status without redrawing when the update queue is full,
+		// so that the timer never blocks.
+		if !a.tryQueueUpdate(func() {
+			hide()
+			a.draw()
+		}) {
+			hide()
+		}
+	})
+}
+
+// statusColor returns the text color of a status with the provided level.
+func statusColor(level LogLevel) tcell.Color {
+	switch level {
+	case LogDebug:
+		return Styles.TertiaryTextColor
+	case LogWarning:
+		return Styles.SecondaryTextColor
+	case LogError:
+		return Styles.FieldErrorColor
+	default:
+		return Styles.PrimaryTextColor
+	}
+}
+
+// drawStatusLine draws the status line, if enabled.
+func (a *Application) drawStatusLine(screen tcell.Screen) {
+	a.RLock()
+	enabled := a.statusLine
+	width, height := a.width, a.height
+	text, level := a.status, a.statusLevel
+	if a.transientStatus != "" {
+		text, level = a.transientStatus, a.transientStatusLevel
+	}
+	a.RUnlock()
+
+	if !enabled || width <= 0 || height <= 0 {
+		return
+	}
+
+	y := height - 1
+	style := tcell.StyleDefault.Background(Styles.MoreContrastBackgroundColor)
+	for x := 0; x < width; x++ {
+		screen.SetContent(x, y, ' ', nil, style)
+	}
+	Print(screen, []byte(text), 1, y, width-2, AlignLeft, statusColor(level))
+}
+
+// drawIndicator draws the provided text in the top-right corner of the screen.
+func (a *Application) drawIndicator(screen tcell.Screen, width int, text string) {
+	if text == "" {
+		return
+	}
+
+	textWidth := TaggedStringWidth(text)
+	x := width - textWidth
+	if x < 0 {
+		x = 0
+	}
+	style := tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.PrimaryTextColor)
+	for i := 0; i < textWidth && x+i < width; i++ {
+		screen.SetContent(x+i, 0, ' ', nil, style)
+	}
+	Print(screen, []byte(Escape(text)), x, 0, textWidth, AlignLeft, Styles.PrimaryTextColor)
+}
@@ -0,0 +1,183 @@
+package cview
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// StyleIssue describes a problem with the colors of a primitive found by
+// AuditStyles.
+type StyleIssue struct {
+	// The primitive with the issue.
+	Primitive Primitive
+
+	// The location of the primitive in the primitive tree, e.g.
+	// "Flex > Grid[2] > TextView".
+	Path string
+
+	// A description of the issue.
+	Description string
+}
+
+// String returns the issue formatted as a single line.
+func (i *StyleIssue) String() string {
+	return i.Path + ": " + i.Description
+}
+
+// boxer is implemented by primitives which embed a Box.
+type boxer interface {
+	getBox() *Box
+}
+
+// containerPrimitive is implemented by primitives which contain other
+// primitives.
+type containerPrimitive interface {
+	children() []Primitive
+}
+
+// textColorer is implemented by primitives which display text.
+type textColorer interface {
+	primaryTextColor() tcell.Color
+}
+
+// getBox returns the box.
+func (b *Box) getBox() *Box {
+	return b
+}
+
+// AuditStyles walks the primitive tree starting at the provided root and
+// returns the primitives whose colors are left at their defaults or conflict
+// with each other, such as text which is drawn in the same color as its
+// background. This is intended to be used during development to verify that
+// an application is themed consistently. See Application.SetStyleAuditPanel.
+func AuditStyles(root Primitive) []*StyleIssue {
+	var issues []*StyleIssue
+	auditStyles(root, primitiveName(root), &issues)
+	return issues
+}
+
+// auditStyles audits a primitive and its children.
+func auditStyles(p Primitive, path string, issues *[]*StyleIssue) {
+	if p == nil {
+		return
+	}
+
+	report := func(format string, a ...interface{}) {
+		*issues = append(*issues, &StyleIssue{
+			Primitive:   p,
+			Path:        path,
+			Description: fmt.Sprintf(format, a...),
+		})
+	}
+
+	if b, ok := p.(boxer); ok {
+		box := b.getBox()
+
+		box.l.RLock()
+		background := box.backgroundColor
+		backgroundDefault := !box.backgroundColorSet && box.inheritedBackgroundColor == ColorUnset
+		textDefault := !box.textColorSet && box.inheritedTextColor == ColorUnset
+		transparent := box.backgroundTransparent
+		border, borderColor := box.border, box.borderColor
+		title, titleColor := len(box.title) > 0, box.titleColor
+		box.l.RUnlock()
+
+		if !transparent && backgroundDefault {
+			report("background color is left at the default")
+		}
+		if t, ok := p.(textColorer); ok {
+			textColor := t.primaryTextColor()
+			if textDefault {
+				report("text color is left at the default")
+			}
+			if !transparent && textColor == background {
+				report("text color %s equals background color", ColorHex(textColor))
+			}
+		}
+		if border && !transparent {
+			if borderColor == background {
+				report("border color %s equals background color", ColorHex(borderColor))
+			}
+			if title && titleColor == background {
+				report("title color %s equals background color", ColorHex(titleColor))
+			}
+		}
+	}
+
+	c, ok := p.(containerPrimitive)
+	if !ok {
+		return
+	}
+	children := c.children()
+	for i, child := range children {
+		name := primitiveName(child)
+		if len(children) > 1 {
+			name = fmt.Sprintf("%s[%d]", name, i)
+		}
+		auditStyles(child, path+" > "+name, issues)
+	}
+}
+
+// primitiveName returns the type name of a primitive.
+func primitiveName(p Primitive) string {
+	t := reflect.TypeOf(p)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Name() == "" {
+		return "Primitive"
+	}
+	return t.Name()
+}
+
+// formatStyleIssues returns the provided issues formatted as text suitable
+// for a TextView.
+func formatStyleIssues(issues []*StyleIssue) string {
+	if len(issues) == 0 {
+		return "No style issues found.\n"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d style issues found:\n", len(issues))
+	for _, issue := range issues {
+		b.WriteString(Escape(issue.String()))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// SetStyleAuditPanel enables a development mode in which the primitive tree is
+// audited via AuditStyles each time the screen is drawn. The report is
+// rendered into the provided panel, which is typically added to the layout
+// beside the primitives being developed. Provide nil to disable the audit.
+func (a *Application) SetStyleAuditPanel(panel *TextView) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.styleAuditPanel = panel
+	a.styleAuditReport = ""
+}
+
+// auditStyles audits the primitive tree and updates the style audit panel.
+func (a *Application) auditStyles(root Primitive) {
+	a.RLock()
+	panel := a.styleAuditPanel
+	previous := a.styleAuditReport
+	a.RUnlock()
+
+	if panel == nil {
+		return
+	}
+
+	report := formatStyleIssues(AuditStyles(root))
+	if report == previous {
+		return
+	}
+	panel.SetText(report)
+
+	a.Lock()
+	a.styleAuditReport = report
+	a.Unlock()
+}
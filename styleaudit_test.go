@@ -0,0 +1,59 @@
+package cview
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestAuditStyles(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tv := NewTextView()
+
+	b := NewButton("OK")
+	b.SetBackgroundColor(tcell.ColorBlue)
+	b.SetLabelColor(tcell.ColorBlue)
+
+	f := NewFlex()
+	f.AddItem(tv, 0, 1, false)
+	f.AddItem(b, 1, 0, false)
+
+	// Audit
+
+	issues := AuditStyles(f)
+
+	var textView, button []string
+	for _, issue := range issues {
+		switch issue.Primitive {
+		case tv:
+			textView = append(textView, issue.Description)
+		case b:
+			button = append(button, issue.Description)
+		case f:
+			t.Errorf("failed to audit styles: unexpected issue with transparent Flex: %s", issue)
+		}
+	}
+	if len(textView) != 2 {
+		t.Errorf("failed to audit styles: expected 2 TextView issues, got %v", textView)
+	}
+	if len(button) != 1 || !strings.HasPrefix(button[0], "text color #0000ff equals") {
+		t.Errorf("failed to audit styles: expected conflicting Button colors, got %v", button)
+	}
+	if len(issues) > 0 && issues[0].Path != "Flex > TextView[0]" {
+		t.Errorf("failed to audit styles: expected path Flex > TextView[0], got %s", issues[0].Path)
+	}
+
+	// Resolve
+
+	tv.SetBackgroundColor(tcell.ColorBlack)
+	tv.SetTextColor(tcell.ColorWhite)
+	b.SetLabelColor(tcell.ColorWhite)
+
+	if issues := AuditStyles(f); len(issues) != 0 {
+		t.Errorf("failed to audit styles: expected no issues, got %v", issues)
+	}
+}
@@ -27,6 +27,7 @@ type Theme struct {
 
 	// Check box
 	CheckBoxCheckedRune rune
+	CheckBoxPartialRune rune // The symbol to draw when the checkbox is partially checked.
 	CheckBoxCursorRune  rune // The symbol to draw within the checkbox when focused.
 
 	// Context menu
@@ -40,6 +41,10 @@ type Theme struct {
 	DropDownSymbol            rune   // The symbol to draw at the end of the field when closed.
 	DropDownOpenSymbol        rune   // The symbol to draw at the end of the field when opened.
 
+	// Field states
+	FieldWarningColor tcell.Color // Form items in the FieldWarning state.
+	FieldErrorColor   tcell.Color // Form items in the FieldError state and errors in the status line.
+
 	// Scroll bar
 	ScrollBarColor tcell.Color
 
@@ -70,6 +75,7 @@ var Styles = Theme{
 	ButtonCursorRune: '◀',
 
 	CheckBoxCheckedRune: 'X',
+	CheckBoxPartialRune: '-',
 	CheckBoxCursorRune:  '◀',
 
 	ContextMenuPaddingTop:    0,
@@ -81,6 +87,9 @@ var Styles = Theme{
 	DropDownSymbol:            '◀',
 	DropDownOpenSymbol:        '▼',
 
+	FieldWarningColor: tcell.ColorOrange.TrueColor(),
+	FieldErrorColor:   tcell.ColorRed.TrueColor(),
+
 	ScrollBarColor: tcell.ColorWhite.TrueColor(),
 
 	WindowMinWidth:  4,
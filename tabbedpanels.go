@@ -26,6 +26,9 @@ type TabbedPanels struct {
 	switcherAfterContent bool
 	switcherHeight       int
 
+	// Whether swiping left and right switches tabs.
+	swipeNavigation bool
+
 	width, lastWidth int
 
 	setFocus func(Primitive)
@@ -42,6 +45,8 @@ func NewTabbedPanels() *TabbedPanels {
 		dividerMid: string(BoxDrawingsDoubleVertical),
 		dividerEnd: string(BoxDrawingsLightVertical),
 		tabLabels:  make(map[string]string),
+
+		swipeNavigation: true,
 	}
 
 	s := t.Switcher
@@ -221,6 +226,36 @@ func (t *TabbedPanels) SetTabSwitcherAfterContent(after bool) {
 	t.rebuild()
 }
 
+// SetSwipeNavigation sets whether swiping left or right with the mouse shows
+// the next or previous tab. This is enabled by default.
+func (t *TabbedPanels) SetSwipeNavigation(swipe bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.swipeNavigation = swipe
+}
+
+// showAdjacentTab shows the tab before (negative offset) or after (positive
+// offset) the current tab.
+func (t *TabbedPanels) showAdjacentTab(offset int) {
+	t.RLock()
+	var names []string
+	current := -1
+	for i, panel := range t.panels.panels {
+		names = append(names, panel.Name)
+		if panel.Name == t.currentTab {
+			current = i
+		}
+	}
+	t.RUnlock()
+
+	next := current + offset
+	if current < 0 || next < 0 || next >= len(names) {
+		return
+	}
+	t.SetCurrentTab(names[next])
+}
+
 func (t *TabbedPanels) rebuild() {
 	f := t.Flex
 	if t.switcherVertical {
@@ -389,6 +424,19 @@ func (t *TabbedPanels) MouseHandler() func(action MouseAction, event *tcell.Even
 			return false, nil
 		}
 
+		t.RLock()
+		swipeNavigation := t.swipeNavigation
+		t.RUnlock()
+		if swipeNavigation && (action == MouseSwipeLeft || action == MouseSwipeRight) {
+			// Swiping left reveals the next tab.
+			if action == MouseSwipeLeft {
+				t.showAdjacentTab(1)
+			} else {
+				t.showAdjacentTab(-1)
+			}
+			return true, nil
+		}
+
 		if t.Switcher.InRect(x, y) {
 			if t.setFocus != nil {
 				defer t.setFocus(t.panels)
@@ -0,0 +1,86 @@
+package cview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestTabbedPanelsSwipe(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tp := NewTabbedPanels()
+	tp.AddTab("a", "A", NewBox())
+	tp.AddTab("b", "B", NewBox())
+	tp.SetCurrentTab("a")
+	tp.SetRect(0, 0, 80, 24)
+
+	app, err := newTestApp(tp)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	tp.Draw(app.screen)
+
+	var actions []MouseAction
+	app.SetMouseCapture(func(event *tcell.EventMouse, action MouseAction) (*tcell.EventMouse, MouseAction) {
+		if action >= MouseDragStart {
+			actions = append(actions, action)
+		}
+		return event, action
+	})
+
+	mouse := func(x, y int, buttons tcell.ButtonMask) {
+		event := tcell.NewEventMouse(x, y, buttons, 0)
+		_, isMouseDownAction := app.fireMouseActions(event)
+		app.lastMouseButtons = event.Buttons()
+		if isMouseDownAction {
+			app.mouseDownX, app.mouseDownY = event.Position()
+		}
+	}
+
+	// Swipe left
+
+	mouse(40, 10, tcell.ButtonPrimary)
+	mouse(39, 10, tcell.ButtonPrimary)
+	mouse(36, 10, tcell.ButtonPrimary)
+	mouse(30, 11, tcell.ButtonPrimary)
+	mouse(30, 11, tcell.ButtonNone)
+
+	expected := []MouseAction{MouseDragStart, MouseDrag, MouseDragEnd, MouseSwipeLeft}
+	if len(actions) != len(expected) {
+		t.Errorf("failed to recognize gestures: expected %v, got %v", expected, actions)
+	} else {
+		for i := range expected {
+			if actions[i] != expected[i] {
+				t.Errorf("failed to recognize gestures: expected %v, got %v", expected, actions)
+			}
+		}
+	}
+	if tab := tp.GetCurrentTab(); tab != "b" {
+		t.Errorf("failed to swipe to next tab: expected b, got %s", tab)
+	}
+
+	// Short drag
+
+	actions = nil
+	mouse(40, 10, tcell.ButtonPrimary)
+	mouse(43, 10, tcell.ButtonPrimary)
+	mouse(43, 10, tcell.ButtonNone)
+	if len(actions) != 2 || actions[1] != MouseDragEnd {
+		t.Errorf("failed to recognize drag: expected drag start and end, got %v", actions)
+	}
+	if tab := tp.GetCurrentTab(); tab != "b" {
+		t.Errorf("failed to ignore short drag: expected b, got %s", tab)
+	}
+
+	// Swipe right
+
+	mouse(30, 10, tcell.ButtonPrimary)
+	mouse(50, 10, tcell.ButtonPrimary)
+	mouse(50, 10, tcell.ButtonNone)
+	if tab := tp.GetCurrentTab(); tab != "a" {
+		t.Errorf("failed to swipe to previous tab: expected a, got %s", tab)
+	}
+}
@@ -1,8 +1,9 @@
 package cview
 
 import (
-	"bytes"
+	"fmt"
 	"sort"
+	"strings"
 	"sync"
 
 	"github.com/gdamore/tcell/v2"
@@ -32,6 +33,10 @@ type TableCell struct {
 	// used to add extra width to a column. See SetExpansion() for details.
 	Expansion int
 
+	// Whether or not the cell text is wrapped onto multiple lines when it
+	// exceeds the width of the column. See SetWrap() for details.
+	Wrap bool
+
 	// The color of the cell text.
 	Color tcell.Color
 
@@ -44,6 +49,13 @@ type TableCell struct {
 	// If set to true, this cell cannot be selected.
 	NotSelectable bool
 
+	// The type of the value of the cell, the value, the precision of
+	// floating-point values and the format of the value, if any.
+	valueType TableCellType
+	value     interface{}
+	precision int
+	format    *CellFormat
+
 	// The position and width of the cell the last time table was drawn.
 	x, y, width int
 
@@ -62,15 +74,16 @@ func NewTableCell(text string) *TableCell {
 	}
 }
 
-// SetBytes sets the cell's text.
+// SetBytes sets the cell's text. Any typed value of the cell is removed.
 func (c *TableCell) SetBytes(text []byte) {
 	c.Lock()
 	defer c.Unlock()
 
 	c.Text = text
+	c.valueType, c.value = TableCellText, nil
 }
 
-// SetText sets the cell's text.
+// SetText sets the cell's text. Any typed value of the cell is removed.
 func (c *TableCell) SetText(text string) {
 	c.SetBytes([]byte(text))
 }
@@ -130,6 +143,18 @@ func (c *TableCell) SetExpansion(expansion int) {
 	c.Expansion = expansion
 }
 
+// SetWrap sets whether or not the cell text is wrapped onto multiple lines
+// when it exceeds the width of its column, instead of being cut off. The row
+// of the cell grows in height to fit the wrapped text. Text is only wrapped
+// when the width of the column is limited, e.g. by SetMaxWidth, by
+// Table.SetColumnMaxWidth or by the available width of the table.
+func (c *TableCell) SetWrap(wrap bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.Wrap = wrap
+}
+
 // SetTextColor sets the cell's text color.
 func (c *TableCell) SetTextColor(color tcell.Color) {
 	c.Lock()
@@ -224,21 +249,38 @@ func (c *TableCell) GetLastPosition() (x, y, width int) {
 // by lines. Therefore one table row will require two rows on screen.
 //
 // Columns will use as much horizontal space as they need. You can constrain
-// their size with the MaxWidth parameter of the TableCell type.
+// their size with the MaxWidth parameter of the TableCell type, or for all
+// cells of a column via SetColumnMaxWidth(). The alignment, expansion and
+// truncation of the cells of a column may be set via SetColumnAlign(),
+// SetColumnExpansion() and SetColumnEllipsis().
+//
+// Instead of setting each cell via SetCell(), the cells may be provided by a
+// TableContent set via SetContent(), which creates only the cells which are
+// displayed. This allows displaying large data sets.
+//
+// Rows may form a hierarchy by setting their levels via SetRowLevel(). The
+// table is then drawn as a tree-table, where the user may expand and collapse
+// rows to show and hide their child rows.
 //
 // Fixed Columns
 //
 // You can define fixed rows and rolumns via SetFixed(). They will always stay
 // in their place, even when the table is scrolled. Fixed rows are always the
-// top rows. Fixed columns are always the leftmost columns.
+// top rows. Fixed columns are always the leftmost columns. A header row, which
+// names the columns and is styled separately, may be set via SetHeaderRow(). It
+// is always the top row and fixed. Footer rows, such as totals, may be defined
+// via SetFooterRows(). They are always the bottom rows and stay visible below
+// the other rows when the table is scrolled.
 //
 // Selections
 //
 // You can call SetSelectable() to set columns and/or rows to "selectable". If
 // the flag is set only for columns, entire columns can be selected by the user.
 // If it is set only for rows, entire rows can be selected. If both flags are
-// set, individual cells can be selected. The "selected" handler set via
-// SetSelectedFunc() is invoked when the user presses Enter on a selection.
+// set, individual cells can be selected. The same may be set via
+// SetSelectionMode(), e.g. to let the user switch between selecting rows and
+// cells. The "selected" handler set via SetSelectedFunc() is invoked when the
+// user presses Enter on a selection.
 //
 // Navigation
 //
@@ -254,6 +296,9 @@ func (c *TableCell) GetLastPosition() (x, y, width int) {
 //   - Ctrl-F, page down: Move down by one page.
 //   - Ctrl-B, page up: Move up by one page.
 //
+// Navigation even more similar to Vim, where the top is reached via gg and
+// Ctrl-D and Ctrl-U move by half a page, may be enabled via SetVimKeys().
+//
 // When there is no selection, this affects the entire table (except for fixed
 // rows and columns). When there is a selection, the user moves the selection.
 // The class will attempt to keep the selection from moving out of the screen.
@@ -268,12 +313,18 @@ type Table struct {
 	// The color of the borders or the separator.
 	bordersColor tcell.Color
 
+	// The text color of cells with the default text color.
+	textColor tcell.Color
+
 	// If there are no borders, the column separator.
 	separator rune
 
 	// The cells of the table. Rows first, then columns.
 	cells [][]*TableCell
 
+	// The content providing the cells of the table instead, if any.
+	content TableContent
+
 	// The rightmost column in the data set.
 	lastColumn int
 
@@ -284,6 +335,10 @@ type Table struct {
 	// The number of fixed rows / columns.
 	fixedRows, fixedColumns int
 
+	// Whether or not separators are drawn between the fixed and the scrolling
+	// rows and columns.
+	fixedSeparators bool
+
 	// Whether or not rows or columns can be selected. If both are set to true,
 	// cells can be selected.
 	rowsSelectable, columnsSelectable bool
@@ -298,21 +353,107 @@ type Table struct {
 	// If set to true, the table's last row will always be visible.
 	trackEnd bool
 
-	// The sort function of the table. Defaults to a case-sensitive comparison.
+	// Whether the table is navigated like Vim, and whether g was pressed once
+	// while it is.
+	vimKeys, vimPrefix bool
+
+	// The sort function of the table. Defaults to a comparison of the cells
+	// via CompareTableCells.
 	sortFunc func(column, i, j int) bool
 
+	// The format of the values of typed cells, or nil to use
+	// DefaultCellFormat.
+	cellFormat *CellFormat
+
+	// The sort functions of individual columns.
+	columnSortFuncs map[int]func(a, b *TableCell) bool
+
+	// The default formatting of the cells of individual columns.
+	columnDefaults map[int]*tableColumn
+
 	// Whether or not the table should be sorted when a fixed row is clicked.
 	sortClicked bool
 
-	// The last direction the table was sorted by when clicked.
-	sortClickedDescending bool
-
-	// The last column the table was sorted by when clicked.
-	sortClickedColumn int
+	// The column the table was last sorted by, or -1 if it was not sorted,
+	// and whether it was sorted in descending order.
+	sortColumn     int
+	sortDescending bool
+
+	// The indicators drawn in the header of the column the table is sorted
+	// by. Indicators are not drawn when they are 0.
+	sortIndicatorAscending, sortIndicatorDescending rune
+
+	// Whether multiple rows, columns or cells may be selected.
+	multiSelect bool
+
+	// The rows, columns or cells selected via multi-selection, keyed by
+	// selectionKey.
+	multiSelection map[[2]int]bool
+
+	// Whether the selected ranges are being extended by moving the selection,
+	// the position the extended range started at and the multi-selection at
+	// that time.
+	extendingSelection                        bool
+	selectionAnchorRow, selectionAnchorColumn int
+	multiSelectionBase                        map[[2]int]bool
+
+	// An optional function which determines the rows which are shown, and
+	// the rows which passed it the last time the table was drawn, or nil if
+	// there was no filter function and no collapsed rows.
+	filter       func(row int) bool
+	filteredRows []int
+
+	// The text highlighted in the rows which are shown and its style.
+	filterHighlight      string
+	filterHighlightStyle tcell.Style
+
+	// The state of the rows of a tree-table, keyed by row.
+	treeRows map[int]*tableRow
+
+	// The indicators drawn before the text of the rows of a tree-table which
+	// are collapsed and expanded.
+	collapsedIndicator, expandedIndicator rune
+
+	// An optional function which gets called when the user expands or
+	// collapses a row of a tree-table.
+	rowExpanded func(row int, expanded bool)
+
+	// Whether the first row is the header row, the style applied to it and
+	// whether lines are drawn between its cells.
+	header           bool
+	headerStyle      tcell.Style
+	headerSeparators bool
+
+	// The cells which matched the pattern provided to Find, in order and as a
+	// set, the index of the current match (-1 if there is none) and the style
+	// applied to matching cells.
+	findMatches      [][2]int
+	findMatchSet     map[[2]int]bool
+	currentFindMatch int
+	matchStyle       tcell.Style
+
+	// The maximum number of rows which are neither fixed nor footer rows, or 0
+	// if there is no maximum.
+	maxRows int
+
+	// The number of footer rows and the style applied to them.
+	footerRows  int
+	footerStyle tcell.Style
+
+	// The line of the table on which the footer rows started the last time
+	// the table was drawn, or -1 if there were no footer rows.
+	footerLine int
+
+	// The lines of the rows drawn the last time the table was drawn, if the
+	// text of any row was wrapped onto multiple lines.
+	rowLines []tableRowLines
 
 	// The number of visible rows the last time the table was drawn.
 	visibleRows int
 
+	// The indices of the visible rows as of the last time the table was drawn.
+	visibleRowIndices []int
+
 	// The indices of the visible columns as of the last time the table was drawn.
 	visibleColumnIndices []int
 
@@ -344,20 +485,110 @@ type Table struct {
 	// or Backtab. Also when the user presses Enter if nothing is selectable.
 	done func(key tcell.Key)
 
+	// An optional function which gets called for each cell filled by Paste.
+	paste func(row, column int, text string) (string, bool)
+
+	// The hidden columns.
+	hiddenColumns map[int]bool
+
+	// An optional function which gets called when the visibility of a column
+	// is changed.
+	columnVisibilityChanged func(column int, visible bool)
+
+	// Whether the column chooser is shown via Keys.ShowColumnChooser.
+	columnChooserEnabled bool
+
+	// The column chooser, whether it is open and the function which restores
+	// the focus when it is closed.
+	columnChooser         *CheckBoxGroup
+	columnChooserOpen     bool
+	columnChooserSetFocus func(p Primitive)
+
+	// Whether rows may be reordered by the user.
+	rowsMovable bool
+
+	// Whether the selected row is being moved, either via the keyboard or by
+	// dragging it with the mouse.
+	movingRow bool
+
+	// Whether a row is being dragged, and the row the left mouse button was
+	// pressed on. Set to -1 when no row may be dragged.
+	draggingRow bool
+	dragRow     int
+
+	// An optional function which gets called when the user moves a row.
+	rowMoved func(from, to int)
+
+	// Whether columns may be resized by the user.
+	columnsResizable bool
+
+	// The widths of columns which were resized, either by the user or via
+	// SetColumnWidth.
+	columnWidths map[int]int
+
+	// The minimum and maximum width of columns resized by the user. A maximum
+	// of 0 means there is no maximum.
+	minColumnWidth, maxColumnWidth int
+
+	// Whether a column is being resized, the column whose right boundary the
+	// left mouse button was pressed on (-1 if none), and the horizontal mouse
+	// position and width of the column at that time.
+	resizingColumn   bool
+	resizeColumn     int
+	resizeStartX     int
+	resizeStartWidth int
+
+	// An optional function which gets called when the user resized a column.
+	columnResized func(column, width int)
+
+	// Whether columns may be reordered by the user.
+	columnsMovable bool
+
+	// The index each column had before columns were moved, or nil if no
+	// column was moved.
+	columnOrder []int
+
+	// Whether a column is being dragged, and the column the left mouse button
+	// was pressed on in a fixed row. Set to -1 when no column may be dragged.
+	draggingColumn bool
+	dragColumn     int
+
+	// An optional function which gets called when the user moves a column.
+	columnOrderChanged func(order []int)
+
 	sync.RWMutex
 }
 
 // NewTable returns a new table.
 func NewTable() *Table {
-	return &Table{
-		Box:                 NewBox(),
-		scrollBarVisibility: ScrollBarAuto,
-		scrollBarColor:      Styles.ScrollBarColor,
-		bordersColor:        Styles.GraphicsColor,
-		separator:           ' ',
-		sortClicked:         true,
-		lastColumn:          -1,
+	t := &Table{
+		Box:                     NewBox(),
+		scrollBarVisibility:     ScrollBarAuto,
+		scrollBarColor:          Styles.ScrollBarColor,
+		bordersColor:            Styles.GraphicsColor,
+		textColor:               Styles.PrimaryTextColor,
+		separator:               ' ',
+		sortClicked:             true,
+		sortColumn:              -1,
+		sortIndicatorAscending:  '▲',
+		sortIndicatorDescending: '▼',
+		collapsedIndicator:      '▶',
+		expandedIndicator:       '▼',
+		lastColumn:              -1,
+		hiddenColumns:           make(map[int]bool),
+		dragRow:                 -1,
+		columnWidths:            make(map[int]int),
+		minColumnWidth:          1,
+		resizeColumn:            -1,
+		dragColumn:              -1,
+		footerLine:              -1,
+		headerStyle:             tcell.StyleDefault.Foreground(Styles.SecondaryTextColor).Bold(true),
+		currentFindMatch:        -1,
+		matchStyle:              tcell.StyleDefault.Foreground(Styles.PrimitiveBackgroundColor).Background(Styles.SecondaryTextColor),
+		filterHighlightStyle:    tcell.StyleDefault.Bold(true).Underline(true),
 	}
+	t.focus = t
+	return t
 }
 
 // Clear removes all table data.
@@ -367,6 +598,13 @@ func (t *Table) Clear() {
 
 	t.cells = nil
 	t.lastColumn = -1
+	t.content = nil
+	t.sortColumn = -1
+	t.header = false
+	t.findMatches, t.findMatchSet, t.currentFindMatch = nil, nil, -1
+	t.columnOrder = nil
+	t.treeRows = nil
+	t.clearMultiSelection()
 }
 
 // SetBorders sets whether or not each cell in the table is surrounded by a
@@ -386,6 +624,25 @@ func (t *Table) SetBordersColor(color tcell.Color) {
 	t.bordersColor = color
 }
 
+// inheritColors applies the colors inherited from the parent container.
+func (t *Table) inheritColors(background, text tcell.Color) {
+	t.Box.inheritColors(background, text)
+
+	t.Lock()
+	defer t.Unlock()
+
+	t.inheritTextColor(&t.textColor, text)
+}
+
+// cellTextColor returns the text color of the provided cell. Cells with the
+// default text color are drawn in the text color inherited by the table.
+func (t *Table) cellTextColor(cell *TableCell) tcell.Color {
+	if cell.Color == Styles.PrimaryTextColor {
+		return t.textColor
+	}
+	return cell.Color
+}
+
 // SetScrollBarVisibility specifies the display of the scroll bar.
 func (t *Table) SetScrollBarVisibility(visibility ScrollBarVisibility) {
 	t.Lock()
@@ -432,14 +689,41 @@ func (t *Table) SetSeparator(separator rune) {
 
 // SetFixed sets the number of fixed rows and columns which are always visible
 // even when the rest of the cells are scrolled out of view. Rows are always the
-// top-most ones. Columns are always the left-most ones.
+// top-most ones. Columns are always the left-most ones. When both are set, the
+// cells in the top left corner are always visible, while the fixed rows scroll
+// horizontally and the fixed columns scroll vertically, like frozen panes in a
+// spreadsheet. See SetFixedSeparators.
 func (t *Table) SetFixed(rows, columns int) {
 	t.Lock()
 	defer t.Unlock()
 
+	if t.header && rows < 1 {
+		rows = 1 // The header row is always fixed.
+	}
 	t.fixedRows, t.fixedColumns = rows, columns
 }
 
+// SetFixedSeparators sets whether or not lines are drawn between the fixed
+// rows and columns (see SetFixed) and the rest of the table, in the color of
+// the borders. The line below the fixed rows takes up one line of the table.
+// This has no effect when borders are shown, as the borders already separate
+// the cells.
+func (t *Table) SetFixedSeparators(visible bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.fixedSeparators = visible
+}
+
+// fixedRowsSeparator returns the number of lines taken up by the separator
+// below the fixed rows.
+func (t *Table) fixedRowsSeparator() int {
+	if t.fixedSeparators && !t.borders && t.fixedRows > 0 {
+		return 1
+	}
+	return 0
+}
+
 // SetSelectable sets the flags which determine what can be selected in a table.
 // There are three selection modi:
 //
@@ -452,6 +736,7 @@ func (t *Table) SetSelectable(rows, columns bool) {
 	defer t.Unlock()
 
 	t.rowsSelectable, t.columnsSelectable = rows, columns
+	t.clearMultiSelection()
 }
 
 // GetSelectable returns what can be selected in a table. Refer to
@@ -479,14 +764,17 @@ func (t *Table) GetSelection() (row, column int) {
 // is available (even if the selection ends up being the same as before and even
 // if cells are not selectable).
 func (t *Table) Select(row, column int) {
+	var calls callbacks
+	defer calls.run()
+
 	t.Lock()
 	defer t.Unlock()
 
 	t.selectedRow, t.selectedColumn = row, column
-	if t.selectionChanged != nil {
-		t.Unlock()
-		t.selectionChanged(row, column)
-		t.Lock()
+	if changed := t.selectionChanged; changed != nil {
+		calls.add(func() {
+			changed(row, column)
+		})
 	}
 }
 
@@ -558,6 +846,156 @@ func (t *Table) SetDoneFunc(handler func(key tcell.Key)) {
 	t.done = handler
 }
 
+// SetPasteFunc sets a handler which is called for each cell filled by Paste.
+// The handler receives the position of the cell and the pasted text. It
+// returns the text to set, which allows transforming the pasted text, and
+// whether the cell should be filled at all. Return false to veto the change.
+func (t *Table) SetPasteFunc(handler func(row, column int, text string) (string, bool)) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.paste = handler
+}
+
+// SetRowsMovable sets a flag which determines whether the user may reorder
+// rows, either by dragging them with the mouse or by pressing the MoveRow key
+// (Alt+m by default) and moving the selected row with the movement keys until
+// Enter, Escape or the MoveRow key is pressed. Fixed rows are never moved.
+// Rows may only be moved while rows are selectable.
+func (t *Table) SetRowsMovable(movable bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.rowsMovable = movable
+	if !movable {
+		t.movingRow = false
+		t.draggingRow = false
+	}
+}
+
+// IsMovingRow returns true while the user is moving the selected row.
+func (t *Table) IsMovingRow() bool {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.movingRow
+}
+
+// SetRowMovedFunc sets a handler which is called each time the user moves a
+// row. The handler receives the previous and the new index of the row, which
+// remains selected. Rows are moved one at a time while they are dragged, so
+// the handler may be called several times during a single drag.
+func (t *Table) SetRowMovedFunc(handler func(from, to int)) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.rowMoved = handler
+}
+
+// Paste fills a rectangular block of cells with the provided text, starting
+// at the selected cell. Rows are separated by newlines and columns are
+// separated by tabs, which is the format used when copying cells from most
+// spreadsheet applications. The block is filled into visible cells only:
+// hidden columns and rows hidden by the filter function or by collapsed rows
+// are skipped. Cells which are not selectable are skipped as well. Nothing is
+// pasted while no cell is selected.
+//
+// While a content is set via SetContent, the text of the cells it provides is
+// replaced. When the content implements TableEditor, the pasted cells are
+// passed to its SetCell function, which also allows pasting into cells the
+// content does not provide yet.
+//
+// When bracketed paste mode is enabled (the default), text pasted while the
+// table has focus is passed to this function.
+func (t *Table) Paste(text string) {
+	text = strings.TrimSuffix(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if text == "" {
+		return
+	}
+	lines := strings.Split(text, "\n")
+	var columnCount int
+	for _, line := range lines {
+		if count := strings.Count(line, "\t") + 1; count > columnCount {
+			columnCount = count
+		}
+	}
+
+	t.RLock()
+	startRow, startColumn := t.selectedRow, t.selectedColumn
+	handler := t.paste
+	if startRow < 0 || startColumn < 0 {
+		t.RUnlock()
+		return
+	}
+	rowCount := t.rowCount()
+	var rows, columns []int
+	for row := startRow; len(rows) < len(lines); row++ {
+		if row < rowCount && t.isFilteredRow(row) {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	for column := startColumn; len(columns) < columnCount; column++ {
+		if t.hiddenColumns[column] {
+			continue
+		}
+		columns = append(columns, column)
+	}
+	t.RUnlock()
+
+	for r, line := range lines {
+		for c, value := range strings.Split(line, "\t") {
+			row, column := rows[r], columns[c]
+
+			if cell := t.GetCell(row, column); cell.NotSelectable {
+				continue
+			}
+
+			if handler != nil {
+				var ok bool
+				value, ok = handler(row, column, value)
+				if !ok {
+					continue
+				}
+			}
+
+			t.pasteCell(row, column, value)
+		}
+	}
+}
+
+// pasteCell sets the text of the cell at the provided position to the pasted
+// text.
+func (t *Table) pasteCell(row, column int, text string) {
+	t.RLock()
+	content := t.content
+	cell := t.cell(row, column)
+	t.RUnlock()
+
+	if content != nil {
+		editor, ok := content.(TableEditor)
+		if cell == nil {
+			if !ok {
+				return // The content does not provide the cell.
+			}
+			cell = NewTableCell(text)
+		} else {
+			cell.SetText(text)
+		}
+		if ok {
+			editor.SetCell(row, column, cell)
+		}
+		return
+	}
+
+	if cell == nil || cell.Text == nil && cell.Color == tcell.ColorDefault {
+		// The cell was not previously set.
+		t.SetCell(row, column, NewTableCell(text))
+		return
+	}
+	cell.SetText(text)
+}
+
 // SetCell sets the content of a cell the specified position. It is ok to
 // directly instantiate a TableCell object. If the cell has content, at least
 // the Text and Color fields should be set.
@@ -582,6 +1020,9 @@ func (t *Table) SetCell(row, column int, cell *TableCell) {
 		}
 	}
 	t.cells[row][column] = cell
+	if cell != nil && t.cellFormat != nil {
+		cell.setFormat(t.cellFormat)
+	}
 	if column > t.lastColumn {
 		t.lastColumn = column
 	}
@@ -601,10 +1042,11 @@ func (t *Table) GetCell(row, column int) *TableCell {
 	t.RLock()
 	defer t.RUnlock()
 
-	if row >= len(t.cells) || column >= len(t.cells[row]) {
+	cell := t.cell(row, column)
+	if cell == nil {
 		return &TableCell{}
 	}
-	return t.cells[row][column]
+	return cell
 }
 
 // RemoveRow removes the row at the given position from the table. If there is
@@ -618,6 +1060,8 @@ func (t *Table) RemoveRow(row int) {
 	}
 
 	t.cells = append(t.cells[:row], t.cells[row+1:]...)
+	t.shiftTreeRows(row, -1)
+	t.clearMultiSelection()
 }
 
 // RemoveColumn removes the column at the given position from the table. If
@@ -632,6 +1076,11 @@ func (t *Table) RemoveColumn(column int) {
 		}
 		t.cells[row] = append(t.cells[row][:column], t.cells[row][column+1:]...)
 	}
+	t.shiftHiddenColumns(column, -1)
+	t.shiftColumnWidths(column, -1)
+	t.shiftColumnDefaults(column, -1)
+	t.columnOrder = nil
+	t.clearMultiSelection()
 }
 
 // InsertRow inserts a row before the row with the given index. Cells on the
@@ -647,6 +1096,8 @@ func (t *Table) InsertRow(row int) {
 	t.cells = append(t.cells, nil)       // Extend by one.
 	copy(t.cells[row+1:], t.cells[row:]) // Shift down.
 	t.cells[row] = nil                   // New row is uninitialized.
+	t.shiftTreeRows(row, 1)
+	t.clearMultiSelection()
 }
 
 // InsertColumn inserts a column before the column with the given index. Cells
@@ -665,6 +1116,93 @@ func (t *Table) InsertColumn(column int) {
 		copy(t.cells[row][column+1:], t.cells[row][column:]) // Shift to the right.
 		t.cells[row][column] = &TableCell{}                  // New element is an uninitialized table cell.
 	}
+	t.shiftHiddenColumns(column, 1)
+	t.shiftColumnWidths(column, 1)
+	t.shiftColumnDefaults(column, 1)
+	t.columnOrder = nil
+	t.clearMultiSelection()
+}
+
+// MoveRow moves the row at the index "from" so that it is found at the index
+// "to" afterwards. Rows in between are shifted by one row. The selection
+// follows the moved rows. If either index is out of range, this function has
+// no effect.
+func (t *Table) MoveRow(from, to int) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.moveRow(from, to)
+}
+
+// moveRow moves a row and updates the selection. It returns whether the row
+// was moved.
+func (t *Table) moveRow(from, to int) bool {
+	if t.content != nil || from < 0 || from >= len(t.cells) || to < 0 || to >= len(t.cells) || from == to {
+		return false
+	}
+
+	row := t.cells[from]
+	if from < to {
+		copy(t.cells[from:], t.cells[from+1:to+1])
+	} else {
+		copy(t.cells[to+1:], t.cells[to:from])
+	}
+	t.cells[to] = row
+	t.moveTreeRow(from, to)
+
+	if t.selectedRow == from {
+		t.selectedRow = to
+	} else if from < to && t.selectedRow > from && t.selectedRow <= to {
+		t.selectedRow--
+	} else if to < from && t.selectedRow >= to && t.selectedRow < from {
+		t.selectedRow++
+	}
+	t.clearMultiSelection()
+	return true
+}
+
+// moveSelectedRow moves the selected row to the provided index, which is
+// limited to the rows which are not fixed, and adds the row moved handler to
+// the provided callbacks. The table must be locked when calling this function.
+func (t *Table) moveSelectedRow(calls *callbacks, to int) {
+	if to < t.fixedRows {
+		to = t.fixedRows
+	}
+	if last := len(t.cells) - t.footerRowCount(len(t.cells)) - 1; to > last {
+		to = last
+	}
+
+	from := t.selectedRow
+	if from < t.fixedRows || t.isFooterRow(from) || !t.moveRow(from, to) {
+		return
+	}
+	if rowMoved := t.rowMoved; rowMoved != nil {
+		calls.add(func() {
+			rowMoved(from, to)
+		})
+	}
+}
+
+// dragTarget returns the index the dragged row is moved to when the mouse is
+// at the provided vertical position. Rows are moved one at a time beyond the
+// edges of the table, scrolling it.
+func (t *Table) dragTarget(y int) int {
+	rectX, rectY, _, height := t.GetInnerRect()
+	top := rectY + t.fixedRows + t.fixedRowsSeparator()
+	if t.borders {
+		top = rectY + 1 + 2*t.fixedRows
+	}
+	if y < top {
+		return t.selectedRow - 1
+	} else if y >= rectY+height {
+		return t.selectedRow + 1
+	}
+
+	row, _ := t.cellAt(rectX, y)
+	if row < 0 {
+		return t.rowCount() - 1
+	}
+	return row
 }
 
 // GetRowCount returns the number of rows in the table.
@@ -672,7 +1210,7 @@ func (t *Table) GetRowCount() int {
 	t.RLock()
 	defer t.RUnlock()
 
-	return len(t.cells)
+	return t.rowCount()
 }
 
 // GetColumnCount returns the (maximum) number of columns in the table.
@@ -680,10 +1218,224 @@ func (t *Table) GetColumnCount() int {
 	t.RLock()
 	defer t.RUnlock()
 
-	if len(t.cells) == 0 {
-		return 0
+	return t.columnCount()
+}
+
+// SetColumnVisible sets whether the column with the given index is shown.
+// Hidden columns are skipped when drawing the table and when moving the
+// selection. Column indices are not affected by hiding columns.
+func (t *Table) SetColumnVisible(column int, visible bool) {
+	t.Lock()
+	if column < 0 || t.hiddenColumns[column] == !visible {
+		t.Unlock()
+		return
+	}
+
+	if visible {
+		delete(t.hiddenColumns, column)
+	} else {
+		t.hiddenColumns[column] = true
+	}
+
+	changed := t.columnVisibilityChanged
+	t.Unlock()
+
+	if changed != nil {
+		changed(column, visible)
+	}
+}
+
+// IsColumnVisible returns whether the column with the given index is shown.
+func (t *Table) IsColumnVisible(column int) bool {
+	t.RLock()
+	defer t.RUnlock()
+
+	return !t.hiddenColumns[column]
+}
+
+// SetHiddenColumns hides the columns with the given indices and shows all
+// other columns. This is typically used to restore the column visibility
+// stored via GetHiddenColumns. The column visibility changed handler is not
+// called.
+func (t *Table) SetHiddenColumns(columns []int) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.hiddenColumns = make(map[int]bool)
+	for _, column := range columns {
+		if column >= 0 {
+			t.hiddenColumns[column] = true
+		}
+	}
+}
+
+// GetHiddenColumns returns the indices of the hidden columns in ascending
+// order.
+func (t *Table) GetHiddenColumns() []int {
+	t.RLock()
+	defer t.RUnlock()
+
+	columns := make([]int, 0, len(t.hiddenColumns))
+	for column := range t.hiddenColumns {
+		columns = append(columns, column)
+	}
+	sort.Ints(columns)
+	return columns
+}
+
+// SetColumnVisibilityChangedFunc sets a handler which is called when the
+// visibility of a column is changed via SetColumnVisible or the column
+// chooser. This may be used to persist the column visibility, which is
+// restored via SetHiddenColumns.
+func (t *Table) SetColumnVisibilityChangedFunc(handler func(column int, visible bool)) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.columnVisibilityChanged = handler
+}
+
+// shiftHiddenColumns shifts the indices of the hidden columns at and after
+// the given column by the given amount.
+func (t *Table) shiftHiddenColumns(column int, amount int) {
+	hiddenColumns := make(map[int]bool)
+	for hidden := range t.hiddenColumns {
+		if hidden == column && amount < 0 {
+			continue // The column was removed.
+		} else if hidden >= column {
+			hidden += amount
+		}
+		hiddenColumns[hidden] = true
+	}
+	t.hiddenColumns = hiddenColumns
+}
+
+// columnName returns the name of the given column as shown in the column
+// chooser, which is the text of its first cell.
+func (t *Table) columnName(column int) string {
+	if cell := t.cell(0, column); cell != nil {
+		name := strings.TrimSpace(string(StripTags(cell.Text, true, false)))
+		if name != "" {
+			return name
+		}
+	}
+	return fmt.Sprintf("Column %d", column+1)
+}
+
+// SetColumnChooserEnabled sets a flag which determines whether the column
+// chooser is shown when the user presses Keys.ShowColumnChooser (Alt+c by
+// default). It is disabled by default, so that the user may not hide columns
+// unless the application allows it.
+func (t *Table) SetColumnChooserEnabled(enabled bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.columnChooserEnabled = enabled
+}
+
+// ShowColumnChooser shows a popup listing the columns of the table, which
+// allows the user to show and hide columns. The name of each column is the
+// text of its cell in the first row. The column chooser is also shown when
+// the user presses Keys.ShowColumnChooser, if enabled via
+// SetColumnChooserEnabled.
+func (t *Table) ShowColumnChooser(setFocus func(p Primitive)) {
+	t.Lock()
+
+	if t.columnChooser == nil {
+		t.columnChooser = NewCheckBoxGroup()
+		t.columnChooser.SetBorder(true)
+		t.columnChooser.SetTitle("Columns")
+		t.columnChooser.SetChangedFunc(func(index int, checked bool) {
+			t.SetColumnVisible(index, checked)
+		})
+		t.columnChooser.SetDoneFunc(func(key tcell.Key) {
+			t.RLock()
+			restoreFocus := t.columnChooserSetFocus
+			t.RUnlock()
+
+			t.HideColumnChooser(restoreFocus)
+		})
+	}
+	chooser := t.columnChooser
+
+	chooser.Lock()
+	chooser.options = nil
+	chooser.currentOption = 0
+	chooser.Unlock()
+	for column := 0; column < t.columnCount(); column++ {
+		chooser.AddOption(t.columnName(column), !t.hiddenColumns[column])
+	}
+
+	t.columnChooserOpen = true
+	t.columnChooserSetFocus = setFocus
+	t.Unlock()
+
+	if setFocus != nil {
+		setFocus(t)
+	}
+}
+
+// HideColumnChooser hides the column chooser.
+func (t *Table) HideColumnChooser(setFocus func(p Primitive)) {
+	t.Lock()
+	t.columnChooserOpen = false
+	t.Unlock()
+
+	if setFocus != nil {
+		setFocus(t)
+	}
+}
+
+// ColumnChooserVisible returns whether the column chooser is visible.
+func (t *Table) ColumnChooserVisible() bool {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.columnChooserOpen
+}
+
+// drawColumnChooser draws the column chooser at the top left corner of the
+// table when it is open.
+func (t *Table) drawColumnChooser(screen tcell.Screen) {
+	if !t.columnChooserOpen {
+		return
+	}
+
+	x, y, width, height := t.GetInnerRect()
+	chooser := t.columnChooser
+	chooserWidth, chooserHeight := chooser.GetFieldWidth()+2, chooser.GetFieldHeight()+2
+	if chooserWidth > width {
+		chooserWidth = width
+	}
+	if chooserHeight > height {
+		chooserHeight = height
+	}
+	chooser.SetRect(x, y, chooserWidth, chooserHeight)
+	chooser.Draw(screen)
+}
+
+// Focus is called by the application when the primitive receives focus.
+func (t *Table) Focus(delegate func(p Primitive)) {
+	t.RLock()
+	chooser, open := t.columnChooser, t.columnChooserOpen
+	t.RUnlock()
+
+	if open {
+		delegate(chooser)
+		return
+	}
+	t.Box.Focus(delegate)
+}
+
+// HasFocus returns whether or not this primitive has focus.
+func (t *Table) HasFocus() bool {
+	t.RLock()
+	chooser, open := t.columnChooser, t.columnChooserOpen
+	t.RUnlock()
+
+	if open {
+		return chooser.HasFocus()
 	}
-	return t.lastColumn + 1
+	return t.Box.HasFocus()
 }
 
 // cellAt returns the row and column located at the given screen coordinates.
@@ -694,18 +1446,29 @@ func (t *Table) cellAt(x, y int) (row, column int) {
 	rectX, rectY, _, _ := t.GetInnerRect()
 
 	// Determine row as seen on screen.
-	if t.borders {
+	if t.rowLines != nil {
+		row = t.rowAtLine(y - rectY)
+	} else if t.borders {
 		row = (y - rectY - 1) / 2
 	} else {
 		row = y - rectY
+		if separator := t.fixedRowsSeparator(); separator > 0 && row >= t.fixedRows {
+			if row == t.fixedRows {
+				row = -1 // The separator was hit.
+			} else {
+				row -= separator
+			}
+		}
 	}
 
-	// Respect fixed rows and row offset.
-	if row >= 0 {
-		if row >= t.fixedRows {
-			row += t.rowOffset
+	// Respect fixed rows, footer rows and row offset.
+	if row >= 0 && t.rowLines == nil {
+		if t.footerLine >= 0 && row >= t.footerLine {
+			row += t.rowCount() - t.footerRowCount(t.rowCount()) - t.footerLine
+		} else if row >= t.fixedRows {
+			row = t.filteredRow(row + t.rowOffset)
 		}
-		if row >= len(t.cells) {
+		if row >= t.rowCount() {
 			row = -1
 		}
 	}
@@ -751,7 +1514,7 @@ func (t *Table) ScrollToEnd() {
 
 	t.trackEnd = true
 	t.columnOffset = 0
-	t.rowOffset = len(t.cells)
+	t.rowOffset = t.rowCount()
 }
 
 // SetSortClicked sets a flag which determines whether the table is sorted when
@@ -763,8 +1526,12 @@ func (t *Table) SetSortClicked(sortClicked bool) {
 	t.sortClicked = sortClicked
 }
 
-// SetSortFunc sets the sorting function used for the table. When unset, a
-// case-sensitive string comparison is used.
+// SetSortFunc sets the sorting function used for the table. The function
+// reports whether the row i sorts before the row j, where i and j are the
+// indices of the rows before sorting. When unset, cells are compared via
+// CompareTableCells, which compares typed values (see TableCell.SetInt and
+// related functions) according to their type and other cells by their text,
+// case-sensitively. Functions set via SetColumnSortFunc take precedence.
 func (t *Table) SetSortFunc(sortFunc func(column, i, j int) bool) {
 	t.Lock()
 	defer t.Unlock()
@@ -772,34 +1539,131 @@ func (t *Table) SetSortFunc(sortFunc func(column, i, j int) bool) {
 	t.sortFunc = sortFunc
 }
 
-// Sort sorts the table by the column at the given index. You may set a custom
-// sorting function with SetSortFunc.
+// SetColumnSortFunc sets the sorting function used when the table is sorted
+// by the column at the given index. The function reports whether the cell a
+// sorts before the cell b. Cells which were not set are passed as empty
+// cells. Provide nil to remove the function.
+func (t *Table) SetColumnSortFunc(column int, less func(a, b *TableCell) bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	if less == nil {
+		delete(t.columnSortFuncs, column)
+		return
+	}
+	if t.columnSortFuncs == nil {
+		t.columnSortFuncs = make(map[int]func(a, b *TableCell) bool)
+	}
+	t.columnSortFuncs[column] = less
+}
+
+// SetSortIndicators sets the indicators drawn at the end of the header cell
+// of the column the table is sorted by, which is the cell in the last fixed
+// row. The default indicators are ▲ for ascending and ▼ for descending order.
+// Provide 0 to draw no indicators.
+func (t *Table) SetSortIndicators(ascending, descending rune) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.sortIndicatorAscending, t.sortIndicatorDescending = ascending, descending
+}
+
+// Sort sorts the table by the column at the given index. Fixed rows are not
+// sorted. The selection follows the selected row. You may set a custom
+// sorting function with SetSortFunc or SetColumnSortFunc.
 func (t *Table) Sort(column int, descending bool) {
 	t.Lock()
 	defer t.Unlock()
 
-	if len(t.cells) == 0 || column < 0 || column >= len(t.cells[0]) {
+	if t.content != nil || len(t.cells) == 0 || column < 0 || column > t.lastColumn {
 		return
 	}
 
-	if t.sortFunc == nil {
-		t.sortFunc = func(column, i, j int) bool {
-			return bytes.Compare(t.cells[i][column].Text, t.cells[j][column].Text) == -1
+	less := t.sortFunc
+	if columnLess := t.columnSortFuncs[column]; columnLess != nil {
+		less = func(column, i, j int) bool {
+			return columnLess(t.cellOrEmpty(i, column), t.cellOrEmpty(j, column))
+		}
+	} else if less == nil {
+		less = func(column, i, j int) bool {
+			return CompareTableCells(t.cell(i, column), t.cell(j, column)) < 0
 		}
 	}
 
-	sort.SliceStable(t.cells, func(i, j int) bool {
-		if i < t.fixedRows {
-			return i < j
-		} else if j < t.fixedRows {
-			return j > i
+	// Sort the indices of the rows, then rearrange the rows.
+	order := make([]int, len(t.cells))
+	for i := range order {
+		order[i] = i
+	}
+	fixed := t.fixedRows
+	if fixed > len(order) {
+		fixed = len(order)
+	}
+	sorted := order[fixed : len(order)-t.footerRowCount(len(order))]
+	rowLess := func(a, b int) bool {
+		if descending {
+			return less(column, b, a)
 		}
+		return less(column, a, b)
+	}
+	if len(t.treeRows) > 0 {
+		t.sortTree(sorted, rowLess)
+	} else {
+		sort.SliceStable(sorted, func(a, b int) bool {
+			return rowLess(sorted[a], sorted[b])
+		})
+	}
 
-		if !descending {
-			return t.sortFunc(column, i, j)
+	cells := make([][]*TableCell, len(t.cells))
+	selectedRow := t.selectedRow
+	for to, from := range order {
+		cells[to] = t.cells[from]
+		if from == t.selectedRow {
+			selectedRow = to
 		}
-		return t.sortFunc(column, j, i)
-	})
+	}
+	t.cells = cells
+	t.reorderTreeRows(order)
+	t.selectedRow = selectedRow
+	t.sortColumn, t.sortDescending = column, descending
+	t.clearMultiSelection()
+}
+
+// SortByColumn sorts the table by the column at the given index in ascending
+// or descending order. See Sort.
+func (t *Table) SortByColumn(column int, ascending bool) {
+	t.Sort(column, !ascending)
+}
+
+// GetSortColumn returns the column the table was last sorted by and whether
+// it was sorted in ascending order. The column is -1 if the table was not
+// sorted.
+func (t *Table) GetSortColumn() (column int, ascending bool) {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.sortColumn, !t.sortDescending
+}
+
+// cellOrEmpty returns the cell at the provided position, or an empty cell if
+// there is no cell at the position.
+func (t *Table) cellOrEmpty(row, column int) *TableCell {
+	if cell := t.cell(row, column); cell != nil {
+		return cell
+	}
+	return &TableCell{}
+}
+
+// sortIndicator returns the sort indicator drawn in the cell at the provided
+// position, or 0 if there is none.
+func (t *Table) sortIndicator(row, column int) rune {
+	if t.fixedRows == 0 || row != t.fixedRows-1 || column != t.sortColumn {
+		return 0
+	}
+	if t.sortDescending {
+		return t.sortIndicatorDescending
+	}
+	return t.sortIndicatorAscending
 }
 
 // Draw draws this primitive onto the screen.
@@ -812,26 +1676,36 @@ func (t *Table) Draw(screen tcell.Screen) {
 
 	t.Lock()
 	defer t.Unlock()
+	defer t.drawColumnChooser(screen)
 
-	// What's our available screen space?
+	// What's our available screen space? (The separator below the fixed rows
+	// and the footer rows are added back before drawing.)
 	x, y, width, height := t.GetInnerRect()
+	rowCount, lastColumn := t.rowCount(), t.columnCount()-1
+	footerRows := t.footerRowCount(rowCount)
+	scrollRowCount := rowCount - footerRows // The number of rows without the footer rows.
+	fixedRowsSeparator, footerHeight := t.fixedRowsSeparator(), footerRows
+	if t.borders {
+		footerHeight *= 2
+	}
+	height -= fixedRowsSeparator + footerHeight
 	if t.borders {
 		t.visibleRows = height / 2
 	} else {
 		t.visibleRows = height
 	}
 
-	showVerticalScrollBar := t.scrollBarVisibility == ScrollBarAlways || (t.scrollBarVisibility == ScrollBarAuto && len(t.cells) > t.visibleRows-t.fixedRows)
-	if showVerticalScrollBar {
-		width-- // Subtract space for scroll bar.
+	// Determine the rows which pass the filter. Rows are counted in lines
+	// below, which exclude the rows hidden by the filter.
+	t.filterRows(scrollRowCount)
+	lineCount := scrollRowCount // The number of lines without the footer rows.
+	if t.filteredRows != nil && t.fixedRows < scrollRowCount {
+		lineCount = t.fixedRows + len(t.filteredRows)
 	}
 
-	// Return the cell at the specified position (nil if it doesn't exist).
-	getCell := func(row, column int) *TableCell {
-		if row < 0 || column < 0 || row >= len(t.cells) || column >= len(t.cells[row]) {
-			return nil
-		}
-		return t.cells[row][column]
+	showVerticalScrollBar := t.scrollBarVisibility == ScrollBarAlways || (t.scrollBarVisibility == ScrollBarAuto && lineCount > t.visibleRows-t.fixedRows)
+	if showVerticalScrollBar {
+		width-- // Subtract space for scroll bar.
 	}
 
 	// If this cell is not selectable, find the next one.
@@ -842,13 +1716,13 @@ func (t *Table) Draw(screen tcell.Screen) {
 		if t.selectedRow < 0 {
 			t.selectedRow = 0
 		}
-		for t.selectedRow < len(t.cells) {
-			cell := getCell(t.selectedRow, t.selectedColumn)
-			if cell == nil || !cell.NotSelectable {
+		for t.selectedRow < rowCount {
+			cell := t.cell(t.selectedRow, t.selectedColumn)
+			if (cell == nil || !cell.NotSelectable) && (!t.hiddenColumns[t.selectedColumn] || !t.columnsSelectable) && !t.isFilteredRow(t.selectedRow) {
 				break
 			}
 			t.selectedColumn++
-			if t.selectedColumn > t.lastColumn {
+			if t.selectedColumn > lastColumn {
 				t.selectedColumn = 0
 				t.selectedRow++
 			}
@@ -856,37 +1730,37 @@ func (t *Table) Draw(screen tcell.Screen) {
 	}
 
 	// Clamp row offsets.
-	if t.rowsSelectable {
-		if t.selectedRow >= t.fixedRows && t.selectedRow < t.fixedRows+t.rowOffset {
-			t.rowOffset = t.selectedRow - t.fixedRows
+	if selectedLine := t.filteredLine(t.selectedRow); t.rowsSelectable && t.selectedRow < scrollRowCount {
+		if selectedLine >= t.fixedRows && selectedLine < t.fixedRows+t.rowOffset {
+			t.rowOffset = selectedLine - t.fixedRows
 			t.trackEnd = false
 		}
 		if t.borders {
-			if 2*(t.selectedRow+1-t.rowOffset) >= height {
-				t.rowOffset = t.selectedRow + 1 - height/2
+			if 2*(selectedLine+1-t.rowOffset) >= height {
+				t.rowOffset = selectedLine + 1 - height/2
 				t.trackEnd = false
 			}
 		} else {
-			if t.selectedRow+1-t.rowOffset >= height {
-				t.rowOffset = t.selectedRow + 1 - height
+			if selectedLine+1-t.rowOffset >= height {
+				t.rowOffset = selectedLine + 1 - height
 				t.trackEnd = false
 			}
 		}
 	}
 	if t.borders {
-		if 2*(len(t.cells)-t.rowOffset) < height {
+		if 2*(lineCount-t.rowOffset) < height {
 			t.trackEnd = true
 		}
 	} else {
-		if len(t.cells)-t.rowOffset < height {
+		if lineCount-t.rowOffset < height {
 			t.trackEnd = true
 		}
 	}
 	if t.trackEnd {
 		if t.borders {
-			t.rowOffset = len(t.cells) - height/2
+			t.rowOffset = lineCount - height/2
 		} else {
-			t.rowOffset = len(t.cells) - height
+			t.rowOffset = lineCount - height
 		}
 	}
 	if t.rowOffset < 0 {
@@ -917,9 +1791,11 @@ func (t *Table) Draw(screen tcell.Screen) {
 		tableWidth = 1 // We start at the second character because of the left table border.
 	}
 	if t.evaluateAllRows {
-		allRows = make([]int, len(t.cells))
-		for row := range t.cells {
-			allRows[row] = row
+		allRows = make([]int, 0, rowCount)
+		for row := 0; row < rowCount; row++ {
+			if t.filteredRows == nil || !t.isFilteredRow(row) {
+				allRows = append(allRows, row)
+			}
 		}
 	}
 	indexRow := func(row int) bool { // Determine if this row is visible, store its index.
@@ -930,19 +1806,30 @@ func (t *Table) Draw(screen tcell.Screen) {
 		tableHeight += rowStep
 		return true
 	}
-	for row := 0; row < t.fixedRows && row < len(t.cells); row++ { // Do the fixed rows first.
+	for row := 0; row < t.fixedRows && row < rowCount; row++ { // Do the fixed rows first.
 		if !indexRow(row) {
 			break
 		}
 	}
-	for row := t.fixedRows + t.rowOffset; row < len(t.cells); row++ { // Then the remaining rows.
-		if !indexRow(row) {
+	for line := t.fixedRows + t.rowOffset; line < lineCount; line++ { // Then the remaining rows.
+		if !indexRow(t.filteredRow(line)) {
 			break
 		}
 	}
+	t.footerLine = -1
+	if footerRows > 0 {
+		height += footerHeight
+		t.footerLine = len(rows)
+		for row := scrollRowCount; row < rowCount; row++ { // And finally the footer rows.
+			if !indexRow(row) {
+				break
+			}
+		}
+	}
 	var (
 		skipped, lastTableWidth, expansionTotal int
 		expansions                              []int
+		wrapping                                bool // Whether the text of any cell may be wrapped.
 	)
 ColumnLoop:
 	for column := 0; ; column++ {
@@ -978,15 +1865,24 @@ ColumnLoop:
 		// What's this column's width (without expansion)?
 		maxWidth := -1
 		expansion := 0
+		if c := t.columnDefaults[column]; c != nil {
+			expansion = c.expansion
+		}
 		evaluationRows := rows
 		if t.evaluateAllRows {
 			evaluationRows = allRows
 		}
 		for _, row := range evaluationRows {
-			if cell := getCell(row, column); cell != nil {
+			if cell := t.cell(row, column); cell != nil {
 				_, _, _, _, _, _, cellWidth := decomposeText(cell.Text, true, false)
-				if cell.MaxWidth > 0 && cell.MaxWidth < cellWidth {
-					cellWidth = cell.MaxWidth
+				if cellMaxWidth := t.cellMaxWidth(cell, column); cellMaxWidth > 0 && cellMaxWidth < cellWidth {
+					cellWidth = cellMaxWidth
+				}
+				if indicator := t.sortIndicator(row, column); indicator != 0 {
+					cellWidth += 1 + stringWidth(string(indicator))
+				}
+				if prefix := t.treePrefix(row, column); prefix != nil {
+					cellWidth += stringWidth(string(prefix))
 				}
 				if cellWidth > maxWidth {
 					maxWidth = cellWidth
@@ -994,11 +1890,18 @@ ColumnLoop:
 				if cell.Expansion > expansion {
 					expansion = cell.Expansion
 				}
+				wrapping = wrapping || t.cellWraps(cell, column)
 			}
 		}
 		if maxWidth < 0 {
 			break // No more cells found in this column.
 		}
+		if t.hiddenColumns[column] {
+			continue
+		}
+		if columnWidth, ok := t.columnWidths[column]; ok {
+			maxWidth, expansion = columnWidth, 0
+		}
 
 		// Store new column info at the end.
 		columns = append(columns, column)
@@ -1025,23 +1928,83 @@ ColumnLoop:
 		tableWidth = width - toDistribute
 	}
 
+	// Determine the widths available to the text of each column.
+	finalWidths := make([]int, len(widths))
+	textX := 0
+	if t.borders {
+		textX = 1
+	}
+	for index, columnWidth := range widths {
+		finalWidths[index] = columnWidth
+		if textX+columnWidth >= width {
+			finalWidths[index] = width - textX
+		}
+		textX += columnWidth + 1
+	}
+
+	// Determine the height of each row, which is more than one line when the
+	// text of its cells is wrapped, and the line each row starts on.
+	rowHeights := make([]int, len(rows))
+	var wrapped bool
+	for index, row := range rows {
+		rowHeights[index] = 1
+		if wrapping {
+			rowHeights[index] = t.rowHeight(row, columns, finalWidths)
+			wrapped = wrapped || rowHeights[index] > 1
+		}
+	}
+	if wrapped {
+		rows, rowHeights = t.fitRows(rows, rowHeights, columns, finalWidths, height, lineCount)
+	}
+	rowTops := make([]int, len(rows))
+	var rowsHeight, fixedRowsHeight int
+	t.rowLines = nil
+	for index, row := range rows {
+		if row < t.fixedRows {
+			fixedRowsHeight = rowsHeight + rowHeights[index]
+		} else if index > 0 && rows[index-1] < t.fixedRows {
+			rowsHeight += fixedRowsSeparator
+		}
+		rowTops[index] = rowsHeight
+		if wrapped {
+			t.rowLines = append(t.rowLines, tableRowLines{row: row, top: rowsHeight, height: rowHeights[index]})
+		}
+		rowsHeight += rowHeights[index]
+		if t.borders {
+			rowsHeight++ // The border above the row.
+		}
+	}
+
 	// Helper function which draws border runes.
 	borderStyle := tcell.StyleDefault.Background(t.backgroundColor).Foreground(t.bordersColor)
 	drawBorder := func(colX, rowY int, ch rune) {
 		screen.SetContent(x+colX, y+rowY, ch, nil, borderStyle)
 	}
 
+	// The number of visible fixed columns, which are followed by a separator.
+	var fixedColumns int
+	for _, column := range columns {
+		if column < t.fixedColumns {
+			fixedColumns++
+		}
+	}
+	fixedColumnsSeparator := t.fixedSeparators && !t.borders && fixedColumns > 0
+
 	// Draw the cells (and borders).
-	var columnX int
+	height += fixedRowsSeparator
+	var columnX, fixedColumnsX int
 	if !t.borders {
 		columnX--
 	}
 	for columnIndex, column := range columns {
 		columnWidth := widths[columnIndex]
-		for rowY, row := range rows {
+		if columnIndex == fixedColumns {
+			fixedColumnsX = columnX
+		}
+		for rowIndex, row := range rows {
+			rowY, rowHeight := rowTops[rowIndex], rowHeights[rowIndex]
 			if t.borders {
 				// Draw borders.
-				rowY *= 2
 				for pos := 0; pos < columnWidth && columnX+1+pos < width; pos++ {
 					drawBorder(columnX+pos+1, rowY, Borders.Horizontal)
 				}
@@ -1060,14 +2023,26 @@ ColumnLoop:
 				if rowY >= height {
 					break // No space for the text anymore.
 				}
-				drawBorder(columnX, rowY, Borders.Vertical)
-			} else if columnIndex > 0 {
-				// Draw separator.
-				drawBorder(columnX, rowY, t.separator)
+				for line := 0; line < rowHeight && rowY+line < height; line++ {
+					drawBorder(columnX, rowY+line, Borders.Vertical)
+				}
+			} else {
+				for line := 0; line < rowHeight; line++ {
+					if fixedColumnsSeparator && columnIndex == fixedColumns {
+						// Draw separator after the fixed columns.
+						drawBorder(columnX, rowY+line, Borders.Vertical)
+					} else if columnIndex > 0 && t.headerSeparators && t.isHeaderRow(row) {
+						// Draw separator between the header cells.
+						drawBorder(columnX, rowY+line, Borders.Vertical)
+					} else if columnIndex > 0 {
+						// Draw separator.
+						drawBorder(columnX, rowY+line, t.separator)
+					}
+				}
 			}
 
 			// Get the cell.
-			cell := getCell(row, column)
+			cell := t.cell(row, column)
 			if cell == nil {
 				continue
 			}
@@ -1078,15 +2053,49 @@ ColumnLoop:
 				finalWidth = width - columnX - 1
 			}
 			cell.x, cell.y, cell.width = x+columnX+1, y+rowY, finalWidth
-			_, printed := PrintStyle(screen, cell.Text, x+columnX+1, y+rowY, finalWidth, cell.Align, SetAttributes(tcell.StyleDefault.Foreground(cell.Color), cell.Attributes))
-			if TaggedTextWidth(cell.Text)-printed > 0 && printed > 0 {
-				_, _, style, _ := screen.GetContent(x+columnX+finalWidth, y+rowY)
-				PrintStyle(screen, []byte(string(SemigraphicsHorizontalEllipsis)), x+columnX+finalWidth, y+rowY, 1, AlignLeft, style)
+			cellStyle := SetAttributes(tcell.StyleDefault.Foreground(t.cellTextColor(cell)), cell.Attributes)
+			if t.isHeaderRow(row) {
+				cellStyle = rowCellStyle(cellStyle, t.headerStyle)
+			} else if t.footerLine >= 0 && rowIndex >= t.footerLine {
+				cellStyle = rowCellStyle(cellStyle, t.footerStyle)
+			}
+			textX, textWidth := x+columnX+1, finalWidth
+			indicator := t.sortIndicator(row, column)
+			if indicator != 0 {
+				textWidth -= 1 + stringWidth(string(indicator))
+			}
+
+			// Draw the indentation and indicator of a tree-table row.
+			if prefix := t.treePrefix(row, column); prefix != nil && textWidth > 0 {
+				_, printed := PrintStyle(screen, prefix, textX, y+rowY, textWidth, AlignLeft, cellStyle)
+				textX += printed
+				textWidth -= printed
+			}
+
+			lines := t.cellLines(cell, column, textWidth)
+			for line := 0; line < rowHeight && line < len(lines) && rowY+line < height; line++ {
+				lineY := y + rowY + line
+				_, printed := PrintStyle(screen, lines[line], textX, lineY, textWidth, t.cellAlign(cell, column), cellStyle)
+				if c := t.columnDefaults[column]; TaggedTextWidth(lines[line])-printed > 0 && printed > 0 && (c == nil || !c.noEllipsis) {
+					_, _, style, _ := screen.GetContent(textX+textWidth-1, lineY)
+					PrintStyle(screen, []byte(string(SemigraphicsHorizontalEllipsis)), textX+textWidth-1, lineY, 1, AlignLeft, style)
+				}
+
+				// Highlight the filter text once the cell backgrounds are drawn.
+				if row >= t.fixedRows && (t.footerLine < 0 || rowIndex < t.footerLine) {
+					defer t.highlightFilter(screen, textX, lineY, textWidth)
+				}
+			}
+
+			// Draw the sort indicator.
+			if indicator != 0 && textWidth >= 0 {
+				indicatorWidth := stringWidth(string(indicator))
+				PrintStyle(screen, []byte(string(indicator)), x+columnX+1+finalWidth-indicatorWidth, y+rowY, indicatorWidth, AlignLeft, cellStyle)
 			}
 		}
 
 		// Draw bottom border.
-		if rowY := 2 * len(rows); t.borders && rowY < height {
+		if rowY := rowsHeight; t.borders && rowY < height {
 			for pos := 0; pos < columnWidth && columnX+1+pos < width; pos++ {
 				drawBorder(columnX+pos+1, rowY, Borders.Horizontal)
 			}
@@ -1100,12 +2109,22 @@ ColumnLoop:
 		columnX += columnWidth + 1
 	}
 
+	// Draw separator below the fixed rows.
+	if fixedRowsSeparator > 0 && len(rows) > 0 && fixedRowsHeight < height {
+		for separatorX := 0; separatorX < columnX && separatorX < width; separatorX++ {
+			ch := Borders.Horizontal
+			if fixedColumnsSeparator && separatorX == fixedColumnsX && fixedColumns < len(columns) {
+				ch = Borders.Cross
+			}
+			drawBorder(separatorX, fixedRowsHeight, ch)
+		}
+	}
+
 	// Draw right border.
-	if t.borders && len(t.cells) > 0 && columnX < width {
-		for rowY := range rows {
-			rowY *= 2
-			if rowY+1 < height {
-				drawBorder(columnX, rowY+1, Borders.Vertical)
+	if t.borders && rowCount > 0 && columnX < width {
+		for index, rowY := range rowTops {
+			for line := 1; line <= rowHeights[index] && rowY+line < height; line++ {
+				drawBorder(columnX, rowY+line, Borders.Vertical)
 			}
 			ch := Borders.RightT
 			if rowY == 0 {
@@ -1113,20 +2132,20 @@ ColumnLoop:
 			}
 			drawBorder(columnX, rowY, ch)
 		}
-		if rowY := 2 * len(rows); rowY < height {
+		if rowY := rowsHeight; rowY < height {
 			drawBorder(columnX, rowY, Borders.BottomRight)
 		}
 	}
 
 	if showVerticalScrollBar {
 		// Calculate scroll bar position and dimensions.
-		rows := len(t.cells)
+		rows := lineCount
 
 		scrollBarItems := rows - t.fixedRows
 		scrollBarHeight := t.visibleRows - t.fixedRows
 
 		scrollBarX := x + width
-		scrollBarY := y + t.fixedRows
+		scrollBarY := y + t.fixedRows + fixedRowsSeparator
 		if scrollBarX > x+tableWidth {
 			scrollBarX = x + tableWidth
 		}
@@ -1186,43 +2205,84 @@ ColumnLoop:
 		}
 	}
 
+	// Helper function which underlines the text of a box.
+	underline := func(fromX, fromY, w, h int) {
+		if t.borders {
+			fromY, h = fromY+1, 1
+		}
+		for by := 0; by < h && fromY+by < y+height; by++ {
+			for bx := 0; bx < w && fromX+bx < x+width; bx++ {
+				m, c, style, _ := screen.GetContent(fromX+bx, fromY+by)
+				screen.SetContent(fromX+bx, fromY+by, m, c, style.Underline(true))
+			}
+		}
+	}
+
 	// Color the cell backgrounds. To avoid undesirable artefacts, we combine
 	// the drawing of a cell by background color, selected cells last.
 	type cellInfo struct {
 		x, y, w, h int
 		color      tcell.Color
 		selected   bool
+		moving     bool
 	}
 	cellsByBackgroundColor := make(map[tcell.Color][]*cellInfo)
 	var backgroundColors []tcell.Color
-	for rowY, row := range rows {
+	for rowIndex, row := range rows {
 		columnX := 0
 		rowSelected := t.rowsSelectable && !t.columnsSelectable && row == t.selectedRow
 		for columnIndex, column := range columns {
 			columnWidth := widths[columnIndex]
-			cell := getCell(row, column)
+			cell := t.cell(row, column)
 			if cell == nil {
 				continue
 			}
-			bx, by, bw, bh := x+columnX, y+rowY, columnWidth+1, 1
+			bx, by, bw, bh := x+columnX, y+rowTops[rowIndex], columnWidth+1, rowHeights[rowIndex]
 			if t.borders {
-				by = y + rowY*2
 				bw++
-				bh = 3
+				bh += 2
 			}
 			columnSelected := t.columnsSelectable && !t.rowsSelectable && column == t.selectedColumn
-			cellSelected := !cell.NotSelectable && (columnSelected || rowSelected || t.rowsSelectable && t.columnsSelectable && column == t.selectedColumn && row == t.selectedRow)
-			entries, ok := cellsByBackgroundColor[cell.BackgroundColor]
-			cellsByBackgroundColor[cell.BackgroundColor] = append(entries, &cellInfo{
+			cursor := columnSelected || rowSelected || t.rowsSelectable && t.columnsSelectable && column == t.selectedColumn && row == t.selectedRow
+			cellSelected := !cell.NotSelectable && (cursor || t.isMultiSelected(row, column))
+			backgroundColor := cell.BackgroundColor
+			if t.isHeaderRow(row) {
+				cellSelected = false
+				if _, bg, _ := t.headerStyle.Decompose(); bg != tcell.ColorDefault {
+					backgroundColor = bg
+				}
+			} else if footer := t.footerLine >= 0 && rowIndex >= t.footerLine; footer {
+				cellSelected = false
+				if _, bg, _ := t.footerStyle.Decompose(); bg != tcell.ColorDefault {
+					backgroundColor = bg
+				}
+			}
+			if t.findMatchSet[[2]int{row, column}] && !cellSelected {
+				// Highlight the match once the cell backgrounds are drawn.
+				mx, my, mw, mh := bx, by, columnWidth, rowHeights[rowIndex]
+				if t.borders {
+					mx, my = mx+1, my+1
+				}
+				if mx+mw > x+width {
+					mw = x + width - mx
+				}
+				if my+mh > y+height {
+					mh = y + height - my
+				}
+				defer t.highlightMatch(screen, mx, my, mw, mh)
+			}
+			entries, ok := cellsByBackgroundColor[backgroundColor]
+			cellsByBackgroundColor[backgroundColor] = append(entries, &cellInfo{
 				x:        bx,
 				y:        by,
 				w:        bw,
 				h:        bh,
-				color:    cell.Color,
+				color:    t.cellTextColor(cell),
 				selected: cellSelected,
+				moving:   t.movingRow && row == t.selectedRow || cursor && len(t.multiSelection) > 0,
 			})
 			if !ok {
-				backgroundColors = append(backgroundColors, cell.BackgroundColor)
+				backgroundColors = append(backgroundColors, backgroundColor)
 			}
 			columnX += columnWidth + 1
 		}
@@ -1241,6 +2301,10 @@ ColumnLoop:
 	for _, bgColor := range backgroundColors {
 		entries := cellsByBackgroundColor[bgColor]
 		for _, cell := range entries {
+			if cell.moving {
+				// Underline the row being moved once it is drawn as selected.
+				defer underline(cell.x, cell.y, cell.w, cell.h)
+			}
 			if cell.selected {
 				if t.selectedStyle != tcell.StyleDefault {
 					defer colorBackground(cell.x, cell.y, cell.w, cell.h, selBg, selFg, selAttr, false)
@@ -1255,24 +2319,59 @@ ColumnLoop:
 
 	// Remember column infos.
 	t.visibleColumnIndices, t.visibleColumnWidths = columns, widths
+	t.visibleRowIndices = rows
 }
 
 // InputHandler returns the handler for this primitive.
 func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
 	return t.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		var calls callbacks
+		defer calls.run()
+
 		t.Lock()
 		defer t.Unlock()
 
 		key := event.Key()
 
+		// Move the selected row.
+		if t.movingRow {
+			if HitShortcut(event, Keys.MoveUp, Keys.MoveUp2) {
+				t.moveSelectedRow(&calls, t.selectedRow-1)
+			} else if HitShortcut(event, Keys.MoveDown, Keys.MoveDown2) {
+				t.moveSelectedRow(&calls, t.selectedRow+1)
+			} else if HitShortcut(event, Keys.MoveFirst, Keys.MoveFirst2) {
+				t.moveSelectedRow(&calls, t.fixedRows)
+			} else if HitShortcut(event, Keys.MoveLast, Keys.MoveLast2) {
+				t.moveSelectedRow(&calls, len(t.cells)-1)
+			} else if HitShortcut(event, Keys.MoveRow, Keys.Select, Keys.Cancel) {
+				t.movingRow = false
+			}
+			return
+		} else if t.rowsMovable && t.rowsSelectable && t.content == nil && t.filter == nil && len(t.treeRows) == 0 && HitShortcut(event, Keys.MoveRow) {
+			if t.selectedRow >= t.fixedRows && t.selectedRow < len(t.cells) {
+				t.movingRow = true
+			}
+			return
+		}
+
+		// Move the selected column.
+		if t.columnsMovable && t.columnsSelectable && t.content == nil && HitShortcut(event, Keys.MoveColumnLeft, Keys.MoveColumnRight) {
+			if HitShortcut(event, Keys.MoveColumnLeft) {
+				t.moveSelectedColumn(&calls, -1)
+			} else {
+				t.moveSelectedColumn(&calls, 1)
+			}
+			return
+		}
+
 		if (!t.rowsSelectable && !t.columnsSelectable && key == tcell.KeyEnter) ||
 			key == tcell.KeyEscape ||
 			key == tcell.KeyTab ||
 			key == tcell.KeyBacktab {
-			if t.done != nil {
-				t.Unlock()
-				t.done(key)
-				t.Lock()
+			if done := t.done; done != nil {
+				calls.add(func() {
+					done(key)
+				})
 			}
 			return
 		}
@@ -1281,10 +2380,10 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 		previouslySelectedRow, previouslySelectedColumn := t.selectedRow, t.selectedColumn
 		var (
 			validSelection = func(row, column int) bool {
-				if row < t.fixedRows || row >= len(t.cells) || column < t.fixedColumns || column > t.lastColumn {
+				if row < t.fixedRows || row >= t.rowCount() || t.isFooterRow(row) || t.isFilteredRow(row) || column < t.fixedColumns || column >= t.columnCount() {
 					return false
 				}
-				cell := t.cells[row][column]
+				cell := t.cell(row, column)
 				return cell == nil || !cell.NotSelectable
 			}
 
@@ -1301,8 +2400,11 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 
 			end = func() {
 				if t.rowsSelectable {
-					t.selectedRow = len(t.cells) - 1
-					t.selectedColumn = t.lastColumn
+					t.selectedRow = t.rowCount() - t.footerRowCount(t.rowCount()) - 1
+					for t.selectedRow > t.fixedRows && t.isFilteredRow(t.selectedRow) {
+						t.selectedRow--
+					}
+					t.selectedColumn = t.columnCount() - 1
 				} else {
 					t.trackEnd = true
 					t.columnOffset = 0
@@ -1311,8 +2413,12 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 
 			down = func() {
 				if t.rowsSelectable {
-					if validSelection(t.selectedRow+1, t.selectedColumn) {
-						t.selectedRow++
+					row := t.selectedRow + 1
+					for row < t.rowCount() && t.isFilteredRow(row) {
+						row++
+					}
+					if validSelection(row, t.selectedColumn) {
+						t.selectedRow = row
 					}
 				} else {
 					t.rowOffset++
@@ -1321,8 +2427,12 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 
 			up = func() {
 				if t.rowsSelectable {
-					if validSelection(t.selectedRow-1, t.selectedColumn) {
-						t.selectedRow--
+					row := t.selectedRow - 1
+					for row >= t.fixedRows && t.isFilteredRow(row) {
+						row--
+					}
+					if validSelection(row, t.selectedColumn) {
+						t.selectedRow = row
 					}
 				} else {
 					t.trackEnd = false
@@ -1332,8 +2442,12 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 
 			left = func() {
 				if t.columnsSelectable {
-					if validSelection(t.selectedRow, t.selectedColumn-1) {
-						t.selectedColumn--
+					column := t.selectedColumn - 1
+					for column >= 0 && t.hiddenColumns[column] {
+						column--
+					}
+					if validSelection(t.selectedRow, column) {
+						t.selectedColumn = column
 					}
 				} else {
 					t.columnOffset--
@@ -1342,36 +2456,33 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 
 			right = func() {
 				if t.columnsSelectable {
-					if validSelection(t.selectedRow, t.selectedColumn+1) {
-						t.selectedColumn++
+					column := t.selectedColumn + 1
+					for column < t.columnCount() && t.hiddenColumns[column] {
+						column++
+					}
+					if validSelection(t.selectedRow, column) {
+						t.selectedColumn = column
 					}
 				} else {
 					t.columnOffset++
 				}
 			}
 
-			pageDown = func() {
-				offsetAmount := t.visibleRows - t.fixedRows
-				if offsetAmount < 0 {
-					offsetAmount = 0
-				}
-
+			pageDown = func(offsetAmount int) {
 				if t.rowsSelectable {
 					t.selectedRow += offsetAmount
-					if t.selectedRow >= len(t.cells) {
-						t.selectedRow = len(t.cells) - 1
+					if last := t.rowCount() - t.footerRowCount(t.rowCount()) - 1; t.selectedRow > last {
+						t.selectedRow = last
+					}
+					for t.selectedRow > t.fixedRows && t.isFilteredRow(t.selectedRow) {
+						t.selectedRow--
 					}
 				} else {
 					t.rowOffset += offsetAmount
 				}
 			}
 
-			pageUp = func() {
-				offsetAmount := t.visibleRows - t.fixedRows
-				if offsetAmount < 0 {
-					offsetAmount = 0
-				}
-
+			pageUp = func(offsetAmount int) {
 				if t.rowsSelectable {
 					t.selectedRow -= offsetAmount
 					if t.selectedRow < 0 {
@@ -1384,35 +2495,78 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 			}
 		)
 
-		if HitShortcut(event, Keys.MoveFirst, Keys.MoveFirst2) {
+		// The number of rows by which a page is moved.
+		pageSize := t.visibleRows - t.fixedRows
+		if pageSize < 0 {
+			pageSize = 0
+		}
+
+		// With Vim keys, the top is reached by pressing g twice.
+		vimPrefix := t.vimPrefix
+		t.vimPrefix = false
+		if t.vimKeys && !vimPrefix && HitShortcut(event, Keys.MoveFirst2) {
+			t.vimPrefix = true
+			return
+		}
+
+		// Extend the selected ranges when moving the selection while holding
+		// Shift.
+		extend := t.multiSelect && (t.rowsSelectable || t.columnsSelectable) &&
+			HitShortcut(event, Keys.SelectUp, Keys.SelectDown, Keys.SelectLeft, Keys.SelectRight, Keys.SelectFirst, Keys.SelectLast, Keys.SelectPreviousPage, Keys.SelectNextPage)
+		if extend {
+			t.startExtendingSelection()
+		} else {
+			t.extendingSelection = false
+		}
+
+		if HitShortcut(event, Keys.MoveFirst, Keys.MoveFirst2) || extend && HitShortcut(event, Keys.SelectFirst) {
 			home()
-		} else if HitShortcut(event, Keys.MoveLast, Keys.MoveLast2) {
+		} else if HitShortcut(event, Keys.MoveLast, Keys.MoveLast2) || extend && HitShortcut(event, Keys.SelectLast) {
 			end()
-		} else if HitShortcut(event, Keys.MoveUp, Keys.MoveUp2) {
+		} else if HitShortcut(event, Keys.MoveUp, Keys.MoveUp2) || extend && HitShortcut(event, Keys.SelectUp) {
 			up()
-		} else if HitShortcut(event, Keys.MoveDown, Keys.MoveDown2) {
+		} else if HitShortcut(event, Keys.MoveDown, Keys.MoveDown2) || extend && HitShortcut(event, Keys.SelectDown) {
 			down()
-		} else if HitShortcut(event, Keys.MoveLeft, Keys.MoveLeft2) {
+		} else if HitShortcut(event, Keys.MoveLeft, Keys.MoveLeft2) || extend && HitShortcut(event, Keys.SelectLeft) {
 			left()
-		} else if HitShortcut(event, Keys.MoveRight, Keys.MoveRight2) {
+		} else if HitShortcut(event, Keys.MoveRight, Keys.MoveRight2) || extend && HitShortcut(event, Keys.SelectRight) {
 			right()
-		} else if HitShortcut(event, Keys.MovePreviousPage) {
-			pageUp()
-		} else if HitShortcut(event, Keys.MoveNextPage) {
-			pageDown()
+		} else if HitShortcut(event, Keys.MovePreviousPage) || extend && HitShortcut(event, Keys.SelectPreviousPage) {
+			pageUp(pageSize)
+		} else if HitShortcut(event, Keys.MoveNextPage) || extend && HitShortcut(event, Keys.SelectNextPage) {
+			pageDown(pageSize)
+		} else if t.vimKeys && HitShortcut(event, Keys.MoveHalfPageUp) {
+			pageUp((pageSize + 1) / 2)
+		} else if t.vimKeys && HitShortcut(event, Keys.MoveHalfPageDown) {
+			pageDown((pageSize + 1) / 2)
+		} else if t.columnChooserEnabled && HitShortcut(event, Keys.ShowColumnChooser) {
+			calls.add(func() {
+				t.ShowColumnChooser(setFocus)
+			})
+		} else if t.rowsSelectable && HitShortcut(event, Keys.ExpandRow) {
+			t.expandRow(&calls, t.selectedRow, true)
+		} else if t.rowsSelectable && HitShortcut(event, Keys.CollapseRow) {
+			t.expandRow(&calls, t.selectedRow, false)
+		} else if t.multiSelect && (t.rowsSelectable || t.columnsSelectable) && HitShortcut(event, Keys.ToggleSelection) {
+			t.toggleSelection(t.selectedRow, t.selectedColumn)
 		} else if HitShortcut(event, Keys.Select, Keys.Select2) {
-			if (t.rowsSelectable || t.columnsSelectable) && t.selected != nil {
-				t.Unlock()
-				t.selected(t.selectedRow, t.selectedColumn)
-				t.Lock()
+			if selected := t.selected; (t.rowsSelectable || t.columnsSelectable) && selected != nil {
+				row, column := t.selectedRow, t.selectedColumn
+				calls.add(func() {
+					selected(row, column)
+				})
 			}
 		}
 
 		// If the selection has changed, notify the handler.
-		if t.selectionChanged != nil && ((t.rowsSelectable && previouslySelectedRow != t.selectedRow) || (t.columnsSelectable && previouslySelectedColumn != t.selectedColumn)) {
-			t.Unlock()
-			t.selectionChanged(t.selectedRow, t.selectedColumn)
-			t.Lock()
+		if extend {
+			t.extendSelection()
+		}
+		if selectionChanged := t.selectionChanged; selectionChanged != nil && ((t.rowsSelectable && previouslySelectedRow != t.selectedRow) || (t.columnsSelectable && previouslySelectedColumn != t.selectedColumn)) {
+			row, column := t.selectedRow, t.selectedColumn
+			calls.add(func() {
+				selectionChanged(row, column)
+			})
 		}
 	})
 }
@@ -1420,12 +2574,122 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 // MouseHandler returns the mouse handler for this primitive.
 func (t *Table) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return t.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		var calls callbacks
+		defer calls.run()
+
 		x, y := event.Position()
+
+		// Move the dragged row.
+		t.Lock()
+		if t.draggingRow {
+			switch action {
+			case MouseDrag:
+				t.moveSelectedRow(&calls, t.dragTarget(y))
+			case MouseDragEnd:
+				t.draggingRow, t.movingRow = false, false
+				t.Unlock()
+				return true, nil
+			}
+			t.Unlock()
+			return true, t
+		}
+
+		// Move the dragged column.
+		if t.draggingColumn {
+			switch action {
+			case MouseDrag:
+				if _, column := t.cellAt(x, y); column >= 0 && column != t.dragColumn {
+					t.dragColumn = t.moveColumnTo(&calls, t.dragColumn, column)
+				}
+			case MouseDragEnd:
+				t.draggingColumn = false
+				t.dragColumn = -1
+				t.Unlock()
+				return true, nil
+			}
+			t.Unlock()
+			return true, t
+		}
+
+		// Resize the dragged column.
+		if t.resizingColumn {
+			switch action {
+			case MouseDrag:
+				t.resizeColumnTo(x)
+			case MouseDragEnd:
+				t.resizingColumn = false
+				if t.columnResized != nil {
+					handler, column, width := t.columnResized, t.resizeColumn, t.columnWidths[t.resizeColumn]
+					calls.add(func() { handler(column, width) })
+				}
+				t.resizeColumn = -1
+				t.Unlock()
+				return true, nil
+			}
+			t.Unlock()
+			return true, t
+		}
+		t.Unlock()
+
 		if !t.InRect(x, y) {
 			return false, nil
 		}
 
+		// Pass events to the column chooser.
+		t.RLock()
+		chooser, chooserOpen := t.columnChooser, t.columnChooserOpen
+		t.RUnlock()
+		if chooserOpen {
+			if chooser.InRect(x, y) {
+				return chooser.MouseHandler()(action, event, setFocus)
+			} else if action == MouseLeftClick {
+				t.HideColumnChooser(setFocus)
+				return true, nil
+			}
+		}
+
 		switch action {
+		case MouseLeftDown:
+			t.Lock()
+			t.dragRow = -1
+			if row, _ := t.cellAt(x, y); t.rowsMovable && t.rowsSelectable && t.content == nil && t.filter == nil && len(t.treeRows) == 0 && row >= t.fixedRows {
+				t.dragRow = row
+			}
+			t.resizeColumn = t.columnBoundaryAt(x, y)
+			if t.resizeColumn >= 0 {
+				t.resizeStartX, t.resizeStartWidth = x, t.visibleColumnWidth(t.resizeColumn)
+			}
+			t.dragColumn = -1
+			if row, column := t.cellAt(x, y); t.columnsMovable && t.content == nil && t.resizeColumn < 0 && row >= 0 && row < t.fixedRows && column >= t.fixedColumns {
+				t.dragColumn = column
+			}
+			t.Unlock()
+		case MouseDragStart:
+			t.Lock()
+			if t.resizeColumn >= 0 {
+				t.resizingColumn = true
+				t.resizeColumnTo(x)
+				t.Unlock()
+				return true, t
+			}
+			if t.dragColumn >= 0 {
+				t.draggingColumn = true
+				t.Unlock()
+
+				setFocus(t)
+				return true, t
+			}
+			if t.dragRow < 0 || t.dragRow >= len(t.cells) {
+				t.Unlock()
+				return false, nil
+			}
+			t.selectedRow = t.dragRow
+			t.draggingRow, t.movingRow = true, true
+			t.moveSelectedRow(&calls, t.dragTarget(y))
+			t.Unlock()
+
+			setFocus(t)
+			return true, t
 		case MouseLeftClick:
 			_, tableY, _, _ := t.GetInnerRect()
 			mul := 1
@@ -1435,21 +2699,30 @@ func (t *Table) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 				maxY = tableY + 1
 			}
 
-			if t.sortClicked && t.fixedRows > 0 && (y >= tableY && y < maxY+(t.fixedRows*mul)) {
+			t.RLock()
+			resizeColumn := t.resizeColumn
+			t.RUnlock()
+
+			// Clicks on column boundaries do not sort the table.
+			if resizeColumn < 0 && t.sortClicked && t.fixedRows > 0 && (y >= tableY && y < maxY+(t.fixedRows*mul)) {
 				_, column := t.cellAt(x, y)
-				if t.sortClickedColumn != column {
-					t.sortClickedColumn = column
-					t.sortClickedDescending = false
-				} else {
-					t.sortClickedDescending = !t.sortClickedDescending
-				}
-				t.Sort(column, t.sortClickedDescending)
+				t.RLock()
+				descending := column == t.sortColumn && !t.sortDescending
+				t.RUnlock()
+				t.Sort(column, descending)
 
 				if t.columnsSelectable {
 					t.selectedColumn = column
 				}
-			} else if t.rowsSelectable || t.columnsSelectable {
-				t.Select(t.cellAt(x, y))
+			} else if !t.expandClicked(&calls, x, y) && (t.rowsSelectable || t.columnsSelectable) {
+				row, column := t.cellAt(x, y)
+				t.RLock()
+				footer := t.isFooterRow(row)
+				t.RUnlock()
+				if !footer {
+					t.selectClicked(row, column, event.Modifiers())
+					t.Select(row, column)
+				}
 			}
 
 			consumed = true
@@ -2,7 +2,11 @@ package cview
 
 import (
 	"fmt"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
 )
 
 var tableTestCases = generateTableTestCases()
@@ -51,6 +55,218 @@ func TestTable(t *testing.T) {
 	}
 }
 
+func TestTableCellValues(t *testing.T) {
+	t.Parallel()
+
+	// Formatting
+
+	cell := NewTableCell("")
+	for _, c := range []struct {
+		set      func()
+		expected string
+		align    int
+	}{
+		{func() { cell.SetInt(-1234567) }, "-1,234,567", AlignRight},
+		{func() { cell.SetFloat(1234.5, 2) }, "1,234.50", AlignRight},
+		{func() { cell.SetFloat(0.125, -1) }, "0.125", AlignRight},
+		{func() { cell.SetByteSize(1000) }, "1,000 B", AlignRight},
+		{func() { cell.SetByteSize(1536) }, "1.5 KiB", AlignRight},
+		{func() { cell.SetByteSize(3 << 30) }, "3.0 GiB", AlignRight},
+		{func() { cell.SetTime(time.Date(2020, 1, 2, 3, 4, 0, 0, time.UTC)) }, "2020-01-02 03:04", AlignLeft},
+		{func() { cell.SetTime(time.Time{}) }, "", AlignLeft},
+		{func() { cell.SetBool(true) }, "true", AlignLeft},
+	} {
+		c.set()
+		if text := cell.GetText(); text != c.expected || cell.Align != c.align {
+			t.Errorf("failed to format value: expected %q aligned %d, got %q aligned %d", c.expected, c.align, text, cell.Align)
+		}
+	}
+
+	cell.SetText("text")
+	if cell.GetValueType() != TableCellText || cell.GetValue() != nil {
+		t.Errorf("failed to reset value: got type %d and value %v", cell.GetValueType(), cell.GetValue())
+	}
+
+	// Table format
+
+	table := NewTable()
+	price := NewTableCell("")
+	price.SetFloat(1234.5, 2)
+	table.SetCell(0, 0, price)
+	table.SetCellFormat(CellFormat{ThousandsSeparator: ".", DecimalSeparator: ",", True: "ja", False: "nein"})
+	if text := price.GetText(); text != "1.234,50" {
+		t.Errorf("failed to apply table format: expected 1.234,50, got %s", text)
+	}
+	flag := NewTableCell("")
+	flag.SetBool(false)
+	table.SetCell(0, 1, flag)
+	if text := flag.GetText(); text != "nein" {
+		t.Errorf("failed to apply table format to added cell: expected nein, got %s", text)
+	}
+
+	// Sorting
+
+	table = NewTable()
+	table.SetFixed(1, 0)
+	table.SetCellSimple(0, 0, "Size")
+	for row, size := range []int64{100, 2 << 20, 9, 1 << 10} {
+		cell := NewTableCell("")
+		cell.SetByteSize(size)
+		table.SetCell(row+1, 0, cell)
+	}
+	table.Sort(0, false)
+	var sorted []string
+	for row := 0; row < table.GetRowCount(); row++ {
+		sorted = append(sorted, table.GetCell(row, 0).GetText())
+	}
+	if expected := "Size|9 B|100 B|1.0 KiB|2.0 MiB"; strings.Join(sorted, "|") != expected {
+		t.Errorf("failed to sort typed cells: expected %s, got %s", expected, strings.Join(sorted, "|"))
+	}
+
+	a, b := NewTableCell(""), NewTableCell("")
+	a.SetInt(2)
+	b.SetFloat(10, 0)
+	if CompareTableCells(a, b) != -1 || CompareTableCells(b, a) != 1 || CompareTableCells(nil, a) != -1 {
+		t.Error("failed to compare numbers numerically")
+	}
+}
+
+func TestTablePaste(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	table := NewTable()
+	table.SetSelectable(true, true)
+	header := NewTableCell("Header")
+	header.SetSelectable(false)
+	table.SetCell(0, 0, header)
+	table.SetCellSimple(1, 1, "B")
+	table.Select(1, 1)
+
+	table.SetPasteFunc(func(row, column int, text string) (string, bool) {
+		if text == "veto" {
+			return "", false
+		}
+		return strings.ToUpper(text), true
+	})
+
+	// Paste
+
+	table.Paste("a\tb\r\nveto\tc\n")
+
+	expected := map[[2]int]string{
+		{0, 0}: "Header",
+		{1, 1}: "A",
+		{1, 2}: "B",
+		{2, 1}: "",
+		{2, 2}: "C",
+	}
+	for position, text := range expected {
+		if cellText := table.GetCell(position[0], position[1]).GetText(); cellText != text {
+			t.Errorf("failed to paste into cell %d,%d: expected %q, got %q", position[0], position[1], text, cellText)
+		}
+	}
+
+	// Paste over non-selectable cell
+
+	table.Select(0, 0)
+	table.Paste("x")
+	if text := table.GetCell(0, 0).GetText(); text != "Header" {
+		t.Errorf("failed to skip non-selectable cell: expected Header, got %s", text)
+	}
+
+	// Paste into visible cells
+
+	table.SetPasteFunc(nil)
+	table.SetColumnVisible(2, false)
+	table.SetFilterFunc(func(row int) bool {
+		return row != 2
+	})
+	table.Select(1, 1)
+	table.Paste("x\ty\nz")
+
+	expected = map[[2]int]string{
+		{1, 1}: "x",
+		{1, 2}: "B",
+		{1, 3}: "y",
+		{2, 1}: "",
+		{3, 1}: "z",
+	}
+	for position, text := range expected {
+		if cellText := table.GetCell(position[0], position[1]).GetText(); cellText != text {
+			t.Errorf("failed to paste into visible cell %d,%d: expected %q, got %q", position[0], position[1], text, cellText)
+		}
+	}
+
+	// Paste without selection
+
+	table.Select(-1, -1)
+	table.Paste("x")
+	table.Select(-1, 0)
+	table.Paste("x")
+}
+
+// tableTestEditor is a TableContent which stores the cells set by the table.
+type tableTestEditor struct {
+	cells map[[2]int]*TableCell
+}
+
+func (c *tableTestEditor) GetCell(row, column int) *TableCell {
+	return c.cells[[2]int{row, column}]
+}
+
+func (c *tableTestEditor) RowCount() int {
+	return 2
+}
+
+func (c *tableTestEditor) ColumnCount() int {
+	return 2
+}
+
+func (c *tableTestEditor) SetCell(row, column int, cell *TableCell) {
+	c.cells[[2]int{row, column}] = cell
+}
+
+func TestTablePasteContent(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	editor := &tableTestEditor{cells: map[[2]int]*TableCell{
+		{0, 0}: NewTableCell("a"),
+	}}
+	table := NewTable()
+	table.SetSelectable(true, true)
+	table.SetCellSimple(0, 0, "hidden")
+	table.SetContent(editor)
+	table.Select(0, 0)
+
+	// Paste into content
+
+	table.Paste("x\ty")
+	if text := editor.GetCell(0, 0).GetText(); text != "x" {
+		t.Errorf("failed to paste into content: expected x, got %q", text)
+	}
+	if cell := editor.GetCell(0, 1); cell == nil || cell.GetText() != "y" {
+		t.Errorf("failed to paste into content: expected y, got %v", cell)
+	}
+	table.SetContent(nil)
+	if text := table.GetCell(0, 0).GetText(); text != "hidden" {
+		t.Errorf("failed to paste into content: expected cell set via SetCell to be unchanged, got %q", text)
+	}
+
+	// Paste into read-only content
+
+	content := &tableTestContent{rows: 2, columns: 2}
+	table.SetContent(content)
+	table.Paste("x")
+	table.SetContent(nil)
+	if text := table.GetCell(0, 0).GetText(); text != "hidden" {
+		t.Errorf("failed to paste into content: expected cell set via SetCell to be unchanged, got %q", text)
+	}
+}
+
 func BenchmarkTableDraw(b *testing.B) {
 	for _, c := range tableTestCases {
 		c := c // Capture
@@ -104,3 +320,1603 @@ func tc(c *tableTestCase) *Table {
 
 	return table
 }
+
+func TestTableColumnVisibility(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	table := NewTable()
+	table.SetSelectable(true, true)
+	for column, name := range []string{"A", "B", "C"} {
+		table.SetCellSimple(0, column, name)
+		table.SetCellSimple(1, column, strings.ToLower(name))
+	}
+
+	var changed []int
+	table.SetColumnVisibilityChangedFunc(func(column int, visible bool) {
+		if !visible {
+			column = -column
+		}
+		changed = append(changed, column)
+	})
+
+	app, err := newTestApp(table)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	app.screen.Init()
+
+	line := func() string {
+		var b strings.Builder
+		for x := 0; x < 5; x++ {
+			r, _, _, _ := app.screen.GetContent(x, 0)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+
+	// Hide column
+
+	table.SetColumnVisible(1, false)
+	table.SetRect(0, 0, 80, 24)
+	table.Draw(app.screen)
+	if l := line(); l != "A C  " {
+		t.Errorf("failed to hide column: expected \"A C  \", got %q", l)
+	}
+	if hidden := table.GetHiddenColumns(); len(hidden) != 1 || hidden[0] != 1 {
+		t.Errorf("failed to get hidden columns: expected [1], got %v", hidden)
+	}
+
+	// Move selection
+
+	table.Select(1, 0)
+	table.InputHandler()(tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModNone), func(p Primitive) {})
+	if _, column := table.GetSelection(); column != 2 {
+		t.Errorf("failed to skip hidden column: expected column 2, got %d", column)
+	}
+
+	// Column chooser
+
+	chooserKey := tcell.NewEventKey(tcell.KeyRune, 'c', tcell.ModAlt)
+	table.InputHandler()(chooserKey, func(p Primitive) {})
+	if table.ColumnChooserVisible() {
+		t.Errorf("failed to ignore column chooser key: expected column chooser to be disabled by default")
+	}
+	table.SetColumnChooserEnabled(true)
+	table.InputHandler()(chooserKey, func(p Primitive) {})
+	if !table.ColumnChooserVisible() {
+		t.Errorf("failed to show column chooser via key")
+	}
+	table.HideColumnChooser(nil)
+
+	table.ShowColumnChooser(nil)
+	if !table.ColumnChooserVisible() {
+		t.Errorf("failed to show column chooser")
+	} else if count := table.columnChooser.GetOptionCount(); count != 3 {
+		t.Errorf("failed to show column chooser: expected 3 options, got %d", count)
+	} else if table.columnChooser.IsChecked(1) {
+		t.Errorf("failed to show column chooser: expected column 1 unchecked")
+	}
+
+	table.columnChooser.toggle(1)
+	if !table.IsColumnVisible(1) {
+		t.Errorf("failed to show column via column chooser")
+	}
+	table.HideColumnChooser(nil)
+
+	if len(changed) != 2 || changed[0] != -1 || changed[1] != 1 {
+		t.Errorf("failed to call visibility changed func: expected [-1 1], got %v", changed)
+	}
+
+	// Remove column
+
+	table.SetHiddenColumns([]int{2})
+	table.RemoveColumn(0)
+	if hidden := table.GetHiddenColumns(); len(hidden) != 1 || hidden[0] != 1 {
+		t.Errorf("failed to shift hidden columns: expected [1], got %v", hidden)
+	}
+}
+
+func TestTableMoveRow(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	table := NewTable()
+	table.SetSelectable(true, false)
+	table.SetFixed(1, 0)
+	table.SetRowsMovable(true)
+	for row, name := range []string{"Header", "A", "B", "C", "D"} {
+		table.SetCellSimple(row, 0, name)
+	}
+
+	var moved [][2]int
+	table.SetRowMovedFunc(func(from, to int) {
+		moved = append(moved, [2]int{from, to})
+	})
+
+	rows := func() string {
+		var names []string
+		for row := 0; row < table.GetRowCount(); row++ {
+			names = append(names, table.GetCell(row, 0).GetText())
+		}
+		return strings.Join(names, " ")
+	}
+
+	// Move programmatically
+
+	table.Select(2, 0)
+	table.MoveRow(4, 1)
+	if r := rows(); r != "Header D A B C" {
+		t.Errorf("failed to move row: expected \"Header D A B C\", got %q", r)
+	}
+	if row, _ := table.GetSelection(); row != 3 {
+		t.Errorf("failed to track selection: expected row 3, got %d", row)
+	}
+	if len(moved) != 0 {
+		t.Errorf("failed to move row: expected no handler calls, got %v", moved)
+	}
+
+	// Move via keyboard
+
+	handler := table.InputHandler()
+	key := func(k tcell.Key, r rune, mod tcell.ModMask) {
+		handler(tcell.NewEventKey(k, r, mod), func(p Primitive) {})
+	}
+	key(tcell.KeyRune, 'm', tcell.ModAlt)
+	if !table.IsMovingRow() {
+		t.Errorf("failed to start moving row")
+	}
+	key(tcell.KeyUp, 0, tcell.ModNone)
+	key(tcell.KeyUp, 0, tcell.ModNone)
+	key(tcell.KeyUp, 0, tcell.ModNone)
+	key(tcell.KeyEnter, 0, tcell.ModNone)
+	if table.IsMovingRow() {
+		t.Errorf("failed to stop moving row")
+	}
+	if r := rows(); r != "Header B D A C" {
+		t.Errorf("failed to move row via keyboard: expected \"Header B D A C\", got %q", r)
+	}
+	if len(moved) != 2 || moved[0] != [2]int{3, 2} || moved[1] != [2]int{2, 1} {
+		t.Errorf("failed to call row moved handler: expected [[3 2] [2 1]], got %v", moved)
+	}
+
+	// Move via mouse
+
+	app, err := newTestApp(table)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	table.SetRect(0, 0, 20, 10)
+	table.Draw(app.screen)
+
+	mouse := table.MouseHandler()
+	drag := func(action MouseAction, y int) Primitive {
+		_, capture := mouse(action, tcell.NewEventMouse(1, y, tcell.ButtonPrimary, tcell.ModNone), func(p Primitive) {})
+		return capture
+	}
+	drag(MouseLeftDown, 1)
+	if capture := drag(MouseDragStart, 2); capture != table {
+		t.Errorf("failed to start drag: expected mouse to be captured")
+	}
+	drag(MouseDrag, 4)
+	drag(MouseDrag, 20)
+	drag(MouseDragEnd, 20)
+	if r := rows(); r != "Header D A C B" {
+		t.Errorf("failed to move row via mouse: expected \"Header D A C B\", got %q", r)
+	}
+	if row, _ := table.GetSelection(); row != 4 || table.IsMovingRow() {
+		t.Errorf("failed to drag row: expected row 4 selected, got %d", row)
+	}
+}
+
+// tableTestContent is a TableContent which creates its cells on demand.
+type tableTestContent struct {
+	rows, columns int
+	calls         int
+}
+
+func (c *tableTestContent) GetCell(row, column int) *TableCell {
+	c.calls++
+	return NewTableCell(fmt.Sprintf("%d,%d", column, row))
+}
+
+func (c *tableTestContent) RowCount() int {
+	return c.rows
+}
+
+func (c *tableTestContent) ColumnCount() int {
+	return c.columns
+}
+
+func TestTableContent(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	content := &tableTestContent{rows: 1000000, columns: 3}
+	table := NewTable()
+	table.SetCellSimple(0, 0, "hidden")
+	table.SetContent(content)
+	table.SetSelectable(true, false)
+	table.SetRect(0, 0, 20, 10)
+
+	app, err := newTestApp(table)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+
+	if rows, columns := table.GetRowCount(), table.GetColumnCount(); rows != 1000000 || columns != 3 {
+		t.Errorf("failed to count rows and columns: expected 1000000 and 3, got %d and %d", rows, columns)
+	}
+	if text := table.GetCell(5, 2).GetText(); text != "2,5" {
+		t.Errorf("failed to get cell: expected 2,5, got %s", text)
+	}
+
+	// Draw visible cells only
+
+	content.calls = 0
+	table.Draw(app.screen)
+	if content.calls > 100 {
+		t.Errorf("failed to draw lazily: expected at most 100 cells, got %d", content.calls)
+	}
+	screenText := func(y int) string {
+		var b strings.Builder
+		for x := 0; x < 3; x++ {
+			r, _, _, _ := app.screen.GetContent(x, y)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+	if text := screenText(0); text != "0,0" {
+		t.Errorf("failed to draw content: expected 0,0, got %s", text)
+	}
+
+	// Navigate
+
+	table.InputHandler()(tcell.NewEventKey(tcell.KeyEnd, 0, tcell.ModNone), func(p Primitive) {})
+	table.Draw(app.screen)
+	if row, _ := table.GetSelection(); row != 999999 {
+		t.Errorf("failed to select last row: expected 999999, got %d", row)
+	}
+	if text := screenText(9); text != "0,9" {
+		t.Errorf("failed to scroll to last row: expected 0,9, got %s", text)
+	}
+
+	// Remove content
+
+	table.SetContent(nil)
+	if text := table.GetCell(0, 0).GetText(); text != "hidden" {
+		t.Errorf("failed to remove content: expected hidden, got %s", text)
+	}
+}
+
+func TestTableSort(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	table := NewTable()
+	table.SetSelectable(true, false)
+	table.SetFixed(1, 0)
+	table.SetCellSimple(0, 0, "Name")
+	table.SetCellSimple(0, 1, "Size")
+	for row, name := range []string{"ccc", "a", "bb"} {
+		table.SetCellSimple(row+1, 0, name)
+		cell := NewTableCell("")
+		cell.SetInt(int64(10 - row))
+		table.SetCell(row+1, 1, cell)
+	}
+	table.Select(3, 0)
+
+	rows := func() string {
+		var names []string
+		for row := 1; row < table.GetRowCount(); row++ {
+			names = append(names, table.GetCell(row, 0).GetText())
+		}
+		return strings.Join(names, " ")
+	}
+
+	// Sort programmatically
+
+	if column, _ := table.GetSortColumn(); column != -1 {
+		t.Errorf("failed to get sort column: expected -1, got %d", column)
+	}
+	table.SortByColumn(0, true)
+	if r := rows(); r != "a bb ccc" {
+		t.Errorf("failed to sort ascending: expected \"a bb ccc\", got %q", r)
+	}
+	if row, _ := table.GetSelection(); row != 2 {
+		t.Errorf("failed to keep selection: expected row 2, got %d", row)
+	}
+	table.SortByColumn(1, false)
+	if r := rows(); r != "ccc a bb" {
+		t.Errorf("failed to sort typed values descending: expected \"ccc a bb\", got %q", r)
+	}
+	if column, ascending := table.GetSortColumn(); column != 1 || ascending {
+		t.Errorf("failed to get sort column: expected 1 descending, got %d (ascending %v)", column, ascending)
+	}
+
+	// Sort with a column sort function
+
+	table.SetColumnSortFunc(0, func(a, b *TableCell) bool {
+		return len(a.GetText()) > len(b.GetText())
+	})
+	table.SortByColumn(0, true)
+	if r := rows(); r != "ccc bb a" {
+		t.Errorf("failed to sort with column sort function: expected \"ccc bb a\", got %q", r)
+	}
+	table.SetColumnSortFunc(0, nil)
+
+	// Draw indicators
+
+	app, err := newTestApp(table)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	table.SetRect(0, 0, 20, 5)
+	table.Draw(app.screen)
+
+	header := func() string {
+		var b strings.Builder
+		for x := 0; x < 12; x++ {
+			r, _, _, _ := app.screen.GetContent(x, 0)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+	if h := header(); h != "Name ▲ Size " {
+		t.Errorf("failed to draw ascending indicator: expected \"Name ▲ Size \", got %q", h)
+	}
+
+	// Sort by clicking
+
+	mouse := table.MouseHandler()
+	click := func(x int) {
+		mouse(MouseLeftClick, tcell.NewEventMouse(x, 0, tcell.ButtonPrimary, tcell.ModNone), func(p Primitive) {})
+		table.Draw(app.screen)
+	}
+	click(1)
+	if r := rows(); r != "ccc bb a" {
+		t.Errorf("failed to sort by clicking: expected \"ccc bb a\", got %q", r)
+	}
+	if h := header(); h != "Name ▼ Size " {
+		t.Errorf("failed to draw descending indicator: expected \"Name ▼ Size \", got %q", h)
+	}
+	click(8)
+	if h := header(); h != "Name Size ▲ " {
+		t.Errorf("failed to move indicator: expected \"Name Size ▲ \", got %q", h)
+	}
+}
+
+func TestTableResizeColumns(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	table := NewTable()
+	table.SetFixed(1, 0)
+	table.SetColumnsResizable(true)
+	table.SetCellSimple(0, 0, "Name")
+	table.SetCellSimple(0, 1, "Size")
+	table.SetCellSimple(1, 0, "a")
+	table.SetCellSimple(1, 1, "10")
+
+	var resized [][2]int
+	table.SetColumnResizedFunc(func(column, width int) {
+		resized = append(resized, [2]int{column, width})
+	})
+
+	app, err := newTestApp(table)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	table.SetRect(0, 0, 40, 5)
+	table.Draw(app.screen)
+
+	mouse := table.MouseHandler()
+	drag := func(action MouseAction, x int) Primitive {
+		_, capture := mouse(action, tcell.NewEventMouse(x, 0, tcell.ButtonPrimary, tcell.ModNone), func(p Primitive) {})
+		table.Draw(app.screen)
+		return capture
+	}
+	header := func() string {
+		var b strings.Builder
+		for x := 0; x < 14; x++ {
+			r, _, _, _ := app.screen.GetContent(x, 0)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+
+	// Resize via mouse
+
+	drag(MouseLeftDown, 4)
+	if capture := drag(MouseDragStart, 6); capture != table {
+		t.Errorf("failed to start resizing: expected mouse to be captured")
+	}
+	drag(MouseDrag, 8)
+	drag(MouseDragEnd, 8)
+	if width := table.GetColumnWidth(0); width != 8 {
+		t.Errorf("failed to resize column: expected width 8, got %d", width)
+	}
+	if h := header(); h != "Name     Size " {
+		t.Errorf("failed to draw resized column: expected \"Name     Size \", got %q", h)
+	}
+	if len(resized) != 1 || resized[0] != [2]int{0, 8} {
+		t.Errorf("failed to call column resized handler: expected [[0 8]], got %v", resized)
+	}
+
+	// Resize within limits
+
+	table.SetColumnWidthLimits(2, 6)
+	drag(MouseLeftDown, 8)
+	drag(MouseDragStart, 0)
+	drag(MouseDragEnd, 0)
+	if width := table.GetColumnWidth(0); width != 2 {
+		t.Errorf("failed to limit column width: expected width 2, got %d", width)
+	}
+	if h := header(); h != "N… Size       " {
+		t.Errorf("failed to draw resized column: expected \"N… Size       \", got %q", h)
+	}
+	drag(MouseLeftDown, 2)
+	drag(MouseDragStart, 30)
+	drag(MouseDragEnd, 30)
+	if width := table.GetColumnWidth(0); width != 6 {
+		t.Errorf("failed to limit column width: expected width 6, got %d", width)
+	}
+
+	// Click on a boundary
+
+	drag(MouseLeftDown, 6)
+	drag(MouseLeftClick, 6)
+	if column, _ := table.GetSortColumn(); column != -1 {
+		t.Errorf("failed to ignore click on column boundary: expected no sort column, got %d", column)
+	}
+
+	// Set widths programmatically
+
+	table.SetColumnWidth(1, 3)
+	table.InsertColumn(0)
+	if width := table.GetColumnWidth(2); width != 3 {
+		t.Errorf("failed to shift column widths: expected width 3, got %d", width)
+	}
+	table.SetColumnWidth(2, 0)
+	if width := table.GetColumnWidth(2); width != 0 {
+		t.Errorf("failed to reset column width: expected width 0, got %d", width)
+	}
+}
+
+func TestTableFixedSeparators(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	table := NewTable()
+	table.SetFixed(1, 1)
+	table.SetFixedSeparators(true)
+	for row := 0; row < 10; row++ {
+		for column := 0; column < 3; column++ {
+			table.SetCellSimple(row, column, fmt.Sprintf("r%dc%d", row, column))
+		}
+	}
+
+	app, err := newTestApp(table)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+
+	line := func(y, width int) string {
+		var b strings.Builder
+		for x := 0; x < width; x++ {
+			r, _, _, _ := app.screen.GetContent(x, y)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+
+	// Draw separators
+
+	table.SetRect(0, 0, 20, 5)
+	table.Draw(app.screen)
+	for y, expected := range []string{
+		"r0c0│r0c1 r0c2",
+		"────┼─────────",
+		"r1c0│r1c1 r1c2",
+	} {
+		if l := line(y, 14); l != expected {
+			t.Errorf("failed to draw line %d: expected %q, got %q", y, expected, l)
+		}
+	}
+	if row, _ := table.cellAt(0, 1); row != -1 {
+		t.Errorf("failed to get row at separator: expected -1, got %d", row)
+	}
+	if row, _ := table.cellAt(0, 2); row != 1 {
+		t.Errorf("failed to get row below separator: expected 1, got %d", row)
+	}
+
+	// Scroll both axes
+
+	table.SetRect(0, 0, 10, 5)
+	table.SetOffset(3, 1)
+	table.Draw(app.screen)
+	for y, expected := range []string{
+		"r0c0│r0c2",
+		"────┼────",
+		"r4c0│r4c2",
+		"r5c0│r5c2",
+		"r6c0│r6c2",
+	} {
+		if l := line(y, 9); l != expected {
+			t.Errorf("failed to draw scrolled line %d: expected %q, got %q", y, expected, l)
+		}
+	}
+}
+
+func TestTableMultiSelect(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	table := NewTable()
+	table.SetSelectable(true, false)
+	table.SetMultiSelect(true)
+	for row := 0; row < 5; row++ {
+		for column := 0; column < 3; column++ {
+			table.SetCellSimple(row, column, fmt.Sprintf("%d,%d", row, column))
+		}
+	}
+	table.Select(1, 0)
+
+	input := table.InputHandler()
+	press := func(key tcell.Key, ch rune, mod tcell.ModMask) {
+		input(tcell.NewEventKey(key, ch, mod), func(p Primitive) {})
+	}
+	ranges := func() string {
+		return fmt.Sprint(table.GetSelectedRanges())
+	}
+
+	if r := ranges(); r != "[{1 0 1 2}]" {
+		t.Errorf("failed to get selected ranges: expected [{1 0 1 2}], got %s", r)
+	}
+
+	// Select rows via keyboard
+
+	press(tcell.KeyRune, ' ', tcell.ModNone)
+	press(tcell.KeyDown, 0, tcell.ModNone)
+	press(tcell.KeyDown, 0, tcell.ModNone)
+	press(tcell.KeyDown, 0, tcell.ModShift)
+	if r := ranges(); r != "[{1 0 1 2} {3 0 4 2}]" {
+		t.Errorf("failed to select rows: expected [{1 0 1 2} {3 0 4 2}], got %s", r)
+	}
+	if !table.IsSelected(4, 1) || table.IsSelected(2, 1) {
+		t.Errorf("failed to determine selected cells")
+	}
+
+	// Toggle via mouse
+
+	app, err := newTestApp(table)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	table.SetRect(0, 0, 20, 10)
+	table.Draw(app.screen)
+
+	table.MouseHandler()(MouseLeftClick, tcell.NewEventMouse(0, 1, tcell.ButtonPrimary, tcell.ModCtrl), func(p Primitive) {})
+	if r := ranges(); r != "[{3 0 4 2}]" {
+		t.Errorf("failed to toggle row via mouse: expected [{3 0 4 2}], got %s", r)
+	}
+	if row, _ := table.GetSelection(); row != 1 {
+		t.Errorf("failed to select clicked row: expected 1, got %d", row)
+	}
+
+	// Select cell ranges
+
+	table.SetSelectable(true, true)
+	table.Select(0, 0)
+	if r := ranges(); r != "[{0 0 0 0}]" {
+		t.Errorf("failed to clear selected ranges: expected [{0 0 0 0}], got %s", r)
+	}
+	press(tcell.KeyRight, 0, tcell.ModShift)
+	press(tcell.KeyDown, 0, tcell.ModShift)
+	if r := ranges(); r != "[{0 0 1 1}]" {
+		t.Errorf("failed to select cell range: expected [{0 0 1 1}], got %s", r)
+	}
+	press(tcell.KeyRight, 0, tcell.ModNone)
+	press(tcell.KeyRune, ' ', tcell.ModNone)
+	if r := ranges(); r != "[{0 0 0 1} {1 0 1 2}]" {
+		t.Errorf("failed to toggle cell: expected [{0 0 0 1} {1 0 1 2}], got %s", r)
+	}
+	table.ClearSelectedRanges()
+	if r := ranges(); r != "[{1 2 1 2}]" {
+		t.Errorf("failed to clear selected ranges: expected [{1 2 1 2}], got %s", r)
+	}
+}
+
+func TestTableColumnDefaults(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	table := NewTable()
+	table.SetColumnMaxWidth(0, 3)
+	table.SetColumnEllipsis(0, false)
+	table.SetColumnAlign(1, AlignRight)
+	for row, values := range [][]string{{"Alice", "1"}, {"Bob", "100"}} {
+		table.SetCellSimple(row, 0, values[0])
+		table.SetCellSimple(row, 1, values[1])
+	}
+
+	app, err := newTestApp(table)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+
+	lines := func(expected ...string) {
+		table.Draw(app.screen)
+		for y, e := range expected {
+			var b strings.Builder
+			for x := 0; x < len([]rune(e)); x++ {
+				r, _, _, _ := app.screen.GetContent(x, y)
+				b.WriteRune(r)
+			}
+			if l := b.String(); l != e {
+				t.Errorf("failed to draw line %d: expected %q, got %q", y, e, l)
+			}
+		}
+	}
+
+	// Draw column defaults
+
+	table.SetRect(0, 0, 20, 5)
+	lines("Ali   1", "Bob 100")
+
+	// Apply to appended rows
+
+	table.SetCellSimple(2, 0, "Carol")
+	table.SetCellSimple(2, 1, "42")
+	lines("Ali   1", "Bob 100", "Car  42")
+
+	// Cell settings take precedence
+
+	table.GetCell(2, 0).SetMaxWidth(4)
+	table.GetCell(2, 1).SetAlign(AlignCenter)
+	lines("Alic   1", "Bob  100", "Caro 42 ")
+
+	// Ellipsis and expansion
+
+	table.SetColumnEllipsis(0, true)
+	table.SetColumnExpansion(1, 1)
+	lines("Ali…              1 ", "Bob             100 ", "Car…       42       ")
+}
+
+func TestTableFooterRows(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	table := NewTable()
+	table.SetSelectable(true, false)
+	table.SetFixed(1, 0)
+	table.SetFooterRows(1)
+	table.SetFooterStyle(tcell.StyleDefault.Foreground(tcell.ColorRed).Background(tcell.ColorBlue).Bold(true))
+	table.SetCellSimple(0, 0, "Header")
+	for row := 1; row <= 20; row++ {
+		table.SetCellSimple(row, 0, fmt.Sprintf("Row %02d", row))
+	}
+	table.SetCellSimple(21, 0, "Total")
+
+	app, err := newTestApp(table)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	table.SetRect(0, 0, 10, 5)
+
+	lines := func(expected ...string) {
+		table.Draw(app.screen)
+		for y, e := range expected {
+			var b strings.Builder
+			for x := 0; x < len(e); x++ {
+				r, _, _, _ := app.screen.GetContent(x, y)
+				b.WriteRune(r)
+			}
+			if l := b.String(); l != e {
+				t.Errorf("failed to draw line %d: expected %q, got %q", y, e, l)
+			}
+		}
+	}
+
+	// Draw footer rows
+
+	lines("Header", "Row 01", "Row 02", "Row 03", "Total ")
+	_, _, style, _ := app.screen.GetContent(0, 4)
+	if fg, bg, attr := style.Decompose(); fg != tcell.ColorRed || bg != tcell.ColorBlue || attr&tcell.AttrBold == 0 {
+		t.Errorf("failed to apply footer style: got foreground %v, background %v and attributes %v", fg, bg, attr)
+	}
+
+	// Scroll
+
+	table.Select(10, 0)
+	lines("Header", "Row 08", "Row 09", "Row 10", "Total ")
+	input := table.InputHandler()
+	input(tcell.NewEventKey(tcell.KeyEnd, 0, tcell.ModNone), func(p Primitive) {})
+	if row, _ := table.GetSelection(); row != 20 {
+		t.Errorf("failed to skip footer rows: expected row 20 selected, got %d", row)
+	}
+	lines("Header", "Row 18", "Row 19", "Row 20", "Total ")
+
+	// Click on footer rows
+
+	if row, _ := table.cellAt(0, 4); row != 21 {
+		t.Errorf("failed to get footer row at position: expected 21, got %d", row)
+	}
+	table.MouseHandler()(MouseLeftClick, tcell.NewEventMouse(0, 4, tcell.ButtonPrimary, tcell.ModNone), func(p Primitive) {})
+	if row, _ := table.GetSelection(); row != 20 {
+		t.Errorf("failed to ignore click on footer row: expected row 20 selected, got %d", row)
+	}
+
+	// Sort
+
+	table.Sort(0, true)
+	if text := table.GetCell(21, 0).GetText(); text != "Total" {
+		t.Errorf("failed to keep footer rows when sorting: expected \"Total\", got %q", text)
+	}
+
+	// Draw short tables
+
+	for row := 20; row >= 3; row-- {
+		table.RemoveRow(row)
+	}
+	table.Select(1, 0)
+	lines("Header", "Row 20", "Row 19", "Total ", "      ")
+}
+
+func TestTableFilter(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	table := NewTable()
+	table.SetSelectable(true, false)
+	table.SetFixed(1, 0)
+	table.SetCellSimple(0, 0, "Fruit")
+	fruits := []string{"Apple", "Banana", "Cherry", "Mango", "Orange", "Papaya"}
+	for row, fruit := range fruits {
+		table.SetCellSimple(row+1, 0, fruit)
+	}
+	table.SetFilterFunc(func(row int) bool {
+		return strings.Contains(strings.ToLower(fruits[row-1]), "an")
+	})
+	table.SetFilterHighlight("AN")
+
+	app, err := newTestApp(table)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	table.SetRect(0, 0, 10, 3)
+
+	lines := func(expected ...string) {
+		table.Draw(app.screen)
+		for y, e := range expected {
+			var b strings.Builder
+			for x := 0; x < len(e); x++ {
+				r, _, _, _ := app.screen.GetContent(x, y)
+				b.WriteRune(r)
+			}
+			if l := b.String(); l != e {
+				t.Errorf("failed to draw line %d: expected %q, got %q", y, e, l)
+			}
+		}
+	}
+
+	// Draw filtered rows
+
+	lines("Fruit ", "Banana", "Mango ")
+	if row, _ := table.cellAt(0, 2); row != 4 {
+		t.Errorf("failed to get filtered row at position: expected 4, got %d", row)
+	}
+
+	// Highlight
+
+	for x, highlighted := range []bool{false, true, true, true, true, false} {
+		_, _, style, _ := app.screen.GetContent(x, 1)
+		if _, _, attr := style.Decompose(); (attr&tcell.AttrUnderline != 0) != highlighted {
+			t.Errorf("failed to highlight filter text at %d: expected %v", x, highlighted)
+		}
+	}
+
+	// Navigate
+
+	input := table.InputHandler()
+	press := func(key tcell.Key) {
+		input(tcell.NewEventKey(key, 0, tcell.ModNone), func(p Primitive) {})
+	}
+	press(tcell.KeyDown)
+	press(tcell.KeyDown)
+	if row, _ := table.GetSelection(); row != 4 {
+		t.Errorf("failed to skip filtered rows: expected row 4 selected, got %d", row)
+	}
+	press(tcell.KeyDown)
+	lines("Fruit ", "Mango ", "Orange")
+	press(tcell.KeyDown)
+	if row, _ := table.GetSelection(); row != 5 {
+		t.Errorf("failed to stop at last row: expected row 5 selected, got %d", row)
+	}
+	press(tcell.KeyUp)
+	press(tcell.KeyUp)
+	if row, _ := table.GetSelection(); row != 2 {
+		t.Errorf("failed to skip filtered rows: expected row 2 selected, got %d", row)
+	}
+	lines("Fruit ", "Banana", "Mango ")
+
+	// Remove filter
+
+	table.SetFilterFunc(nil)
+	table.Select(1, 0)
+	lines("Fruit ", "Apple ", "Banana")
+}
+
+func TestTableWrap(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	table := NewTable()
+	table.SetSelectable(true, false)
+	table.SetColumnMaxWidth(1, 5)
+	table.SetColumnWrap(1, true)
+	for row, text := range []string{"a b", "one two three", "x", "y"} {
+		table.SetCellSimple(row, 0, fmt.Sprint(row))
+		table.SetCellSimple(row, 1, text)
+	}
+
+	app, err := newTestApp(table)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	table.SetRect(0, 0, 7, 4)
+
+	lines := func(expected ...string) {
+		table.Draw(app.screen)
+		for y, e := range expected {
+			var b strings.Builder
+			for x := 0; x < len(e); x++ {
+				r, _, _, _ := app.screen.GetContent(x, y)
+				b.WriteRune(r)
+			}
+			if l := b.String(); l != e {
+				t.Errorf("failed to draw line %d: expected %q, got %q", y, e, l)
+			}
+		}
+	}
+
+	// Draw wrapped rows
+
+	lines("0 a b  ", "1 one  ", "  two  ", "  three")
+	if row, _ := table.cellAt(2, 2); row != 1 {
+		t.Errorf("failed to get wrapped row at position: expected 1, got %d", row)
+	}
+
+	// Highlight selected row
+
+	input := table.InputHandler()
+	press := func(key tcell.Key) {
+		input(tcell.NewEventKey(key, 0, tcell.ModNone), func(p Primitive) {})
+	}
+	press(tcell.KeyDown)
+	table.Draw(app.screen)
+	_, _, unselected, _ := app.screen.GetContent(6, 0)
+	for y := 1; y < 4; y++ {
+		if _, _, style, _ := app.screen.GetContent(6, y); style == unselected {
+			t.Errorf("failed to highlight line %d of selected row", y)
+		}
+	}
+
+	// Scroll
+
+	press(tcell.KeyDown)
+	lines("1 one  ", "  two  ", "  three", "2 x    ")
+	press(tcell.KeyDown)
+	lines("2 x    ", "3 y    ")
+	if row, _ := table.cellAt(2, 1); row != 3 {
+		t.Errorf("failed to get scrolled row at position: expected 3, got %d", row)
+	}
+	press(tcell.KeyHome)
+	lines("0 a b  ", "1 one  ")
+}
+
+func TestTableTree(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	table := NewTable()
+	table.SetSelectable(true, false)
+	table.SetFixed(1, 0)
+	table.SetCellSimple(0, 0, "Name")
+	for row, name := range []string{"tank", "tank/a", "tank/a@1", "tank/b", "data"} {
+		table.SetCellSimple(row+1, 0, name)
+		table.SetRowLevel(row+1, strings.Count(name, "/")+strings.Count(name, "@"))
+	}
+	table.SetRowExpandable(5, true)
+	table.SetRowExpanded(5, false)
+
+	var expanded []string
+	table.SetRowExpandedFunc(func(row int, e bool) {
+		expanded = append(expanded, fmt.Sprintf("%d %v", row, e))
+		if row == 5 && e && table.GetRowCount() == 6 {
+			// Load the child rows lazily.
+			table.SetCellSimple(6, 0, "data/c")
+			table.SetRowLevel(6, 1)
+		}
+	})
+
+	app, err := newTestApp(table)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	table.SetRect(0, 0, 14, 7)
+
+	lines := func(expected ...string) {
+		table.Draw(app.screen)
+		for y, e := range expected {
+			var b strings.Builder
+			for x := 0; x < len([]rune(e)); x++ {
+				r, _, _, _ := app.screen.GetContent(x, y)
+				b.WriteRune(r)
+			}
+			if l := b.String(); l != e {
+				t.Errorf("failed to draw line %d: expected %q, got %q", y, e, l)
+			}
+		}
+	}
+
+	// Draw tree
+
+	lines("Name        ", "▼ tank      ", "  ▼ tank/a  ", "      tank/a", "    tank/b  ", "▶ data      ", "            ")
+
+	// Collapse via keyboard
+
+	input := table.InputHandler()
+	press := func(key tcell.Key, ch rune) {
+		input(tcell.NewEventKey(key, ch, tcell.ModNone), func(p Primitive) {})
+	}
+	press(tcell.KeyDown, 0)
+	press(tcell.KeyDown, 0)
+	press(tcell.KeyRune, '-')
+	lines("Name        ", "▼ tank      ", "  ▶ tank/a  ", "    tank/b  ", "▶ data      ")
+	if row, _ := table.GetSelection(); row != 2 {
+		t.Errorf("failed to select collapsed row: expected row 2 selected, got %d", row)
+	}
+	press(tcell.KeyDown, 0)
+	if row, _ := table.GetSelection(); row != 4 {
+		t.Errorf("failed to skip collapsed rows: expected row 4 selected, got %d", row)
+	}
+
+	// Expand lazily via mouse
+
+	table.MouseHandler()(MouseLeftClick, tcell.NewEventMouse(0, 4, tcell.ButtonPrimary, 0), func(p Primitive) {})
+	lines("Name        ", "▼ tank      ", "  ▶ tank/a  ", "    tank/b  ", "▼ data      ", "    data/c  ")
+	if e := strings.Join(expanded, ","); e != "2 false,5 true" {
+		t.Errorf("failed to notify expanded rows: expected 2 false,5 true, got %s", e)
+	}
+
+	// Sort
+
+	table.Sort(0, true)
+	lines("Name     ▼  ", "▼ tank      ", "    tank/b  ", "  ▶ tank/a  ", "▼ data      ", "    data/c  ")
+}
+
+func TestTableMoveColumns(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	table := NewTable()
+	table.SetFixed(1, 0)
+	table.SetSelectable(false, true)
+	table.SetColumnsMovable(true)
+	for column, name := range []string{"A", "B", "C"} {
+		table.SetCellSimple(0, column, name)
+		table.SetCellSimple(1, column, strings.ToLower(name))
+	}
+	table.SetColumnWidth(2, 3)
+
+	var orders []string
+	table.SetColumnOrderChangedFunc(func(order []int) {
+		orders = append(orders, fmt.Sprint(order))
+	})
+
+	app, err := newTestApp(table)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	table.SetRect(0, 0, 10, 2)
+
+	lines := func(expected ...string) {
+		table.Draw(app.screen)
+		for y, e := range expected {
+			var b strings.Builder
+			for x := 0; x < len(e); x++ {
+				r, _, _, _ := app.screen.GetContent(x, y)
+				b.WriteRune(r)
+			}
+			if l := b.String(); l != e {
+				t.Errorf("failed to draw line %d: expected %q, got %q", y, e, l)
+			}
+		}
+	}
+	lines("A B C  ", "a b c  ")
+
+	// Move via keyboard
+
+	input := table.InputHandler()
+	input(tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModCtrl), func(p Primitive) {})
+	lines("B A C  ", "b a c  ")
+	if _, column := table.GetSelection(); column != 1 {
+		t.Errorf("failed to move selection with column: expected column 1 selected, got %d", column)
+	}
+
+	// Drag via mouse
+
+	mouse := table.MouseHandler()
+	mouse(MouseLeftDown, tcell.NewEventMouse(4, 0, tcell.ButtonPrimary, 0), func(p Primitive) {})
+	mouse(MouseDragStart, tcell.NewEventMouse(4, 0, tcell.ButtonPrimary, 0), func(p Primitive) {})
+	mouse(MouseDrag, tcell.NewEventMouse(0, 0, tcell.ButtonPrimary, 0), func(p Primitive) {})
+	mouse(MouseDragEnd, tcell.NewEventMouse(0, 0, tcell.ButtonPrimary, 0), func(p Primitive) {})
+	lines("C   B A", "c   b a")
+	if width := table.GetColumnWidth(0); width != 3 {
+		t.Errorf("failed to move column width: expected 3, got %d", width)
+	}
+	if o := strings.Join(orders, ","); o != "[1 0 2],[2 1 0]" {
+		t.Errorf("failed to notify column order: expected [1 0 2],[2 1 0], got %s", o)
+	}
+
+	// Restore order
+
+	table.SetColumnOrder([]int{0, 1, 2})
+	lines("A B C  ", "a b c  ")
+	if order := fmt.Sprint(table.GetColumnOrder()); order != "[0 1 2]" {
+		t.Errorf("failed to restore column order: expected [0 1 2], got %s", order)
+	}
+}
+
+func TestTableHeaderRow(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	table := NewTable()
+	table.SetSelectable(true, false)
+	for row := 0; row < 5; row++ {
+		table.SetCellSimple(row+1, 0, fmt.Sprintf("n%d", row))
+		table.SetCellSimple(row+1, 1, fmt.Sprint(row))
+	}
+	table.SetHeaderRow(NewTableCell("Name"), NewTableCell("Size"))
+	table.SetHeaderSeparators(true)
+	table.SetScrollBarVisibility(ScrollBarNever)
+	table.SetFixed(0, 0)
+	table.Select(0, 0)
+
+	app, err := newTestApp(table)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	table.SetRect(0, 0, 9, 3)
+
+	lines := func(expected ...string) {
+		table.Draw(app.screen)
+		for y, e := range expected {
+			var b strings.Builder
+			for x := 0; x < len([]rune(e)); x++ {
+				r, _, _, _ := app.screen.GetContent(x, y)
+				b.WriteRune(r)
+			}
+			if l := b.String(); l != e {
+				t.Errorf("failed to draw line %d: expected %q, got %q", y, e, l)
+			}
+		}
+	}
+
+	// Draw header
+
+	lines("Name│Size", "n0   0   ", "n1   1   ")
+	if !table.HasHeaderRow() {
+		t.Error("failed to set header row")
+	}
+	_, _, style, _ := app.screen.GetContent(0, 0)
+	if fg, _, attr := style.Decompose(); fg != Styles.SecondaryTextColor || attr&tcell.AttrBold == 0 || attr&tcell.AttrReverse != 0 {
+		t.Errorf("failed to draw header style: got foreground %v and attributes %v", fg, attr)
+	}
+
+	// Scroll
+
+	table.Select(5, 0)
+	lines("Name│Size", "n3   3   ", "n4   4   ")
+}
+
+func TestTableMaxRows(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	table := NewTable()
+	table.SetSelectable(true, false)
+	table.SetHeaderRow(NewTableCell("Event"))
+	table.SetCellSimple(1, 0, "Total")
+	table.SetFooterRows(1)
+	table.SetMaxRows(3)
+
+	// Append rows
+
+	for event := 0; event < 3; event++ {
+		if row := table.AppendRow(NewTableCell(fmt.Sprintf("e%d", event))); row != event+1 {
+			t.Errorf("failed to append row: expected index %d, got %d", event+1, row)
+		}
+	}
+	table.Select(2, 0)
+
+	// Evict oldest rows
+
+	if row := table.AppendRow(NewTableCell("e3")); row != 3 {
+		t.Errorf("failed to append row: expected index 3, got %d", row)
+	}
+	var texts []string
+	for row := 0; row < table.GetRowCount(); row++ {
+		texts = append(texts, table.GetCell(row, 0).GetText())
+	}
+	if s := strings.Join(texts, ","); s != "Event,e1,e2,e3,Total" {
+		t.Errorf("failed to evict oldest row: expected Event,e1,e2,e3,Total, got %s", s)
+	}
+	if row, _ := table.GetSelection(); row != 1 {
+		t.Errorf("failed to keep selection: expected row 1 selected, got %d", row)
+	}
+
+	// Keep selected ranges
+
+	table.SetMultiSelect(true)
+	for _, row := range []int{1, 3} {
+		table.Select(row, 0)
+		table.InputHandler()(tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone), func(p Primitive) {})
+	}
+	table.Select(1, 0)
+	table.AppendRow(NewTableCell("e4"))
+	if ranges := table.GetSelectedRanges(); len(ranges) != 1 || ranges[0] != (TableRange{FromRow: 2, ToRow: 2}) {
+		t.Errorf("failed to keep selected ranges: expected row 2 selected, got %v", ranges)
+	} else if text := table.GetCell(ranges[0].FromRow, 0).GetText(); text != "e3" {
+		t.Errorf("failed to keep selected ranges: expected e3 selected, got %s", text)
+	}
+
+	// Lower maximum
+
+	table.SetMaxRows(1)
+	if rows := table.GetRowCount(); rows != 3 {
+		t.Errorf("failed to apply maximum: expected 3 rows, got %d", rows)
+	}
+	if text := table.GetCell(1, 0).GetText(); text != "e4" {
+		t.Errorf("failed to apply maximum: expected e4, got %s", text)
+	}
+	if ranges := table.GetSelectedRanges(); len(ranges) != 1 || ranges[0] != (TableRange{FromRow: 1, ToRow: 1}) {
+		t.Errorf("failed to drop evicted selected ranges: expected current selection, got %v", ranges)
+	}
+
+	// Stream rows
+
+	table.SetMaxRows(100)
+	for event := 5; event < 10000; event++ {
+		table.AppendRow(NewTableCell(fmt.Sprintf("e%d", event)))
+	}
+	if rows := table.GetRowCount(); rows != 102 {
+		t.Errorf("failed to stream rows: expected 102 rows, got %d", rows)
+	}
+	texts = texts[:0]
+	for _, row := range []int{0, 1, 100, 101} {
+		texts = append(texts, table.GetCell(row, 0).GetText())
+	}
+	if s := strings.Join(texts, ","); s != "Event,e9900,e9999,Total" {
+		t.Errorf("failed to stream rows: expected Event,e9900,e9999,Total, got %s", s)
+	}
+}
+
+func TestTableSelectionModeVimKeys(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	table := NewTable()
+	for row := 0; row < 20; row++ {
+		table.SetCellSimple(row, 0, fmt.Sprintf("a%d", row))
+		table.SetCellSimple(row, 1, fmt.Sprintf("b%d", row))
+	}
+
+	app, err := newTestApp(table)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	table.SetRect(0, 0, 10, 10)
+	table.Draw(app.screen)
+
+	// Selection modes
+
+	for _, mode := range []TableSelectionMode{TableSelectCells, TableSelectRows, TableSelectColumns, TableSelectNone} {
+		table.SetSelectionMode(mode)
+		if m := table.GetSelectionMode(); m != mode {
+			t.Errorf("failed to set selection mode: expected %d, got %d", mode, m)
+		}
+	}
+	table.SetSelectionMode(TableSelectRows)
+	if rows, columns := table.GetSelectable(); !rows || columns {
+		t.Errorf("failed to select rows: expected true and false, got %v and %v", rows, columns)
+	}
+
+	// Vim keys
+
+	table.SetVimKeys(true)
+	input := table.InputHandler()
+	press := func(key tcell.Key, ch rune, mod tcell.ModMask) {
+		input(tcell.NewEventKey(key, ch, mod), func(p Primitive) {})
+	}
+	expectRow := func(expected int) {
+		if row, _ := table.GetSelection(); row != expected {
+			t.Errorf("failed to navigate: expected row %d selected, got %d", expected, row)
+		}
+	}
+	press(tcell.KeyRune, 'j', tcell.ModNone)
+	expectRow(1)
+	press(tcell.KeyCtrlD, 0, tcell.ModCtrl)
+	expectRow(6)
+	press(tcell.KeyCtrlU, 0, tcell.ModCtrl)
+	expectRow(1)
+	press(tcell.KeyRune, 'G', tcell.ModNone)
+	expectRow(19)
+	press(tcell.KeyRune, 'g', tcell.ModNone)
+	expectRow(19)
+	press(tcell.KeyRune, 'g', tcell.ModNone)
+	expectRow(0)
+}
+
+func TestTableExport(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	table := NewTable()
+	table.SetFixed(1, 0)
+	table.SetCellSimple(0, 0, "Name")
+	table.SetCellSimple(0, 1, "Size")
+	table.SetCellSimple(0, 2, "Hidden")
+	for row, name := range []string{"[red]a|b", "c", "d"} {
+		table.SetCellSimple(row+1, 0, name)
+		cell := NewTableCell(fmt.Sprint(row * 50))
+		cell.SetAlign(AlignRight)
+		table.SetCell(row+1, 1, cell)
+		table.SetCellSimple(row+1, 2, "x")
+	}
+	table.SetColumnVisible(2, false)
+
+	app, err := newTestApp(table)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	table.SetRect(0, 0, 20, 3)
+	table.Draw(app.screen)
+
+	// Export entire table
+
+	expected := `| Name | Size |
+| :--- | ---: |
+| a\|b |    0 |
+| c    |   50 |
+| d    |  100 |
+`
+	if markdown := string(table.ExportMarkdown(true)); markdown != expected {
+		t.Errorf("failed to export Markdown: expected %q, got %q", expected, markdown)
+	}
+
+	// Export visible rows
+
+	expected = `+------+------+
+| Name | Size |
++======+======+
+| a|b  |    0 |
+| c    |   50 |
++------+------+
+`
+	if ascii := string(table.ExportASCII(false)); ascii != expected {
+		t.Errorf("failed to export ASCII: expected %q, got %q", expected, ascii)
+	}
+}
+
+func TestTableFind(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	table := NewTable()
+	table.SetContent(&tableTestContent{rows: 1000, columns: 3})
+	table.SetSelectable(true, true)
+
+	app, err := newTestApp(table)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	table.SetRect(0, 0, 20, 5)
+	table.Draw(app.screen)
+
+	// Find
+
+	if err := table.Find(`^2,9\d\d$`, true); err != nil {
+		t.Errorf("failed to find matches: %s", err)
+	}
+	if count := table.GetMatchCount(); count != 100 {
+		t.Errorf("failed to find matches: expected 100, got %d", count)
+	}
+	if row, column := table.GetSelection(); row != 900 || column != 2 {
+		t.Errorf("failed to select first match: expected 900 and 2, got %d and %d", row, column)
+	}
+
+	// Move between matches
+
+	table.PreviousMatch()
+	if row, column := table.GetCurrentMatch(); row != 999 || column != 2 {
+		t.Errorf("failed to move to previous match: expected 999 and 2, got %d and %d", row, column)
+	}
+	table.NextMatch()
+	table.NextMatch()
+	if row, _ := table.GetSelection(); row != 901 {
+		t.Errorf("failed to move to next match: expected 901, got %d", row)
+	}
+
+	// Highlight
+
+	table.Draw(app.screen)
+	var highlighted bool
+	for y := 0; y < 5; y++ {
+		var b strings.Builder
+		for x := 0; x < 5; x++ {
+			r, _, _, _ := app.screen.GetContent(x, y)
+			b.WriteRune(r)
+		}
+		if b.String() != "0,900" {
+			continue
+		}
+		highlighted = true
+		_, _, style, _ := app.screen.GetContent(12, y)
+		if _, bg, _ := style.Decompose(); bg != Styles.SecondaryTextColor {
+			t.Errorf("failed to highlight match: expected background %v, got %v", Styles.SecondaryTextColor, bg)
+		}
+	}
+	if !highlighted {
+		t.Error("failed to highlight match: row 900 is not visible")
+	}
+
+	// Hidden columns
+
+	table.SetColumnVisible(1, false)
+	if err := table.Find(`^1,999$`, true); err != nil {
+		t.Errorf("failed to find matches: %s", err)
+	}
+	if count := table.GetMatchCount(); count != 0 {
+		t.Errorf("failed to skip hidden column: expected 0 matches, got %d", count)
+	}
+	if err := table.Find(`,999$`, true); err != nil {
+		t.Errorf("failed to find matches: %s", err)
+	}
+	if count := table.GetMatchCount(); count != 2 {
+		t.Errorf("failed to skip hidden column: expected 2 matches, got %d", count)
+	}
+	table.NextMatch()
+	if row, column := table.GetSelection(); row != 999 || column != 2 {
+		t.Errorf("failed to skip hidden column: expected 999 and 2, got %d and %d", row, column)
+	}
+	table.SetColumnVisible(1, true)
+
+	// Clear
+
+	if err := table.Find("", false); err != nil {
+		t.Errorf("failed to clear matches: %s", err)
+	}
+	if row, column := table.GetCurrentMatch(); row != -1 || column != -1 {
+		t.Errorf("failed to clear matches: expected -1 and -1, got %d and %d", row, column)
+	}
+}
+
+func TestTableCallbacks(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	table := NewTable()
+	table.SetSelectable(true, false)
+	for row := 0; row < 5; row++ {
+		table.SetCellSimple(row, 0, fmt.Sprintf("a%d", row))
+	}
+
+	var changed, selected []int
+	table.SetSelectionChangedFunc(func(row, column int) {
+		// Calling methods of the table from its callbacks must not deadlock.
+		if r, _ := table.GetSelection(); r != row {
+			t.Errorf("failed to call selection changed handler: expected selected row %d, got %d", row, r)
+		}
+		table.SetOffset(row, 0)
+		changed = append(changed, row)
+	})
+	table.SetSelectedFunc(func(row, column int) {
+		table.SetCellSimple(row, 0, "selected")
+		selected = append(selected, row)
+	})
+
+	app, err := newTestApp(table)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	table.SetRect(0, 0, 10, 5)
+	table.Draw(app.screen)
+
+	// Select and navigate
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		table.Select(2, 0)
+		table.InputHandler()(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone), func(p Primitive) {})
+		table.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("failed to call Table callbacks: deadlock")
+	}
+
+	if len(changed) != 2 || changed[0] != 2 || changed[1] != 3 {
+		t.Errorf("failed to call selection changed handler: expected [2 3], got %v", changed)
+	} else if len(selected) != 1 || selected[0] != 3 {
+		t.Errorf("failed to call selected handler: expected [3], got %v", selected)
+	} else if text := table.GetCell(3, 0).GetText(); text != "selected" {
+		t.Errorf("failed to update cell from selected handler: expected selected, got %s", text)
+	}
+}
+
+func TestTableItemColors(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tb := NewTable()
+	inherited := NewTableCell("a")
+	explicit := NewTableCell("b")
+	explicit.SetTextColor(tcell.ColorRed)
+	tb.SetCell(0, 0, inherited)
+	tb.SetCell(0, 1, explicit)
+
+	flex := NewFlex()
+	flex.SetItemColors(tcell.ColorNavy, tcell.ColorYellow)
+	flex.AddItem(tb, 0, 1, false)
+	flex.SetRect(0, 0, 20, 5)
+
+	app, err := newTestApp(flex)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+
+	expectColor := func(cell *TableCell, expected tcell.Color) {
+		_, _, style, _ := app.screen.GetContent(cell.x, cell.y)
+		if fg, _, _ := style.Decompose(); fg != expected {
+			t.Errorf("failed to draw cell %q: expected text color %v, got %v", cell.Text, expected, fg)
+		}
+	}
+
+	// Inherit
+
+	flex.Draw(app.screen)
+
+	if color := tb.GetBackgroundColor(); color != tcell.ColorNavy {
+		t.Errorf("failed to inherit background color: expected %v, got %v", tcell.ColorNavy, color)
+	}
+	expectColor(inherited, tcell.ColorYellow)
+	expectColor(explicit, tcell.ColorRed)
+
+	// Unset
+
+	flex.SetItemColors(ColorUnset, ColorUnset)
+	flex.Draw(app.screen)
+
+	if color := tb.GetBackgroundColor(); color != Styles.PrimitiveBackgroundColor {
+		t.Errorf("failed to restore background color: expected %v, got %v", Styles.PrimitiveBackgroundColor, color)
+	}
+	expectColor(inherited, Styles.PrimaryTextColor)
+	expectColor(explicit, tcell.ColorRed)
+}
@@ -0,0 +1,111 @@
+package cview
+
+// tableColumn holds the default formatting of the cells of a column.
+type tableColumn struct {
+	// The alignment of cells which are aligned to the left, and whether it
+	// was set.
+	align    int
+	alignSet bool
+
+	// The maximum width of cells which do not have a maximum width, or 0 if
+	// there is no maximum width.
+	maxWidth int
+
+	// The minimum expansion of the column.
+	expansion int
+
+	// Whether truncated text is drawn without an ellipsis.
+	noEllipsis bool
+
+	// Whether the text of the cells is wrapped.
+	wrap bool
+}
+
+// SetColumnAlign sets the alignment of the cells of the given column which
+// are aligned to the left (the default alignment of cells), so the alignment
+// of a column does not need to be set on each of its cells, including cells
+// which are added later. Must be one of AlignLeft, AlignCenter or AlignRight.
+func (t *Table) SetColumnAlign(column, align int) {
+	t.Lock()
+	defer t.Unlock()
+
+	c := t.tableColumn(column)
+	c.align, c.alignSet = align, true
+}
+
+// SetColumnMaxWidth sets the maximum width of the cells of the given column
+// which do not have a maximum width (see TableCell.SetMaxWidth). Set to 0 if
+// there is no maximum width.
+func (t *Table) SetColumnMaxWidth(column, maxWidth int) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.tableColumn(column).maxWidth = maxWidth
+}
+
+// SetColumnExpansion sets the value by which the given column expands if the
+// available width for the table is more than the table width. See
+// TableCell.SetExpansion for details. Cells with a greater expansion value
+// take precedence.
+func (t *Table) SetColumnExpansion(column, expansion int) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.tableColumn(column).expansion = expansion
+}
+
+// SetColumnEllipsis sets whether or not an ellipsis is drawn at the end of the
+// text of the cells of the given column which is cut off. This is enabled by
+// default.
+func (t *Table) SetColumnEllipsis(column int, ellipsis bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.tableColumn(column).noEllipsis = !ellipsis
+}
+
+// tableColumn returns the default formatting of the given column, creating
+// it if it does not exist.
+func (t *Table) tableColumn(column int) *tableColumn {
+	c := t.columnDefaults[column]
+	if c == nil {
+		c = &tableColumn{}
+		if t.columnDefaults == nil {
+			t.columnDefaults = make(map[int]*tableColumn)
+		}
+		t.columnDefaults[column] = c
+	}
+	return c
+}
+
+// cellAlign returns the alignment of the provided cell of the given column.
+func (t *Table) cellAlign(cell *TableCell, column int) int {
+	if c := t.columnDefaults[column]; c != nil && c.alignSet && cell.Align == AlignLeft {
+		return c.align
+	}
+	return cell.Align
+}
+
+// cellMaxWidth returns the maximum width of the provided cell of the given
+// column, or 0 if there is no maximum width.
+func (t *Table) cellMaxWidth(cell *TableCell, column int) int {
+	if c := t.columnDefaults[column]; c != nil && cell.MaxWidth <= 0 {
+		return c.maxWidth
+	}
+	return cell.MaxWidth
+}
+
+// shiftColumnDefaults shifts the default formatting of the columns starting at
+// the given column by the given amount.
+func (t *Table) shiftColumnDefaults(column int, amount int) {
+	columnDefaults := make(map[int]*tableColumn)
+	for c, defaults := range t.columnDefaults {
+		if c == column && amount < 0 {
+			continue // The column was removed.
+		} else if c >= column {
+			c += amount
+		}
+		columnDefaults[c] = defaults
+	}
+	t.columnDefaults = columnDefaults
+}
@@ -0,0 +1,197 @@
+package cview
+
+// SetColumnsMovable sets whether or not the user may reorder columns, either
+// by dragging a cell of the fixed rows of the table (see SetFixed) with the
+// mouse or by pressing Keys.MoveColumnLeft or Keys.MoveColumnRight (Ctrl+Left
+// and Ctrl+Right by default) while columns are selectable. Fixed columns may
+// not be moved. See SetColumnOrderChangedFunc.
+//
+// Columns may not be moved when the cells are provided by a TableContent.
+func (t *Table) SetColumnsMovable(movable bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.columnsMovable = movable
+	if !movable {
+		t.draggingColumn = false
+		t.dragColumn = -1
+	}
+}
+
+// MoveColumn moves the column at the index "from" so that it is found at the
+// index "to" afterwards. Columns in between are shifted by one column. The
+// selection, the hidden columns and the formatting, width and sort function of
+// each column follow the moved columns. If either index is out of range, this
+// function has no effect.
+func (t *Table) MoveColumn(from, to int) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.moveColumn(from, to)
+}
+
+// GetColumnOrder returns the index each column had before columns were moved,
+// in the current order of the columns. The order is reset when columns are
+// inserted or removed, or when the table is cleared.
+func (t *Table) GetColumnOrder() []int {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.columnOrderOrDefault()
+}
+
+// SetColumnOrder moves the columns into the provided order, as returned by
+// GetColumnOrder. This may be used to restore the order of the columns the
+// user set in a previous session. Indices which are out of range are ignored.
+func (t *Table) SetColumnOrder(order []int) {
+	t.Lock()
+	defer t.Unlock()
+
+	position := 0
+	for _, column := range order {
+		current := t.columnOrderOrDefault()
+		for index := position; index < len(current); index++ {
+			if current[index] == column {
+				t.moveColumn(index, position)
+				position++
+				break
+			}
+		}
+	}
+}
+
+// SetColumnOrderChangedFunc sets a handler which is called when the user
+// moves a column. The handler receives the new order of the columns (see
+// GetColumnOrder), which may be stored and restored via SetColumnOrder.
+func (t *Table) SetColumnOrderChangedFunc(handler func(order []int)) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.columnOrderChanged = handler
+}
+
+// columnOrderOrDefault returns a copy of the order of the columns.
+func (t *Table) columnOrderOrDefault() []int {
+	order := make([]int, t.columnCount())
+	for index := range order {
+		if index < len(t.columnOrder) {
+			order[index] = t.columnOrder[index]
+		} else {
+			order[index] = index
+		}
+	}
+	return order
+}
+
+// movedColumn returns the index of the provided column after the column at
+// the index "from" was moved to the index "to".
+func movedColumn(column, from, to int) int {
+	if column == from {
+		return to
+	} else if from < to && column > from && column <= to {
+		return column - 1
+	} else if to < from && column >= to && column < from {
+		return column + 1
+	}
+	return column
+}
+
+// moveColumn moves a column and updates the selection and the state of the
+// columns. It returns whether the column was moved.
+func (t *Table) moveColumn(from, to int) bool {
+	columnCount := t.columnCount()
+	if t.content != nil || from < 0 || from >= columnCount || to < 0 || to >= columnCount || from == to {
+		return false
+	}
+
+	for row, cells := range t.cells {
+		for len(cells) < columnCount {
+			cells = append(cells, nil)
+		}
+		cell := cells[from]
+		if from < to {
+			copy(cells[from:], cells[from+1:to+1])
+		} else {
+			copy(cells[to+1:], cells[to:from])
+		}
+		cells[to] = cell
+		t.cells[row] = cells
+	}
+
+	order := t.columnOrderOrDefault()
+	column := order[from]
+	if from < to {
+		copy(order[from:], order[from+1:to+1])
+	} else {
+		copy(order[to+1:], order[to:from])
+	}
+	order[to] = column
+	t.columnOrder = order
+
+	hiddenColumns := make(map[int]bool)
+	for column := range t.hiddenColumns {
+		hiddenColumns[movedColumn(column, from, to)] = true
+	}
+	t.hiddenColumns = hiddenColumns
+	columnWidths := make(map[int]int)
+	for column, width := range t.columnWidths {
+		columnWidths[movedColumn(column, from, to)] = width
+	}
+	t.columnWidths = columnWidths
+	columnDefaults := make(map[int]*tableColumn)
+	for column, defaults := range t.columnDefaults {
+		columnDefaults[movedColumn(column, from, to)] = defaults
+	}
+	t.columnDefaults = columnDefaults
+	if t.columnSortFuncs != nil {
+		columnSortFuncs := make(map[int]func(a, b *TableCell) bool)
+		for column, less := range t.columnSortFuncs {
+			columnSortFuncs[movedColumn(column, from, to)] = less
+		}
+		t.columnSortFuncs = columnSortFuncs
+	}
+
+	if t.sortColumn >= 0 {
+		t.sortColumn = movedColumn(t.sortColumn, from, to)
+	}
+	t.selectedColumn = movedColumn(t.selectedColumn, from, to)
+	t.clearMultiSelection()
+	return true
+}
+
+// moveColumnTo moves the provided column to the provided index, which is
+// limited to the columns which are not fixed, and adds the column order
+// changed handler to the provided callbacks. It returns the index of the
+// column afterwards. The table must be locked when calling this function.
+func (t *Table) moveColumnTo(calls *callbacks, from, to int) int {
+	if to < t.fixedColumns {
+		to = t.fixedColumns
+	}
+	if last := t.columnCount() - 1; to > last {
+		to = last
+	}
+	if from < t.fixedColumns || !t.moveColumn(from, to) {
+		return from
+	}
+	if handler := t.columnOrderChanged; handler != nil {
+		order := t.columnOrderOrDefault()
+		calls.add(func() {
+			handler(order)
+		})
+	}
+	return to
+}
+
+// moveSelectedColumn moves the selected column by one visible column in the
+// provided direction (-1 for left, 1 for right) and adds the column order
+// changed handler to the provided callbacks.
+func (t *Table) moveSelectedColumn(calls *callbacks, direction int) {
+	to := t.selectedColumn + direction
+	for to >= 0 && to < t.columnCount() && t.hiddenColumns[to] {
+		to += direction
+	}
+	if to < 0 || to >= t.columnCount() {
+		return
+	}
+	t.moveColumnTo(calls, t.selectedColumn, to)
+}
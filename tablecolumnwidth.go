@@ -0,0 +1,143 @@
+package cview
+
+// SetColumnsResizable sets whether or not the user may resize columns by
+// dragging the right boundary of a column in the fixed rows of the table (see
+// SetFixed) with the mouse. Resized columns keep their width regardless of
+// their content and are not expanded (see TableCell.SetExpansion). See
+// SetColumnWidthLimits and SetColumnResizedFunc.
+func (t *Table) SetColumnsResizable(resizable bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.columnsResizable = resizable
+	if !resizable {
+		t.resizingColumn = false
+		t.resizeColumn = -1
+	}
+}
+
+// SetColumnWidth sets the width of the given column. A width of 0 or less
+// restores the default behavior of sizing the column to its content. This
+// may be used to restore column widths the user set in a previous session.
+// The width is limited to the limits set via SetColumnWidthLimits.
+func (t *Table) SetColumnWidth(column, width int) {
+	t.Lock()
+	defer t.Unlock()
+
+	if width <= 0 {
+		delete(t.columnWidths, column)
+		return
+	}
+	t.columnWidths[column] = t.limitColumnWidth(width)
+}
+
+// GetColumnWidth returns the width of the given column as set by the user or
+// via SetColumnWidth, or 0 if the column is sized to its content.
+func (t *Table) GetColumnWidth(column int) int {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.columnWidths[column]
+}
+
+// SetColumnWidthLimits sets the minimum and maximum width of columns resized
+// by the user. A maximum of 0 means there is no maximum. The minimum defaults
+// to 1 and is never less than 1.
+func (t *Table) SetColumnWidthLimits(min, max int) {
+	t.Lock()
+	defer t.Unlock()
+
+	if min < 1 {
+		min = 1
+	}
+	if max > 0 && max < min {
+		max = min
+	}
+	t.minColumnWidth, t.maxColumnWidth = min, max
+}
+
+// SetColumnResizedFunc sets a handler which is called when the user has
+// finished resizing a column. The handler receives the column and its new
+// width, which may be stored and restored via SetColumnWidth.
+func (t *Table) SetColumnResizedFunc(handler func(column, width int)) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.columnResized = handler
+}
+
+// limitColumnWidth returns the provided width limited to the limits set via
+// SetColumnWidthLimits.
+func (t *Table) limitColumnWidth(width int) int {
+	if width < t.minColumnWidth {
+		width = t.minColumnWidth
+	}
+	if t.maxColumnWidth > 0 && width > t.maxColumnWidth {
+		width = t.maxColumnWidth
+	}
+	return width
+}
+
+// columnBoundaryAt returns the column whose right boundary is located at the
+// given screen coordinates in the fixed rows of the table, as of the last time
+// the table was drawn. -1 is returned if there is no such column or columns
+// may not be resized.
+func (t *Table) columnBoundaryAt(x, y int) int {
+	if !t.columnsResizable || t.fixedRows == 0 {
+		return -1
+	}
+	rectX, rectY, _, _ := t.GetInnerRect()
+	if y < rectY {
+		return -1
+	}
+	if row, _ := t.cellAt(x, y); row < 0 || row >= t.fixedRows {
+		return -1
+	}
+
+	columnX := rectX
+	if t.borders {
+		columnX++
+	}
+	for index, width := range t.visibleColumnWidths {
+		columnX += width + 1
+		if x == columnX-1 {
+			return t.visibleColumnIndices[index]
+		}
+	}
+	return -1
+}
+
+// resizeColumnTo resizes the column being resized such that its right
+// boundary is located at the given horizontal screen position.
+func (t *Table) resizeColumnTo(x int) {
+	if t.resizeColumn < 0 {
+		return
+	}
+	t.columnWidths[t.resizeColumn] = t.limitColumnWidth(t.resizeStartWidth + x - t.resizeStartX)
+}
+
+// visibleColumnWidth returns the width of the given column as of the last
+// time the table was drawn, or 0 if it was not visible.
+func (t *Table) visibleColumnWidth(column int) int {
+	for index, visible := range t.visibleColumnIndices {
+		if visible == column {
+			return t.visibleColumnWidths[index]
+		}
+	}
+	return 0
+}
+
+// shiftColumnWidths shifts the widths of the columns starting at the given
+// column by the given amount.
+func (t *Table) shiftColumnWidths(column int, amount int) {
+	columnWidths := make(map[int]int)
+	for c, width := range t.columnWidths {
+		if c == column && amount < 0 {
+			continue // The column was removed.
+		} else if c >= column {
+			c += amount
+		}
+		columnWidths[c] = width
+	}
+	t.columnWidths = columnWidths
+}
@@ -0,0 +1,92 @@
+package cview
+
+// TableContent provides the cells of a Table. Tables render only the cells
+// which are visible (unless SetEvaluateAllRows is enabled), so a TableContent
+// may create cells on demand instead of keeping all of them in memory, e.g.
+// to display the results of a database query or large sets of metrics. See
+// Table.SetContent.
+//
+// The returned cells should not change between calls unless the data itself
+// changed, as the table stores the positions of drawn cells in them (see
+// TableCell.GetLastPosition). The methods of a TableContent are called while
+// the table is locked, so they must not call methods of the table.
+type TableContent interface {
+	// GetCell returns the cell at the provided position, or nil if there is
+	// no cell at the position.
+	GetCell(row, column int) *TableCell
+
+	// RowCount returns the number of rows of the table.
+	RowCount() int
+
+	// ColumnCount returns the number of columns of the table.
+	ColumnCount() int
+}
+
+// TableEditor may be implemented by a TableContent to store the cells set by
+// the table, such as when text is pasted into it. See Table.Paste.
+type TableEditor interface {
+	// SetCell sets the cell at the provided position.
+	SetCell(row, column int, cell *TableCell)
+}
+
+// SetContent sets the content of the table, which provides its cells. While a
+// content is set, the cells set via SetCell and related functions are not
+// displayed, MoveRow and Sort have no effect and rows may not be moved by the
+// user. The format set via SetCellFormat is not applied to the cells of the
+// content. Clear removes the content.
+//
+// Call Draw or QueueUpdateDraw on the application to display changes of the
+// content. Set a nil content to display the cells set via SetCell again.
+func (t *Table) SetContent(content TableContent) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.content = content
+	t.movingRow, t.draggingRow = false, false
+}
+
+// GetContent returns the content set via SetContent, or nil if no content
+// is set.
+func (t *Table) GetContent() TableContent {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.content
+}
+
+// cell returns the cell at the provided position, or nil if there is no cell
+// at the position.
+func (t *Table) cell(row, column int) *TableCell {
+	if row < 0 || column < 0 {
+		return nil
+	}
+	if t.content != nil {
+		if row >= t.content.RowCount() || column >= t.content.ColumnCount() {
+			return nil
+		}
+		return t.content.GetCell(row, column)
+	}
+	if row >= len(t.cells) || column >= len(t.cells[row]) {
+		return nil
+	}
+	return t.cells[row][column]
+}
+
+// rowCount returns the number of rows of the table.
+func (t *Table) rowCount() int {
+	if t.content != nil {
+		return t.content.RowCount()
+	}
+	return len(t.cells)
+}
+
+// columnCount returns the number of columns of the table.
+func (t *Table) columnCount() int {
+	if t.content != nil {
+		return t.content.ColumnCount()
+	}
+	if len(t.cells) == 0 {
+		return 0
+	}
+	return t.lastColumn + 1
+}
@@ -0,0 +1,174 @@
+package cview
+
+import (
+	"bytes"
+	"strings"
+)
+
+// ExportMarkdown returns the text of the table as a Markdown table, so that it
+// may be pasted into documentation or chat messages. When all is false, only
+// the rows and columns which were visible the last time the table was drawn
+// are exported. Otherwise, all rows and all columns which are not hidden (see
+// SetColumnVisible) are exported. The first exported row is the header of the
+// Markdown table. The alignment of each column is taken from the first row
+// below the fixed rows. Color tags are removed and pipe characters are
+// escaped.
+func (t *Table) ExportMarkdown(all bool) []byte {
+	t.RLock()
+	defer t.RUnlock()
+
+	cells, aligns, _ := t.exportCells(all)
+	if len(cells) == 0 {
+		return nil
+	}
+	for _, row := range cells {
+		for column, text := range row {
+			row[column] = strings.Replace(text, "|", `\|`, -1)
+		}
+	}
+	widths := exportWidths(cells, 3)
+
+	var b bytes.Buffer
+	writeRow := func(row []string) {
+		b.WriteByte('|')
+		for column, text := range row {
+			b.WriteString(" " + alignExport(text, widths[column], aligns[column]) + " |")
+		}
+		b.WriteByte('\n')
+	}
+	writeRow(cells[0])
+	b.WriteByte('|')
+	for column, width := range widths {
+		separator := []byte(" " + strings.Repeat("-", width) + " |")
+		switch aligns[column] {
+		case AlignCenter:
+			separator[1], separator[width] = ':', ':'
+		case AlignRight:
+			separator[width] = ':'
+		default:
+			separator[1] = ':'
+		}
+		b.Write(separator)
+	}
+	b.WriteByte('\n')
+	for _, row := range cells[1:] {
+		writeRow(row)
+	}
+	return b.Bytes()
+}
+
+// ExportASCII returns the text of the table as a grid of ASCII characters, so
+// that it may be pasted into documentation or chat messages. The cells are
+// aligned according to their alignment and the fixed rows are separated from
+// the other rows. See ExportMarkdown for the rows and columns which are
+// exported.
+func (t *Table) ExportASCII(all bool) []byte {
+	t.RLock()
+	defer t.RUnlock()
+
+	cells, aligns, fixed := t.exportCells(all)
+	if len(cells) == 0 {
+		return nil
+	}
+	widths := exportWidths(cells, 0)
+
+	var b bytes.Buffer
+	writeLine := func(ch string) {
+		b.WriteByte('+')
+		for _, width := range widths {
+			b.WriteString(strings.Repeat(ch, width+2) + "+")
+		}
+		b.WriteByte('\n')
+	}
+	writeLine("-")
+	for index, row := range cells {
+		if index == fixed && fixed > 0 {
+			writeLine("=")
+		}
+		b.WriteByte('|')
+		for column, text := range row {
+			b.WriteString(" " + alignExport(text, widths[column], aligns[column]) + " |")
+		}
+		b.WriteByte('\n')
+	}
+	writeLine("-")
+	return b.Bytes()
+}
+
+// exportCells returns the text of the exported cells, row by row, the
+// alignment of each exported column and the number of exported fixed rows.
+func (t *Table) exportCells(all bool) (cells [][]string, aligns []int, fixed int) {
+	rows, columns := t.visibleRowIndices, t.visibleColumnIndices
+	if all {
+		rows, columns = nil, nil
+		for row := 0; row < t.rowCount(); row++ {
+			rows = append(rows, row)
+		}
+		for column := 0; column < t.columnCount(); column++ {
+			if !t.hiddenColumns[column] {
+				columns = append(columns, column)
+			}
+		}
+	}
+	if len(rows) == 0 || len(columns) == 0 {
+		return nil, nil, 0
+	}
+
+	aligns = make([]int, len(columns))
+	alignSet := make([]bool, len(columns))
+	for _, row := range rows {
+		if row < t.fixedRows {
+			fixed++
+		}
+		texts := make([]string, len(columns))
+		for index, column := range columns {
+			cell := t.cell(row, column)
+			if cell == nil {
+				continue
+			}
+			_, _, _, _, _, stripped, _ := decomposeText(cell.Text, true, false)
+			texts[index] = string(t.treePrefix(row, column)) + string(stripped)
+			if !alignSet[index] && row >= t.fixedRows {
+				aligns[index], alignSet[index] = t.cellAlign(cell, column), true
+			}
+		}
+		cells = append(cells, texts)
+	}
+	return cells, aligns, fixed
+}
+
+// exportWidths returns the width of each column of the provided exported
+// cells, which is at least the provided minimum width.
+func exportWidths(cells [][]string, min int) []int {
+	widths := make([]int, len(cells[0]))
+	for _, row := range cells {
+		for column, text := range row {
+			if width := stringWidth(text); width > widths[column] {
+				widths[column] = width
+			}
+		}
+	}
+	for column := range widths {
+		if widths[column] < min {
+			widths[column] = min
+		}
+	}
+	return widths
+}
+
+// alignExport pads the provided exported text with spaces to the provided
+// width according to the provided alignment.
+func alignExport(text string, width, align int) string {
+	padding := width - stringWidth(text)
+	if padding <= 0 {
+		return text
+	}
+	switch align {
+	case AlignCenter:
+		return strings.Repeat(" ", padding/2) + text + strings.Repeat(" ", padding-padding/2)
+	case AlignRight:
+		return strings.Repeat(" ", padding) + text
+	default:
+		return text + strings.Repeat(" ", padding)
+	}
+}
@@ -0,0 +1,159 @@
+package cview
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// SetFilterFunc sets a function which determines which rows of the table are
+// shown. Rows for which the function returns false are not drawn and may not
+// be selected or moved, but keep their indices, so all functions of the table
+// refer to the same rows with or without a filter. Fixed rows (see SetFixed)
+// and footer rows (see SetFooterRows) are always shown. Set a nil function to
+// show all rows.
+//
+// The function is called for each row whenever the table is drawn. It is
+// called while the table is locked, so it must not call methods of the table.
+// See SetFilterHighlight to highlight the text which matched a filter.
+func (t *Table) SetFilterFunc(filter func(row int) bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.filter = filter
+	t.filteredRows = nil
+	t.movingRow, t.draggingRow = false, false
+}
+
+// SetFilterHighlight sets a text which is highlighted in the cells of the rows
+// which are drawn, e.g. the text which the rows were filtered by. Matches are
+// case-insensitive. Set an empty text to highlight nothing.
+func (t *Table) SetFilterHighlight(text string) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.filterHighlight = text
+}
+
+// SetFilterHighlightStyle sets the style of the text highlighted via
+// SetFilterHighlight. Its foreground and background colors replace the colors
+// of the text unless they are tcell.ColorDefault, and its attributes are added
+// to the attributes of the text. Highlighted text is bold and underlined by
+// default.
+func (t *Table) SetFilterHighlightStyle(style tcell.Style) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.filterHighlightStyle = style
+}
+
+// isFilteredRow returns whether the row at the provided index is hidden by the
+// filter function or because a row of a tree-table it is a descendant of is
+// collapsed.
+func (t *Table) isFilteredRow(row int) bool {
+	if row < t.fixedRows || t.isFooterRow(row) {
+		return false
+	}
+	return t.filter != nil && !t.filter(row) || t.isCollapsedRow(row)
+}
+
+// filterRows determines the rows which pass the filter function and are not
+// hidden by collapsed rows of a tree-table, among the rows which are neither
+// fixed nor footer rows.
+func (t *Table) filterRows(scrollRowCount int) {
+	t.filteredRows = nil
+	collapsed := t.hasCollapsedRows()
+	if t.filter == nil && !collapsed {
+		return
+	}
+	t.filteredRows = make([]int, 0, scrollRowCount)
+	collapsedLevel := -1 // The level of the collapsed row whose descendants are skipped.
+	for row := t.fixedRows; row < scrollRowCount; row++ {
+		if collapsed {
+			level := t.rowLevel(row)
+			if collapsedLevel >= 0 && level > collapsedLevel {
+				continue
+			}
+			collapsedLevel = -1
+			if r := t.treeRows[row]; r != nil && r.collapsed {
+				collapsedLevel = level
+			}
+		}
+		if t.filter == nil || t.filter(row) {
+			t.filteredRows = append(t.filteredRows, row)
+		}
+	}
+}
+
+// filteredRow returns the index of the row shown at the provided line, when
+// the lines of the table are counted from the first fixed row without
+// scrolling and without the rows hidden by the filter function.
+func (t *Table) filteredRow(line int) int {
+	if t.filteredRows == nil || line < t.fixedRows {
+		return line
+	}
+	if line-t.fixedRows < len(t.filteredRows) {
+		return t.filteredRows[line-t.fixedRows]
+	}
+	return t.rowCount() + line - t.fixedRows - len(t.filteredRows)
+}
+
+// filteredLine returns the line at which the row at the provided index is
+// shown. See filteredRow. Rows hidden by the filter function are considered to
+// be shown at the line of the next row.
+func (t *Table) filteredLine(row int) int {
+	if t.filteredRows == nil || row < t.fixedRows {
+		return row
+	}
+	return t.fixedRows + sort.SearchInts(t.filteredRows, row)
+}
+
+// highlightFilter highlights the text set via SetFilterHighlight in the
+// provided area of the screen.
+func (t *Table) highlightFilter(screen tcell.Screen, x, y, width int) {
+	if t.filterHighlight == "" || width <= 0 {
+		return
+	}
+
+	// Read the text drawn in the area.
+	var (
+		text      strings.Builder
+		positions []int // The screen position of each byte of the text.
+	)
+	for offset := 0; offset < width; offset++ {
+		m, c, _, w := screen.GetContent(x+offset, y)
+		if w == 0 {
+			continue // The second cell of a wide character.
+		}
+		lower := strings.ToLower(string(append([]rune{m}, c...)))
+		text.WriteString(lower)
+		for i := 0; i < len(lower); i++ {
+			positions = append(positions, offset)
+		}
+	}
+
+	// Highlight the matches.
+	highlight := strings.ToLower(t.filterHighlight)
+	fg, bg, attr := t.filterHighlightStyle.Decompose()
+	s := text.String()
+	for from := 0; ; {
+		index := strings.Index(s[from:], highlight)
+		if index < 0 {
+			break
+		}
+		start, end := from+index, from+index+len(highlight)
+		for offset := positions[start]; offset <= positions[end-1]; offset++ {
+			m, c, style, _ := screen.GetContent(x+offset, y)
+			if fg != tcell.ColorDefault {
+				style = style.Foreground(fg)
+			}
+			if bg != tcell.ColorDefault {
+				style = style.Background(bg)
+			}
+			_, _, a := style.Decompose()
+			screen.SetContent(x+offset, y, m, c, SetAttributes(style, a|attr))
+		}
+		from = end
+	}
+}
@@ -0,0 +1,210 @@
+package cview
+
+import (
+	"regexp"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// TableSearcher may be implemented by a TableContent to search its cells
+// without each cell being requested, e.g. by using an index. See Table.Find.
+type TableSearcher interface {
+	// FindCells returns the positions of all cells whose text, without color
+	// tags, matches the given pattern, sorted by row, then by column. Each
+	// position consists of a row and a column.
+	FindCells(pattern *regexp.Regexp) [][2]int
+}
+
+// Find highlights all cells whose text matches the provided pattern, ignoring
+// color tags, and scrolls to the first match. When regex is true, the pattern
+// is a regular expression, otherwise it is matched literally. All cells of the
+// table are searched, including the cells of a TableContent, which may
+// implement TableSearcher to search its cells more efficiently. Rows which are
+// hidden by the filter function (see SetFilterFunc) or by collapsed rows (see
+// SetRowExpanded) and hidden columns (see SetColumnVisible) are not searched.
+// Provide an empty pattern to clear the search.
+//
+// When cells are selectable, the cell of the current match is selected.
+// Otherwise, the table is scrolled to it. Matches are found when Find is
+// called, so it must be called again to update them after the cells changed.
+// Use GetMatchCount to retrieve the number of matches and NextMatch and
+// PreviousMatch to move to each match.
+func (t *Table) Find(pattern string, regex bool) error {
+	var findPattern *regexp.Regexp
+	if pattern != "" {
+		if !regex {
+			pattern = regexp.QuoteMeta(pattern)
+		}
+		var err error
+		findPattern, err = regexp.Compile(pattern)
+		if err != nil {
+			return err
+		}
+	}
+
+	var calls callbacks
+	defer calls.run()
+
+	t.Lock()
+	defer t.Unlock()
+
+	t.findMatches, t.findMatchSet, t.currentFindMatch = nil, nil, -1
+	if findPattern == nil {
+		return nil
+	}
+
+	var matches [][2]int
+	if searcher, ok := t.content.(TableSearcher); ok {
+		matches = searcher.FindCells(findPattern)
+	} else {
+		rowCount, columnCount := t.rowCount(), t.columnCount()
+		for row := 0; row < rowCount; row++ {
+			for column := 0; column < columnCount; column++ {
+				cell := t.cell(row, column)
+				if cell == nil {
+					continue
+				}
+				if _, _, _, _, _, stripped, _ := decomposeText(cell.Text, true, false); findPattern.Match(stripped) {
+					matches = append(matches, [2]int{row, column})
+				}
+			}
+		}
+	}
+	t.findMatchSet = make(map[[2]int]bool)
+	for _, match := range matches {
+		if t.isFilteredRow(match[0]) || t.hiddenColumns[match[1]] {
+			continue
+		}
+		t.findMatches = append(t.findMatches, match)
+		t.findMatchSet[match] = true
+	}
+
+	if len(t.findMatches) > 0 {
+		t.currentFindMatch = 0
+		t.showMatch(&calls)
+	}
+	return nil
+}
+
+// GetMatchCount returns the number of cells which matched the pattern
+// provided to Find.
+func (t *Table) GetMatchCount() int {
+	t.RLock()
+	defer t.RUnlock()
+
+	return len(t.findMatches)
+}
+
+// GetCurrentMatch returns the position of the cell of the current match of
+// the pattern provided to Find, or -1 and -1 if there is no current match.
+func (t *Table) GetCurrentMatch() (row, column int) {
+	t.RLock()
+	defer t.RUnlock()
+
+	if t.currentFindMatch < 0 {
+		return -1, -1
+	}
+	match := t.findMatches[t.currentFindMatch]
+	return match[0], match[1]
+}
+
+// NextMatch moves to the next match of the pattern provided to Find. After
+// the last match, the first match is selected again.
+func (t *Table) NextMatch() {
+	t.moveMatch(1)
+}
+
+// PreviousMatch moves to the previous match of the pattern provided to Find.
+// Before the first match, the last match is selected again.
+func (t *Table) PreviousMatch() {
+	t.moveMatch(-1)
+}
+
+// SetMatchStyle sets the style which is applied to the cells which matched
+// the pattern provided to Find, unless they are selected. Its foreground and
+// background colors replace the colors of the cells unless they are
+// tcell.ColorDefault, and its attributes are added to the attributes of the
+// cells.
+func (t *Table) SetMatchStyle(style tcell.Style) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.matchStyle = style
+}
+
+// moveMatch moves the current match by the provided offset.
+func (t *Table) moveMatch(offset int) {
+	var calls callbacks
+	defer calls.run()
+
+	t.Lock()
+	defer t.Unlock()
+
+	if len(t.findMatches) == 0 {
+		return
+	}
+	if t.currentFindMatch < 0 {
+		if offset > 0 {
+			t.currentFindMatch = 0
+		} else {
+			t.currentFindMatch = len(t.findMatches) - 1
+		}
+	} else {
+		t.currentFindMatch = (t.currentFindMatch + offset + len(t.findMatches)) % len(t.findMatches)
+	}
+	t.showMatch(&calls)
+}
+
+// showMatch selects the cell of the current match, or scrolls to it if cells
+// are not selectable, and adds the selection changed handler to the provided
+// callbacks.
+func (t *Table) showMatch(calls *callbacks) {
+	row, column := t.findMatches[t.currentFindMatch][0], t.findMatches[t.currentFindMatch][1]
+	t.trackEnd = false
+	if !t.rowsSelectable && !t.columnsSelectable {
+		if row >= t.fixedRows {
+			t.rowOffset = t.filteredLine(row) - t.fixedRows
+		}
+		if column >= t.fixedColumns && !t.isVisibleColumn(column) {
+			t.columnOffset = column - t.fixedColumns
+		}
+		return
+	}
+
+	previousRow, previousColumn := t.selectedRow, t.selectedColumn
+	t.selectedRow, t.selectedColumn = row, column
+	if selectionChanged := t.selectionChanged; selectionChanged != nil && (previousRow != row || previousColumn != column) {
+		calls.add(func() {
+			selectionChanged(row, column)
+		})
+	}
+}
+
+// isVisibleColumn returns whether the provided column was visible the last
+// time the table was drawn.
+func (t *Table) isVisibleColumn(column int) bool {
+	for _, visible := range t.visibleColumnIndices {
+		if visible == column {
+			return true
+		}
+	}
+	return false
+}
+
+// highlightMatch applies the match style to the provided area of the screen.
+func (t *Table) highlightMatch(screen tcell.Screen, x, y, width, height int) {
+	fg, bg, attr := t.matchStyle.Decompose()
+	for by := y; by < y+height; by++ {
+		for bx := x; bx < x+width; bx++ {
+			m, c, style, _ := screen.GetContent(bx, by)
+			if fg != tcell.ColorDefault {
+				style = style.Foreground(fg)
+			}
+			if bg != tcell.ColorDefault {
+				style = style.Background(bg)
+			}
+			_, _, a := style.Decompose()
+			screen.SetContent(bx, by, m, c, SetAttributes(style, a|attr))
+		}
+	}
+}
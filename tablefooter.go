@@ -0,0 +1,70 @@
+package cview
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// SetFooterRows sets the number of footer rows, which are the bottom-most rows
+// of the table, such as totals or other aggregates. Footer rows are drawn
+// below the other rows and stay visible when the table is scrolled
+// vertically. They may not be selected, moved or sorted. Footer rows never
+// include fixed rows (see SetFixed).
+func (t *Table) SetFooterRows(rows int) {
+	t.Lock()
+	defer t.Unlock()
+
+	if rows < 0 {
+		rows = 0
+	}
+	t.footerRows = rows
+}
+
+// GetFooterRows returns the number of footer rows set via SetFooterRows.
+func (t *Table) GetFooterRows() int {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.footerRows
+}
+
+// SetFooterStyle sets the style which is applied to the cells of the footer
+// rows. Its foreground and background colors replace the colors of the cells
+// unless they are tcell.ColorDefault, and its attributes are added to the
+// attributes of the cells.
+func (t *Table) SetFooterStyle(style tcell.Style) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.footerStyle = style
+}
+
+// footerRowCount returns the number of footer rows of a table with the provided
+// number of rows.
+func (t *Table) footerRowCount(rowCount int) int {
+	footers := t.footerRows
+	if footers > rowCount-t.fixedRows {
+		footers = rowCount - t.fixedRows
+	}
+	if footers < 0 {
+		return 0
+	}
+	return footers
+}
+
+// isFooterRow returns whether the row at the provided index is a footer row.
+func (t *Table) isFooterRow(row int) bool {
+	rowCount := t.rowCount()
+	return row >= rowCount-t.footerRowCount(rowCount) && row < rowCount
+}
+
+// rowCellStyle returns the provided text style of a cell with the foreground
+// color and attributes of the provided style of its row, such as the footer
+// style, applied.
+func rowCellStyle(style, rowStyle tcell.Style) tcell.Style {
+	fg, _, attr := rowStyle.Decompose()
+	if fg != tcell.ColorDefault {
+		style = style.Foreground(fg)
+	}
+	_, _, cellAttr := style.Decompose()
+	return SetAttributes(style, cellAttr|attr)
+}
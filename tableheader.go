@@ -0,0 +1,79 @@
+package cview
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// SetHeaderRow sets the cells of the header row, which is the first row of
+// the table and names its columns. The header row is fixed (see SetFixed), so
+// it stays visible when the table is scrolled vertically, its cells are drawn
+// with the header style (see SetHeaderStyle) and it may not be selected.
+// Previous cells of the first row are replaced. Nil cells are left empty.
+//
+// When the cells are provided by a TableContent (see SetContent), the first
+// row it provides is the header row and the provided cells are ignored.
+func (t *Table) SetHeaderRow(cells ...*TableCell) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.header = true
+	if t.fixedRows < 1 {
+		t.fixedRows = 1
+	}
+	if t.content != nil {
+		return
+	}
+
+	if len(t.cells) == 0 {
+		t.cells = make([][]*TableCell, 1)
+	}
+	row := make([]*TableCell, len(cells))
+	for column, cell := range cells {
+		if cell == nil {
+			cell = &TableCell{}
+		} else if t.cellFormat != nil {
+			cell.setFormat(t.cellFormat)
+		}
+		row[column] = cell
+		if column > t.lastColumn {
+			t.lastColumn = column
+		}
+	}
+	t.cells[0] = row
+}
+
+// HasHeaderRow returns whether the header row was set via SetHeaderRow.
+func (t *Table) HasHeaderRow() bool {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.header
+}
+
+// SetHeaderStyle sets the style which is applied to the cells of the header
+// row. Its foreground and background colors replace the colors of the cells
+// unless they are tcell.ColorDefault, and its attributes are added to the
+// attributes of the cells. The header row is bold and drawn in
+// Styles.SecondaryTextColor by default.
+func (t *Table) SetHeaderStyle(style tcell.Style) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.headerStyle = style
+}
+
+// SetHeaderSeparators sets whether or not lines are drawn between the cells
+// of the header row, regardless of the separator set via SetSeparator. If
+// cell borders are activated, this is ignored.
+func (t *Table) SetHeaderSeparators(visible bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.headerSeparators = visible
+}
+
+// isHeaderRow returns whether the row at the provided index is the header
+// row.
+func (t *Table) isHeaderRow(row int) bool {
+	return t.header && row == 0
+}
@@ -0,0 +1,260 @@
+package cview
+
+import (
+	"sort"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// TableRange is a rectangular range of cells of a Table, from the cell at
+// FromRow and FromColumn to the cell at ToRow and ToColumn, inclusive.
+type TableRange struct {
+	FromRow, FromColumn int
+	ToRow, ToColumn     int
+}
+
+// Contains returns whether the range contains the cell at the provided
+// position.
+func (r TableRange) Contains(row, column int) bool {
+	return row >= r.FromRow && row <= r.ToRow && column >= r.FromColumn && column <= r.ToColumn
+}
+
+// SetMultiSelect sets whether or not multiple rows, columns or cells (see
+// SetSelectable) may be selected at once. When enabled, the user may toggle
+// whether the current selection is part of the selected ranges by pressing
+// Keys.ToggleSelection (Space by default) or by clicking while holding Ctrl,
+// and extend the selected ranges by moving the selection while holding Shift
+// (see Keys.SelectUp and related keys) or by clicking while holding Shift.
+// Selected ranges are retrieved via GetSelectedRanges.
+//
+// The selected ranges are cleared when rows or columns are inserted, removed,
+// moved or sorted, and when the selectable flags are changed. They follow the
+// remaining rows when the oldest rows are removed (see SetMaxRows).
+func (t *Table) SetMultiSelect(multi bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.multiSelect = multi
+	if !multi {
+		t.clearMultiSelection()
+	}
+}
+
+// GetSelectedRanges returns the selected ranges of cells, sorted by their
+// position. When entire rows or columns are selected, the ranges span all
+// columns or rows of the table. When no ranges were selected via
+// multi-selection (see SetMultiSelect), the current selection (see
+// GetSelection) is returned as a single range. Nil is returned when nothing
+// may be selected.
+func (t *Table) GetSelectedRanges() []TableRange {
+	t.RLock()
+	defer t.RUnlock()
+
+	if !t.rowsSelectable && !t.columnsSelectable {
+		return nil
+	}
+	if len(t.multiSelection) == 0 {
+		return []TableRange{t.selectionRange(t.selectionKey(t.selectedRow, t.selectedColumn))}
+	}
+
+	// Sort the selected keys by row, then by column.
+	keys := make([][2]int, 0, len(t.multiSelection))
+	for key := range t.multiSelection {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+
+	// Combine adjacent keys of each row, then the runs of adjacent rows which
+	// span the same columns.
+	var ranges []TableRange
+	for _, key := range keys {
+		r := t.selectionRange(key)
+		if last := len(ranges) - 1; last >= 0 && ranges[last].FromRow == r.FromRow && ranges[last].ToColumn+1 == r.FromColumn {
+			ranges[last].ToColumn = r.ToColumn
+			continue
+		}
+		ranges = append(ranges, r)
+	}
+	var merged []TableRange
+RangeLoop:
+	for _, r := range ranges {
+		for index := range merged {
+			if m := &merged[index]; m.ToRow+1 == r.FromRow && m.FromColumn == r.FromColumn && m.ToColumn == r.ToColumn {
+				m.ToRow = r.ToRow
+				continue RangeLoop
+			}
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// IsSelected returns whether the cell at the provided position is part of the
+// selected ranges. See GetSelectedRanges.
+func (t *Table) IsSelected(row, column int) bool {
+	for _, r := range t.GetSelectedRanges() {
+		if r.Contains(row, column) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClearSelectedRanges clears the ranges selected via multi-selection. See
+// SetMultiSelect.
+func (t *Table) ClearSelectedRanges() {
+	t.Lock()
+	defer t.Unlock()
+
+	t.clearMultiSelection()
+}
+
+// clearMultiSelection clears the ranges selected via multi-selection.
+func (t *Table) clearMultiSelection() {
+	t.multiSelection = nil
+	t.extendingSelection = false
+}
+
+// evictMultiSelection moves the multi-selection up along with the rows after
+// the provided number of rows below the fixed rows were removed. Selected rows
+// which were removed are dropped.
+func (t *Table) evictMultiSelection(fixed, evicted int) {
+	evict := func(selection map[[2]int]bool) map[[2]int]bool {
+		if len(selection) == 0 {
+			return selection
+		}
+		moved := make(map[[2]int]bool, len(selection))
+		for key := range selection {
+			if key[0] >= fixed {
+				key[0] -= evicted
+				if key[0] < fixed {
+					continue
+				}
+			}
+			moved[key] = true
+		}
+		return moved
+	}
+	t.multiSelection = evict(t.multiSelection)
+	t.multiSelectionBase = evict(t.multiSelectionBase)
+
+	if t.selectionAnchorRow >= fixed {
+		t.selectionAnchorRow -= evicted
+		if t.selectionAnchorRow < fixed {
+			t.selectionAnchorRow = fixed
+		}
+	}
+}
+
+// selectionKey returns the key of the provided position in the
+// multi-selection. When entire rows or columns are selected, the column or
+// row of the key is -1.
+func (t *Table) selectionKey(row, column int) [2]int {
+	if !t.columnsSelectable {
+		column = -1
+	} else if !t.rowsSelectable {
+		row = -1
+	}
+	return [2]int{row, column}
+}
+
+// selectionRange returns the range of cells covered by the provided
+// multi-selection key.
+func (t *Table) selectionRange(key [2]int) TableRange {
+	r := TableRange{FromRow: key[0], ToRow: key[0], FromColumn: key[1], ToColumn: key[1]}
+	if key[0] < 0 {
+		r.FromRow, r.ToRow = 0, t.rowCount()-1
+	}
+	if key[1] < 0 {
+		r.FromColumn, r.ToColumn = 0, t.columnCount()-1
+	}
+	return r
+}
+
+// isMultiSelected returns whether the cell at the provided position was
+// selected via multi-selection.
+func (t *Table) isMultiSelected(row, column int) bool {
+	return t.multiSelection[t.selectionKey(row, column)]
+}
+
+// toggleSelection toggles whether the provided position is selected via
+// multi-selection.
+func (t *Table) toggleSelection(row, column int) {
+	t.extendingSelection = false
+	key := t.selectionKey(row, column)
+	if t.multiSelection[key] {
+		delete(t.multiSelection, key)
+		return
+	}
+	if t.multiSelection == nil {
+		t.multiSelection = make(map[[2]int]bool)
+	}
+	t.multiSelection[key] = true
+}
+
+// startExtendingSelection remembers the current selection as the start of a
+// range which is extended by moving the selection, unless a range is already
+// being extended.
+func (t *Table) startExtendingSelection() {
+	if t.extendingSelection {
+		return
+	}
+	t.extendingSelection = true
+	t.selectionAnchorRow, t.selectionAnchorColumn = t.selectedRow, t.selectedColumn
+	t.multiSelectionBase = make(map[[2]int]bool)
+	for key := range t.multiSelection {
+		t.multiSelectionBase[key] = true
+	}
+}
+
+// extendSelection selects the range between the start of the extended range
+// and the current selection, in addition to the ranges which were selected
+// before the range was started.
+func (t *Table) extendSelection() {
+	t.multiSelection = make(map[[2]int]bool)
+	for key := range t.multiSelectionBase {
+		t.multiSelection[key] = true
+	}
+	fromRow, toRow := t.selectionAnchorRow, t.selectedRow
+	if fromRow > toRow {
+		fromRow, toRow = toRow, fromRow
+	}
+	fromColumn, toColumn := t.selectionAnchorColumn, t.selectedColumn
+	if fromColumn > toColumn {
+		fromColumn, toColumn = toColumn, fromColumn
+	}
+	for row := fromRow; row <= toRow; row++ {
+		for column := fromColumn; column <= toColumn; column++ {
+			if cell := t.cell(row, column); cell != nil && cell.NotSelectable || t.isFilteredRow(row) {
+				continue
+			}
+			t.multiSelection[t.selectionKey(row, column)] = true
+		}
+	}
+}
+
+// selectClicked updates the multi-selection after the user clicked on the
+// cell at the provided position while holding the provided modifier keys.
+func (t *Table) selectClicked(row, column int, modifiers tcell.ModMask) {
+	t.Lock()
+	defer t.Unlock()
+
+	if !t.multiSelect || row < 0 || column < 0 {
+		return
+	}
+	switch {
+	case modifiers&tcell.ModShift != 0:
+		t.startExtendingSelection()
+		t.selectedRow, t.selectedColumn = row, column
+		t.extendSelection()
+	case modifiers&tcell.ModCtrl != 0:
+		t.toggleSelection(row, column)
+	default:
+		t.extendingSelection = false
+	}
+}
@@ -0,0 +1,67 @@
+package cview
+
+// TableSelectionMode determines what the user may select in a Table.
+type TableSelectionMode int
+
+// Selection modes of a Table.
+const (
+	// TableSelectNone disables the selection.
+	TableSelectNone TableSelectionMode = iota
+
+	// TableSelectCells selects individual cells.
+	TableSelectCells
+
+	// TableSelectRows selects entire rows.
+	TableSelectRows
+
+	// TableSelectColumns selects entire columns.
+	TableSelectColumns
+)
+
+// SetSelectionMode sets whether individual cells, entire rows or entire
+// columns may be selected, or nothing at all. This is equivalent to
+// SetSelectable and may be changed at any time, e.g. to let the user switch
+// between selecting rows and cells. The selected row and column are kept.
+func (t *Table) SetSelectionMode(mode TableSelectionMode) {
+	switch mode {
+	case TableSelectCells:
+		t.SetSelectable(true, true)
+	case TableSelectRows:
+		t.SetSelectable(true, false)
+	case TableSelectColumns:
+		t.SetSelectable(false, true)
+	default:
+		t.SetSelectable(false, false)
+	}
+}
+
+// GetSelectionMode returns the selection mode of the table. See
+// SetSelectionMode.
+func (t *Table) GetSelectionMode() TableSelectionMode {
+	t.RLock()
+	defer t.RUnlock()
+
+	switch {
+	case t.rowsSelectable && t.columnsSelectable:
+		return TableSelectCells
+	case t.rowsSelectable:
+		return TableSelectRows
+	case t.columnsSelectable:
+		return TableSelectColumns
+	default:
+		return TableSelectNone
+	}
+}
+
+// SetVimKeys sets whether or not the table is navigated like Vim: the top is
+// reached by pressing g twice instead of once, and Keys.MoveHalfPageDown and
+// Keys.MoveHalfPageUp (Ctrl+D and Ctrl+U by default) move down and up by half
+// a page. The other key bindings similar to Vim, such as h, j, k, l and G, are
+// always available. This is disabled by default.
+func (t *Table) SetVimKeys(enabled bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.vimKeys = enabled
+	t.vimPrefix = false
+}
@@ -0,0 +1,121 @@
+package cview
+
+// SetMaxRows sets the maximum number of rows of the table which are neither
+// fixed rows (see SetFixed) nor footer rows (see SetFooterRows). When more
+// rows are added, the oldest rows, which are the top-most rows below the fixed
+// rows, are removed. This is useful for live feeds of events or metrics,
+// which are added via AppendRow. Set to 0 for no maximum, which is the
+// default.
+//
+// The selection, the selected ranges (see SetMultiSelect) and the row offset
+// follow the remaining rows, so the rows which are shown do not change unless
+// the table tracks its end (see ScrollToEnd).
+//
+// Removing rows only moves the fixed rows, so appending a row once the
+// maximum is reached takes amortized constant time, regardless of the maximum
+// number of rows. The rows are reallocated occasionally as rows are appended.
+func (t *Table) SetMaxRows(rows int) {
+	t.Lock()
+	defer t.Unlock()
+
+	if rows < 0 {
+		rows = 0
+	}
+	t.maxRows = rows
+	t.evictRows()
+}
+
+// GetMaxRows returns the maximum number of rows set via SetMaxRows.
+func (t *Table) GetMaxRows() int {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.maxRows
+}
+
+// AppendRow adds a row with the provided cells below the last row of the
+// table which is not a footer row, removing the oldest rows when the maximum
+// number of rows is exceeded (see SetMaxRows). Nil cells are left empty. It
+// returns the index of the added row.
+//
+// Unlike SetCell, the row is added at once, and only the column widths of the
+// rows which are shown are measured when the table is drawn (unless
+// SetEvaluateAllRows is enabled), so rows may be appended quickly. This has no
+// effect when the cells are provided by a TableContent and -1 is returned.
+func (t *Table) AppendRow(cells ...*TableCell) int {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.content != nil {
+		return -1
+	}
+
+	row := make([]*TableCell, len(cells))
+	for column, cell := range cells {
+		if cell == nil {
+			cell = &TableCell{}
+		} else if t.cellFormat != nil {
+			cell.setFormat(t.cellFormat)
+		}
+		row[column] = cell
+		if column > t.lastColumn {
+			t.lastColumn = column
+		}
+	}
+
+	// Insert the row above the footer rows.
+	index := len(t.cells) - t.footerRowCount(len(t.cells))
+	if index < t.fixedRows {
+		index = len(t.cells)
+	}
+	t.cells = append(t.cells, nil)
+	copy(t.cells[index+1:], t.cells[index:])
+	t.cells[index] = row
+	t.shiftTreeRows(index, 1)
+
+	return index - t.evictRows()
+}
+
+// evictRows removes the oldest rows which exceed the maximum number of rows
+// and updates the selection and the row offset. It returns the number of
+// removed rows.
+func (t *Table) evictRows() int {
+	if t.maxRows <= 0 || t.content != nil {
+		return 0
+	}
+	fixed := t.fixedRows
+	if fixed > len(t.cells) {
+		fixed = len(t.cells)
+	}
+	evicted := len(t.cells) - fixed - t.footerRowCount(len(t.cells)) - t.maxRows
+	if evicted <= 0 {
+		return 0
+	}
+
+	// Move the fixed rows down over the removed rows and drop the front of
+	// the slice, so that the remaining rows are not copied. Append
+	// reallocates the slice once its capacity is used up.
+	copy(t.cells[evicted:], t.cells[:fixed])
+	for row := 0; row < evicted; row++ {
+		t.cells[row] = nil
+	}
+	t.cells = t.cells[evicted:]
+
+	if t.selectedRow >= fixed {
+		t.selectedRow -= evicted
+		if t.selectedRow < fixed {
+			t.selectedRow = fixed
+		}
+	}
+	if !t.trackEnd {
+		t.rowOffset -= evicted
+		if t.rowOffset < 0 {
+			t.rowOffset = 0
+		}
+	}
+	for i := 0; i < evicted; i++ {
+		t.shiftTreeRows(fixed, -1)
+	}
+	t.evictMultiSelection(fixed, evicted)
+	return evicted
+}
@@ -0,0 +1,309 @@
+package cview
+
+import (
+	"sort"
+	"strings"
+)
+
+// tableRow holds the state of a row of a tree-table.
+type tableRow struct {
+	// The level of the row in the hierarchy.
+	level int
+
+	// Whether the row may be expanded even when it has no child rows.
+	expandable bool
+
+	// Whether the child rows of the row are hidden.
+	collapsed bool
+}
+
+// SetRowLevel sets the level of the given row in the hierarchy of a
+// tree-table. The child rows of a row are the rows directly below it which
+// have a higher level. Once the level of any row is set, the table is drawn as
+// a tree-table: the text of the first column is indented according to the
+// level of each row and preceded by an indicator which shows whether rows with
+// child rows are expanded or collapsed (see SetExpandIndicators). Rows are
+// expanded by default.
+//
+// Fixed rows and footer rows are not part of the hierarchy. The rows of a
+// tree-table may not be moved by the user, and sorting the table sorts the
+// child rows of each row separately, keeping them below their parent row.
+func (t *Table) SetRowLevel(row, level int) {
+	t.Lock()
+	defer t.Unlock()
+
+	if level < 0 {
+		level = 0
+	}
+	t.treeRow(row).level = level
+	t.movingRow, t.draggingRow = false, false
+}
+
+// GetRowLevel returns the level of the given row in the hierarchy of a
+// tree-table. See SetRowLevel.
+func (t *Table) GetRowLevel(row int) int {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.rowLevel(row)
+}
+
+// SetRowExpandable sets whether the given row may be expanded even when it
+// has no child rows. This allows loading child rows lazily: collapse the row
+// via SetRowExpanded and insert its child rows in the handler set via
+// SetRowExpandedFunc once the user expands it.
+func (t *Table) SetRowExpandable(row int, expandable bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.treeRow(row).expandable = expandable
+}
+
+// SetRowExpanded sets whether the child rows of the given row are shown.
+func (t *Table) SetRowExpanded(row int, expanded bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.treeRow(row).collapsed = !expanded
+	if !expanded && t.rowsSelectable && t.isCollapsedRow(t.selectedRow) {
+		t.selectedRow = row
+	}
+}
+
+// IsRowExpanded returns whether the child rows of the given row are shown.
+func (t *Table) IsRowExpanded(row int) bool {
+	t.RLock()
+	defer t.RUnlock()
+
+	r := t.treeRows[row]
+	return r == nil || !r.collapsed
+}
+
+// SetExpandIndicators sets the indicators drawn before the text of the rows of
+// a tree-table which are collapsed and expanded. The default indicators are ▶
+// for collapsed and ▼ for expanded rows.
+func (t *Table) SetExpandIndicators(collapsed, expanded rune) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.collapsedIndicator, t.expandedIndicator = collapsed, expanded
+}
+
+// SetRowExpandedFunc sets a handler which is called when the user expands or
+// collapses a row of a tree-table by pressing Keys.ExpandRow or
+// Keys.CollapseRow (+ and - by default) or by clicking on its indicator. Child
+// rows may be inserted below the row when it is expanded. See
+// SetRowExpandable.
+func (t *Table) SetRowExpandedFunc(handler func(row int, expanded bool)) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.rowExpanded = handler
+}
+
+// treeRow returns the state of the given row of a tree-table, creating it if
+// it does not exist.
+func (t *Table) treeRow(row int) *tableRow {
+	r := t.treeRows[row]
+	if r == nil {
+		r = &tableRow{}
+		if t.treeRows == nil {
+			t.treeRows = make(map[int]*tableRow)
+		}
+		t.treeRows[row] = r
+	}
+	return r
+}
+
+// rowLevel returns the level of the row at the provided index.
+func (t *Table) rowLevel(row int) int {
+	if r := t.treeRows[row]; r != nil {
+		return r.level
+	}
+	return 0
+}
+
+// isRowExpandable returns whether the row at the provided index has child
+// rows or may be expanded nonetheless.
+func (t *Table) isRowExpandable(row int) bool {
+	if len(t.treeRows) == 0 || row < t.fixedRows || t.isFooterRow(row) {
+		return false
+	} else if r := t.treeRows[row]; r != nil && r.expandable {
+		return true
+	}
+	next := row + 1
+	return next < t.rowCount() && !t.isFooterRow(next) && t.rowLevel(next) > t.rowLevel(row)
+}
+
+// hasCollapsedRows returns whether any row of a tree-table is collapsed.
+func (t *Table) hasCollapsedRows() bool {
+	for _, r := range t.treeRows {
+		if r.collapsed {
+			return true
+		}
+	}
+	return false
+}
+
+// isCollapsedRow returns whether the row at the provided index is hidden
+// because a row it is a descendant of is collapsed.
+func (t *Table) isCollapsedRow(row int) bool {
+	if !t.hasCollapsedRows() {
+		return false
+	}
+	level := t.rowLevel(row)
+	for parent := row - 1; parent >= t.fixedRows && level > 0; parent-- {
+		if parentLevel := t.rowLevel(parent); parentLevel < level {
+			if r := t.treeRows[parent]; r != nil && r.collapsed {
+				return true
+			}
+			level = parentLevel
+		}
+	}
+	return false
+}
+
+// expandRow expands or collapses the provided row on behalf of the user and
+// adds the row expanded handler to the provided callbacks. When the selected
+// row is hidden by collapsing the row, the row is selected instead.
+func (t *Table) expandRow(calls *callbacks, row int, expanded bool) {
+	if !t.isRowExpandable(row) {
+		return
+	}
+	r := t.treeRow(row)
+	if r.collapsed != expanded {
+		return // Nothing changes.
+	}
+	r.collapsed = !expanded
+	if !expanded && t.rowsSelectable && t.isCollapsedRow(t.selectedRow) {
+		t.selectedRow = row
+	}
+	if handler := t.rowExpanded; handler != nil {
+		calls.add(func() {
+			handler(row, expanded)
+		})
+	}
+}
+
+// expandClicked expands or collapses the row whose indicator is drawn at the
+// provided screen coordinates, if any. It returns whether an indicator was
+// clicked.
+func (t *Table) expandClicked(calls *callbacks, x, y int) bool {
+	t.Lock()
+	defer t.Unlock()
+
+	row, column := t.cellAt(x, y)
+	if row < 0 || column != 0 || !t.isRowExpandable(row) {
+		return false
+	}
+	cell := t.cell(row, column)
+	if cell == nil {
+		return false
+	}
+	indicatorX := cell.x + 2*t.rowLevel(row)
+	if x < indicatorX || x >= indicatorX+stringWidth(string(t.rowIndicator(row))) {
+		return false
+	}
+	r := t.treeRows[row]
+	t.expandRow(calls, row, r != nil && r.collapsed)
+	return true
+}
+
+// rowIndicator returns the indicator drawn before the text of the row at the
+// provided index of a tree-table.
+func (t *Table) rowIndicator(row int) rune {
+	if !t.isRowExpandable(row) {
+		return ' '
+	} else if r := t.treeRows[row]; r != nil && r.collapsed {
+		return t.collapsedIndicator
+	}
+	return t.expandedIndicator
+}
+
+// treePrefix returns the indentation and the indicator drawn before the text
+// of the cell at the provided position, or nil if there is none.
+func (t *Table) treePrefix(row, column int) []byte {
+	if len(t.treeRows) == 0 || column != 0 || row < t.fixedRows || t.isFooterRow(row) {
+		return nil
+	}
+	return []byte(strings.Repeat(" ", 2*t.rowLevel(row)) + string(t.rowIndicator(row)) + " ")
+}
+
+// sortTree sorts the provided rows of a tree-table, which are consecutive,
+// using the provided function. Each row is sorted among its sibling rows,
+// together with its descendants, which stay below it.
+func (t *Table) sortTree(rows []int, less func(a, b int) bool) {
+	// Split the rows into subtrees.
+	var subtrees [][]int
+	for start := 0; start < len(rows); {
+		end := start + 1
+		for end < len(rows) && t.rowLevel(rows[end]) > t.rowLevel(rows[start]) {
+			end++
+		}
+		subtrees = append(subtrees, append([]int(nil), rows[start:end]...))
+		start = end
+	}
+
+	// Sort the subtrees by their first row and their child rows recursively.
+	sort.SliceStable(subtrees, func(a, b int) bool {
+		return less(subtrees[a][0], subtrees[b][0])
+	})
+	index := 0
+	for _, subtree := range subtrees {
+		t.sortTree(subtree[1:], less)
+		index += copy(rows[index:], subtree)
+	}
+}
+
+// shiftTreeRows shifts the state of the rows of a tree-table starting at the
+// given row by the given amount.
+func (t *Table) shiftTreeRows(row int, amount int) {
+	if len(t.treeRows) == 0 {
+		return
+	}
+	treeRows := make(map[int]*tableRow)
+	for r, state := range t.treeRows {
+		if r == row && amount < 0 {
+			continue // The row was removed.
+		} else if r >= row {
+			r += amount
+		}
+		treeRows[r] = state
+	}
+	t.treeRows = treeRows
+}
+
+// reorderTreeRows rearranges the state of the rows of a tree-table after the
+// rows were rearranged so that the row at order[i] is found at index i.
+func (t *Table) reorderTreeRows(order []int) {
+	if len(t.treeRows) == 0 {
+		return
+	}
+	treeRows := make(map[int]*tableRow)
+	for to, from := range order {
+		if state := t.treeRows[from]; state != nil {
+			treeRows[to] = state
+		}
+	}
+	t.treeRows = treeRows
+}
+
+// moveTreeRow moves the state of the row of a tree-table at the index "from"
+// to the index "to", shifting the state of the rows in between by one row.
+func (t *Table) moveTreeRow(from, to int) {
+	if len(t.treeRows) == 0 {
+		return
+	}
+	treeRows := make(map[int]*tableRow)
+	for r, state := range t.treeRows {
+		if r == from {
+			r = to
+		} else if from < to && r > from && r <= to {
+			r--
+		} else if to < from && r >= to && r < from {
+			r++
+		}
+		treeRows[r] = state
+	}
+	t.treeRows = treeRows
+}
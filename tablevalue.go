@@ -0,0 +1,313 @@
+package cview
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TableCellType is the type of the value of a TableCell.
+type TableCellType int
+
+// Table cell types.
+const (
+	TableCellText  TableCellType = iota // Text without a typed value.
+	TableCellInt                        // An int64, aligned to the right.
+	TableCellFloat                      // A float64, aligned to the right.
+	TableCellTime                       // A time.Time.
+	TableCellBytes                      // A number of bytes as an int64, aligned to the right.
+	TableCellBool                       // A bool.
+)
+
+// CellFormat defines how the values of typed table cells are formatted, e.g.
+// to match the conventions of a locale:
+//
+//   table.SetCellFormat(cview.CellFormat{
+//     ThousandsSeparator: ".",
+//     DecimalSeparator:   ",",
+//     TimeLayout:         "02.01.2006 15:04",
+//     True:               "ja",
+//     False:              "nein",
+//   })
+type CellFormat struct {
+	// The separator between groups of thousands of numbers, or an empty
+	// string for no separator.
+	ThousandsSeparator string
+
+	// The separator between the integer and the fractional part of numbers.
+	DecimalSeparator string
+
+	// The layout of times (see time.Time.Format).
+	TimeLayout string
+
+	// The texts of the boolean values.
+	True, False string
+}
+
+// DefaultCellFormat is the format of typed table cells which are not part of
+// a table with a format set via Table.SetCellFormat.
+var DefaultCellFormat = CellFormat{
+	ThousandsSeparator: ",",
+	DecimalSeparator:   ".",
+	TimeLayout:         "2006-01-02 15:04",
+	True:               "true",
+	False:              "false",
+}
+
+// The units of byte sizes, starting with kibibytes.
+var byteSizeUnits = []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// SetInt sets the value of the cell to an integer. The value is formatted
+// with thousands separators and aligned to the right.
+func (c *TableCell) SetInt(value int64) {
+	c.setValue(TableCellInt, value, 0, AlignRight)
+}
+
+// SetFloat sets the value of the cell to a floating-point number, formatted
+// with the provided number of digits after the decimal separator and with
+// thousands separators. A precision of -1 uses the smallest number of digits
+// necessary to represent the value exactly. The value is aligned to the right.
+func (c *TableCell) SetFloat(value float64, precision int) {
+	c.setValue(TableCellFloat, value, precision, AlignRight)
+}
+
+// SetTime sets the value of the cell to a time. The zero time is shown as an
+// empty cell.
+func (c *TableCell) SetTime(value time.Time) {
+	c.setValue(TableCellTime, value, 0, AlignLeft)
+}
+
+// SetByteSize sets the value of the cell to a number of bytes. The value is
+// formatted using binary units (e.g. "1.5 MiB") and aligned to the right.
+func (c *TableCell) SetByteSize(value int64) {
+	c.setValue(TableCellBytes, value, 0, AlignRight)
+}
+
+// SetBool sets the value of the cell to a boolean.
+func (c *TableCell) SetBool(value bool) {
+	c.setValue(TableCellBool, value, 0, AlignLeft)
+}
+
+// GetValue returns the value of the cell: an int64 for TableCellInt and
+// TableCellBytes, a float64 for TableCellFloat, a time.Time for TableCellTime
+// and a bool for TableCellBool. Returns nil when the cell holds text only.
+func (c *TableCell) GetValue() interface{} {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.value
+}
+
+// GetValueType returns the type of the value of the cell. Setting the text of
+// the cell resets its type to TableCellText.
+func (c *TableCell) GetValueType() TableCellType {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.valueType
+}
+
+// setValue sets the typed value of the cell, formats its text and sets its
+// alignment.
+func (c *TableCell) setValue(valueType TableCellType, value interface{}, precision int, align int) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.valueType, c.value, c.precision = valueType, value, precision
+	c.Align = align
+	c.formatValue()
+}
+
+// setFormat sets the format of the cell and formats its value.
+func (c *TableCell) setFormat(format *CellFormat) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.format = format
+	c.formatValue()
+}
+
+// formatValue sets the text of the cell to its formatted value. Cells which
+// hold text only are not modified. The lock must be held.
+func (c *TableCell) formatValue() {
+	if c.valueType == TableCellText {
+		return
+	}
+
+	format := &DefaultCellFormat
+	if c.format != nil {
+		format = c.format
+	}
+
+	var text string
+	switch value := c.value.(type) {
+	case int64:
+		if c.valueType == TableCellBytes {
+			text = formatByteSize(value, format)
+		} else {
+			text = formatNumber(strconv.FormatInt(value, 10), format)
+		}
+	case float64:
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			text = strconv.FormatFloat(value, 'f', -1, 64)
+		} else {
+			text = formatNumber(strconv.FormatFloat(value, 'f', c.precision, 64), format)
+		}
+	case time.Time:
+		if !value.IsZero() {
+			text = value.Format(format.TimeLayout)
+		}
+	case bool:
+		text = format.False
+		if value {
+			text = format.True
+		}
+	}
+	c.Text = []byte(Escape(text))
+}
+
+// formatNumber inserts the thousands separator into the integer part of the
+// provided number and replaces its decimal point with the decimal separator.
+func formatNumber(number string, format *CellFormat) string {
+	var sign string
+	if strings.HasPrefix(number, "-") {
+		sign, number = "-", number[1:]
+	}
+	integer, fraction := number, ""
+	if i := strings.IndexByte(number, '.'); i >= 0 {
+		integer, fraction = number[:i], number[i+1:]
+	}
+
+	var b strings.Builder
+	b.WriteString(sign)
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(format.ThousandsSeparator)
+		}
+		b.WriteRune(digit)
+	}
+	if fraction != "" {
+		b.WriteString(format.DecimalSeparator)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
+
+// formatByteSize formats a number of bytes using binary units with one digit
+// after the decimal separator.
+func formatByteSize(size int64, format *CellFormat) string {
+	if size > -1024 && size < 1024 {
+		return formatNumber(strconv.FormatInt(size, 10), format) + " B"
+	}
+	value := float64(size)
+	var unit string
+	for _, unit = range byteSizeUnits {
+		value /= 1024
+		if math.Abs(value) < 1024 {
+			break
+		}
+	}
+	return formatNumber(strconv.FormatFloat(value, 'f', 1, 64), format) + " " + unit
+}
+
+// SetCellFormat sets the format of the values of typed cells (see
+// TableCell.SetInt and related functions). The text of all typed cells of the
+// table, including cells which are added later, is formatted accordingly. The
+// default is DefaultCellFormat.
+func (t *Table) SetCellFormat(format CellFormat) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.cellFormat = &format
+	for _, row := range t.cells {
+		for _, cell := range row {
+			if cell != nil {
+				cell.setFormat(t.cellFormat)
+			}
+		}
+	}
+}
+
+// CompareTableCells compares two table cells and returns -1 if a sorts before
+// b, 1 if a sorts after b and 0 if they are equal. Numbers (integers,
+// floating-point numbers and byte sizes) are compared numerically, times
+// chronologically and booleans with false sorting before true. Other cells
+// are compared by their text, case-sensitively. A nil cell sorts before any
+// other cell. This is the comparison used by Table.Sort unless a sort function
+// is set via Table.SetSortFunc.
+func CompareTableCells(a, b *TableCell) int {
+	if a == nil || b == nil {
+		switch {
+		case a == b:
+			return 0
+		case a == nil:
+			return -1
+		default:
+			return 1
+		}
+	}
+
+	a.RLock()
+	aValue, aText := a.value, a.Text
+	a.RUnlock()
+	b.RLock()
+	bValue, bText := b.value, b.Text
+	b.RUnlock()
+
+	switch aValue := aValue.(type) {
+	case int64:
+		switch bValue := bValue.(type) {
+		case int64:
+			switch {
+			case aValue < bValue:
+				return -1
+			case aValue > bValue:
+				return 1
+			}
+			return 0
+		case float64:
+			return compareFloats(float64(aValue), bValue)
+		}
+	case float64:
+		switch bValue := bValue.(type) {
+		case int64:
+			return compareFloats(aValue, float64(bValue))
+		case float64:
+			return compareFloats(aValue, bValue)
+		}
+	case time.Time:
+		if bValue, ok := bValue.(time.Time); ok {
+			switch {
+			case aValue.Before(bValue):
+				return -1
+			case aValue.After(bValue):
+				return 1
+			}
+			return 0
+		}
+	case bool:
+		if bValue, ok := bValue.(bool); ok {
+			switch {
+			case aValue == bValue:
+				return 0
+			case !aValue:
+				return -1
+			}
+			return 1
+		}
+	}
+	return bytes.Compare(aText, bText)
+}
+
+// compareFloats compares two numbers. NaN sorts before any other number.
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b || math.IsNaN(a) && !math.IsNaN(b):
+		return -1
+	case a > b || math.IsNaN(b) && !math.IsNaN(a):
+		return 1
+	}
+	return 0
+}
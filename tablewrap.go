@@ -0,0 +1,180 @@
+package cview
+
+// tableRowLines describes the lines a row was drawn on.
+type tableRowLines struct {
+	// The index of the row.
+	row int
+
+	// The first line of the row, relative to the top of the table, and the
+	// number of lines of its text.
+	top, height int
+}
+
+// SetColumnWrap sets whether or not the text of the cells of the given column
+// is wrapped onto multiple lines when it exceeds the width of the column. See
+// TableCell.SetWrap.
+func (t *Table) SetColumnWrap(column int, wrap bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.tableColumn(column).wrap = wrap
+}
+
+// cellWraps returns whether the text of the provided cell of the given column
+// is wrapped.
+func (t *Table) cellWraps(cell *TableCell, column int) bool {
+	if cell.Wrap {
+		return true
+	}
+	c := t.columnDefaults[column]
+	return c != nil && c.wrap
+}
+
+// cellLines returns the lines of the text of the provided cell of the given
+// column, wrapped to the provided width if the cell is wrapped.
+func (t *Table) cellLines(cell *TableCell, column, width int) [][]byte {
+	if width <= 0 || !t.cellWraps(cell, column) || TaggedTextWidth(cell.Text) <= width {
+		return [][]byte{cell.Text}
+	}
+	wrapped := WordWrap(string(cell.Text), width)
+	lines := make([][]byte, len(wrapped))
+	for index, line := range wrapped {
+		lines[index] = []byte(line)
+	}
+	return lines
+}
+
+// textWidth returns the width available to the text of the cell at the
+// provided position, when its column is drawn with the provided width.
+func (t *Table) textWidth(row, column, width int) int {
+	if indicator := t.sortIndicator(row, column); indicator != 0 {
+		width -= 1 + stringWidth(string(indicator))
+	}
+	if prefix := t.treePrefix(row, column); prefix != nil {
+		width -= stringWidth(string(prefix))
+	}
+	return width
+}
+
+// rowHeight returns the number of lines of the text of the provided row, when
+// the provided columns are drawn with the provided widths.
+func (t *Table) rowHeight(row int, columns, widths []int) int {
+	height := 1
+	for index, column := range columns {
+		cell := t.cell(row, column)
+		if cell == nil {
+			continue
+		}
+		if lines := len(t.cellLines(cell, column, t.textWidth(row, column, widths[index]))); lines > height {
+			height = lines
+		}
+	}
+	return height
+}
+
+// fitRows fits the provided rows, which were determined assuming each row
+// takes up a single line, into the provided number of lines when their text
+// has the provided heights. Scrolling rows are removed from the top until the
+// selected row (or the last row, when tracking the end) is fully visible, and
+// added or removed at the bottom to fill the available lines. The last row may
+// be cut off. The row offset and the position of the footer rows are updated
+// accordingly.
+func (t *Table) fitRows(rows, heights, columns, widths []int, height, lineCount int) ([]int, []int) {
+	lines := func(height int) int {
+		if t.borders {
+			return height + 1 // The border above the row.
+		}
+		return height
+	}
+
+	// Determine the lines available to the scrolling rows.
+	fixed := 0
+	for fixed < len(rows) && rows[fixed] < t.fixedRows {
+		fixed++
+	}
+	footer := len(rows)
+	if t.footerLine >= 0 {
+		footer = t.footerLine
+	}
+	available := height
+	for index := range rows {
+		if index < fixed || index >= footer {
+			available -= lines(heights[index])
+		}
+	}
+	scrolling := append([]int(nil), rows[fixed:footer]...)
+	scrollingHeights := append([]int(nil), heights[fixed:footer]...)
+	var used int
+	for _, h := range scrollingHeights {
+		used += lines(h)
+	}
+
+	// Keep the selected row or the last row visible.
+	anchor := -1
+	if t.rowsSelectable {
+		for index, row := range scrolling {
+			if row == t.selectedRow {
+				anchor = index
+			}
+		}
+	}
+	if anchor < 0 && t.trackEnd {
+		anchor = len(scrolling) - 1
+	}
+	for anchor > 0 {
+		var anchorLines int
+		for _, h := range scrollingHeights[:anchor+1] {
+			anchorLines += lines(h)
+		}
+		if anchorLines <= available {
+			break
+		}
+		used -= lines(scrollingHeights[0])
+		scrolling, scrollingHeights = scrolling[1:], scrollingHeights[1:]
+		anchor--
+		t.rowOffset++
+	}
+
+	// Fill the remaining lines.
+	for line := t.fixedRows + t.rowOffset + len(scrolling); used < available && line < lineCount; line++ {
+		row := t.filteredRow(line)
+		h := t.rowHeight(row, columns, widths)
+		scrolling, scrollingHeights = append(scrolling, row), append(scrollingHeights, h)
+		used += lines(h)
+	}
+
+	// Remove the rows which do not fit and cut off the last row.
+	for len(scrolling) > 0 && used > available {
+		last := len(scrolling) - 1
+		if excess := used - available; scrollingHeights[last] > excess {
+			scrollingHeights[last] -= excess
+			break
+		}
+		used -= lines(scrollingHeights[last])
+		scrolling, scrollingHeights = scrolling[:last], scrollingHeights[:last]
+	}
+
+	fitted := append(append(append([]int(nil), rows[:fixed]...), scrolling...), rows[footer:]...)
+	fittedHeights := append(append(append([]int(nil), heights[:fixed]...), scrollingHeights...), heights[footer:]...)
+	if t.footerLine >= 0 {
+		t.footerLine = fixed + len(scrolling)
+	}
+	return fitted, fittedHeights
+}
+
+// rowAtLine returns the row drawn at the provided line, relative to the top of
+// the table, the last time the table was drawn with wrapped rows, or -1 if
+// there is no row at the line. With borders, the border below a row is
+// considered to be part of the row.
+func (t *Table) rowAtLine(line int) int {
+	for _, r := range t.rowLines {
+		top := r.top
+		if t.borders {
+			top++
+		}
+		if line >= top && line < top+r.height || t.borders && line == top+r.height {
+			return r.row
+		}
+	}
+	return -1
+}
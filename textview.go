@@ -2,25 +2,59 @@ package cview
 
 import (
 	"bytes"
+	"fmt"
+	"math"
 	"regexp"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 	"unicode"
 	"unicode/utf8"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/lucasb-eyer/go-colorful"
-	"github.com/mattn/go-runewidth"
 	"github.com/rivo/uniseg"
 )
 
 var (
-	// TabSize is the number of spaces with which a tab character will be replaced.
+	// TabSize is the distance between tab stops of text printed via Print and
+	// the default tab width of text views (see TextView.SetTabWidth).
 	TabSize = 4
 )
 
+// DefaultWrapCacheSize is the default maximum estimated size in bytes of the
+// wrapped line layouts cached by a TextView. See TextView.SetWrapCacheSize.
+const DefaultWrapCacheSize = 16 * 1024 * 1024
+
+// The estimated size in bytes of a line of the index of a TextView.
+const textViewIndexSize = 128
+
 var (
 	openColorRegex  = regexp.MustCompile(`\[([a-zA-Z]*|#[0-9a-zA-Z]*)$`)
-	openRegionRegex = regexp.MustCompile(`\["[a-zA-Z0-9_,;: \-\.]*"?$`)
+	openRegionRegex = regexp.MustCompile(`\["([a-zA-Z0-9_,;: \-\.]*|[a-zA-Z][a-zA-Z0-9+\-\.]*:/{0,2}[a-zA-Z0-9_,;:\-\./?#&=%+~@!$*()']*)"?$`)
+)
+
+// WrapMode determines how lines of a TextView which are longer than the
+// available width are wrapped.
+type WrapMode int
+
+// Available wrap modes.
+const (
+	// WrapNone doesn't wrap lines. Characters beyond the available width are
+	// not displayed.
+	WrapNone WrapMode = iota
+
+	// WrapAnywhere wraps lines at any character, which suits long URLs and
+	// paths.
+	WrapAnywhere
+
+	// WrapWord wraps lines at spaces or after punctuation marks.
+	WrapWord
+
+	// WrapIndent wraps lines like WrapWord and indents continuation lines to
+	// the indentation of the line they continue, which suits code.
+	WrapIndent
 )
 
 // textViewIndex contains information about each line displayed in the text
@@ -34,6 +68,7 @@ type textViewIndex struct {
 	BackgroundColor string // The starting background color ("" = don't change, "-" = reset).
 	Attributes      string // The starting attributes ("" = don't change, "-" = reset).
 	Region          []byte // The starting region ID.
+	Indent          int    // The screen width of the indentation of a continuation line.
 }
 
 // textViewRegion contains information about a region.
@@ -46,6 +81,47 @@ type textViewRegion struct {
 	FromX, FromY, ToX, ToY int
 }
 
+// textViewMatch contains information about a match of the search pattern.
+type textViewMatch struct {
+	Line int // The index into the "buffer" variable.
+	From int // The (byte) position of the match in the stripped buffer line.
+	To   int // The (byte) position after the match in the stripped buffer line.
+}
+
+// textViewPosition is a position in the text of a TextView.
+type textViewPosition struct {
+	Line int // The index into the "buffer" variable.
+	Pos  int // The (byte) position in the stripped buffer line.
+}
+
+// textViewStyleRange contains information about a span of text which is
+// drawn using a custom style.
+type textViewStyleRange struct {
+	FromLine, FromColumn int // The first character of the span.
+	ToLine, ToColumn     int // The character after the span on the last line.
+	Style                tcell.Style
+}
+
+// LineProvider provides the lines of a TextView on demand. This allows
+// displaying documents which are too large to be kept in memory, as only the
+// visible lines are requested. See TextView.SetLineProvider.
+type LineProvider interface {
+	// LineCount returns the number of lines.
+	LineCount() int
+
+	// Line returns the line with the given index, starting at 0. The returned
+	// line should not contain any newline characters.
+	Line(n int) []byte
+}
+
+// LineSearcher may be implemented by a LineProvider to search its lines
+// without each line being requested, e.g. by using an index.
+type LineSearcher interface {
+	// SearchLines returns the indices of all lines which contain the given
+	// text, in ascending order.
+	SearchLines(text string) []int
+}
+
 // TextView is a box which displays text. It implements the io.Writer interface
 // so you can stream text to it. This does not trigger a redraw automatically
 // but if a handler is installed via SetChangedFunc(), you can cause it to be
@@ -97,6 +173,26 @@ type textViewRegion struct {
 //
 // The ScrollToHighlight() function can be used to jump to the currently
 // highlighted region once when the text view is drawn the next time.
+//
+// Hyperlinks
+//
+// A region whose ID is a URL, such as ["https://example.com"], is a hyperlink.
+// Hyperlinks are underlined. When one is clicked, the handler set via
+// SetLinkClickedFunc() is called with its URL instead of highlighting it, so
+// that the application may open it. Regions must be enabled to use
+// hyperlinks.
+//
+// Hyperlinks are also passed on to the terminal as OSC 8 hyperlinks, so that
+// terminals which support them may open them by themselves, e.g. when they are
+// clicked while a modifier key is held. ExportANSI exports them as OSC 8
+// hyperlinks as well.
+//
+// Line Providers
+//
+// Instead of keeping text in a buffer, a text view may request only the lines
+// visible on the screen from a LineProvider. See SetLineProvider() for more
+// information. Scrollback is a LineProvider which bounds the memory used by
+// long-running output by spilling older lines to disk.
 type TextView struct {
 	*Box
 
@@ -119,6 +215,30 @@ type TextView struct {
 	// If set to true, the buffer will be reindexed each time it is modified.
 	reindex bool
 
+	// Incremented each time the index is invalidated.
+	indexGeneration int
+
+	// The index as of the last time the buffer was indexed while drawing, and
+	// whether text was appended to the buffer since. Appended text is indexed
+	// without re-indexing the lines before it.
+	indexed       *textViewPreparedIndex
+	indexAppended bool
+
+	// The indices prepared for widths other than the current width, most
+	// recently used last, and the maximum estimated size of these indices in
+	// bytes. Cached indices are reused when the text view is resized.
+	wrapCache     []*textViewPreparedIndex
+	wrapCacheSize int
+
+	// If set to true, the buffer is indexed by a background goroutine.
+	backgroundIndexing bool
+
+	// The index most recently prepared in the background, whether the
+	// background goroutine is running and the width it should index for.
+	preparedIndex     *textViewPreparedIndex
+	indexing          bool
+	indexRequestWidth int
+
 	// The horizontal text alignment, one of AlignLeft, AlignCenter, or AlignRight.
 	align int
 
@@ -152,6 +272,13 @@ type TextView struct {
 	// If set to true, the text view will always remain at the end of the content.
 	trackEnd bool
 
+	// If set to true, the text view follows new content unless scrolled up, and
+	// the number of lines written while scrolled up is indicated.
+	follow bool
+
+	// The number of lines written while the end of the content was not shown.
+	unseenLines int
+
 	// The number of characters to be skipped on each line (not in wrap mode).
 	columnOffset int
 
@@ -180,6 +307,18 @@ type TextView struct {
 	// after punctuation characters.
 	wordWrap bool
 
+	// If set to true and if wordWrap is also true, continuation lines are
+	// indented to the indentation of the line they continue.
+	wrapIndent bool
+
+	// The rune drawn at the start of continuation lines (0 = none).
+	wrapIndicator rune
+
+	// The distance between tab stops, and whether the tab stops of
+	// consecutive lines containing tabs are aligned.
+	tabWidth    int
+	elasticTabs bool
+
 	// The (starting) color of the text.
 	textColor tcell.Color
 
@@ -216,6 +355,93 @@ type TextView struct {
 	// highlighted.
 	highlighted func(added, removed, remaining []string)
 
+	// An optional function which is called when the user clicks a hyperlink.
+	linkClicked func(url string)
+
+	// An optional function which is called when the user clicks a line.
+	lineClicked func(line int, reference interface{})
+
+	// The references attached to the lines of the buffer. This may be shorter
+	// than the buffer.
+	lineReferences []interface{}
+
+	// The line drawn on each row starting at lineRowsY, as of the last time
+	// the text view was drawn.
+	lineRows  []int
+	lineRowsY int
+
+	// An optional provider of the lines to display instead of the buffer.
+	provider LineProvider
+
+	// The reader text is streamed from, if any.
+	source *textViewSource
+
+	// If set, ANSI escape sequences written to the text view are translated
+	// into color tags by this writer.
+	ansiWriter *ansi
+
+	// The search pattern, if any.
+	searchPattern *regexp.Regexp
+
+	// The matches of the search pattern. These are updated when the buffer
+	// changes.
+	matches      []*textViewMatch
+	matchesValid bool
+
+	// The index of the current match. Set to -1 if there is no current match.
+	currentMatch int
+
+	// A temporary flag which, when true, will automatically bring the current
+	// match into the visible screen.
+	scrollToMatch bool
+
+	// The colors of search matches other than the current match. The current
+	// match is drawn using the highlight colors.
+	matchForeground tcell.Color
+	matchBackground tcell.Color
+
+	// Spans of text which are drawn using a custom style.
+	styleRanges []*textViewStyleRange
+
+	// Annotated spans of text, the annotations drawn at each screen position
+	// and the annotation the mouse is currently over.
+	annotations     []*TextViewAnnotation
+	annotationCells map[[2]int]*TextViewAnnotation
+	hoverAnnotation *TextViewAnnotation
+
+	// An optional function which is called when the mouse moves onto or off
+	// an annotation.
+	annotationHover func(annotation *TextViewAnnotation)
+
+	// An optional function which is called when an annotation is clicked.
+	annotationSelected func(annotation *TextViewAnnotation)
+
+	// The registered widgets, the spans of the buffer displaying them, the
+	// interval at which they are updated and a channel which is closed to
+	// stop updating them.
+	widgets        map[string]TextViewWidget
+	widgetSpans    []*textViewWidgetSpan
+	widgetInterval time.Duration
+	widgetStop     chan struct{}
+
+	// Named lines which may be scrolled to.
+	anchors map[string]int
+
+	// The line to scroll to the next time the text view is drawn, or -1.
+	scrollToAnchor int
+
+	// Whether text may be selected via the keyboard.
+	selectable bool
+
+	// Whether text is selected, the position the selection started at and the
+	// position of the selection cursor.
+	selecting                        bool
+	selectionAnchor, selectionCursor textViewPosition
+
+	// A temporary flag which, when true, will automatically bring the
+	// selection cursor into the visible screen.
+	scrollToSelection bool
+
 	sync.RWMutex
 }
 
@@ -232,9 +458,16 @@ func NewTextView() *TextView {
 		align:               AlignLeft,
 		valign:              AlignTop,
 		wrap:                true,
+		tabWidth:            TabSize,
 		textColor:           Styles.PrimaryTextColor,
 		highlightForeground: Styles.PrimitiveBackgroundColor,
 		highlightBackground: Styles.PrimaryTextColor,
+		currentMatch:        -1,
+		scrollToAnchor:      -1,
+		wrapCacheSize:       DefaultWrapCacheSize,
+		matchForeground:     Styles.PrimitiveBackgroundColor,
+		matchBackground:     Styles.SecondaryTextColor,
+		widgetInterval:      DefaultWidgetInterval,
 	}
 }
 
@@ -275,7 +508,7 @@ func (t *TextView) SetWrap(wrap bool) {
 	defer t.Unlock()
 
 	if t.wrap != wrap {
-		t.index = nil
+		t.invalidateIndex()
 	}
 	t.wrap = wrap
 }
@@ -290,11 +523,84 @@ func (t *TextView) SetWordWrap(wrapOnWords bool) {
 	defer t.Unlock()
 
 	if t.wordWrap != wrapOnWords {
-		t.index = nil
+		t.invalidateIndex()
 	}
 	t.wordWrap = wrapOnWords
 }
 
+// SetWrapMode sets how lines that are longer than the available width are
+// wrapped. See WrapMode for the available modes. This replaces the settings of
+// SetWrap() and SetWordWrap().
+func (t *TextView) SetWrapMode(mode WrapMode) {
+	t.Lock()
+	defer t.Unlock()
+
+	wrap := mode != WrapNone
+	wordWrap := mode == WrapWord || mode == WrapIndent
+	wrapIndent := mode == WrapIndent
+	if t.wrap != wrap || t.wordWrap != wordWrap || t.wrapIndent != wrapIndent {
+		t.invalidateIndex()
+	}
+	t.wrap, t.wordWrap, t.wrapIndent = wrap, wordWrap, wrapIndent
+}
+
+// GetWrapMode returns how lines that are longer than the available width are
+// wrapped.
+func (t *TextView) GetWrapMode() WrapMode {
+	t.RLock()
+	defer t.RUnlock()
+
+	switch {
+	case !t.wrap:
+		return WrapNone
+	case !t.wordWrap:
+		return WrapAnywhere
+	case t.wrapIndent:
+		return WrapIndent
+	default:
+		return WrapWord
+	}
+}
+
+// SetWrapIndicator sets the rune drawn at the start of lines which continue a
+// wrapped line, such as '↪'. Continuation lines are indented by the width of
+// the rune. Set to 0 to disable the indicator (the default).
+func (t *TextView) SetWrapIndicator(indicator rune) {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.wrapIndicator != indicator {
+		t.invalidateIndex()
+	}
+	t.wrapIndicator = indicator
+}
+
+// SetTabWidth sets the distance between tab stops. Tab characters are
+// replaced with spaces up to the next tab stop when text is written, so this
+// only applies to text written afterwards. A width of 0 removes tab
+// characters. The default is TabSize.
+func (t *TextView) SetTabWidth(width int) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.tabWidth = width
+}
+
+// SetElasticTabs sets the flag that, if true, aligns the columns of
+// consecutive lines containing tab characters, such as tab-separated output
+// of tools, instead of using fixed tab stops. Each column is widened to the
+// first tab stop after its widest cell (see SetTabWidth). Columns are aligned
+// within the lines passed to a single call to Write or SetText, so lines
+// written before are not realigned. While enabled, lines are not displayed
+// until they are terminated by a newline. This only applies to text written
+// afterwards.
+func (t *TextView) SetElasticTabs(elastic bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.elasticTabs = elastic
+}
+
 // SetTextAlign sets the horizontal alignment of the text. This must be either
 // AlignLeft, AlignCenter, or AlignRight.
 func (t *TextView) SetTextAlign(align int) {
@@ -302,7 +608,7 @@ func (t *TextView) SetTextAlign(align int) {
 	defer t.Unlock()
 
 	if t.align != align {
-		t.index = nil
+		t.invalidateIndex()
 	}
 	t.align = align
 }
@@ -314,7 +620,7 @@ func (t *TextView) SetVerticalAlign(valign VerticalAlignment) {
 	defer t.Unlock()
 
 	if t.valign != valign {
-		t.index = nil
+		t.invalidateIndex()
 	}
 	t.valign = valign
 }
@@ -327,6 +633,25 @@ func (t *TextView) SetTextColor(color tcell.Color) {
 	defer t.Unlock()
 
 	t.textColor = color
+	t.textColorSet = true
+}
+
+// inheritColors applies the colors inherited from the parent container.
+func (t *TextView) inheritColors(background, text tcell.Color) {
+	t.Box.inheritColors(background, text)
+
+	t.Lock()
+	defer t.Unlock()
+
+	t.inheritTextColor(&t.textColor, text)
+}
+
+// primaryTextColor returns the color of the primary text.
+func (t *TextView) primaryTextColor() tcell.Color {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.textColor
 }
 
 // SetHighlightForegroundColor sets the foreground color of highlighted text.
@@ -345,6 +670,24 @@ func (t *TextView) SetHighlightBackgroundColor(color tcell.Color) {
 	t.highlightBackground = color
 }
 
+// SetMatchForegroundColor sets the foreground color of search matches. The
+// current match is drawn using the highlight colors.
+func (t *TextView) SetMatchForegroundColor(color tcell.Color) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.matchForeground = color
+}
+
+// SetMatchBackgroundColor sets the background color of search matches. The
+// current match is drawn using the highlight colors.
+func (t *TextView) SetMatchBackgroundColor(color tcell.Color) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.matchBackground = color
+}
+
 // SetBytes sets the text of this text view to the provided byte slice.
 // Previously contained text will be removed.
 func (t *TextView) SetBytes(text []byte) {
@@ -368,10 +711,7 @@ func (t *TextView) GetBytes(stripTags bool) []byte {
 	defer t.RUnlock()
 
 	if !stripTags {
-		if len(t.recentBytes) > 0 {
-			return bytes.Join(append(t.buffer, t.recentBytes), []byte("\n"))
-		}
-		return bytes.Join(t.buffer, []byte("\n"))
+		return append(bytes.Join(t.buffer, []byte("\n")), t.recentBytes...)
 	}
 
 	buffer := bytes.Join(t.buffer, []byte("\n"))
@@ -400,11 +740,36 @@ func (t *TextView) SetDynamicColors(dynamic bool) {
 	defer t.Unlock()
 
 	if t.dynamicColors != dynamic {
-		t.index = nil
+		t.invalidateIndex()
 	}
 	t.dynamicColors = dynamic
 }
 
+// SetANSI sets the flag that allows ANSI escape sequences to be written to
+// the text view, such as the output of an external command. SGR sequences
+// which set colors and text attributes are translated into color tags, while
+// other escape sequences are removed. Text which resembles color or region
+// tags is escaped so that it is displayed as is. Dynamic colors are enabled
+// along with this mode, as they are required to display the translated
+// colors.
+func (t *TextView) SetANSI(ansi bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	if ansi == (t.ansiWriter != nil) {
+		return
+	}
+	if !ansi {
+		t.ansiWriter = nil
+		return
+	}
+	t.ansiWriter = newANSIWriter(textViewANSIWriter{t}, true)
+	if !t.dynamicColors {
+		t.dynamicColors = true
+		t.invalidateIndex()
+	}
+}
+
 // SetRegions sets the flag that allows to define regions in the text. See class
 // description for details.
 func (t *TextView) SetRegions(regions bool) {
@@ -412,7 +777,7 @@ func (t *TextView) SetRegions(regions bool) {
 	defer t.Unlock()
 
 	if t.regions != regions {
-		t.index = nil
+		t.invalidateIndex()
 	}
 	t.regions = regions
 }
@@ -461,22 +826,216 @@ func (t *TextView) SetHighlightedFunc(handler func(added, removed, remaining []s
 	t.highlighted = handler
 }
 
-func (t *TextView) clipBuffer() {
-	if t.maxLines <= 0 {
+// SetLinkClickedFunc sets a handler which is called with the URL of a
+// hyperlink when the user clicks it. See the class description for details.
+func (t *TextView) SetLinkClickedFunc(handler func(url string)) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.linkClicked = handler
+}
+
+// SetLineClickedFunc sets a handler which is called with the index of a line
+// and its reference, if any, when the user clicks the line. Lines are counted
+// like the lines of the buffer, starting at 0, regardless of wrapping. When a
+// line provider is set, the index of the line of the provider is passed. The
+// handler is not called when a hyperlink is clicked while a handler is set via
+// SetLinkClickedFunc.
+func (t *TextView) SetLineClickedFunc(handler func(line int, reference interface{})) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.lineClicked = handler
+}
+
+// WriteLine writes the provided text followed by a new line and attaches the
+// provided reference to each line of the buffer the text is written to, such
+// as the ID of a log entry. References are passed to the handler set via
+// SetLineClickedFunc, allowing a line to be resolved to the value it displays
+// without parsing its text. Text written while a line provider is set is
+// discarded.
+func (t *TextView) WriteLine(text string, reference interface{}) {
+	t.Lock()
+	changed := t.changed
+	if changed != nil {
+		// Notify at the end.
+		defer changed()
+	}
+	defer t.Unlock()
+
+	t.write([]byte(text + "\n"))
+	if t.provider != nil {
 		return
 	}
 
+	// The buffer ends with the empty line following the text.
+	last := len(t.buffer) - 2
+	for line := last - strings.Count(text, "\n"); line <= last; line++ {
+		t.setLineReference(line, reference)
+	}
+}
+
+// SetLineReference attaches a reference to the line of the buffer with the
+// provided index, starting at 0. See WriteLine for details.
+func (t *TextView) SetLineReference(line int, reference interface{}) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.setLineReference(line, reference)
+}
+
+// GetLineReference returns the reference attached to the line of the buffer
+// with the provided index, or nil if it has none.
+func (t *TextView) GetLineReference(line int) interface{} {
+	t.RLock()
+	defer t.RUnlock()
+
+	if line < 0 || line >= len(t.lineReferences) {
+		return nil
+	}
+	return t.lineReferences[line]
+}
+
+// setLineReference attaches a reference to a line of the buffer.
+func (t *TextView) setLineReference(line int, reference interface{}) {
+	if line < 0 || line >= len(t.buffer) {
+		return
+	}
+	for len(t.lineReferences) <= line {
+		t.lineReferences = append(t.lineReferences, nil)
+	}
+	t.lineReferences[line] = reference
+}
+
+// removeLineReferences removes the references of the provided number of
+// lines at the start of the buffer, which were discarded.
+func (t *TextView) removeLineReferences(removed int) {
+	if removed >= len(t.lineReferences) {
+		t.lineReferences = nil
+		return
+	}
+	for i := 0; i < removed; i++ {
+		t.lineReferences[i] = nil
+	}
+	t.lineReferences = t.lineReferences[removed:]
+}
+
+// isLink returns whether the provided region ID is a hyperlink.
+func isLink(regionID []byte) bool {
+	return bytes.Contains(regionID, []byte("://"))
+}
+
+// clipBuffer discards the oldest lines of the buffer when it holds more than
+// the maximum number of lines. It returns whether any lines were discarded.
+func (t *TextView) clipBuffer() bool {
+	if t.maxLines <= 0 {
+		return false
+	}
+
 	lenbuf := len(t.buffer)
 	if lenbuf > t.maxLines {
-		t.buffer = t.buffer[lenbuf-t.maxLines:]
+		removed := lenbuf - t.maxLines
+
+		// Release the discarded lines.
+		for i := 0; i < removed; i++ {
+			t.buffer[i] = nil
+		}
+		t.buffer = t.buffer[removed:]
+		t.matchesValid = false
+
+		// Move anchors and references along with their lines.
+		for name, line := range t.anchors {
+			if line < removed {
+				delete(t.anchors, name)
+			} else {
+				t.anchors[name] = line - removed
+			}
+		}
+		t.removeLineReferences(removed)
+		t.removeSelectionLines(removed)
+		t.removeWidgetLines(removed)
+
+		// Keep the visible text in place while scrolled up. The line offset
+		// refers to the index, which holds more than one line per buffer line
+		// when wrapping, so scroll to the buffer line at the top once the
+		// buffer is indexed again.
+		if !t.trackEnd && t.lineOffset > 0 {
+			top := t.scrollToAnchor
+			if top < 0 && t.index != nil && t.lineOffset < len(t.index) {
+				top = t.index[t.lineOffset].Line
+			}
+			if top >= 0 {
+				top -= removed
+				if top < 0 {
+					top = 0
+				}
+				t.scrollToAnchor = top
+			} else {
+				t.lineOffset -= removed
+				if t.lineOffset < 0 {
+					t.lineOffset = 0
+				}
+			}
+		}
+		return true
 	}
+	return false
 }
 
 // SetMaxLines sets the maximum number of newlines the text view will hold
-// before discarding older data from the buffer.
+// before discarding older data from the buffer. Combined with SetFollow, this
+// allows tailing the output of long-running processes using bounded memory.
 func (t *TextView) SetMaxLines(maxLines int) {
+	t.Lock()
+	defer t.Unlock()
+
 	t.maxLines = maxLines
-	t.clipBuffer()
+	if t.clipBuffer() {
+		t.invalidateIndex()
+	}
+}
+
+// GetMaxLines returns the maximum number of newlines the text view will hold,
+// or 0 if the number of lines is not limited.
+func (t *TextView) GetMaxLines() int {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.maxLines
+}
+
+// SetFollow sets the flag that determines whether the text view follows new
+// content. When enabled, the text view scrolls to the end of its content and
+// remains there as text is written, unless the user scrolls up. While
+// scrolled up, the number of lines written since is indicated in the
+// bottom-right corner. Scrolling to the end (see ScrollToEnd) follows new
+// content again.
+func (t *TextView) SetFollow(follow bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.follow = follow
+	t.unseenLines = 0
+	if follow {
+		t.trackEnd = true
+	}
+}
+
+// GetFollow returns whether the text view follows new content.
+func (t *TextView) GetFollow() bool {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.follow
+}
+
+// GetUnseenLines returns the number of lines written while the end of the
+// content was not shown. This is only counted while following new content.
+func (t *TextView) GetUnseenLines() int {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.unseenLines
 }
 
 // ScrollTo scrolls to the specified row and column (both starting with 0).
@@ -513,36 +1072,618 @@ func (t *TextView) ScrollToEnd() {
 	t.Lock()
 	defer t.Unlock()
 
-	if !t.scrollable {
-		return
+	if !t.scrollable {
+		return
+	}
+	t.trackEnd = true
+	t.columnOffset = 0
+}
+
+// GetScrollOffset returns the number of rows and columns that are skipped at
+// the top left corner when the text view has been scrolled.
+func (t *TextView) GetScrollOffset() (row, column int) {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.lineOffset, t.columnOffset
+}
+
+// Clear removes all text from the buffer.
+func (t *TextView) Clear() {
+	t.Lock()
+	defer t.Unlock()
+
+	t.clear()
+}
+
+// ReplaceRange replaces a span of text with the provided text, allowing
+// applications to update parts of the text in place without rewriting it.
+// The span starts at the character startCol of line startLine and ends before
+// the character endCol of line endLine. Lines are separated by newline
+// characters and columns count characters, ignoring color and region tags.
+// Both start at 0. Positions beyond the end of a line or of the text refer to
+// the end of the line or of the text.
+//
+// Tags preceding the start of the span and tags following its end are kept,
+// so the replacement text is drawn using the style at the start of the span.
+// The scroll position is kept. Text replaced while a line provider is set is
+// discarded.
+func (t *TextView) ReplaceRange(startLine, startCol, endLine, endCol int, text string) {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.provider != nil {
+		return
+	}
+	if len(t.buffer) == 0 {
+		t.buffer = [][]byte{nil}
+	}
+
+	// Clamp the span to the text.
+	clamp := func(line, col int) (int, int) {
+		if line < 0 {
+			return 0, 0
+		} else if line >= len(t.buffer) {
+			return len(t.buffer) - 1, math.MaxInt32
+		} else if col < 0 {
+			return line, 0
+		}
+		return line, col
+	}
+	startLine, startCol = clamp(startLine, startCol)
+	endLine, endCol = clamp(endLine, endCol)
+	if endLine < startLine || endLine == startLine && endCol < startCol {
+		endLine, endCol = startLine, startCol
+	}
+
+	// Translate ANSI escape sequences.
+	if t.ansiWriter != nil {
+		var buf bytes.Buffer
+		newANSIWriter(&buf, true).Write([]byte(text))
+		text = buf.String()
+	}
+
+	// Replace the lines of the span. New lines are allocated as the buffer may
+	// be shared with a background indexer.
+	from := t.rawPos(t.buffer[startLine], startCol, true)
+	to := t.rawPos(t.buffer[endLine], endCol, false)
+	if endLine == startLine && to < from {
+		to = from
+	}
+	var replaced []byte
+	replaced = append(replaced, t.buffer[startLine][:from]...)
+	_, _, _, _, _, _, column := decomposeText(t.buffer[startLine][:from], t.dynamicColors, t.regions)
+	replaced = append(replaced, expandTabs([]byte(text), t.tabWidth, column, t.dynamicColors, t.regions)...)
+	replaced = append(replaced, t.buffer[endLine][to:]...)
+	lines := bytes.Split(replaced, []byte("\n"))
+	t.replaceWidgetLines(startLine, from, endLine, to, len(t.buffer[endLine]), lines)
+
+	buffer := make([][]byte, 0, len(t.buffer)-(endLine-startLine+1)+len(lines))
+	buffer = append(buffer, t.buffer[:startLine]...)
+	buffer = append(buffer, lines...)
+	buffer = append(buffer, t.buffer[endLine+1:]...)
+	t.buffer = buffer
+
+	// Move anchors and references following the span.
+	delta := len(lines) - (endLine - startLine + 1)
+	for name, line := range t.anchors {
+		if line > endLine {
+			t.anchors[name] = line + delta
+		} else if line > startLine+len(lines)-1 {
+			t.anchors[name] = startLine + len(lines) - 1
+		}
+	}
+	if startLine+1 < len(t.lineReferences) {
+		references := make([]interface{}, startLine+len(lines), len(t.buffer))
+		copy(references, t.lineReferences[:startLine+1])
+		if endLine+1 < len(t.lineReferences) {
+			references = append(references, t.lineReferences[endLine+1:]...)
+		}
+		t.lineReferences = references
+	}
+
+	t.clipBuffer()
+	t.matchesValid = false
+	if t.reindex {
+		t.invalidateIndex()
+	}
+}
+
+// InsertAt inserts text before the character col of the provided line. See
+// ReplaceRange for details.
+func (t *TextView) InsertAt(line, col int, text string) {
+	t.ReplaceRange(line, col, line, col, text)
+}
+
+// rawPos returns the (byte) position in the provided buffer line of the
+// provided character, ignoring color and region tags. When afterTags is true,
+// tags preceding the character are skipped. Characters beyond the end of the
+// line refer to the end of the line.
+func (t *TextView) rawPos(line []byte, column int, afterTags bool) int {
+	colorIndices, _, regionIndices, _, escapeIndices, _, _ := decomposeText(line, t.dynamicColors, t.regions)
+
+	// Collect the bytes which are not displayed.
+	var hidden [][]int
+	hidden = append(hidden, colorIndices...)
+	hidden = append(hidden, regionIndices...)
+	for _, escape := range escapeIndices {
+		hidden = append(hidden, []int{escape[1] - 2, escape[1] - 1})
+	}
+	sort.Slice(hidden, func(i, j int) bool {
+		return hidden[i][0] < hidden[j][0]
+	})
+
+	var pos, hiddenPos int
+	for ; ; column-- {
+		if column <= 0 && !afterTags {
+			return pos
+		}
+		for hiddenPos < len(hidden) && hidden[hiddenPos][0] <= pos {
+			if hidden[hiddenPos][1] > pos {
+				pos = hidden[hiddenPos][1]
+			}
+			hiddenPos++
+		}
+		if column <= 0 || pos >= len(line) {
+			return pos
+		}
+		_, size := utf8.DecodeRune(line[pos:])
+		pos += size
+	}
+}
+
+func (t *TextView) clear() {
+	t.buffer = nil
+	t.recentBytes = nil
+	t.lineReferences = nil
+	t.widgetSpans = nil
+	t.matchesValid = false
+	t.selecting = false
+	if t.reindex {
+		t.invalidateIndex()
+	}
+}
+
+// SetLineProvider sets a provider of the lines to display. Only the lines
+// visible on the screen are requested from the provider each time the text
+// view is drawn, so even very large documents may be displayed. Provide nil
+// to display the buffer again.
+//
+// While a line provider is set, the buffer is cleared and text written to the
+// text view is discarded. Color and region tags are processed for each line
+// separately. Lines are not purged when the text view is not scrollable.
+//
+// The provider is called while the text view is locked, so it must not call
+// any methods of the text view.
+func (t *TextView) SetLineProvider(provider LineProvider) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.provider = provider
+	t.clear()
+	t.invalidateIndex()
+	t.lineOffset = 0
+	t.trackEnd = false
+}
+
+// GetLineProvider returns the provider of the lines to display, or nil if
+// the buffer is displayed.
+func (t *TextView) GetLineProvider() LineProvider {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.provider
+}
+
+// SearchLines returns the indices of all lines which contain the given text,
+// ignoring color and region tags. When a line provider is set, the search is
+// delegated to the provider if it implements LineSearcher. Otherwise, each
+// line of the provider is searched.
+func (t *TextView) SearchLines(text string) []int {
+	t.RLock()
+	defer t.RUnlock()
+
+	if text == "" {
+		return nil
+	}
+
+	if searcher, ok := t.provider.(LineSearcher); ok {
+		return searcher.SearchLines(text)
+	}
+
+	search := []byte(text)
+	var indices []int
+	if t.provider != nil {
+		count := t.provider.LineCount()
+		for n := 0; n < count; n++ {
+			if bytes.Contains(StripTags(t.provider.Line(n), t.dynamicColors, t.regions), search) {
+				indices = append(indices, n)
+			}
+		}
+		return indices
+	}
+
+	for n, line := range t.buffer {
+		if bytes.Contains(StripTags(line, t.dynamicColors, t.regions), search) {
+			indices = append(indices, n)
+		}
+	}
+	return indices
+}
+
+// Search highlights all matches of the provided pattern, ignoring color and
+// region tags, and scrolls to the first match. When regex is true, the pattern
+// is a regular expression, otherwise it is matched literally. Matches are
+// updated as text is written to the text view. Provide an empty pattern to
+// clear the search. Search is not supported while a line provider is set, see
+// SearchLines.
+//
+// Matches do not span multiple lines. Use GetMatchCount to retrieve the
+// number of matches and NextMatch and PreviousMatch to scroll to each match.
+func (t *TextView) Search(pattern string, regex bool) error {
+	var searchPattern *regexp.Regexp
+	if pattern != "" {
+		if !regex {
+			pattern = regexp.QuoteMeta(pattern)
+		}
+		var err error
+		searchPattern, err = regexp.Compile(pattern)
+		if err != nil {
+			return err
+		}
+	}
+
+	t.Lock()
+	defer t.Unlock()
+
+	t.searchPattern = searchPattern
+	t.matchesValid = false
+	t.updateMatches()
+	t.currentMatch = -1
+	if len(t.matches) > 0 {
+		t.currentMatch = 0
+		t.scrollToMatch = true
+		t.trackEnd = false
+	}
+	return nil
+}
+
+// GetMatchCount returns the number of matches of the search pattern.
+func (t *TextView) GetMatchCount() int {
+	t.Lock()
+	defer t.Unlock()
+
+	t.updateMatches()
+	return len(t.matches)
+}
+
+// GetCurrentMatch returns the index of the current match of the search
+// pattern, or -1 if there is no current match.
+func (t *TextView) GetCurrentMatch() int {
+	t.Lock()
+	defer t.Unlock()
+
+	t.updateMatches()
+	return t.currentMatch
+}
+
+// NextMatch scrolls to the next match of the search pattern. After the last
+// match, the first match is selected again.
+func (t *TextView) NextMatch() {
+	t.moveMatch(1)
+}
+
+// PreviousMatch scrolls to the previous match of the search pattern. Before
+// the first match, the last match is selected again.
+func (t *TextView) PreviousMatch() {
+	t.moveMatch(-1)
+}
+
+// moveMatch moves the current match by the provided offset.
+func (t *TextView) moveMatch(offset int) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.updateMatches()
+	if len(t.matches) == 0 {
+		return
+	}
+	if t.currentMatch < 0 {
+		if offset > 0 {
+			t.currentMatch = 0
+		} else {
+			t.currentMatch = len(t.matches) - 1
+		}
+	} else {
+		t.currentMatch = (t.currentMatch + offset + len(t.matches)) % len(t.matches)
+	}
+	t.scrollToMatch = true
+	t.trackEnd = false
+}
+
+// findMatches returns the positions of the matches of the search pattern in
+// the provided stripped line. Empty matches are ignored.
+func (t *TextView) findMatches(stripped []byte) [][]int {
+	var matches [][]int
+	for _, m := range t.searchPattern.FindAllIndex(stripped, -1) {
+		if m[1] > m[0] {
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
+
+// updateMatches finds the matches of the search pattern in the buffer if the
+// buffer has changed since the matches were last updated.
+func (t *TextView) updateMatches() {
+	if t.matchesValid {
+		return
+	}
+	t.matchesValid = true
+
+	t.matches = nil
+	if t.searchPattern == nil || t.provider != nil {
+		t.currentMatch = -1
+		return
+	}
+	for n, line := range t.buffer {
+		for _, m := range t.findMatches(StripTags(line, t.dynamicColors, t.regions)) {
+			t.matches = append(t.matches, &textViewMatch{
+				Line: n,
+				From: m[0],
+				To:   m[1],
+			})
+		}
+	}
+	if t.currentMatch >= len(t.matches) {
+		t.currentMatch = len(t.matches) - 1
+	}
+}
+
+// strippedPos returns the (byte) position in the stripped buffer line at which
+// the provided index line starts.
+func (t *TextView) strippedPos(index *textViewIndex) int {
+	if index.Pos == 0 {
+		return 0
+	}
+	return len(StripTags(t.buffer[index.Line][:index.Pos], t.dynamicColors, t.regions))
+}
+
+// scrollToCurrentMatch scrolls such that the current match is visible.
+func (t *TextView) scrollToCurrentMatch(width, height int) {
+	if t.currentMatch < 0 || t.currentMatch >= len(t.matches) {
+		return
+	}
+	m := t.matches[t.currentMatch]
+
+	line, pos := -1, 0
+	for i, index := range t.index {
+		if index.Line > m.Line {
+			break
+		} else if index.Line < m.Line {
+			continue
+		}
+		if p := t.strippedPos(index); p <= m.From {
+			line, pos = i, p
+		}
+	}
+	if line < 0 {
+		return
+	}
+
+	// Center the line of the match.
+	t.lineOffset = line - height/2
+
+	// Bring the match onscreen horizontally.
+	if !t.wrap {
+		stripped := StripTags(t.buffer[m.Line], t.dynamicColors, t.regions)
+		column := stringWidth(string(stripped[pos:m.From]))
+		if column-t.columnOffset > 3*width/4 || column-t.columnOffset < 0 {
+			t.columnOffset = column - width/2
+		}
+	}
+}
+
+// AddStyleRange draws a span of text using the provided style, allowing
+// applications to highlight text such as diagnostics or diff hunks without
+// modifying it. The span starts at the character startCol of line startLine
+// and ends before the character endCol of line endLine. Lines are separated by
+// newline characters and columns count characters, ignoring color and region
+// tags. Both start at 0.
+//
+// The foreground and background colors of the style replace those of the
+// text unless they are tcell.ColorDefault, and its attributes are added to
+// those of the text. Search matches are drawn on top of style ranges. When
+// ranges overlap, the range added last takes precedence.
+func (t *TextView) AddStyleRange(startLine, startCol, endLine, endCol int, style tcell.Style) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.styleRanges = append(t.styleRanges, &textViewStyleRange{
+		FromLine:   startLine,
+		FromColumn: startCol,
+		ToLine:     endLine,
+		ToColumn:   endCol,
+		Style:      style,
+	})
+}
+
+// ClearStyleRanges removes all style ranges added via AddStyleRange.
+func (t *TextView) ClearStyleRanges() {
+	t.Lock()
+	defer t.Unlock()
+
+	t.styleRanges = nil
+}
+
+// AddAnchor names a line of the text, allowing it to be scrolled to via
+// ScrollToAnchor. Lines are separated by newline characters and start at 0.
+// An existing anchor with the same name is replaced. Anchors are kept when the
+// text is replaced, see ClearAnchors.
+//
+// NextAnchor and PreviousAnchor scroll to the anchors following and preceding
+// the top line of the text view, which may also be done using Keys.NextAnchor
+// and Keys.PreviousAnchor while the text view has focus.
+func (t *TextView) AddAnchor(name string, line int) {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.anchors == nil {
+		t.anchors = make(map[string]int)
+	}
+	t.anchors[name] = line
+}
+
+// RemoveAnchor removes an anchor.
+func (t *TextView) RemoveAnchor(name string) {
+	t.Lock()
+	defer t.Unlock()
+
+	delete(t.anchors, name)
+}
+
+// ClearAnchors removes all anchors.
+func (t *TextView) ClearAnchors() {
+	t.Lock()
+	defer t.Unlock()
+
+	t.anchors = nil
+}
+
+// ScrollToAnchor scrolls such that the line of the provided anchor is at the
+// top of the text view. Returns false if there is no such anchor.
+func (t *TextView) ScrollToAnchor(name string) bool {
+	t.Lock()
+	defer t.Unlock()
+
+	line, ok := t.anchors[name]
+	if !ok || !t.scrollable {
+		return false
+	}
+	t.scrollToAnchor = line
+	t.trackEnd = false
+	return true
+}
+
+// NextAnchor scrolls to the first anchor after the top line of the text view.
+// Returns the name of the anchor, or an empty string if there is no such
+// anchor.
+func (t *TextView) NextAnchor() string {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.moveAnchor(true)
+}
+
+// PreviousAnchor scrolls to the last anchor before the top line of the text
+// view. Returns the name of the anchor, or an empty string if there is no such
+// anchor.
+func (t *TextView) PreviousAnchor() string {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.moveAnchor(false)
+}
+
+// moveAnchor scrolls to the anchor following or preceding the top line of the
+// text view.
+func (t *TextView) moveAnchor(next bool) string {
+	if !t.scrollable {
+		return ""
+	}
+
+	// Find the top line.
+	top := t.scrollToAnchor
+	if top < 0 {
+		top = 0
+		if t.lineOffset > 0 && t.lineOffset < len(t.index) {
+			top = t.index[t.lineOffset].Line
+		}
+	}
+
+	var name string
+	target := -1
+	for n, line := range t.anchors {
+		if next && line > top && (target < 0 || line < target || line == target && n < name) {
+			name, target = n, line
+		} else if !next && line < top && (target < 0 || line > target || line == target && n < name) {
+			name, target = n, line
+		}
 	}
-	t.trackEnd = true
-	t.columnOffset = 0
+	if target < 0 {
+		return ""
+	}
+	t.scrollToAnchor = target
+	t.trackEnd = false
+	return name
 }
 
-// GetScrollOffset returns the number of rows and columns that are skipped at
-// the top left corner when the text view has been scrolled.
-func (t *TextView) GetScrollOffset() (row, column int) {
-	t.RLock()
-	defer t.RUnlock()
-
-	return t.lineOffset, t.columnOffset
+// lineStyleRanges returns the (byte) positions in the provided stripped line
+// covered by each style range, along with the styles of the ranges. Ranges
+// which do not cover the line are omitted.
+func (t *TextView) lineStyleRanges(line int, stripped []byte) (positions [][]int, styles []tcell.Style) {
+	for _, r := range t.styleRanges {
+		from, to := lineSpan(line, stripped, r.FromLine, r.FromColumn, r.ToLine, r.ToColumn)
+		if from >= to {
+			continue
+		}
+		positions = append(positions, []int{from, to})
+		styles = append(styles, r.Style)
+	}
+	return positions, styles
 }
 
-// Clear removes all text from the buffer.
-func (t *TextView) Clear() {
-	t.Lock()
-	defer t.Unlock()
+// lineSpan returns the (byte) positions in the provided stripped line covered
+// by a span of text which starts at the character fromColumn of line fromLine
+// and ends before the character toColumn of line toLine. The start is not
+// before the end when the span does not cover the line.
+func lineSpan(line int, stripped []byte, fromLine, fromColumn, toLine, toColumn int) (from, to int) {
+	if line < fromLine || line > toLine {
+		return 0, 0
+	}
 
-	t.clear()
+	// bytePos returns the position of the provided character in the line.
+	bytePos := func(column int) int {
+		pos := 0
+		for ; column > 0 && pos < len(stripped); column-- {
+			_, size := utf8.DecodeRune(stripped[pos:])
+			pos += size
+		}
+		return pos
+	}
+
+	from, to = 0, len(stripped)
+	if line == fromLine {
+		from = bytePos(fromColumn)
+	}
+	if line == toLine {
+		to = bytePos(toColumn)
+	}
+	return from, to
 }
 
-func (t *TextView) clear() {
+// fetchProviderLines replaces the buffer with the lines of the line provider
+// which are visible at the current line offset. It returns the line offset
+// and the number of lines of the provider.
+func (t *TextView) fetchProviderLines(height int) (offset, count int) {
+	count = t.provider.LineCount()
+	if t.lineOffset+height > count {
+		t.trackEnd = true
+	}
+	if t.trackEnd {
+		t.lineOffset = count - height
+	}
+	if t.lineOffset < 0 {
+		t.lineOffset = 0
+	}
+
 	t.buffer = nil
-	t.recentBytes = nil
-	if t.reindex {
-		t.index = nil
+	for n := t.lineOffset; n < count && n < t.lineOffset+height; n++ {
+		t.buffer = append(t.buffer, expandTabs(t.provider.Line(n), t.tabWidth, 0, t.dynamicColors, t.regions))
 	}
+	t.invalidateIndex()
+
+	return t.lineOffset, count
 }
 
 // Highlight specifies which regions should be highlighted. If highlight
@@ -604,7 +1745,7 @@ func (t *TextView) Highlight(regionIDs ...string) {
 		}
 		t.highlights[id] = struct{}{}
 	}
-	t.index = nil
+	t.invalidateIndex()
 
 	// Notify.
 	if t.highlighted != nil && (len(added) > 0 || len(removed) > 0) {
@@ -649,7 +1790,7 @@ func (t *TextView) ScrollToHighlight() {
 	if len(t.highlights) == 0 || !t.scrollable || !t.regions {
 		return
 	}
-	t.index = nil
+	t.invalidateIndex()
 	t.scrollToHighlights = true
 	t.trackEnd = false
 }
@@ -754,8 +1895,8 @@ func (t *TextView) HasFocus() bool {
 }
 
 // Write lets us implement the io.Writer interface. Tab characters will be
-// replaced with TabSize space characters. A "\n" or "\r\n" will be interpreted
-// as a new line.
+// replaced with space characters up to the next tab stop (see SetTabWidth and
+// SetElasticTabs). A "\n" or "\r\n" will be interpreted as a new line.
 func (t *TextView) Write(p []byte) (n int, err error) {
 	t.Lock()
 	changed := t.changed
@@ -769,6 +1910,32 @@ func (t *TextView) Write(p []byte) (n int, err error) {
 }
 
 func (t *TextView) write(p []byte) (n int, err error) {
+	// Translate ANSI escape sequences.
+	if t.ansiWriter != nil {
+		return t.ansiWriter.Write(p)
+	}
+
+	return t.writeText(p)
+}
+
+// textViewANSIWriter writes text translated by an ANSI writer to a locked
+// TextView.
+type textViewANSIWriter struct {
+	t *TextView
+}
+
+// Write writes the provided text to the TextView.
+func (w textViewANSIWriter) Write(p []byte) (n int, err error) {
+	return w.t.writeText(p)
+}
+
+// writeText writes the provided text to the buffer.
+func (t *TextView) writeText(p []byte) (n int, err error) {
+	// Discard text while a line provider is set.
+	if t.provider != nil {
+		return len(p), nil
+	}
+
 	// Copy data over.
 	newBytes := append(t.recentBytes, p...)
 	t.recentBytes = nil
@@ -797,9 +1964,44 @@ func (t *TextView) write(p []byte) (n int, err error) {
 		}
 	}
 
-	// Transform the new bytes into strings.
-	newBytes = bytes.Replace(newBytes, []byte{'\t'}, bytes.Repeat([]byte{' '}, TabSize), -1)
-	for index, line := range bytes.Split(newBytes, []byte("\n")) {
+	// If we have a trailing incomplete widget placeholder, exclude it.
+	if len(t.widgets) > 0 {
+		location := openWidgetPattern.FindIndex(newBytes)
+		if location != nil {
+			t.recentBytes = append(newBytes[location[0]:len(newBytes):len(newBytes)], t.recentBytes...)
+			newBytes = newBytes[:location[0]]
+		}
+	}
+
+	// Wait for the end of the last line to align its tab stops.
+	if t.elasticTabs {
+		if i := bytes.LastIndexByte(newBytes, '\n') + 1; i < len(newBytes) {
+			t.recentBytes = append(newBytes[i:len(newBytes):len(newBytes)], t.recentBytes...)
+			newBytes = newBytes[:i]
+		}
+	}
+
+	// Transform the new bytes into strings, replacing tabs.
+	lines := bytes.Split(newBytes, []byte("\n"))
+	if t.elasticTabs {
+		alignTabs(lines, t.tabWidth, t.dynamicColors, t.regions)
+	} else {
+		for index := range lines {
+			var column int
+			if index == 0 && len(t.buffer) > 0 && bytes.IndexByte(lines[0], '\t') >= 0 {
+				_, _, _, _, _, _, column = decomposeText(t.buffer[len(t.buffer)-1], t.dynamicColors, t.regions)
+			}
+			lines[index] = expandTabs(lines[index], t.tabWidth, column, t.dynamicColors, t.regions)
+		}
+	}
+	for index, line := range lines {
+		if len(t.widgets) > 0 {
+			bufferLine, offset := len(t.buffer), 0
+			if index == 0 && len(t.buffer) > 0 {
+				bufferLine, offset = len(t.buffer)-1, len(t.buffer[len(t.buffer)-1])
+			}
+			line = t.replaceWidgetPlaceholders(line, bufferLine, offset)
+		}
 		if index == 0 {
 			if len(t.buffer) == 0 {
 				t.buffer = [][]byte{line}
@@ -811,11 +2013,21 @@ func (t *TextView) write(p []byte) (n int, err error) {
 		}
 	}
 
-	t.clipBuffer()
+	if t.follow && !t.trackEnd {
+		t.unseenLines += bytes.Count(newBytes, []byte("\n"))
+	}
 
-	// Reset the index.
+	clipped := t.clipBuffer()
+	t.matchesValid = false
+
+	// Reset the index. When text was only appended, just the new text is
+	// indexed the next time the buffer is drawn.
 	if t.reindex {
-		t.index = nil
+		if clipped || t.index == nil || t.indexed == nil || t.backgroundIndexing {
+			t.invalidateIndex()
+		} else {
+			t.indexAppended = true
+		}
 	}
 
 	return len(p), nil
@@ -841,8 +2053,212 @@ func (t *TextView) SetReindexBuffer(reindex bool) {
 	t.reindex = reindex
 
 	if reindex {
-		t.index = nil
+		t.invalidateIndex()
+	}
+}
+
+// textViewIndexer holds the buffer and the settings required to index it, so
+// that a buffer may be indexed without holding the lock of its text view.
+type textViewIndexer struct {
+	buffer                                 [][]byte
+	wrapWidth                              int
+	wrap, wordWrap, dynamicColors, regions bool
+	wrapIndent                             bool
+	wrapIndicator                          rune
+	highlights                             map[string]struct{}
+}
+
+// textViewIndexState is the state of the indexer at the start of a buffer
+// line, consisting of the style and the region carried over from the lines
+// before it.
+type textViewIndexState struct {
+	foregroundColor, backgroundColor, attributes string
+	regionID                                     []byte
+}
+
+// textViewPreparedIndex is the result of indexing a buffer.
+type textViewPreparedIndex struct {
+	// The generation of the buffer which was indexed.
+	generation int
+
+	// The indexed buffer and the width it was indexed for.
+	buffer [][]byte
+	width  int
+
+	// The index and the information gathered while indexing.
+	index                                    []*textViewIndex
+	fromHighlight, toHighlight, posHighlight int
+	longestLine                              int
+
+	// The last buffer line which was indexed, the position of its first line
+	// in the index and the state of the indexer at its start. Text appended
+	// to the buffer is indexed starting with this line.
+	tailLine, tailPos int
+	tailState         textViewIndexState
+}
+
+// newIndexer returns an indexer for the provided buffer. When snapshot is true,
+// the buffer and highlights are copied so they may be indexed by another
+// goroutine. Lines are only ever appended to, so they are not copied.
+func (t *TextView) newIndexer(buffer [][]byte, snapshot bool) *textViewIndexer {
+	x := &textViewIndexer{
+		buffer:        buffer,
+		wrapWidth:     t.wrapWidth,
+		wrap:          t.wrap,
+		wordWrap:      t.wordWrap,
+		wrapIndent:    t.wrapIndent,
+		wrapIndicator: t.wrapIndicator,
+		dynamicColors: t.dynamicColors,
+		regions:       t.regions,
+		highlights:    t.highlights,
+	}
+	if snapshot {
+		x.buffer = append([][]byte(nil), buffer...)
+		x.highlights = make(map[string]struct{}, len(t.highlights))
+		for id := range t.highlights {
+			x.highlights[id] = struct{}{}
+		}
+	}
+	return x
+}
+
+// SetBackgroundIndexing sets a flag controlling whether the buffer is indexed
+// by a background goroutine instead of while drawing. Indexing a large buffer
+// (which happens each time it is modified) may take a long time, during which
+// the application would otherwise not respond. When enabled, the most recently
+// indexed copy of the buffer is drawn while the next one is being prepared.
+// The changed handler (see SetChangedFunc) is called each time indexing
+// completes, so the text view is typically redrawn via Application.Draw.
+//
+// While background indexing is enabled, text which scrolled out of view is not
+// purged from the buffer of a text view which is not scrollable. Use
+// SetMaxLines to limit the size of the buffer instead.
+func (t *TextView) SetBackgroundIndexing(background bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.backgroundIndexing = background
+	t.preparedIndex = nil
+	t.invalidateIndex()
+}
+
+// SetWrapCacheSize sets the maximum estimated size in bytes of the wrapped
+// line layouts which are cached for widths other than the current width. When
+// the text view is resized to a width it was previously drawn at, the cached
+// layout is reused instead of wrapping the entire buffer again. Cached layouts
+// are discarded when the text changes, except when text is appended. A size
+// of 0 disables the cache. The default size is DefaultWrapCacheSize.
+//
+// Layouts are not cached while background indexing is enabled.
+func (t *TextView) SetWrapCacheSize(size int) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.wrapCacheSize = size
+	t.trimWrapCache()
+}
+
+// cacheIndex adds the provided prepared index to the wrap cache.
+func (t *TextView) cacheIndex(p *textViewPreparedIndex) {
+	if p == nil || !t.wrap || t.wrapCacheSize <= 0 {
+		return
+	}
+	for i, cached := range t.wrapCache {
+		if cached.width == p.width {
+			t.wrapCache = append(t.wrapCache[:i], t.wrapCache[i+1:]...)
+			break
+		}
+	}
+	t.wrapCache = append(t.wrapCache, p)
+	t.trimWrapCache()
+}
+
+// cachedIndex removes the prepared index for the provided width from the wrap
+// cache and returns it, or nil if there is none.
+func (t *TextView) cachedIndex(width int) *textViewPreparedIndex {
+	for i, p := range t.wrapCache {
+		if p.width == width {
+			t.wrapCache = append(t.wrapCache[:i], t.wrapCache[i+1:]...)
+			return p
+		}
+	}
+	return nil
+}
+
+// trimWrapCache discards the least recently used indices from the wrap cache
+// until it no longer exceeds its maximum size.
+func (t *TextView) trimWrapCache() {
+	var size int
+	for i := len(t.wrapCache) - 1; i >= 0; i-- {
+		size += len(t.wrapCache[i].index) * textViewIndexSize
+		if size > t.wrapCacheSize {
+			for j := 0; j <= i; j++ {
+				t.wrapCache[j] = nil
+			}
+			t.wrapCache = t.wrapCache[i+1:]
+			return
+		}
+	}
+}
+
+// requestIndex starts indexing the provided buffer in the background for the
+// provided width. If a buffer is already being indexed, the buffer is indexed
+// again once indexing completes.
+func (t *TextView) requestIndex(buffer [][]byte, width int) {
+	t.indexRequestWidth = width
+	if t.indexing {
+		return
+	}
+	t.indexing = true
+
+	generation, x := t.indexGeneration, t.newIndexer(buffer, true)
+	go func() {
+		p := x.index(width)
+		p.generation = generation
+
+		t.Lock()
+		if t.preparedIndex == nil || t.preparedIndex.generation <= generation {
+			t.preparedIndex = p
+		}
+		t.indexing = false
+		if t.backgroundIndexing && (generation != t.indexGeneration || t.wrap && width != t.indexRequestWidth) {
+			t.requestIndex(t.buffer, t.indexRequestWidth)
+		}
+		changed := t.changed
+		t.Unlock()
+
+		if changed != nil {
+			changed()
+		}
+	}()
+}
+
+// usePreparedIndexAndBuffer replaces the index and the buffer with the index
+// most recently prepared in the background and the buffer it refers to. When
+// request is true and the prepared index is outdated, the provided buffer is
+// indexed again.
+func (t *TextView) usePreparedIndexAndBuffer(buffer [][]byte, width int, request bool) {
+	p := t.preparedIndex
+	if request && (p == nil || p.generation != t.indexGeneration || t.wrap && p.width != width) {
+		t.requestIndex(buffer, width)
+	}
+	if p == nil {
+		t.buffer, t.index = nil, nil
+		return
 	}
+	t.buffer = p.buffer
+	t.usePreparedIndex(p)
+}
+
+// invalidateIndex discards the index. The buffer is re-indexed the next time
+// it is drawn.
+func (t *TextView) invalidateIndex() {
+	t.index = nil
+	t.indexed = nil
+	t.indexAppended = false
+	t.wrapCache = nil
+	t.indexGeneration++
+	t.matchesValid = false
 }
 
 // reindexBuffer re-indexes the buffer such that we can use it to easily draw
@@ -851,38 +2267,111 @@ func (t *TextView) SetReindexBuffer(reindex bool) {
 // color with which the line starts.
 func (t *TextView) reindexBuffer(width int) {
 	if t.index != nil && (!t.wrap || width == t.indexWidth) {
-		return // Nothing has changed. We can still use the current index.
+		if t.indexAppended && t.indexed != nil {
+			// Index the appended text.
+			t.indexed.truncate()
+			t.newIndexer(t.buffer, false).extend(t.indexed, t.indexed.tailLine, t.indexed.tailState)
+			t.usePreparedIndex(t.indexed)
+			t.indexAppended = false
+		}
+		return // Nothing else has changed. We can still use the current index.
 	}
-	t.index = nil
-	t.indexWidth = width
-	t.fromHighlight, t.toHighlight, t.posHighlight = -1, -1, -1
+
+	// Reuse the index prepared when the text view last had this width.
+	if t.index != nil {
+		t.cacheIndex(t.indexed)
+	}
+	if p := t.cachedIndex(width); p != nil {
+		// Index any text which was appended since.
+		p.truncate()
+		t.newIndexer(t.buffer, false).extend(p, p.tailLine, p.tailState)
+		t.indexed = p
+	} else {
+		t.indexed = t.newIndexer(t.buffer, false).index(width)
+	}
+	t.indexAppended = false
+	t.usePreparedIndex(t.indexed)
+}
+
+// usePreparedIndex replaces the index with the provided prepared index.
+func (t *TextView) usePreparedIndex(p *textViewPreparedIndex) {
+	t.index, t.indexWidth = p.index, p.width
+	t.fromHighlight, t.toHighlight, t.posHighlight = p.fromHighlight, p.toHighlight, p.posHighlight
+	t.longestLine = p.longestLine
+}
+
+// index indexes the buffer for the provided width.
+func (x *textViewIndexer) index(width int) *textViewPreparedIndex {
+	p := &textViewPreparedIndex{
+		buffer:        x.buffer,
+		width:         width,
+		fromHighlight: -1,
+		toHighlight:   -1,
+		posHighlight:  -1,
+	}
+	x.extend(p, 0, textViewIndexState{})
+	return p
+}
+
+// truncate removes the last indexed buffer line from the index, so that it
+// may be indexed again after text was appended to it.
+func (p *textViewPreparedIndex) truncate() {
+	for i := p.tailPos; i < len(p.index); i++ {
+		p.index[i] = nil
+	}
+	p.index = p.index[:p.tailPos]
+
+	if p.fromHighlight >= p.tailPos {
+		p.fromHighlight, p.toHighlight, p.posHighlight = -1, -1, -1
+	} else if p.toHighlight >= p.tailPos {
+		p.toHighlight = p.tailPos - 1
+	}
+}
+
+// extend indexes the buffer lines starting with the provided line, at which
+// the indexer is in the provided state, and appends them to the index.
+func (x *textViewIndexer) extend(p *textViewPreparedIndex, from int, state textViewIndexState) {
+	p.buffer = x.buffer
 
 	// If there's no space, there's no index.
+	width := p.width
 	if width < 1 {
 		return
 	}
 
-	if t.wrapWidth > 0 && t.wrapWidth < width {
-		width = t.wrapWidth
+	if x.wrapWidth > 0 && x.wrapWidth < width {
+		width = x.wrapWidth
 	}
 
 	// Initial states.
-	var regionID []byte
-	var (
-		highlighted                                  bool
-		foregroundColor, backgroundColor, attributes string
-	)
+	regionID := state.regionID
+	var highlighted bool
+	foregroundColor, backgroundColor, attributes := state.foregroundColor, state.backgroundColor, state.attributes
+	start := len(p.index)
 
 	// Go through each line in the buffer.
-	for bufferIndex, buf := range t.buffer {
-		colorTagIndices, colorTags, regionIndices, regions, escapeIndices, strippedStr, _ := decomposeText(buf, t.dynamicColors, t.regions)
+	for bufferIndex := from; bufferIndex < len(x.buffer); bufferIndex++ {
+		buf := x.buffer[bufferIndex]
+		p.tailLine, p.tailPos = bufferIndex, len(p.index)
+		p.tailState = textViewIndexState{
+			foregroundColor: foregroundColor,
+			backgroundColor: backgroundColor,
+			attributes:      attributes,
+			regionID:        regionID,
+		}
+
+		colorTagIndices, colorTags, regionIndices, regions, escapeIndices, strippedStr, _ := decomposeText(buf, x.dynamicColors, x.regions)
 
 		// Split the line if required.
 		var splitLines []string
+		var indent int
 		str := string(strippedStr)
-		if t.wrap && len(str) > 0 {
+		if x.wrap && len(str) > 0 {
 			for len(str) > 0 {
-				extract := runewidth.Truncate(str, width, "")
+				if len(splitLines) == 1 {
+					indent = x.continuationIndent(string(strippedStr), width)
+				}
+				extract := truncateString(str, width-indent)
 				if len(extract) == 0 {
 					// We'll extract at least one grapheme cluster.
 					gr := uniseg.NewGraphemes(str)
@@ -890,7 +2379,7 @@ func (t *TextView) reindexBuffer(width int) {
 					_, to := gr.Positions()
 					extract = str[:to]
 				}
-				if t.wordWrap && len(extract) < len(str) {
+				if x.wordWrap && len(extract) < len(str) {
 					// Add any spaces from the next line.
 					if spaces := spacePattern.FindStringIndex(str[len(extract):]); spaces != nil && spaces[0] == 0 {
 						extract = str[:len(extract)+spaces[1]]
@@ -913,7 +2402,7 @@ func (t *TextView) reindexBuffer(width int) {
 
 		// Create index from split lines.
 		var originalPos, colorPos, regionPos, escapePos int
-		for _, splitLine := range splitLines {
+		for i, splitLine := range splitLines {
 			line := &textViewIndex{
 				Line:            bufferIndex,
 				Pos:             originalPos,
@@ -922,6 +2411,9 @@ func (t *TextView) reindexBuffer(width int) {
 				Attributes:      attributes,
 				Region:          regionID,
 			}
+			if i > 0 {
+				line.Indent = indent
+			}
 
 			// Shift original position with tags.
 			lineLength := len(splitLine)
@@ -973,16 +2465,19 @@ func (t *TextView) reindexBuffer(width int) {
 				case 1:
 					// Process region tags.
 					regionID = regions[regionPos][1]
-					_, highlighted = t.highlights[string(regionID)]
+					_, highlighted = x.highlights[string(regionID)]
 
 					// Update highlight range.
 					if highlighted {
-						line := len(t.index)
-						if t.fromHighlight < 0 {
-							t.fromHighlight, t.toHighlight = line, line
-							t.posHighlight = runewidth.StringWidth(splitLine[:strippedTagStart])
-						} else if line > t.toHighlight {
-							t.toHighlight = line
+						line := len(p.index)
+						if p.fromHighlight < 0 {
+							p.fromHighlight, p.toHighlight = line, line
+							p.posHighlight = stringWidth(splitLine[:strippedTagStart])
+							if i > 0 {
+								p.posHighlight += indent
+							}
+						} else if line > p.toHighlight {
+							p.toHighlight = line
 						}
 					}
 
@@ -998,33 +2493,74 @@ func (t *TextView) reindexBuffer(width int) {
 
 			// Append this line.
 			line.NextPos = originalPos
-			line.Width = runewidth.StringWidth(splitLine)
-			t.index = append(t.index, line)
+			line.Width = line.Indent + stringWidth(splitLine)
+			p.index = append(p.index, line)
 		}
 
 		// Word-wrapped lines may have trailing whitespace. Remove it.
-		if t.wrap && t.wordWrap {
-			for _, line := range t.index {
-				str := t.buffer[line.Line][line.Pos:line.NextPos]
+		if x.wrap && x.wordWrap {
+			for _, line := range p.index[p.tailPos:] {
+				str := x.buffer[line.Line][line.Pos:line.NextPos]
 				trimmed := bytes.TrimRightFunc(str, unicode.IsSpace)
 				if len(trimmed) != len(str) {
 					oldNextPos := line.NextPos
 					line.NextPos -= len(str) - len(trimmed)
-					line.Width -= runewidth.StringWidth(string(t.buffer[line.Line][line.NextPos:oldNextPos]))
+					line.Width -= stringWidth(string(x.buffer[line.Line][line.NextPos:oldNextPos]))
 				}
 			}
 		}
 	}
 
 	// Calculate longest line.
-	t.longestLine = 0
-	for _, line := range t.index {
-		if line.Width > t.longestLine {
-			t.longestLine = line.Width
+	for _, line := range p.index[start:] {
+		if line.Width > p.longestLine {
+			p.longestLine = line.Width
 		}
 	}
 }
 
+// continuationIndent returns the screen width of the indentation of lines
+// continuing the provided line, which consists of the wrap indicator and, when
+// preserving indentation, the leading spaces of the line. The indentation is
+// limited to half of the available width.
+func (x *textViewIndexer) continuationIndent(line string, width int) int {
+	var indent int
+	if x.wrapIndicator != 0 {
+		indent = graphemeWidth(string(x.wrapIndicator), 0)
+	}
+	if x.wordWrap && x.wrapIndent {
+		indent += len(line) - len(strings.TrimLeft(line, " "))
+	}
+	if indent > width/2 {
+		indent = width / 2
+	}
+	return indent
+}
+
+// highlightStyle returns the provided style with the colors of highlighted
+// regions.
+func (t *TextView) highlightStyle(style tcell.Style) tcell.Style {
+	fg := t.highlightForeground
+	bg := t.highlightBackground
+	if fg == tcell.ColorDefault {
+		fg = Styles.PrimaryTextColor
+		if fg == tcell.ColorDefault {
+			fg = tcell.ColorWhite.TrueColor()
+		}
+	}
+	if bg == tcell.ColorDefault {
+		r, g, b := fg.RGB()
+		c := colorful.Color{R: float64(r) / 255, G: float64(g) / 255, B: float64(b) / 255}
+		_, _, li := c.Hcl()
+		if li < .5 {
+			bg = tcell.ColorWhite.TrueColor()
+		} else {
+			bg = tcell.ColorBlack.TrueColor()
+		}
+	}
+	return style.Foreground(fg).Background(bg)
+}
+
 // Draw draws this primitive onto the screen.
 func (t *TextView) Draw(screen tcell.Screen) {
 	if !t.GetVisible() {
@@ -1043,17 +2579,47 @@ func (t *TextView) Draw(screen tcell.Screen) {
 	}
 	t.pageSize = height
 
-	if t.index == nil || width != t.lastWidth || height != t.lastHeight {
+	// Fetch the visible lines from the line provider. The buffer then starts
+	// at the line offset, so it is drawn from its first line.
+	var providerOffset, providerLines int
+	if t.provider != nil {
+		providerOffset, providerLines = t.fetchProviderLines(height)
+		trackEnd := t.trackEnd
+		t.lineOffset, t.trackEnd = 0, false
+		defer func() {
+			t.lineOffset, t.trackEnd = providerOffset, trackEnd
+		}()
+	}
+
+	t.updateMatches()
+
+	// Draw the index prepared in the background and the buffer it refers to.
+	background := t.backgroundIndexing && t.provider == nil
+	buffer := t.buffer
+	if background {
+		defer func() {
+			t.buffer, t.index = buffer, nil
+		}()
+		t.usePreparedIndexAndBuffer(buffer, width, false)
+	} else if t.index == nil || t.indexAppended || width != t.lastWidth || height != t.lastHeight {
 		t.reindexBuffer(width)
 	}
 	t.lastWidth, t.lastHeight = width, height
 
-	showVerticalScrollBar := t.scrollBarVisibility == ScrollBarAlways || (t.scrollBarVisibility == ScrollBarAuto && len(t.index) > height)
+	lines := len(t.index)
+	if t.provider != nil {
+		lines = providerLines
+	}
+	showVerticalScrollBar := t.scrollBarVisibility == ScrollBarAlways || (t.scrollBarVisibility == ScrollBarAuto && lines > height)
 	if showVerticalScrollBar {
 		width-- // Subtract space for scroll bar.
 	}
 
-	t.reindexBuffer(width)
+	if background {
+		t.usePreparedIndexAndBuffer(buffer, width, true)
+	} else {
+		t.reindexBuffer(width)
+	}
 	if t.regions {
 		t.regionInfos = nil
 	}
@@ -1064,8 +2630,11 @@ func (t *TextView) Draw(screen tcell.Screen) {
 			return
 		}
 
-		items := len(t.index)
-		cursor := int(float64(len(t.index)) * (float64(t.lineOffset) / float64(len(t.index)-height)))
+		items, offset := len(t.index), t.lineOffset
+		if t.provider != nil {
+			items, offset = providerLines, providerOffset
+		}
+		cursor := int(float64(items) * (float64(offset) / float64(items-height)))
 
 		// Render cursor at the bottom when tracking end
 		if t.trackEnd && items <= height {
@@ -1106,17 +2675,48 @@ func (t *TextView) Draw(screen tcell.Screen) {
 	}
 	t.scrollToHighlights = false
 
+	// Move to the current search match.
+	if t.scrollToMatch {
+		t.scrollToCurrentMatch(width, height)
+	}
+	t.scrollToMatch = false
+
+	// Move to the anchor.
+	if t.scrollToAnchor >= 0 {
+		t.lineOffset = len(t.index)
+		for i, index := range t.index {
+			if index.Line >= t.scrollToAnchor {
+				t.lineOffset = i
+				break
+			}
+		}
+		t.trackEnd = false
+		t.scrollToAnchor = -1
+	}
+
+	// Move to the selection cursor.
+	if t.scrollToSelection {
+		t.scrollToSelectionCursor(width, height)
+	}
+	t.scrollToSelection = false
+
 	// Adjust line offset.
 	if t.lineOffset+height > len(t.index) {
 		t.trackEnd = true
 	}
 	if t.trackEnd {
 		t.lineOffset = len(t.index) - height
+		t.unseenLines = 0
 	}
 	if t.lineOffset < 0 {
 		t.lineOffset = 0
 	}
 
+	// Indicate new lines while scrolled up.
+	if t.follow && t.unseenLines > 0 && t.provider == nil {
+		defer t.drawUnseenLines(screen, x, y, width, height)
+	}
+
 	// Adjust column offset.
 	if t.align == AlignLeft {
 		if t.columnOffset+width > t.longestLine {
@@ -1158,6 +2758,9 @@ func (t *TextView) Draw(screen tcell.Screen) {
 
 	// Draw the buffer.
 	defaultStyle := tcell.StyleDefault.Foreground(t.textColor).Background(t.backgroundColor)
+	selectionStart, selectionEnd, selected := t.selectionRange()
+	t.lineRows, t.lineRowsY = t.lineRows[:0], y+verticalOffset
+	t.annotationCells = nil
 	for line := t.lineOffset; line < len(t.index); line++ {
 		// Are we done?
 		if line-t.lineOffset >= height {
@@ -1166,6 +2769,7 @@ func (t *TextView) Draw(screen tcell.Screen) {
 
 		// Get the text for this line.
 		index := t.index[line]
+		t.lineRows = append(t.lineRows, providerOffset+index.Line)
 		text := t.buffer[index.Line][index.Pos:index.NextPos]
 		foregroundColor := index.ForegroundColor
 		backgroundColor := index.BackgroundColor
@@ -1192,6 +2796,49 @@ func (t *TextView) Draw(screen tcell.Screen) {
 		// Process tags.
 		colorTagIndices, colorTags, regionIndices, regions, escapeIndices, strippedText, _ := decomposeText(text, t.dynamicColors, t.regions)
 
+		// Find search matches.
+		var lineMatches [][]int
+		var matchOffset int
+		if t.searchPattern != nil && t.provider == nil {
+			lineMatches = t.findMatches(StripTags(t.buffer[index.Line], t.dynamicColors, t.regions))
+			matchOffset = t.strippedPos(index)
+		}
+		var current *textViewMatch
+		if t.currentMatch >= 0 && t.currentMatch < len(t.matches) {
+			current = t.matches[t.currentMatch]
+		}
+
+		// Find style ranges.
+		var rangePositions [][]int
+		var rangeStyles []tcell.Style
+		var rangeOffset int
+		if len(t.styleRanges) > 0 {
+			rangePositions, rangeStyles = t.lineStyleRanges(providerOffset+index.Line, StripTags(t.buffer[index.Line], t.dynamicColors, t.regions))
+			rangeOffset = t.strippedPos(index)
+		}
+
+		// Find annotations.
+		var annotationPositions [][]int
+		var annotations []*TextViewAnnotation
+		var annotationOffset int
+		if len(t.annotations) > 0 {
+			annotationPositions, annotations = t.lineAnnotations(providerOffset+index.Line, StripTags(t.buffer[index.Line], t.dynamicColors, t.regions))
+			annotationOffset = t.strippedPos(index)
+		}
+
+		// Find selected text.
+		selectionFrom, selectionTo := -1, -1
+		if selected && index.Line >= selectionStart.Line && index.Line <= selectionEnd.Line {
+			offset := t.strippedPos(index)
+			selectionFrom, selectionTo = 0, len(strippedText)
+			if index.Line == selectionStart.Line {
+				selectionFrom = selectionStart.Pos - offset
+			}
+			if index.Line == selectionEnd.Line {
+				selectionTo = selectionEnd.Pos - offset
+			}
+		}
+
 		// Calculate the position of the line.
 		var skip, posX int
 		if t.align == AlignLeft {
@@ -1208,6 +2855,19 @@ func (t *TextView) Draw(screen tcell.Screen) {
 
 		drawAtY := y + line - t.lineOffset + verticalOffset
 
+		// Indent continuation lines.
+		if index.Indent > 0 {
+			if t.wrapIndicator != 0 && skip == 0 && drawAtY >= 0 && posX < width {
+				screen.SetContent(x+posX, drawAtY, t.wrapIndicator, nil, defaultStyle)
+			}
+			if skip >= index.Indent {
+				skip -= index.Indent
+			} else {
+				posX += index.Indent - skip
+				skip = 0
+			}
+		}
+
 		// Print the line.
 		if drawAtY >= 0 {
 			var colorPos, regionPos, escapePos, tagOffset, skipped int
@@ -1263,25 +2923,58 @@ func (t *TextView) Draw(screen tcell.Screen) {
 					}
 				}
 				if highlighted {
-					fg := t.highlightForeground
-					bg := t.highlightBackground
-					if fg == tcell.ColorDefault {
-						fg = Styles.PrimaryTextColor
-						if fg == tcell.ColorDefault {
-							fg = tcell.ColorWhite.TrueColor()
-						}
+					style = t.highlightStyle(style)
+				}
+
+				// Underline hyperlinks and pass them on to the terminal.
+				if isLink(regionID) {
+					style = style.Underline(true).Url(string(regionID))
+				}
+
+				// Do we draw a style range?
+				for i := len(rangePositions) - 1; i >= 0; i-- {
+					if pos := rangeOffset + textPos; pos < rangePositions[i][0] || pos >= rangePositions[i][1] {
+						continue
 					}
-					if bg == tcell.ColorDefault {
-						r, g, b := fg.RGB()
-						c := colorful.Color{R: float64(r) / 255, G: float64(g) / 255, B: float64(b) / 255}
-						_, _, li := c.Hcl()
-						if li < .5 {
-							bg = tcell.ColorWhite.TrueColor()
-						} else {
-							bg = tcell.ColorBlack.TrueColor()
-						}
+					fg, bg, attr := rangeStyles[i].Decompose()
+					if fg != tcell.ColorDefault {
+						style = style.Foreground(fg)
+					}
+					if bg != tcell.ColorDefault {
+						style = style.Background(bg)
+					}
+					_, _, existing := style.Decompose()
+					style = style.Attributes(existing | attr)
+					break
+				}
+
+				// Do we draw an annotation?
+				var annotation *TextViewAnnotation
+				for i := len(annotationPositions) - 1; i >= 0; i-- {
+					if pos := annotationOffset + textPos; pos >= annotationPositions[i][0] && pos < annotationPositions[i][1] {
+						annotation = annotations[i]
+						style = annotation.style(style)
+						break
+					}
+				}
+
+				// Do we highlight a search match?
+				pos := matchOffset + textPos
+				for _, m := range lineMatches {
+					if pos < m[0] || pos >= m[1] {
+						continue
+					}
+					if current != nil && current.Line == index.Line && current.From == m[0] {
+						style = style.Foreground(t.highlightForeground).Background(t.highlightBackground)
+					} else {
+						style = style.Foreground(t.matchForeground).Background(t.matchBackground)
 					}
-					style = style.Foreground(fg).Background(bg)
+					break
+				}
+
+				// Do we highlight selected text?
+				if textPos >= selectionFrom && textPos < selectionTo {
+					style = t.highlightStyle(style)
 				}
 
 				// Skip to the right.
@@ -1302,6 +2995,12 @@ func (t *TextView) Draw(screen tcell.Screen) {
 					} else {
 						screen.SetContent(x+posX+offset, drawAtY, ' ', nil, style)
 					}
+					if annotation != nil {
+						if t.annotationCells == nil {
+							t.annotationCells = make(map[[2]int]*TextViewAnnotation)
+						}
+						t.annotationCells[[2]int{x + posX + offset, drawAtY}] = annotation
+					}
 				}
 
 				// Advance.
@@ -1313,22 +3012,54 @@ func (t *TextView) Draw(screen tcell.Screen) {
 
 	// If this view is not scrollable, we'll purge the buffer of lines that have
 	// scrolled out of view.
-	if !t.scrollable && t.lineOffset > 0 {
+	if !t.scrollable && t.lineOffset > 0 && t.provider == nil && !background {
 		if t.lineOffset >= len(t.index) {
 			t.buffer = nil
+			t.lineReferences = nil
 		} else {
+			t.removeLineReferences(t.index[t.lineOffset].Line)
+			t.removeWidgetLines(t.index[t.lineOffset].Line)
 			t.buffer = t.buffer[t.index[t.lineOffset].Line:]
 		}
-		t.index = nil
+		t.invalidateIndex()
 		t.lineOffset = 0
 	}
 }
 
+// drawUnseenLines draws the number of lines written while scrolled up in the
+// bottom-right corner of the provided rect.
+func (t *TextView) drawUnseenLines(screen tcell.Screen, x, y, width, height int) {
+	text := fmt.Sprintf(" %d new lines ", t.unseenLines)
+	if t.unseenLines == 1 {
+		text = " 1 new line "
+	}
+	textWidth := len(text)
+	if textWidth > width {
+		return
+	}
+	style := tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.ContrastPrimaryTextColor)
+	for i := 0; i < textWidth; i++ {
+		screen.SetContent(x+width-textWidth+i, y+height-1, ' ', nil, style)
+	}
+	Print(screen, []byte(text), x+width-textWidth, y+height-1, textWidth, AlignLeft, Styles.ContrastPrimaryTextColor)
+}
+
 // InputHandler returns the handler for this primitive.
 func (t *TextView) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
 	return t.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
 		key := event.Key()
 
+		// Clear the selection.
+		if HitShortcut(event, Keys.Cancel) {
+			t.Lock()
+			selecting := t.selecting
+			t.selecting = false
+			t.Unlock()
+			if selecting {
+				return
+			}
+		}
+
 		if HitShortcut(event, Keys.Cancel, Keys.Select, Keys.Select2, Keys.MovePreviousField, Keys.MoveNextField) {
 			if t.done != nil {
 				t.done(key)
@@ -1343,6 +3074,10 @@ func (t *TextView) InputHandler() func(event *tcell.EventKey, setFocus func(p Pr
 			return
 		}
 
+		if t.handleSelectionKey(event) {
+			return
+		}
+
 		if HitShortcut(event, Keys.MoveFirst, Keys.MoveFirst2) {
 			t.trackEnd = false
 			t.lineOffset = 0
@@ -1364,6 +3099,10 @@ func (t *TextView) InputHandler() func(event *tcell.EventKey, setFocus func(p Pr
 			t.lineOffset -= t.pageSize
 		} else if HitShortcut(event, Keys.MoveNextPage) {
 			t.lineOffset += t.pageSize
+		} else if HitShortcut(event, Keys.PreviousAnchor) {
+			t.moveAnchor(false)
+		} else if HitShortcut(event, Keys.NextAnchor) {
+			t.moveAnchor(true)
 		}
 	})
 }
@@ -1372,12 +3111,41 @@ func (t *TextView) InputHandler() func(event *tcell.EventKey, setFocus func(p Pr
 func (t *TextView) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return t.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 		x, y := event.Position()
-		if !t.InRect(x, y) {
-			return false, nil
+		inRect := t.InRect(x, y)
+
+		// Track the annotation under the mouse.
+		t.Lock()
+		var annotation *TextViewAnnotation
+		if inRect {
+			annotation = t.annotationAt(x, y)
+		}
+		hover := t.annotationHover
+		hoverChanged := annotation != t.hoverAnnotation
+		t.hoverAnnotation = annotation
+		selected := t.annotationSelected
+		t.Unlock()
+		if hoverChanged && hover != nil {
+			hover(annotation)
+		}
+
+		if !inRect {
+			return hoverChanged, nil
 		}
 
 		switch action {
+		case MouseMove:
+			if annotation != nil {
+				// Capture the mouse to be notified when it leaves the text view.
+				capture = t
+			}
 		case MouseLeftClick:
+			if annotation != nil && selected != nil {
+				selected(annotation)
+				setFocus(t)
+				return true, nil
+			}
+
+			var linkClicked bool
 			if t.regions {
 				// Find a region to highlight.
 				for _, region := range t.regionInfos {
@@ -1387,10 +3155,34 @@ func (t *TextView) MouseHandler() func(action MouseAction, event *tcell.EventMou
 						region.ToY >= 0 && y > region.ToY {
 						continue
 					}
-					t.Highlight(string(region.ID))
+					t.RLock()
+					clicked := t.linkClicked
+					t.RUnlock()
+					if isLink(region.ID) && clicked != nil {
+						clicked(string(region.ID))
+						linkClicked = true
+					} else {
+						t.Highlight(string(region.ID))
+					}
 					break
 				}
 			}
+
+			t.RLock()
+			lineClicked := t.lineClicked
+			line := -1
+			if row := y - t.lineRowsY; row >= 0 && row < len(t.lineRows) {
+				line = t.lineRows[row]
+			}
+			var reference interface{}
+			if t.provider == nil && line >= 0 && line < len(t.lineReferences) {
+				reference = t.lineReferences[line]
+			}
+			t.RUnlock()
+			if lineClicked != nil && line >= 0 && !linkClicked {
+				lineClicked(line, reference)
+			}
+
 			consumed = true
 			setFocus(t)
 		case MouseScrollUp:
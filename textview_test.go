@@ -2,8 +2,15 @@ package cview
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
 )
 
 const (
@@ -353,6 +360,151 @@ func TestTextViewMaxLines(t *testing.T) {
 	}
 }
 
+type testLineProvider struct {
+	lines int
+}
+
+func (p *testLineProvider) LineCount() int {
+	return p.lines
+}
+
+func (p *testLineProvider) Line(n int) []byte {
+	return []byte(fmt.Sprintf("L%d", n))
+}
+
+func TestTextViewLineProvider(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tv := NewTextView()
+	tv.SetRect(0, 0, 10, 5)
+	tv.SetLineProvider(&testLineProvider{lines: 100000})
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	topLine := func() string {
+		var b []rune
+		for x := 0; x < 6; x++ {
+			r, _, _, _ := app.screen.GetContent(x, 0)
+			if r != ' ' {
+				b = append(b, r)
+			}
+		}
+		return string(b)
+	}
+
+	// Draw
+
+	tv.Draw(app.screen)
+	if line := topLine(); line != "L0" {
+		t.Errorf("failed to draw provided lines: expected L0, got %s", line)
+	}
+
+	tv.ScrollTo(5000, 0)
+	tv.Draw(app.screen)
+	if line := topLine(); line != "L5000" {
+		t.Errorf("failed to draw provided lines: expected L5000, got %s", line)
+	}
+
+	tv.ScrollToEnd()
+	tv.Draw(app.screen)
+	if line := topLine(); line != "L99995" {
+		t.Errorf("failed to draw provided lines: expected L99995, got %s", line)
+	}
+
+	// Search
+
+	tv.SetLineProvider(&testLineProvider{lines: 1000})
+	indices := tv.SearchLines("L99")
+	expected := []int{99, 990, 991, 992, 993, 994, 995, 996, 997, 998, 999}
+	if fmt.Sprint(indices) != fmt.Sprint(expected) {
+		t.Errorf("failed to search provided lines: expected %v, got %v", expected, indices)
+	}
+}
+
+func TestTextViewBackgroundIndexing(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tv := NewTextView()
+	tv.SetRect(0, 0, 10, 5)
+	tv.SetWrap(true)
+	tv.SetBackgroundIndexing(true)
+
+	indexed := make(chan struct{}, 1)
+	tv.SetChangedFunc(func() {
+		select {
+		case indexed <- struct{}{}:
+		default:
+		}
+	})
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	topLine := func() string {
+		var b []rune
+		for x := 0; x < 6; x++ {
+			r, _, _, _ := app.screen.GetContent(x, 0)
+			if r != ' ' {
+				b = append(b, r)
+			}
+		}
+		return string(b)
+	}
+
+	// Write
+
+	for i := 0; i < 10000; i++ {
+		fmt.Fprintf(tv, "L%d\n", i)
+	}
+
+	// Draw
+
+	tv.ScrollToBeginning()
+	tv.Draw(app.screen)
+	if line := topLine(); line != "" {
+		t.Errorf("failed to draw while indexing in background: expected empty line, got %s", line)
+	}
+
+	deadline := time.After(10 * time.Second)
+	for topLine() != "L0" {
+		select {
+		case <-indexed:
+			tv.Draw(app.screen)
+		case <-deadline:
+			t.Errorf("failed to index in background: expected L0, got %s", topLine())
+			return
+		}
+	}
+
+	// Write while drawing the prepared index
+
+	tv.SetText("Done")
+	tv.Draw(app.screen)
+	if line := topLine(); line != "L0" {
+		t.Errorf("failed to draw prepared index: expected L0, got %s", line)
+	}
+
+	deadline = time.After(10 * time.Second)
+	for topLine() != "Done" {
+		select {
+		case <-indexed:
+			tv.Draw(app.screen)
+		case <-deadline:
+			t.Errorf("failed to index in background: expected Done, got %s", topLine())
+			return
+		}
+	}
+}
+
 func generateTestCases() []*textViewTestCase {
 	var cases []*textViewTestCase
 	for i := 0; i < 2; i++ {
@@ -436,3 +588,1268 @@ func prepareAppendTextView(t *TextView) ([]byte, error) {
 
 	return b, nil
 }
+
+func TestTextViewSearch(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tv := NewTextView()
+	tv.SetRect(0, 0, 20, 3)
+	tv.SetDynamicColors(true)
+	tv.SetMatchBackgroundColor(tcell.ColorYellow)
+	tv.SetHighlightBackgroundColor(tcell.ColorRed)
+	for i := 0; i < 20; i++ {
+		fmt.Fprintf(tv, "line %d [green]foo[-]\n", i)
+	}
+	tv.Write([]byte("foo foo"))
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+
+	// Search
+
+	if err := tv.Search("(", true); err == nil {
+		t.Errorf("failed to search: expected error for invalid pattern, got nil")
+	}
+	if err := tv.Search("fo+", true); err != nil {
+		t.Errorf("failed to search: %s", err)
+	}
+	if count := tv.GetMatchCount(); count != 22 {
+		t.Errorf("failed to search: expected 22 matches, got %d", count)
+	}
+	if err := tv.Search("fo+", false); err != nil {
+		t.Errorf("failed to search: %s", err)
+	}
+	if count := tv.GetMatchCount(); count != 0 {
+		t.Errorf("failed to search literally: expected 0 matches, got %d", count)
+	}
+
+	// Navigate
+
+	tv.Search("foo", false)
+	tv.PreviousMatch()
+	tv.PreviousMatch()
+	if current := tv.GetCurrentMatch(); current != 20 {
+		t.Errorf("failed to move to previous match: expected 20, got %d", current)
+	}
+	tv.NextMatch()
+	tv.NextMatch()
+	if current := tv.GetCurrentMatch(); current != 0 {
+		t.Errorf("failed to move to next match: expected 0, got %d", current)
+	}
+
+	for i := 0; i < 5; i++ {
+		tv.NextMatch()
+	}
+	tv.Draw(app.screen)
+
+	if row, _ := tv.GetScrollOffset(); row != 4 {
+		t.Errorf("failed to scroll to match: expected row 4, got %d", row)
+	}
+	_, _, style, _ := app.screen.GetContent(7, 1)
+	if _, bg, _ := style.Decompose(); bg != tcell.ColorRed {
+		t.Errorf("failed to highlight current match: expected red background, got %v", bg)
+	}
+	_, _, style, _ = app.screen.GetContent(7, 0)
+	if _, bg, _ := style.Decompose(); bg != tcell.ColorYellow {
+		t.Errorf("failed to highlight match: expected yellow background, got %v", bg)
+	}
+
+	// Write
+
+	tv.Write([]byte(" foo\n"))
+	if count := tv.GetMatchCount(); count != 23 {
+		t.Errorf("failed to update matches: expected 23 matches, got %d", count)
+	}
+}
+
+func TestTextViewANSI(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tv := NewTextView()
+	tv.SetANSI(true)
+
+	// Write
+
+	fmt.Fprint(tv, "\x1b[31mred\x1b")
+	fmt.Fprint(tv, "[0m [INFO] ok\n")
+
+	if text := tv.GetText(true); text != "red [INFO] ok\n" {
+		t.Errorf("failed to translate ANSI escape sequences: expected \"red [INFO] ok\\n\", got %q", text)
+	}
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	tv.SetRect(0, 0, 20, 1)
+	tv.Draw(app.screen)
+
+	r, _, style, _ := app.screen.GetContent(0, 0)
+	if fg, _, _ := style.Decompose(); r != 'r' || fg != tcell.ColorMaroon {
+		t.Errorf("failed to draw translated colors: expected maroon r, got %v %c", fg, r)
+	}
+	r, _, _, _ = app.screen.GetContent(4, 0)
+	if r != '[' {
+		t.Errorf("failed to draw escaped text: expected [, got %c", r)
+	}
+}
+
+func TestTextViewMaxLinesWrap(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tv := NewTextView()
+	tv.SetRect(0, 0, 10, 4)
+	tv.SetScrollBarVisibility(ScrollBarNever)
+	tv.SetWrap(true)
+	tv.SetMaxLines(20)
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+
+	for i := 0; i < 20; i++ {
+		fmt.Fprintf(tv, "W%02d-abcdefghij\n", i)
+	}
+	tv.Draw(app.screen)
+
+	topLine := func() string {
+		var b strings.Builder
+		for x := 0; x < 10; x++ {
+			r, _, _, _ := app.screen.GetContent(x, 0)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+
+	// Scroll up
+
+	tv.ScrollTo(20, 0)
+	tv.Draw(app.screen)
+	if line := topLine(); line != "W11-abcdef" {
+		t.Errorf("failed to scroll: expected W11-abcdef, got %s", line)
+	}
+
+	// Clip while scrolled up
+
+	for i := 20; i < 25; i++ {
+		fmt.Fprintf(tv, "W%02d-abcdefghij\n", i)
+	}
+	tv.Draw(app.screen)
+	if line := topLine(); line != "W11-abcdef" {
+		t.Errorf("failed to keep wrapped view in place: expected W11-abcdef, got %s", line)
+	}
+	if row, _ := tv.GetScrollOffset(); row != 10 {
+		t.Errorf("failed to adjust scroll offset: expected row 10, got %d", row)
+	}
+}
+
+func TestTextViewFollow(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tv := NewTextView()
+	tv.SetRect(0, 0, 20, 5)
+	tv.SetMaxLines(50)
+	tv.SetFollow(true)
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+
+	for i := 0; i < 20; i++ {
+		fmt.Fprintf(tv, "L%d\n", i)
+	}
+	tv.Draw(app.screen)
+	if row, _ := tv.GetScrollOffset(); row != 16 {
+		t.Errorf("failed to follow content: expected row 16, got %d", row)
+	}
+
+	// Scroll up
+
+	tv.ScrollTo(10, 0)
+	tv.Draw(app.screen)
+	for i := 20; i < 63; i++ {
+		fmt.Fprintf(tv, "L%d\n", i)
+	}
+	tv.Draw(app.screen)
+
+	if unseen := tv.GetUnseenLines(); unseen != 43 {
+		t.Errorf("failed to count unseen lines: expected 43, got %d", unseen)
+	}
+	if row, _ := tv.GetScrollOffset(); row != 0 {
+		t.Errorf("failed to keep view in place: expected row 0, got %d", row)
+	}
+
+	var line []rune
+	for x := 5; x < 19; x++ {
+		r, _, _, _ := app.screen.GetContent(x, 4)
+		line = append(line, r)
+	}
+	if string(line) != " 43 new lines " {
+		t.Errorf("failed to draw unseen lines indicator: expected \" 43 new lines \", got %q", string(line))
+	}
+
+	// Scroll to end
+
+	tv.ScrollToEnd()
+	tv.Draw(app.screen)
+	if unseen := tv.GetUnseenLines(); unseen != 0 {
+		t.Errorf("failed to reset unseen lines: expected 0, got %d", unseen)
+	}
+}
+
+func TestTextViewStyleRanges(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tv := NewTextView()
+	tv.SetRect(0, 0, 10, 3)
+	tv.SetDynamicColors(true)
+	tv.SetText("ab[green]cd[-]ef\nghijkl\nmnopqr")
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+
+	// Add ranges
+
+	tv.AddStyleRange(0, 3, 1, 2, tcell.StyleDefault.Background(tcell.ColorRed))
+	tv.AddStyleRange(1, 1, 1, 4, tcell.StyleDefault.Background(tcell.ColorBlue).Bold(true))
+	tv.Draw(app.screen)
+
+	expected := []string{"...rrr", "rbbb..", "......"}
+	for y, line := range expected {
+		for x, c := range line {
+			_, _, style, _ := app.screen.GetContent(x, y)
+			_, bg, attr := style.Decompose()
+			var want tcell.Color
+			switch c {
+			case 'r':
+				want = tcell.ColorRed
+			case 'b':
+				want = tcell.ColorBlue
+			default:
+				want = tv.backgroundColor
+			}
+			if bg != want {
+				t.Errorf("failed to draw style range at %d,%d: expected background %v, got %v", x, y, want, bg)
+			}
+			if (c == 'b') != (attr&tcell.AttrBold != 0) {
+				t.Errorf("failed to draw style range at %d,%d: unexpected attributes %v", x, y, attr)
+			}
+		}
+	}
+
+	// Clear ranges
+
+	tv.ClearStyleRanges()
+	tv.Draw(app.screen)
+	_, _, style, _ := app.screen.GetContent(4, 0)
+	if _, bg, _ := style.Decompose(); bg != tv.backgroundColor {
+		t.Errorf("failed to clear style ranges: expected background %v, got %v", tv.backgroundColor, bg)
+	}
+}
+
+func TestTextViewLinks(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	const url = "https://example.com/issues?id=42&view=full#top"
+
+	tv := NewTextView()
+	tv.SetRect(0, 0, 20, 2)
+	tv.SetRegions(true)
+	tv.SetText(`See ["` + url + `"]docs[""] now` + "\n" + Escape(`["`+url+`"]`))
+
+	var clicked []string
+	tv.SetLinkClickedFunc(func(url string) {
+		clicked = append(clicked, url)
+	})
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	tv.Draw(app.screen)
+
+	// Draw
+
+	var line []rune
+	for x := 0; x < 12; x++ {
+		r, _, style, _ := app.screen.GetContent(x, 0)
+		line = append(line, r)
+		if _, _, attr := style.Decompose(); (x >= 4 && x < 8) != (attr&tcell.AttrUnderline != 0) {
+			t.Errorf("failed to underline hyperlink at column %d: unexpected attributes %v", x, attr)
+		}
+		if (x >= 4 && x < 8) != (style.Url(url) == style) {
+			t.Errorf("failed to pass hyperlink to the terminal at column %d: unexpected URL", x)
+		}
+	}
+	if string(line) != "See docs now" {
+		t.Errorf("failed to draw hyperlink: expected \"See docs now\", got %q", string(line))
+	}
+	r, _, _, _ := app.screen.GetContent(0, 1)
+	if r != '[' {
+		t.Errorf("failed to escape hyperlink: expected [, got %c", r)
+	}
+
+	// Click
+
+	handler := tv.MouseHandler()
+	handler(MouseLeftClick, tcell.NewEventMouse(1, 0, tcell.ButtonPrimary, tcell.ModNone), func(p Primitive) {})
+	handler(MouseLeftClick, tcell.NewEventMouse(5, 0, tcell.ButtonPrimary, tcell.ModNone), func(p Primitive) {})
+	if len(clicked) != 1 || clicked[0] != url {
+		t.Errorf("failed to click hyperlink: expected [%s], got %v", url, clicked)
+	}
+	if highlights := tv.GetHighlights(); len(highlights) != 0 {
+		t.Errorf("failed to click hyperlink: expected no highlights, got %v", highlights)
+	}
+}
+
+func TestTextViewAppend(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tv := NewTextView()
+	tv.SetRect(0, 0, 12, 5)
+	tv.SetDynamicColors(true)
+	tv.SetRegions(true)
+	tv.SetWordWrap(true)
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+
+	// Append
+
+	chunks := []string{
+		"first line ", "of [red]text\nsecond", " line which wraps ",
+		"around\n", `["a"]region`, " text[\"\"] [-]after\n\n", "last",
+	}
+	for _, chunk := range chunks {
+		fmt.Fprint(tv, chunk)
+		tv.Draw(app.screen)
+
+		if tv.index == nil {
+			t.Errorf("failed to index appended text: index is nil")
+			return
+		}
+		expected := tv.newIndexer(tv.buffer, false).index(tv.indexWidth)
+		if len(tv.index) != len(expected.index) {
+			t.Errorf("failed to index appended text %q: expected %d lines, got %d", chunk, len(expected.index), len(tv.index))
+			continue
+		}
+		for i, line := range tv.index {
+			if !reflect.DeepEqual(line, expected.index[i]) {
+				t.Errorf("failed to index appended text %q: expected line %d to be %+v, got %+v", chunk, i, *expected.index[i], *line)
+			}
+		}
+		if tv.longestLine != expected.longestLine {
+			t.Errorf("failed to index appended text %q: expected longest line %d, got %d", chunk, expected.longestLine, tv.longestLine)
+		}
+	}
+}
+
+func TestTextViewWrapMode(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tv := NewTextView()
+	tv.SetRect(0, 0, 10, 4)
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+
+	screenLines := func() []string {
+		var lines []string
+		for y := 0; y < 4; y++ {
+			var line []rune
+			for x := 0; x < 10; x++ {
+				r, _, _, _ := app.screen.GetContent(x, y)
+				line = append(line, r)
+			}
+			lines = append(lines, string(line))
+		}
+		return lines
+	}
+
+	testCases := []struct {
+		mode      WrapMode
+		indicator rune
+		expected  []string
+	}{
+		{WrapNone, 0, []string{"  if a && ", "          ", "          ", "          "}},
+		{WrapAnywhere, 0, []string{"  if a && ", "bb && c { ", "          ", "          "}},
+		{WrapWord, 0, []string{"  if a && ", "bb && c { ", "          ", "          "}},
+		{WrapIndent, 0, []string{"  if a && ", "  bb && c ", "  {       ", "          "}},
+		{WrapIndent, '>', []string{"  if a && ", ">  bb && c", ">  {      ", "          "}},
+	}
+	for _, c := range testCases {
+		tv.SetWrapMode(c.mode)
+		tv.SetWrapIndicator(c.indicator)
+		tv.SetText("  if a && bb && c {")
+		if mode := tv.GetWrapMode(); mode != c.mode {
+			t.Errorf("failed to set wrap mode: expected %d, got %d", c.mode, mode)
+		}
+
+		app.screen.Clear()
+		tv.Draw(app.screen)
+		lines := screenLines()
+		for i := range c.expected {
+			if lines[i] != c.expected[i] {
+				t.Errorf("failed to wrap text with mode %d: expected line %d to be %q, got %q", c.mode, i, c.expected[i], lines[i])
+			}
+		}
+	}
+}
+
+func TestTextViewAnchors(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tv := NewTextView()
+	tv.SetRect(0, 0, 10, 3)
+	var text []string
+	for i := 0; i < 20; i++ {
+		text = append(text, fmt.Sprintf("line %d", i))
+	}
+	tv.SetText(strings.Join(text, "\n"))
+	tv.AddAnchor("intro", 0)
+	tv.AddAnchor("usage", 5)
+	tv.AddAnchor("options", 12)
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	tv.Draw(app.screen)
+
+	// Scroll to anchor
+
+	if tv.ScrollToAnchor("unknown") {
+		t.Errorf("failed to scroll to anchor: expected false for unknown anchor, got true")
+	}
+	if !tv.ScrollToAnchor("options") {
+		t.Errorf("failed to scroll to anchor: expected true, got false")
+	}
+	tv.Draw(app.screen)
+	if row, _ := tv.GetScrollOffset(); row != 12 {
+		t.Errorf("failed to scroll to anchor: expected row 12, got %d", row)
+	}
+
+	// Previous and next anchors
+
+	if name := tv.PreviousAnchor(); name != "usage" {
+		t.Errorf("failed to scroll to previous anchor: expected usage, got %q", name)
+	}
+	tv.Draw(app.screen)
+	if row, _ := tv.GetScrollOffset(); row != 5 {
+		t.Errorf("failed to scroll to previous anchor: expected row 5, got %d", row)
+	}
+
+	tv.InputHandler()(tcell.NewEventKey(tcell.KeyRune, ']', tcell.ModNone), nil)
+	tv.Draw(app.screen)
+	if row, _ := tv.GetScrollOffset(); row != 12 {
+		t.Errorf("failed to scroll to next anchor: expected row 12, got %d", row)
+	}
+	if name := tv.NextAnchor(); name != "" {
+		t.Errorf("failed to scroll to next anchor: expected no anchor, got %q", name)
+	}
+
+	// Remove lines
+
+	tv.SetMaxLines(10)
+	tv.ScrollToAnchor("options")
+	tv.Draw(app.screen)
+	if row, _ := tv.GetScrollOffset(); row != 2 {
+		t.Errorf("failed to move anchor: expected row 2, got %d", row)
+	}
+	if tv.ScrollToAnchor("usage") {
+		t.Errorf("failed to remove anchor: expected false for discarded line, got true")
+	}
+}
+
+func TestTextViewReplaceRange(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tv := NewTextView()
+	tv.SetDynamicColors(true)
+	tv.SetText("Status: [green]running[-]\nProgress: 10%\nDone")
+	tv.AddAnchor("done", 2)
+
+	// Replace
+
+	tv.ReplaceRange(0, 8, 0, 15, "stopped")
+	expected := "Status: [green]stopped[-]\nProgress: 10%\nDone"
+	if text := tv.GetText(false); text != expected {
+		t.Errorf("failed to replace range: expected %q, got %q", expected, text)
+	}
+
+	tv.ReplaceRange(1, 10, 1, 13, "50%\nETA: 1m")
+	expected = "Status: [green]stopped[-]\nProgress: 50%\nETA: 1m\nDone"
+	if text := tv.GetText(false); text != expected {
+		t.Errorf("failed to replace range: expected %q, got %q", expected, text)
+	}
+	if !tv.ScrollToAnchor("done") || tv.anchors["done"] != 3 {
+		t.Errorf("failed to move anchor: expected line 3, got %d", tv.anchors["done"])
+	}
+
+	// Insert
+
+	tv.InsertAt(3, 0, "[red]")
+	tv.InsertAt(100, 100, "!")
+	expected = "Status: [green]stopped[-]\nProgress: 50%\nETA: 1m\n[red]Done!"
+	if text := tv.GetText(false); text != expected {
+		t.Errorf("failed to insert text: expected %q, got %q", expected, text)
+	}
+
+	// Replace across lines
+
+	tv.ReplaceRange(1, 0, 2, 5, "")
+	expected = "Status: [green]stopped[-]\n1m\n[red]Done!"
+	if text := tv.GetText(false); text != expected {
+		t.Errorf("failed to replace range: expected %q, got %q", expected, text)
+	}
+}
+
+func TestTextViewSource(t *testing.T) {
+	t.Parallel()
+
+	// ReadFrom
+
+	tv := NewTextView()
+	n, err := tv.ReadFrom(strings.NewReader("Hello\nWorld"))
+	if err != nil {
+		t.Errorf("failed to read from reader: %s", err)
+	} else if n != 11 {
+		t.Errorf("failed to read from reader: expected 11 bytes, got %d", n)
+	} else if text := tv.GetText(true); text != "Hello\nWorld" {
+		t.Errorf("failed to read from reader: expected %q, got %q", "Hello\nWorld", text)
+	}
+
+	// SetSource
+
+	tv = NewTextView()
+	r, w := io.Pipe()
+	done := make(chan error, 1)
+	tv.SetSource(nil, r, func(err error) {
+		done <- err
+	})
+	for i := 0; i < 3; i++ {
+		fmt.Fprintf(w, "Line %d\n", i)
+	}
+	w.Close()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("failed to stream source: expected no error, got %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("failed to stream source: done handler not called")
+	}
+	if text, expected := tv.GetText(true), "Line 0\nLine 1\nLine 2\n"; text != expected {
+		t.Errorf("failed to stream source: expected %q, got %q", expected, text)
+	}
+
+	// Read error
+
+	r, w = io.Pipe()
+	tv.SetSource(nil, r, func(err error) {
+		done <- err
+	})
+	readErr := errors.New("read error")
+	w.CloseWithError(readErr)
+
+	select {
+	case err := <-done:
+		if err != readErr {
+			t.Errorf("failed to stream source: expected %s, got %v", readErr, err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("failed to stream source: done handler not called")
+	}
+
+	// Stop
+
+	r, w = io.Pipe()
+	tv.SetSource(nil, r, func(err error) {
+		done <- err
+	})
+	tv.SetSource(nil, nil, nil)
+	w.Close()
+
+	select {
+	case err := <-done:
+		t.Errorf("failed to stop source: done handler called with %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestTextViewWrapCache(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tv := NewTextView()
+	tv.SetScrollBarVisibility(ScrollBarNever)
+	tv.SetWordWrap(true)
+	for i := 0; i < 100; i++ {
+		fmt.Fprintf(tv, "Line %d of the text view wraps at narrow widths\n", i)
+	}
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+
+	draw := func(width int) *textViewPreparedIndex {
+		tv.SetRect(0, 0, width, 10)
+		tv.Draw(app.screen)
+		return tv.indexed
+	}
+
+	// Resize
+
+	wide := draw(40)
+	narrow := draw(20)
+	if narrow == wide {
+		t.Fatalf("failed to index text view: expected separate index for each width")
+	}
+	if index := draw(40); index != wide {
+		t.Errorf("failed to reuse cached index: expected index prepared at width 40")
+	}
+
+	// Append while narrow
+
+	draw(20)
+	fmt.Fprint(tv, "Appended text which wraps")
+	draw(40)
+	fresh := tv.newIndexer(tv.buffer, false).index(40)
+	if !reflect.DeepEqual(tv.index, fresh.index) {
+		t.Errorf("failed to index appended text: cached index differs from fresh index")
+	}
+
+	// Change text
+
+	tv.SetText("Replaced")
+	if len(tv.wrapCache) != 0 {
+		t.Errorf("failed to discard cached indices: expected 0, got %d", len(tv.wrapCache))
+	}
+
+	// Limit size
+
+	tv.SetText(strings.Repeat("Line of text\n", 100))
+	draw(40)
+	draw(20)
+	if len(tv.wrapCache) != 1 {
+		t.Errorf("failed to cache index: expected 1 cached index, got %d", len(tv.wrapCache))
+	}
+	tv.SetWrapCacheSize(textViewIndexSize)
+	if len(tv.wrapCache) != 0 {
+		t.Errorf("failed to limit cache size: expected 0 cached indices, got %d", len(tv.wrapCache))
+	}
+}
+
+func TestTextViewLineReferences(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tv := NewTextView()
+	tv.SetRect(0, 0, 10, 5)
+	tv.WriteLine("Entry A", "a")
+	tv.WriteLine("Entry B wraps", "b")
+	tv.WriteLine("Entry C\nDetails", "c")
+
+	expected := []interface{}{"a", "b", "c", "c", nil}
+	for line, reference := range expected {
+		if r := tv.GetLineReference(line); r != reference {
+			t.Errorf("failed to attach reference to line %d: expected %v, got %v", line, reference, r)
+		}
+	}
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	tv.Draw(app.screen)
+
+	// Click lines
+
+	var clickedLine int
+	var clickedReference interface{}
+	tv.SetLineClickedFunc(func(line int, reference interface{}) {
+		clickedLine, clickedReference = line, reference
+	})
+
+	testCases := []struct {
+		y         int
+		line      int
+		reference interface{}
+	}{
+		{0, 0, "a"},
+		{2, 1, "b"}, // Wrapped continuation of line 1.
+		{4, 3, "c"},
+	}
+	for _, c := range testCases {
+		tv.MouseHandler()(MouseLeftClick, tcell.NewEventMouse(1, c.y, tcell.Button1, tcell.ModNone), func(p Primitive) {})
+		if clickedLine != c.line || clickedReference != c.reference {
+			t.Errorf("failed to click row %d: expected line %d with reference %v, got line %d with reference %v", c.y, c.line, c.reference, clickedLine, clickedReference)
+		}
+	}
+
+	// Replace lines
+
+	tv.ReplaceRange(0, 0, 1, 13, "Merged")
+	if r := tv.GetLineReference(1); r != "c" {
+		t.Errorf("failed to move references: expected c, got %v", r)
+	}
+
+	// Clip lines
+
+	tv.SetMaxLines(2)
+	if r := tv.GetLineReference(0); r != "c" {
+		t.Errorf("failed to clip references: expected c, got %v", r)
+	}
+}
+
+func TestTextViewExport(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tv := NewTextView()
+	tv.SetDynamicColors(true)
+	tv.SetRegions(true)
+	tv.SetText(`a<b [red]red[-] [::b]bold[::-]` + "\n" + `["https://example.com"]link[""] ["r"]region[""] [green]green` + "\n" + `still green`)
+
+	// ANSI
+
+	ansi := string(tv.ExportANSI())
+	for _, expected := range []string{
+		"a<b \x1b[0;91mred\x1b[0m \x1b[0;1mbold\x1b[0m\n",
+		"\x1b]8;;https://example.com\x1b\\\x1b[0;4mlink\x1b]8;;\x1b\\\x1b[0m region ",
+		"\x1b[0;32mgreen\x1b[0m\n\x1b[0;32mstill green\x1b[0m\n",
+	} {
+		if !strings.Contains(ansi, expected) {
+			t.Errorf("failed to export ANSI: expected %q in %q", expected, ansi)
+		}
+	}
+
+	// HTML
+
+	tv.Highlight("r")
+	exported := string(tv.ExportHTML())
+	for _, expected := range []string{
+		`<pre style="color:#ffffff;background-color:#000000">a&lt;b <span style="color:#ff0000">red</span> <span style="font-weight:bold">bold</span>` + "\n",
+		`<a href="https://example.com"><span style="text-decoration:underline">link</span></a>`,
+		`<span data-region="r"><span style="color:#000000;background-color:#ffffff">region</span></span>`,
+		`<span style="color:#008000">still green</span></pre>`,
+	} {
+		if !strings.Contains(exported, expected) {
+			t.Errorf("failed to export HTML: expected %q in %q", expected, exported)
+		}
+	}
+}
+
+func TestTextViewTabs(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tv := NewTextView()
+	tv.SetDynamicColors(true)
+
+	// Tab stops
+
+	fmt.Fprint(tv, "a\tb\n[red]abcd[-]\te\nab")
+	fmt.Fprint(tv, "c\tf")
+	if text := tv.GetText(true); text != "a   b\nabcd    e\nabc f" {
+		t.Errorf("failed to expand tabs: got %q", text)
+	}
+
+	tv.Clear()
+	tv.SetTabWidth(8)
+	tv.SetText("a\tb")
+	if text := tv.GetText(true); text != "a       b" {
+		t.Errorf("failed to set tab width: got %q", text)
+	}
+
+	// Elastic tabs
+
+	tv.Clear()
+	tv.SetElasticTabs(true)
+	tv.SetTabWidth(2)
+	fmt.Fprint(tv, "PID\tCOMMAND\tSTATE\n1\t[green]init[-]\tS\nno tabs\n12345\tx\ty\nz\tw")
+	if text := tv.GetText(true); text != "PID COMMAND STATE\n1   init    S\nno tabs\n12345 x y\n" {
+		t.Errorf("failed to align tabs: got %q", text)
+	}
+	if text := tv.GetText(false); !strings.HasSuffix(text, "12345 x y\nz\tw") {
+		t.Errorf("failed to get unterminated line: got %q", text)
+	}
+	fmt.Fprintln(tv)
+	if text := tv.GetText(true); !strings.HasSuffix(text, "\nz w\n") {
+		t.Errorf("failed to align tabs of terminated line: got %q", text)
+	}
+
+	// Print
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	_, width := Print(app.screen, []byte("ab\tc"), 0, 0, 20, AlignLeft, tcell.ColorWhite)
+	if r, _, _, _ := app.screen.GetContent(TabSize, 0); width != TabSize+1 || r != 'c' {
+		t.Errorf("failed to print tab: expected c at %d and width %d, got %c and %d", TabSize, TabSize+1, r, width)
+	}
+	if width := TaggedStringWidth("[red]a[-]\tb"); width != TabSize+1 {
+		t.Errorf("failed to measure tab: expected %d, got %d", TabSize+1, width)
+	}
+}
+
+func TestTextViewSelection(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tv := NewTextView()
+	tv.SetDynamicColors(true)
+	tv.SetScrollBarVisibility(ScrollBarNever)
+	tv.SetSelectable(true)
+	for i := 0; i < 20; i++ {
+		fmt.Fprintf(tv, "[red]line[-] %d\n", i)
+	}
+	fmt.Fprint(tv, "the last line is longer")
+	tv.ScrollToBeginning()
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	tv.SetRect(0, 0, 10, 5)
+	tv.Draw(app.screen)
+
+	input := tv.InputHandler()
+	key := func(key tcell.Key, mod tcell.ModMask) {
+		input(tcell.NewEventKey(key, 0, mod), func(p Primitive) {})
+		tv.Draw(app.screen)
+	}
+
+	// Select characters and lines
+
+	key(tcell.KeyRight, tcell.ModShift)
+	key(tcell.KeyRight, tcell.ModShift)
+	if selection := tv.GetSelection(); selection != "li" {
+		t.Errorf("failed to select characters: expected li, got %q", selection)
+	}
+	if _, _, style, _ := app.screen.GetContent(1, 0); style != tv.highlightStyle(style) {
+		t.Errorf("failed to highlight selection: got %v", style)
+	}
+	if _, _, style, _ := app.screen.GetContent(2, 0); style == tv.highlightStyle(style) {
+		t.Errorf("failed to highlight selection: unexpected highlight at column 2")
+	}
+
+	key(tcell.KeyDown, tcell.ModShift)
+	if selection := tv.GetSelection(); selection != "line 0\nli" {
+		t.Errorf("failed to select lines: expected %q, got %q", "line 0\nli", selection)
+	}
+
+	key(tcell.KeyLeft, tcell.ModShift)
+	key(tcell.KeyLeft, tcell.ModShift)
+	key(tcell.KeyLeft, tcell.ModShift)
+	if selection := tv.GetSelection(); selection != "line 0" {
+		t.Errorf("failed to select across lines: expected %q, got %q", "line 0", selection)
+	}
+
+	// Scroll with the selection
+
+	key(tcell.KeyPgDn, tcell.ModShift)
+	key(tcell.KeyPgDn, tcell.ModShift)
+	if row, _ := tv.GetScrollOffset(); row != 6 {
+		t.Errorf("failed to scroll to selection: expected offset 6, got %d", row)
+	}
+	if selection := tv.GetSelection(); !strings.HasPrefix(selection, "line 0\n") || !strings.HasSuffix(selection, "line 9\nline 1") {
+		t.Errorf("failed to select pages: got %q", selection)
+	}
+
+	key(tcell.KeyEnd, tcell.ModShift)
+	if selection := tv.GetSelection(); !strings.HasSuffix(selection, "line 19\nthe last line is longer") {
+		t.Errorf("failed to select to the end: got %q", selection)
+	}
+
+	// Wrapped lines
+
+	key(tcell.KeyUp, tcell.ModShift)
+	if selection := tv.GetSelection(); !strings.HasSuffix(selection, "line 19\nthe last line") {
+		t.Errorf("failed to select wrapped line: got %q", selection)
+	}
+
+	// Clear
+
+	key(tcell.KeyEscape, tcell.ModNone)
+	if selection := tv.GetSelection(); selection != "" {
+		t.Errorf("failed to clear selection: got %q", selection)
+	}
+
+	tv.SetSelectable(false)
+	key(tcell.KeyRight, tcell.ModShift)
+	if selection := tv.GetSelection(); selection != "" {
+		t.Errorf("failed to disable selection: got %q", selection)
+	}
+}
+
+func TestTextViewAnnotations(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tv := NewTextView()
+	tv.SetRect(0, 0, 20, 3)
+	tv.SetText("first line\nsecond line")
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+
+	errorAnnotation := &TextViewAnnotation{FromLine: 0, FromColumn: 6, ToLine: 1, ToColumn: 6, Color: tcell.ColorRed, Message: "error"}
+	warningAnnotation := &TextViewAnnotation{FromLine: 1, FromColumn: 0, ToLine: 1, ToColumn: 3, Style: AnnotationBackground, Color: tcell.ColorYellow, Message: "warning"}
+	tv.AddAnnotation(errorAnnotation)
+	tv.AddAnnotation(warningAnnotation)
+	if annotations := tv.GetAnnotations(); len(annotations) != 2 {
+		t.Errorf("failed to add annotations: expected 2, got %d", len(annotations))
+	}
+	tv.Draw(app.screen)
+
+	// Draw annotations
+
+	testCases := []struct {
+		x, y       int
+		underline  bool
+		background tcell.Color
+	}{
+		{5, 0, false, tcell.ColorDefault},
+		{6, 0, true, tcell.ColorDefault},
+		{1, 1, false, tcell.ColorYellow}, // The last annotation takes precedence.
+		{4, 1, true, tcell.ColorDefault},
+		{6, 1, false, tcell.ColorDefault},
+	}
+	for _, c := range testCases {
+		_, _, style, _ := app.screen.GetContent(c.x, c.y)
+		_, bg, attr := style.Decompose()
+		if underline := attr&tcell.AttrUnderline != 0; underline != c.underline {
+			t.Errorf("failed to draw annotation at %d,%d: expected underline %v, got %v", c.x, c.y, c.underline, underline)
+		}
+		if c.background != tcell.ColorDefault && bg != c.background {
+			t.Errorf("failed to draw annotation at %d,%d: expected background %v, got %v", c.x, c.y, c.background, bg)
+		}
+	}
+
+	// Hover and select annotations
+
+	var hovered, selected *TextViewAnnotation
+	var hoverCalls int
+	tv.SetAnnotationHoverFunc(func(annotation *TextViewAnnotation) {
+		hovered = annotation
+		hoverCalls++
+	})
+	tv.SetAnnotationSelectedFunc(func(annotation *TextViewAnnotation) {
+		selected = annotation
+	})
+	mouse := func(action MouseAction, x, y int) Primitive {
+		_, capture := tv.MouseHandler()(action, tcell.NewEventMouse(x, y, tcell.ButtonNone, 0), func(p Primitive) {})
+		return capture
+	}
+
+	if capture := mouse(MouseMove, 8, 0); hovered != errorAnnotation || capture != tv {
+		t.Errorf("failed to hover annotation: expected %v, got %v (capture %v)", errorAnnotation, hovered, capture)
+	}
+	mouse(MouseMove, 9, 0)
+	if hoverCalls != 1 {
+		t.Errorf("failed to hover annotation: expected 1 call, got %d", hoverCalls)
+	}
+	mouse(MouseMove, 2, 0)
+	if hovered != nil {
+		t.Errorf("failed to leave annotation: expected nil, got %v", hovered)
+	}
+	mouse(MouseMove, 0, 1)
+	mouse(MouseMove, 30, 10)
+	if hovered != nil || hoverCalls != 4 {
+		t.Errorf("failed to leave text view: expected nil after 4 calls, got %v after %d calls", hovered, hoverCalls)
+	}
+
+	mouse(MouseLeftClick, 1, 1)
+	if selected != warningAnnotation {
+		t.Errorf("failed to select annotation: expected %v, got %v", warningAnnotation, selected)
+	}
+
+	// Remove annotations
+
+	tv.RemoveAnnotation(warningAnnotation)
+	tv.Draw(app.screen)
+	if _, _, style, _ := app.screen.GetContent(1, 1); style != tcell.StyleDefault.Foreground(tcell.ColorRed).Background(Styles.PrimitiveBackgroundColor).Underline(true) {
+		t.Errorf("failed to remove annotation: got style %v", style)
+	}
+	tv.ClearAnnotations()
+	if annotations := tv.GetAnnotations(); len(annotations) != 0 {
+		t.Errorf("failed to clear annotations: expected 0, got %d", len(annotations))
+	}
+}
+
+func TestTextViewWidgets(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tv := NewTextView()
+	tv.SetDynamicColors(true)
+	tv.SetWidgetInterval(time.Hour)
+
+	spinner := NewSpinnerWidget()
+	spinner.SetFrames([]string{"-", "\\", "|", "/"})
+	progress := NewProgressWidget(4)
+	counter := NewCounterWidget("%d files")
+	tv.SetWidget("spinner", spinner)
+	tv.SetWidget("progress", progress)
+	tv.SetWidget("files", counter)
+
+	// Replace placeholders
+
+	fmt.Fprint(tv, "[widget:spinner] Downloading [widget:progress] [widget:fi")
+	fmt.Fprint(tv, "les]\n[widget:unknown] done\n")
+	expected := "- Downloading ░░░░   0% 0 files\n[widget:unknown] done\n"
+	if text := tv.GetText(false); text != expected {
+		t.Errorf("failed to replace placeholders: expected %q, got %q", expected, text)
+	}
+
+	// Update widgets
+
+	var changed int
+	tv.SetChangedFunc(func() {
+		changed++
+	})
+	progress.SetProgress(50)
+	counter.Add(12)
+	tv.tickWidgets()
+	expected = "\\ Downloading ██░░  50% 12 files\n[widget:unknown] done\n"
+	if text := tv.GetText(false); text != expected {
+		t.Errorf("failed to update widgets: expected %q, got %q", expected, text)
+	}
+	if changed != 1 {
+		t.Errorf("failed to notify about updated widgets: expected 1 call, got %d", changed)
+	}
+	spinner.Stop("✓")
+	tv.tickWidgets()
+	tv.tickWidgets()
+	if text := tv.GetText(false); !strings.HasPrefix(text, "✓ Downloading") {
+		t.Errorf("failed to stop spinner: got %q", text)
+	}
+	if changed != 2 {
+		t.Errorf("failed to skip unchanged widgets: expected 2 calls, got %d", changed)
+	}
+
+	// Move widgets along with their lines
+
+	tv.SetMaxLines(2)
+	fmt.Fprint(tv, "[widget:files] indexed")
+	counter.SetValue(3)
+	tv.tickWidgets()
+	expected = "[widget:unknown] done\n3 files indexed"
+	if text := tv.GetText(false); text != expected {
+		t.Errorf("failed to move widgets: expected %q, got %q", expected, text)
+	}
+
+	// Unregister widgets
+
+	tv.SetWidget("spinner", nil)
+	tv.SetWidget("progress", nil)
+	tv.SetWidget("files", nil)
+	counter.SetValue(4)
+	tv.tickWidgets()
+	if text := tv.GetText(false); text != expected {
+		t.Errorf("failed to unregister widgets: expected %q, got %q", expected, text)
+	}
+}
+
+// tickingWidget is a TextViewWidget which reports when it is ticked.
+type tickingWidget chan struct{}
+
+func (w tickingWidget) Tick() {
+	select {
+	case w <- struct{}{}:
+	default:
+	}
+}
+
+func (w tickingWidget) Text() string {
+	return ""
+}
+
+func TestTextViewWidgetsLifecycle(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tv := NewTextView()
+	tv.SetWidgetInterval(time.Millisecond)
+
+	stopChannel := func() chan struct{} {
+		tv.RLock()
+		defer tv.RUnlock()
+		return tv.widgetStop
+	}
+	if stopChannel() != nil {
+		t.Errorf("failed to initialize TextView: expected no widget goroutine")
+	}
+
+	// Register
+
+	ticks := make(tickingWidget, 1)
+	tv.SetWidget("a", ticks)
+	tv.SetWidget("b", NewCounterWidget("%d"))
+	select {
+	case <-ticks:
+	case <-time.After(time.Second):
+		t.Fatal("failed to update widgets: expected widget to be ticked")
+	}
+
+	// Change interval
+
+	stop := stopChannel()
+	tv.SetWidgetInterval(2 * time.Millisecond)
+	select {
+	case <-stop:
+	default:
+		t.Errorf("failed to change widget interval: expected previous goroutine to be stopped")
+	}
+	if stopChannel() == nil {
+		t.Errorf("failed to change widget interval: expected widget goroutine")
+	}
+
+	// Unregister
+
+	stop = stopChannel()
+	tv.SetWidget("a", nil)
+	select {
+	case <-stop:
+		t.Errorf("failed to unregister widget: expected goroutine to keep running while widgets are registered")
+	default:
+	}
+	tv.SetWidget("b", nil)
+	select {
+	case <-stop:
+	default:
+		t.Errorf("failed to unregister widgets: expected goroutine to be stopped")
+	}
+	if stopChannel() != nil {
+		t.Errorf("failed to unregister widgets: expected no widget goroutine")
+	}
+
+	// The goroutine exits when it is stopped.
+
+	exited := make(chan struct{})
+	stop = make(chan struct{})
+	go func() {
+		tv.runWidgets(time.Millisecond, stop)
+		close(exited)
+	}()
+	close(stop)
+	select {
+	case <-exited:
+	case <-time.After(time.Second):
+		t.Fatal("failed to stop widget goroutine: expected goroutine to exit")
+	}
+}
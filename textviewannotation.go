@@ -0,0 +1,148 @@
+package cview
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// AnnotationStyle determines how the text of a TextViewAnnotation is marked.
+type AnnotationStyle int
+
+// Annotation styles.
+const (
+	AnnotationUnderline     AnnotationStyle = iota // Underline the text. Underlines are drawn straight, as terminals do not support wavy underlines.
+	AnnotationStrikeThrough                        // Strike the text through.
+	AnnotationBackground                           // Draw the text on a background in the color of the annotation.
+)
+
+// TextViewAnnotation marks a span of text of a TextView, such as a diagnostic
+// of a linter, without modifying the text. The span starts at the character
+// FromColumn of line FromLine and ends before the character ToColumn of line
+// ToLine. Lines are separated by newline characters and columns count
+// characters, ignoring color and region tags. Both start at 0.
+type TextViewAnnotation struct {
+	// The first character of the span.
+	FromLine, FromColumn int
+
+	// The character after the span on the last line.
+	ToLine, ToColumn int
+
+	// How the text is marked.
+	Style AnnotationStyle
+
+	// The color of the annotation. The text is drawn in this color unless the
+	// style is AnnotationBackground, in which case it is used as the
+	// background color. tcell.ColorDefault keeps the colors of the text.
+	Color tcell.Color
+
+	// An optional message, such as the description of an error.
+	Message string
+
+	// An optional reference object.
+	Reference interface{}
+}
+
+// style returns the provided style of annotated text marked with the
+// annotation.
+func (a *TextViewAnnotation) style(style tcell.Style) tcell.Style {
+	switch a.Style {
+	case AnnotationStrikeThrough:
+		style = style.StrikeThrough(true)
+	case AnnotationBackground:
+		if a.Color != tcell.ColorDefault {
+			return style.Background(a.Color)
+		}
+		return style.Reverse(true)
+	default:
+		style = style.Underline(true)
+	}
+	if a.Color != tcell.ColorDefault {
+		style = style.Foreground(a.Color)
+	}
+	return style
+}
+
+// AddAnnotation adds an annotation, which marks a span of text, allowing
+// applications such as linters and diagnostic viewers to point out parts of
+// the text. Annotations are drawn on top of style ranges and below search
+// matches. When annotations overlap, the annotation added last takes
+// precedence. See SetAnnotationHoverFunc and SetAnnotationSelectedFunc.
+func (t *TextView) AddAnnotation(annotation *TextViewAnnotation) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.annotations = append(t.annotations, annotation)
+}
+
+// RemoveAnnotation removes an annotation added via AddAnnotation.
+func (t *TextView) RemoveAnnotation(annotation *TextViewAnnotation) {
+	t.Lock()
+	defer t.Unlock()
+
+	for i, a := range t.annotations {
+		if a == annotation {
+			t.annotations = append(t.annotations[:i], t.annotations[i+1:]...)
+			break
+		}
+	}
+	if t.hoverAnnotation == annotation {
+		t.hoverAnnotation = nil
+	}
+}
+
+// ClearAnnotations removes all annotations added via AddAnnotation.
+func (t *TextView) ClearAnnotations() {
+	t.Lock()
+	defer t.Unlock()
+
+	t.annotations = nil
+	t.hoverAnnotation = nil
+}
+
+// GetAnnotations returns the annotations added via AddAnnotation.
+func (t *TextView) GetAnnotations() []*TextViewAnnotation {
+	t.RLock()
+	defer t.RUnlock()
+
+	return append([]*TextViewAnnotation(nil), t.annotations...)
+}
+
+// SetAnnotationHoverFunc sets a handler which is called when the mouse moves
+// onto an annotation, e.g. to show its message in a status bar. The handler
+// is called with nil when the mouse leaves the annotation.
+func (t *TextView) SetAnnotationHoverFunc(handler func(annotation *TextViewAnnotation)) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.annotationHover = handler
+}
+
+// SetAnnotationSelectedFunc sets a handler which is called when the user
+// clicks on an annotation. Clicks on annotations are not passed to the
+// handlers set via SetLinkClickedFunc and SetLineClickedFunc.
+func (t *TextView) SetAnnotationSelectedFunc(handler func(annotation *TextViewAnnotation)) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.annotationSelected = handler
+}
+
+// lineAnnotations returns the (byte) positions in the provided stripped line
+// covered by each annotation, along with the annotations. Annotations which
+// do not cover the line are omitted.
+func (t *TextView) lineAnnotations(line int, stripped []byte) (positions [][]int, annotations []*TextViewAnnotation) {
+	for _, a := range t.annotations {
+		from, to := lineSpan(line, stripped, a.FromLine, a.FromColumn, a.ToLine, a.ToColumn)
+		if from >= to {
+			continue
+		}
+		positions = append(positions, []int{from, to})
+		annotations = append(annotations, a)
+	}
+	return positions, annotations
+}
+
+// annotationAt returns the annotation drawn at the provided screen position
+// the last time the text view was drawn, or nil if there is none.
+func (t *TextView) annotationAt(x, y int) *TextViewAnnotation {
+	return t.annotationCells[[2]int{x, y}]
+}
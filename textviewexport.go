@@ -0,0 +1,321 @@
+package cview
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// textViewRun is a run of text of a line of a TextView which has the same
+// style and region.
+type textViewRun struct {
+	// The text of the run, without tags.
+	text string
+
+	// The style of the text. Colors equal to the text and background colors of
+	// the text view are replaced by tcell.ColorDefault.
+	style tcell.Style
+
+	// The ID of the region the text belongs to, if any.
+	region string
+}
+
+// ExportANSI returns the text of the text view with its colors and attributes
+// translated to ANSI escape sequences, so that it may be saved and viewed in a
+// terminal, for example via "less -R". Hyperlinks are exported as OSC 8
+// hyperlinks and highlighted regions are exported with the colors of
+// highlights. Colors which equal the text and background colors of the text
+// view are not exported. The text of a LineProvider is exported as well.
+func (t *TextView) ExportANSI() []byte {
+	t.RLock()
+	defer t.RUnlock()
+
+	var b bytes.Buffer
+	for _, line := range t.exportRuns() {
+		var style tcell.Style
+		var link string
+		for _, run := range line {
+			if !isLink([]byte(run.region)) {
+				run.region = ""
+			}
+			if run.region != link {
+				if link != "" {
+					b.WriteString("\x1b]8;;\x1b\\")
+				}
+				if run.region != "" {
+					b.WriteString("\x1b]8;;" + run.region + "\x1b\\")
+				}
+				link = run.region
+			}
+			if run.style != style {
+				b.WriteString(ansiStyle(run.style))
+				style = run.style
+			}
+			b.WriteString(run.text)
+		}
+		if link != "" {
+			b.WriteString("\x1b]8;;\x1b\\")
+		}
+		if style != tcell.StyleDefault {
+			b.WriteString("\x1b[0m")
+		}
+		b.WriteByte('\n')
+	}
+	return b.Bytes()
+}
+
+// ExportHTML returns the text of the text view as an HTML pre element with
+// its colors and attributes translated to inline CSS styles, so that it may be
+// saved or attached to reports. The pre element has the text and background
+// colors of the text view. Hyperlinks are exported as links, other regions as
+// span elements with a data-region attribute, and highlighted regions are
+// exported with the colors of highlights. The text of a LineProvider is
+// exported as well.
+func (t *TextView) ExportHTML() []byte {
+	t.RLock()
+	defer t.RUnlock()
+
+	var b bytes.Buffer
+	b.WriteString("<pre")
+	if css := cssStyle(tcell.StyleDefault.Foreground(t.textColor).Background(t.backgroundColor), 0, 0); css != "" {
+		b.WriteString(` style="` + css + `"`)
+	}
+	b.WriteString(">")
+	for i, line := range t.exportRuns() {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		for _, run := range line {
+			text := html.EscapeString(run.text)
+			if css := cssStyle(run.style, t.textColor, t.backgroundColor); css != "" {
+				text = `<span style="` + css + `">` + text + "</span>"
+			}
+			if isLink([]byte(run.region)) {
+				text = `<a href="` + html.EscapeString(run.region) + `">` + text + "</a>"
+			} else if run.region != "" {
+				text = `<span data-region="` + html.EscapeString(run.region) + `">` + text + "</span>"
+			}
+			b.WriteString(text)
+		}
+	}
+	b.WriteString("</pre>\n")
+	return b.Bytes()
+}
+
+// exportRuns returns the runs of text of each line of the text view, styled
+// as they are drawn, excluding search matches. The lock must be held.
+func (t *TextView) exportRuns() [][]textViewRun {
+	var lines [][]byte
+	if t.provider != nil {
+		for n := 0; n < t.provider.LineCount(); n++ {
+			lines = append(lines, t.provider.Line(n))
+		}
+	} else {
+		lines = t.buffer
+		if len(t.recentBytes) > 0 {
+			lines = append([][]byte(nil), lines...)
+			if len(lines) == 0 {
+				lines = append(lines, nil)
+			}
+			last := lines[len(lines)-1]
+			lines[len(lines)-1] = append(last[:len(last):len(last)], t.recentBytes...)
+		}
+	}
+
+	defaultStyle := tcell.StyleDefault.Foreground(t.textColor).Background(t.backgroundColor)
+	var foregroundColor, backgroundColor, attributes string
+	var regionID []byte
+	runs := make([][]textViewRun, len(lines))
+	for n, text := range lines {
+		colorTagIndices, colorTags, regionIndices, regions, escapeIndices, strippedText, _ := decomposeText(text, t.dynamicColors, t.regions)
+
+		var rangePositions [][]int
+		var rangeStyles []tcell.Style
+		if len(t.styleRanges) > 0 {
+			rangePositions, rangeStyles = t.lineStyleRanges(n, StripTags(text, t.dynamicColors, t.regions))
+		}
+
+		var colorPos, regionPos, escapePos, tagOffset int
+		processTags := func(textPos int, all bool) {
+			for {
+				if colorPos < len(colorTags) && (all || textPos+tagOffset >= colorTagIndices[colorPos][0] && textPos+tagOffset < colorTagIndices[colorPos][1]) {
+					foregroundColor, backgroundColor, attributes = styleFromTag(foregroundColor, backgroundColor, attributes, colorTags[colorPos])
+					tagOffset += colorTagIndices[colorPos][1] - colorTagIndices[colorPos][0]
+					colorPos++
+				} else if regionPos < len(regionIndices) && (all || textPos+tagOffset >= regionIndices[regionPos][0] && textPos+tagOffset < regionIndices[regionPos][1]) {
+					regionID = regions[regionPos][1]
+					tagOffset += regionIndices[regionPos][1] - regionIndices[regionPos][0]
+					regionPos++
+				} else {
+					return
+				}
+			}
+		}
+
+		iterateString(string(strippedText), func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
+			processTags(textPos, false)
+
+			// Skip the second-to-last character of an escape tag.
+			if escapePos < len(escapeIndices) && textPos+tagOffset == escapeIndices[escapePos][1]-2 {
+				tagOffset++
+				escapePos++
+			}
+
+			style := overlayStyle(t.backgroundColor, defaultStyle, foregroundColor, backgroundColor, attributes)
+			if len(regionID) > 0 {
+				if _, ok := t.highlights[string(regionID)]; ok {
+					style = t.highlightStyle(style)
+				}
+			}
+			if isLink(regionID) {
+				style = style.Underline(true)
+			}
+			for i := len(rangePositions) - 1; i >= 0; i-- {
+				if textPos < rangePositions[i][0] || textPos >= rangePositions[i][1] {
+					continue
+				}
+				fg, bg, attr := rangeStyles[i].Decompose()
+				if fg != tcell.ColorDefault {
+					style = style.Foreground(fg)
+				}
+				if bg != tcell.ColorDefault {
+					style = style.Background(bg)
+				}
+				_, _, existing := style.Decompose()
+				style = style.Attributes(existing | attr)
+				break
+			}
+
+			// Omit the default colors.
+			fg, bg, _ := style.Decompose()
+			if fg == t.textColor {
+				style = style.Foreground(tcell.ColorDefault)
+			}
+			if bg == t.backgroundColor {
+				style = style.Background(tcell.ColorDefault)
+			}
+
+			character := string(strippedText[textPos : textPos+textWidth])
+			line := runs[n]
+			if len(line) > 0 && line[len(line)-1].style == style && line[len(line)-1].region == string(regionID) {
+				line[len(line)-1].text += character
+			} else {
+				runs[n] = append(line, textViewRun{
+					text:   character,
+					style:  style,
+					region: string(regionID),
+				})
+			}
+			return false
+		})
+
+		// Tags at the end of the line apply to the following lines.
+		processTags(0, true)
+	}
+	return runs
+}
+
+// ansiStyle returns the ANSI escape sequence which resets the style of the
+// terminal and sets the provided style.
+func ansiStyle(style tcell.Style) string {
+	fg, bg, attributes := style.Decompose()
+
+	codes := []string{"0"}
+	for _, attribute := range []struct {
+		mask tcell.AttrMask
+		code string
+	}{
+		{tcell.AttrBold, "1"},
+		{tcell.AttrDim, "2"},
+		{tcell.AttrItalic, "3"},
+		{tcell.AttrUnderline, "4"},
+		{tcell.AttrBlink, "5"},
+		{tcell.AttrReverse, "7"},
+		{tcell.AttrStrikeThrough, "9"},
+	} {
+		if attributes&attribute.mask != 0 {
+			codes = append(codes, attribute.code)
+		}
+	}
+	if code := ansiColor(fg, 30, 90, "38"); code != "" {
+		codes = append(codes, code)
+	}
+	if code := ansiColor(bg, 40, 100, "48"); code != "" {
+		codes = append(codes, code)
+	}
+	return "\x1b[" + strings.Join(codes, ";") + "m"
+}
+
+// ansiColor returns the SGR parameters which set the provided color, using
+// the provided codes for the first 8 colors, the following 8 bright colors and
+// any other color. Returns an empty string for the default color.
+func ansiColor(c tcell.Color, base, brightBase int, extended string) string {
+	switch {
+	case !c.Valid() || c&tcell.ColorSpecial != 0:
+		return ""
+	case c.IsRGB():
+		r, g, b := c.RGB()
+		return fmt.Sprintf("%s;2;%d;%d;%d", extended, r, g, b)
+	}
+
+	index := int(c - tcell.ColorValid)
+	switch {
+	case index < 8:
+		return strconv.Itoa(base + index)
+	case index < 16:
+		return strconv.Itoa(brightBase + index - 8)
+	default:
+		return fmt.Sprintf("%s;5;%d", extended, index)
+	}
+}
+
+// cssStyle returns the CSS declarations which apply the provided style. The
+// provided default colors are swapped for reversed text without colors.
+func cssStyle(style tcell.Style, defaultForeground, defaultBackground tcell.Color) string {
+	fg, bg, attributes := style.Decompose()
+	if attributes&tcell.AttrReverse != 0 {
+		if fg == tcell.ColorDefault {
+			fg = defaultForeground
+		}
+		if bg == tcell.ColorDefault {
+			bg = defaultBackground
+		}
+		fg, bg = bg, fg
+	}
+
+	var declarations []string
+	if color := ColorHex(fg); color != "" {
+		declarations = append(declarations, "color:"+color)
+	}
+	if color := ColorHex(bg); color != "" {
+		declarations = append(declarations, "background-color:"+color)
+	}
+	if attributes&tcell.AttrBold != 0 {
+		declarations = append(declarations, "font-weight:bold")
+	}
+	if attributes&tcell.AttrDim != 0 {
+		declarations = append(declarations, "opacity:0.5")
+	}
+	if attributes&tcell.AttrItalic != 0 {
+		declarations = append(declarations, "font-style:italic")
+	}
+
+	var decorations []string
+	if attributes&tcell.AttrUnderline != 0 {
+		decorations = append(decorations, "underline")
+	}
+	if attributes&tcell.AttrStrikeThrough != 0 {
+		decorations = append(decorations, "line-through")
+	}
+	if attributes&tcell.AttrBlink != 0 {
+		decorations = append(decorations, "blink")
+	}
+	if len(decorations) > 0 {
+		declarations = append(declarations, "text-decoration:"+strings.Join(decorations, " "))
+	}
+	return strings.Join(declarations, ";")
+}
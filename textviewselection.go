@@ -0,0 +1,314 @@
+package cview
+
+import (
+	"bytes"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// SetSelectable sets a flag which determines whether the user may select text
+// via the keyboard, which does not require mouse support. Pressing
+// Keys.SelectUp, Keys.SelectDown, Keys.SelectLeft or Keys.SelectRight (Shift
+// and the arrow keys by default), Keys.SelectPreviousPage or
+// Keys.SelectNextPage (Shift+PageUp and Shift+PageDown) or Keys.SelectFirst or
+// Keys.SelectLast (Shift+Home and Shift+End) starts a selection at the
+// beginning of the first visible line and moves its end, scrolling the text
+// view as needed. Selected text is drawn using the highlight colors (see
+// SetHighlightForegroundColor). Escape clears the selection. See GetSelection.
+//
+// Text may only be selected while the text view is scrollable and no line
+// provider is set.
+func (t *TextView) SetSelectable(selectable bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.selectable = selectable
+	if !selectable {
+		t.selecting = false
+	}
+}
+
+// GetSelection returns the selected text without color and region tags, or an
+// empty string if no text is selected. Lines are separated by newlines.
+func (t *TextView) GetSelection() string {
+	t.RLock()
+	defer t.RUnlock()
+
+	start, end, ok := t.selectionRange()
+	if !ok {
+		return ""
+	}
+
+	var b bytes.Buffer
+	for line := start.Line; line <= end.Line; line++ {
+		stripped := t.strippedLine(line)
+		from, to := 0, len(stripped)
+		if line == start.Line {
+			from = start.Pos
+		}
+		if line == end.Line {
+			to = end.Pos
+		}
+		if line > start.Line {
+			b.WriteByte('\n')
+		}
+		b.Write(stripped[from:to])
+	}
+	return b.String()
+}
+
+// ClearSelection clears the selection.
+func (t *TextView) ClearSelection() {
+	t.Lock()
+	defer t.Unlock()
+
+	t.selecting = false
+}
+
+// handleSelectionKey starts or extends the selection when one of the
+// selection keys is pressed. Returns whether the event was handled. The lock
+// must be held.
+func (t *TextView) handleSelectionKey(event *tcell.EventKey) bool {
+	if !t.selectable || t.provider != nil || len(t.buffer) == 0 ||
+		!HitShortcut(event, Keys.SelectUp, Keys.SelectDown, Keys.SelectLeft, Keys.SelectRight, Keys.SelectFirst, Keys.SelectLast, Keys.SelectPreviousPage, Keys.SelectNextPage) {
+		return false
+	}
+
+	// Start the selection at the beginning of the first visible line.
+	if !t.selecting {
+		var start textViewPosition
+		if t.index != nil && t.lineOffset >= 0 && t.lineOffset < len(t.index) {
+			index := t.index[t.lineOffset]
+			start = textViewPosition{Line: index.Line, Pos: t.strippedPos(index)}
+		}
+		t.selecting = true
+		t.selectionAnchor, t.selectionCursor = start, start
+	}
+	t.selectionCursor = t.clampPosition(t.selectionCursor)
+
+	pageSize := t.pageSize
+	if pageSize < 1 {
+		pageSize = 1
+	}
+	switch {
+	case HitShortcut(event, Keys.SelectUp):
+		t.moveSelectionRows(-1)
+	case HitShortcut(event, Keys.SelectDown):
+		t.moveSelectionRows(1)
+	case HitShortcut(event, Keys.SelectLeft):
+		t.moveSelectionLeft()
+	case HitShortcut(event, Keys.SelectRight):
+		t.moveSelectionRight()
+	case HitShortcut(event, Keys.SelectPreviousPage):
+		t.moveSelectionRows(-pageSize)
+	case HitShortcut(event, Keys.SelectNextPage):
+		t.moveSelectionRows(pageSize)
+	case HitShortcut(event, Keys.SelectFirst):
+		t.selectionCursor = textViewPosition{}
+	case HitShortcut(event, Keys.SelectLast):
+		line := len(t.buffer) - 1
+		t.selectionCursor = textViewPosition{Line: line, Pos: len(t.strippedLine(line))}
+	}
+	t.scrollToSelection = true
+	return true
+}
+
+// moveSelectionLeft moves the selection cursor to the previous character.
+func (t *TextView) moveSelectionLeft() {
+	p := &t.selectionCursor
+	if p.Pos == 0 {
+		if p.Line > 0 {
+			p.Line--
+			p.Pos = len(t.strippedLine(p.Line))
+		}
+		return
+	}
+	iterateStringReverse(string(t.strippedLine(p.Line)[:p.Pos]), func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
+		p.Pos -= textWidth
+		return true
+	})
+}
+
+// moveSelectionRight moves the selection cursor to the next character.
+func (t *TextView) moveSelectionRight() {
+	p := &t.selectionCursor
+	stripped := t.strippedLine(p.Line)
+	if p.Pos >= len(stripped) {
+		if p.Line < len(t.buffer)-1 {
+			p.Line++
+			p.Pos = 0
+		}
+		return
+	}
+	iterateString(string(stripped[p.Pos:]), func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
+		p.Pos += textWidth
+		return true
+	})
+}
+
+// moveSelectionRows moves the selection cursor by the provided number of
+// lines as they are displayed, keeping its screen column. Lines are separated
+// by newlines when the text has not been indexed yet.
+func (t *TextView) moveSelectionRows(rows int) {
+	p := &t.selectionCursor
+	if t.index == nil {
+		stripped := t.strippedLine(p.Line)
+		column := stringWidth(string(stripped[:p.Pos]))
+		p.Line += rows
+		if p.Line < 0 {
+			p.Line = 0
+		} else if p.Line >= len(t.buffer) {
+			p.Line = len(t.buffer) - 1
+		}
+		p.Pos = positionAtColumn(t.strippedLine(p.Line), column, true)
+		return
+	}
+
+	row := t.selectionRow(*p)
+	if row < 0 {
+		return
+	}
+	stripped := t.strippedLine(p.Line)
+	start, _, _ := t.selectionRowRange(row, stripped)
+	column := stringWidth(string(stripped[start:p.Pos]))
+
+	row += rows
+	if row < 0 {
+		row = 0
+	} else if row >= len(t.index) {
+		row = len(t.index) - 1
+	}
+	p.Line = t.index[row].Line
+	stripped = t.strippedLine(p.Line)
+	start, end, last := t.selectionRowRange(row, stripped)
+	p.Pos = start + positionAtColumn(stripped[start:end], column, last)
+}
+
+// scrollToSelectionCursor scrolls such that the selection cursor is visible.
+func (t *TextView) scrollToSelectionCursor(width, height int) {
+	if !t.selecting {
+		return
+	}
+	p := t.clampPosition(t.selectionCursor)
+	row := t.selectionRow(p)
+	if row < 0 {
+		return
+	}
+
+	t.trackEnd = false
+	if row < t.lineOffset {
+		t.lineOffset = row
+	} else if row >= t.lineOffset+height {
+		t.lineOffset = row - height + 1
+	}
+
+	// Bring the cursor onscreen horizontally.
+	if !t.wrap {
+		stripped := t.strippedLine(p.Line)
+		start, _, _ := t.selectionRowRange(row, stripped)
+		column := stringWidth(string(stripped[start:p.Pos]))
+		if column < t.columnOffset {
+			t.columnOffset = column
+		} else if column >= t.columnOffset+width {
+			t.columnOffset = column - width + 1
+		}
+	}
+}
+
+// selectionRange returns the start and the end of the selected text, and
+// whether any text is selected.
+func (t *TextView) selectionRange() (start, end textViewPosition, ok bool) {
+	if !t.selecting || t.provider != nil {
+		return start, end, false
+	}
+	start, end = t.clampPosition(t.selectionAnchor), t.clampPosition(t.selectionCursor)
+	if end.Line < start.Line || end.Line == start.Line && end.Pos < start.Pos {
+		start, end = end, start
+	}
+	return start, end, start != end
+}
+
+// selectionRow returns the index of the displayed line containing the
+// provided position, or -1 if the text has not been indexed.
+func (t *TextView) selectionRow(p textViewPosition) int {
+	row := -1
+	for i, index := range t.index {
+		if index.Line > p.Line {
+			break
+		} else if index.Line < p.Line {
+			continue
+		}
+		if t.strippedPos(index) <= p.Pos {
+			row = i
+		}
+	}
+	return row
+}
+
+// selectionRowRange returns the (byte) positions in the provided stripped
+// buffer line at which the displayed line with the provided index starts and
+// ends, and whether it is the last displayed line of the buffer line.
+func (t *TextView) selectionRowRange(row int, stripped []byte) (start, end int, last bool) {
+	start = t.strippedPos(t.index[row])
+	if row+1 < len(t.index) && t.index[row+1].Line == t.index[row].Line {
+		return start, t.strippedPos(t.index[row+1]), false
+	}
+	return start, len(stripped), true
+}
+
+// clampPosition returns the provided position, moved into the text.
+func (t *TextView) clampPosition(p textViewPosition) textViewPosition {
+	if len(t.buffer) == 0 || p.Line < 0 {
+		return textViewPosition{}
+	}
+	if p.Line >= len(t.buffer) {
+		p.Line = len(t.buffer) - 1
+		p.Pos = len(t.strippedLine(p.Line))
+	} else if length := len(t.strippedLine(p.Line)); p.Pos > length {
+		p.Pos = length
+	}
+	if p.Pos < 0 {
+		p.Pos = 0
+	}
+	return p
+}
+
+// removeSelectionLines moves the selection along with its lines when the
+// provided number of lines at the start of the buffer were discarded.
+func (t *TextView) removeSelectionLines(removed int) {
+	if !t.selecting {
+		return
+	}
+	t.selectionAnchor.Line -= removed
+	t.selectionCursor.Line -= removed
+	if t.selectionCursor.Line < 0 {
+		t.selecting = false
+	} else if t.selectionAnchor.Line < 0 {
+		t.selectionAnchor = textViewPosition{}
+	}
+}
+
+// strippedLine returns the buffer line with the provided index without tags.
+func (t *TextView) strippedLine(line int) []byte {
+	return StripTags(t.buffer[line], t.dynamicColors, t.regions)
+}
+
+// positionAtColumn returns the (byte) position of the character of the
+// provided text which is drawn at the provided screen column. If the column is
+// beyond the end of the text, the length of the text is returned, or the
+// position of its last character if end is false.
+func positionAtColumn(text []byte, column int, end bool) int {
+	pos, last, found := len(text), -1, false
+	iterateString(string(text), func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
+		if screenPos+screenWidth > column {
+			pos, found = textPos, true
+			return true
+		}
+		last = textPos
+		return false
+	})
+	if !found && !end && last >= 0 {
+		pos = last
+	}
+	return pos
+}
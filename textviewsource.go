@@ -0,0 +1,205 @@
+package cview
+
+import (
+	"io"
+	"time"
+)
+
+const (
+	// The size of the chunks read from the source of a TextView.
+	textViewSourceChunkSize = 32 * 1024
+
+	// The number of chunks read ahead of the chunks written to a TextView.
+	// Reading from the source pauses while this many chunks are pending.
+	textViewSourceChunks = 16
+
+	// The number of bytes after which pending text is written to a TextView
+	// before the next interval.
+	textViewSourceBatchSize = 256 * 1024
+
+	// The interval at which pending text is written to a TextView.
+	textViewSourceInterval = 50 * time.Millisecond
+)
+
+// textViewSource is a reader text is streamed from.
+type textViewSource struct {
+	// Closed when the source is replaced or removed.
+	stop chan struct{}
+}
+
+// ReadFrom reads text from the provided reader until EOF or an error occurs
+// and writes it to the text view, implementing the io.ReaderFrom interface.
+// Returns the number of bytes read and any error other than io.EOF. ReadFrom
+// blocks until the reader is exhausted, so it should not be called from the
+// main goroutine. See SetSource for streaming text in the background.
+func (t *TextView) ReadFrom(r io.Reader) (n int64, err error) {
+	var read int
+	buf := make([]byte, textViewSourceChunkSize)
+	for {
+		read, err = r.Read(buf)
+		if read > 0 {
+			n += int64(read)
+			t.Write(buf[:read])
+		}
+		if err == io.EOF {
+			return n, nil
+		} else if err != nil {
+			return n, err
+		}
+	}
+}
+
+// SetSource streams text from the provided reader into the text view in the
+// background, such as the standard output of a subprocess:
+//
+//   cmd := exec.Command("make")
+//   stdout, _ := cmd.StdoutPipe()
+//   cmd.Start()
+//   textView.SetSource(app, stdout, func(err error) {
+//     cmd.Wait()
+//   })
+//
+// Text is written in batches via Application.QueueUpdateDraw at most every
+// 50 milliseconds, so that fast sources do not flood the event loop. Reading
+// pauses while the application has not caught up with the text read so far,
+// which in turn blocks writers at the other end of pipes. When app is nil,
+// text is written directly without drawing the screen.
+//
+// The done handler, if any, is called once the reader is exhausted, with nil
+// on EOF or the error returned by the reader otherwise. It is called on the
+// main goroutine of the application. Setting another source or a nil reader
+// stops streaming from the previous source without calling its done handler.
+// Readers are not closed.
+func (t *TextView) SetSource(app *Application, r io.Reader, done func(err error)) {
+	t.Lock()
+	if t.source != nil {
+		close(t.source.stop)
+		t.source = nil
+	}
+	if r == nil {
+		t.Unlock()
+		return
+	}
+	s := &textViewSource{
+		stop: make(chan struct{}),
+	}
+	t.source = s
+	t.Unlock()
+
+	chunks := make(chan []byte, textViewSourceChunks)
+	errs := make(chan error, 1)
+	go s.read(r, chunks, errs)
+	go t.streamSource(app, s, chunks, errs, done)
+}
+
+// read reads chunks from the provided reader until it is exhausted or the
+// source is stopped. The error returned by the reader, or nil on EOF, is sent
+// before the chunks channel is closed.
+func (s *textViewSource) read(r io.Reader, chunks chan<- []byte, errs chan<- error) {
+	defer close(chunks)
+
+	for {
+		buf := make([]byte, textViewSourceChunkSize)
+		n, err := r.Read(buf)
+		if n > 0 {
+			select {
+			case chunks <- buf[:n]:
+			case <-s.stop:
+				return
+			}
+		}
+		if err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			errs <- err
+			return
+		}
+	}
+}
+
+// streamSource writes the chunks read from a source to the text view in
+// batches and calls the done handler once the source is exhausted.
+func (t *TextView) streamSource(app *Application, s *textViewSource, chunks <-chan []byte, errs <-chan error, done func(err error)) {
+	ticker := time.NewTicker(textViewSourceInterval)
+	defer ticker.Stop()
+
+	var pending []byte
+	for {
+		select {
+		case <-s.stop:
+			return
+		case chunk, ok := <-chunks:
+			if !ok {
+				if !t.writeSource(app, s, pending) {
+					return
+				}
+				t.finishSource(app, s, <-errs, done)
+				return
+			}
+			pending = append(pending, chunk...)
+			if len(pending) < textViewSourceBatchSize {
+				continue
+			}
+		case <-ticker.C:
+			if len(pending) == 0 {
+				continue
+			}
+		}
+
+		if !t.writeSource(app, s, pending) {
+			return
+		}
+		pending = nil
+	}
+}
+
+// writeSource writes text read from a source to the text view and waits until
+// it has been written. Returns false if the source was stopped.
+func (t *TextView) writeSource(app *Application, s *textViewSource, p []byte) bool {
+	if len(p) == 0 {
+		return true
+	} else if app == nil {
+		t.Write(p)
+		return true
+	}
+
+	written := make(chan struct{})
+	app.QueueUpdateDraw(func() {
+		defer close(written)
+
+		select {
+		case <-s.stop:
+		default:
+			t.Write(p)
+		}
+	})
+
+	select {
+	case <-written:
+		return true
+	case <-s.stop:
+		return false
+	}
+}
+
+// finishSource removes an exhausted source and calls its done handler, unless
+// the source was stopped.
+func (t *TextView) finishSource(app *Application, s *textViewSource, err error, done func(err error)) {
+	t.Lock()
+	current := t.source == s
+	if current {
+		t.source = nil
+	}
+	t.Unlock()
+
+	if !current || done == nil {
+		return
+	} else if app == nil {
+		done(err)
+		return
+	}
+	app.QueueUpdate(func() {
+		done(err)
+	})
+}
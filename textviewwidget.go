@@ -0,0 +1,486 @@
+package cview
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// DefaultWidgetInterval is the default interval at which the widgets of a
+// TextView are updated.
+const DefaultWidgetInterval = 100 * time.Millisecond
+
+// DefaultSpinnerFrames are the default frames of a SpinnerWidget.
+var DefaultSpinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+var (
+	// widgetPattern matches widget placeholders.
+	widgetPattern = regexp.MustCompile(`\[widget:([a-zA-Z0-9_\-\.]+)\]`)
+
+	// openWidgetPattern matches a trailing incomplete widget placeholder.
+	openWidgetPattern = regexp.MustCompile(`\[(w(i(d(g(e(t(:[a-zA-Z0-9_\-\.]*)?)?)?)?)?)?)?$`)
+)
+
+// TextViewWidget is a live widget which is displayed in the text of a
+// TextView in place of a placeholder. See TextView.SetWidget.
+type TextViewWidget interface {
+	// Tick is called at the widget interval of the text view and may be
+	// used to advance animations.
+	Tick()
+
+	// Text returns the text currently displayed by the widget. Color and
+	// region tags are not processed.
+	Text() string
+}
+
+// textViewWidgetSpan is a span of a buffer line of a TextView which displays
+// the text of a widget.
+type textViewWidgetSpan struct {
+	// The name of the widget.
+	name string
+
+	// The buffer line and the (byte) positions in the line at which the span
+	// starts and ends.
+	line, from, to int
+}
+
+// SpinnerWidget is a TextViewWidget which displays an animated spinner. When
+// stopped, it displays a final text instead, e.g. a check mark.
+type SpinnerWidget struct {
+	// The frames of the animation.
+	frames []string
+
+	// The current frame.
+	frame int
+
+	// Whether the spinner is stopped and the text displayed while stopped.
+	stopped bool
+	done    string
+
+	sync.RWMutex
+}
+
+// NewSpinnerWidget returns a new spinner using DefaultSpinnerFrames.
+func NewSpinnerWidget() *SpinnerWidget {
+	return &SpinnerWidget{
+		frames: DefaultSpinnerFrames,
+	}
+}
+
+// SetFrames sets the frames of the animation.
+func (s *SpinnerWidget) SetFrames(frames []string) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.frames = frames
+	s.frame = 0
+}
+
+// Stop stops the animation and displays the provided text instead.
+func (s *SpinnerWidget) Stop(done string) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.stopped, s.done = true, done
+}
+
+// Start restarts the animation after it was stopped.
+func (s *SpinnerWidget) Start() {
+	s.Lock()
+	defer s.Unlock()
+
+	s.stopped = false
+}
+
+// Tick advances the animation by one frame.
+func (s *SpinnerWidget) Tick() {
+	s.Lock()
+	defer s.Unlock()
+
+	if !s.stopped && len(s.frames) > 0 {
+		s.frame = (s.frame + 1) % len(s.frames)
+	}
+}
+
+// Text returns the current frame of the animation.
+func (s *SpinnerWidget) Text() string {
+	s.RLock()
+	defer s.RUnlock()
+
+	if s.stopped {
+		return s.done
+	} else if len(s.frames) == 0 {
+		return ""
+	}
+	return s.frames[s.frame%len(s.frames)]
+}
+
+// ProgressWidget is a TextViewWidget which displays a progress bar of a fixed
+// width followed by the percentage of progress, e.g. "███░░░░░░░  30%".
+type ProgressWidget struct {
+	// The width of the bar.
+	width int
+
+	// The progress and the maximum progress.
+	progress, max int
+
+	sync.RWMutex
+}
+
+// NewProgressWidget returns a new progress bar with the provided width and a
+// maximum progress of 100.
+func NewProgressWidget(width int) *ProgressWidget {
+	return &ProgressWidget{
+		width: width,
+		max:   100,
+	}
+}
+
+// SetProgress sets the progress.
+func (p *ProgressWidget) SetProgress(progress int) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.progress = progress
+}
+
+// AddProgress adds to the progress.
+func (p *ProgressWidget) AddProgress(progress int) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.progress += progress
+}
+
+// GetProgress returns the progress.
+func (p *ProgressWidget) GetProgress() int {
+	p.RLock()
+	defer p.RUnlock()
+
+	return p.progress
+}
+
+// SetMax sets the maximum progress.
+func (p *ProgressWidget) SetMax(max int) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.max = max
+}
+
+// Tick does nothing. The progress bar is updated when its progress changes.
+func (p *ProgressWidget) Tick() {
+}
+
+// Text returns the progress bar.
+func (p *ProgressWidget) Text() string {
+	p.RLock()
+	defer p.RUnlock()
+
+	var fraction float64
+	if p.max > 0 {
+		fraction = float64(p.progress) / float64(p.max)
+	}
+	if fraction < 0 {
+		fraction = 0
+	} else if fraction > 1 {
+		fraction = 1
+	}
+	filled := int(fraction * float64(p.width))
+	return strings.Repeat(string(tcell.RuneBlock), filled) + strings.Repeat(string(tcell.RuneBoard), p.width-filled) + fmt.Sprintf(" %3d%%", int(fraction*100))
+}
+
+// CounterWidget is a TextViewWidget which displays a number, e.g. the number
+// of files downloaded so far.
+type CounterWidget struct {
+	// The format of the number (see fmt.Sprintf).
+	format string
+
+	// The current value.
+	value int
+
+	sync.RWMutex
+}
+
+// NewCounterWidget returns a new counter which formats its value using the
+// provided format, e.g. "%d files".
+func NewCounterWidget(format string) *CounterWidget {
+	return &CounterWidget{
+		format: format,
+	}
+}
+
+// SetValue sets the value of the counter.
+func (c *CounterWidget) SetValue(value int) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.value = value
+}
+
+// Add adds to the value of the counter.
+func (c *CounterWidget) Add(delta int) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.value += delta
+}
+
+// GetValue returns the value of the counter.
+func (c *CounterWidget) GetValue() int {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.value
+}
+
+// Tick does nothing. The counter is updated when its value changes.
+func (c *CounterWidget) Tick() {
+}
+
+// Text returns the formatted value.
+func (c *CounterWidget) Text() string {
+	c.RLock()
+	defer c.RUnlock()
+
+	return fmt.Sprintf(c.format, c.value)
+}
+
+// SetWidget registers a live widget which is displayed in place of the
+// placeholder "[widget:name]" in text written to the text view afterwards,
+// e.g. to show spinners and progress bars in streaming status output:
+//
+//   spinner := cview.NewSpinnerWidget()
+//   textView.SetWidget("download", spinner)
+//   fmt.Fprintln(textView, "[widget:download] Downloading packages")
+//
+// Widgets are updated at the interval set via SetWidgetInterval, which calls
+// their Tick function, and the handler set via SetChangedFunc is called when
+// the text of any widget changes. Placeholders are replaced when text is
+// written to the text view, so widgets must be registered beforehand.
+// Placeholders of unregistered widgets are left in the text unchanged. Text
+// inserted via ReplaceRange is not searched for placeholders.
+//
+// Providing a nil widget unregisters the widget. Its placeholders keep
+// displaying its last text.
+//
+// Each text view updates its widgets in its own goroutine, which is started
+// when the first widget is registered and exits once all widgets are
+// unregistered. Unregister all widgets of a text view which is no longer
+// used, so that its goroutine exits.
+func (t *TextView) SetWidget(name string, widget TextViewWidget) {
+	t.Lock()
+	defer t.Unlock()
+
+	if widget == nil {
+		delete(t.widgets, name)
+		for i := len(t.widgetSpans) - 1; i >= 0; i-- {
+			if t.widgetSpans[i].name == name {
+				t.widgetSpans = append(t.widgetSpans[:i], t.widgetSpans[i+1:]...)
+			}
+		}
+		if len(t.widgets) == 0 && t.widgetStop != nil {
+			close(t.widgetStop)
+			t.widgetStop = nil
+		}
+		return
+	}
+
+	if t.widgets == nil {
+		t.widgets = make(map[string]TextViewWidget)
+	}
+	t.widgets[name] = widget
+	if t.widgetStop == nil {
+		t.widgetStop = make(chan struct{})
+		go t.runWidgets(t.widgetInterval, t.widgetStop)
+	}
+}
+
+// SetWidgetInterval sets the interval at which widgets are updated. The
+// default is DefaultWidgetInterval.
+func (t *TextView) SetWidgetInterval(interval time.Duration) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.widgetInterval = interval
+	if t.widgetStop != nil {
+		close(t.widgetStop)
+		t.widgetStop = make(chan struct{})
+		go t.runWidgets(t.widgetInterval, t.widgetStop)
+	}
+}
+
+// runWidgets updates the widgets until the provided channel is closed.
+func (t *TextView) runWidgets(interval time.Duration, stop chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			t.tickWidgets()
+		}
+	}
+}
+
+// tickWidgets advances the widgets and updates the text they display.
+func (t *TextView) tickWidgets() {
+	t.RLock()
+	widgets := make([]TextViewWidget, 0, len(t.widgets))
+	for _, widget := range t.widgets {
+		widgets = append(widgets, widget)
+	}
+	t.RUnlock()
+
+	for _, widget := range widgets {
+		widget.Tick()
+	}
+
+	t.Lock()
+	updated := t.updateWidgets()
+	changed := t.changed
+	t.Unlock()
+
+	if updated && changed != nil {
+		changed()
+	}
+}
+
+// updateWidgets replaces the text of all widget spans with the current text
+// of their widgets. Returns whether any text changed.
+func (t *TextView) updateWidgets() bool {
+	var updated bool
+	for i, span := range t.widgetSpans {
+		widget, ok := t.widgets[span.name]
+		if !ok || span.line >= len(t.buffer) {
+			continue
+		}
+		text := t.widgetText(widget)
+		line := t.buffer[span.line]
+		if bytes.Equal(line[span.from:span.to], text) {
+			continue
+		}
+
+		// Replace the span in a new line as the buffer may be shared with a
+		// background indexer.
+		replaced := make([]byte, 0, len(line)-(span.to-span.from)+len(text))
+		replaced = append(replaced, line[:span.from]...)
+		replaced = append(replaced, text...)
+		replaced = append(replaced, line[span.to:]...)
+		t.buffer[span.line] = replaced
+
+		// Move the following spans of the line.
+		delta := len(text) - (span.to - span.from)
+		for _, s := range t.widgetSpans[i+1:] {
+			if s.line == span.line && s.from >= span.to {
+				s.from += delta
+				s.to += delta
+			}
+		}
+		span.to = span.from + len(text)
+		updated = true
+	}
+
+	if updated {
+		t.matchesValid = false
+		if t.reindex {
+			t.invalidateIndex()
+		}
+	}
+	return updated
+}
+
+// widgetText returns the text of the provided widget, escaped such that it is
+// not interpreted as color or region tags.
+func (t *TextView) widgetText(widget TextViewWidget) []byte {
+	text := []byte(widget.Text())
+	if t.dynamicColors || t.regions {
+		text = EscapeBytes(text)
+	}
+	return bytes.ReplaceAll(text, []byte("\n"), []byte(" "))
+}
+
+// replaceWidgetPlaceholders replaces the placeholders of registered widgets
+// in the provided line, which starts at the provided (byte) position of the
+// provided buffer line, with the text of the widgets. The replaced spans are
+// added to the widget spans.
+func (t *TextView) replaceWidgetPlaceholders(text []byte, line, offset int) []byte {
+	if len(t.widgets) == 0 {
+		return text
+	}
+
+	var replaced []byte
+	var from int
+	for _, match := range widgetPattern.FindAllSubmatchIndex(text, -1) {
+		name := string(text[match[2]:match[3]])
+		widget, ok := t.widgets[name]
+		if !ok {
+			continue
+		}
+		replaced = append(replaced, text[from:match[0]]...)
+		widgetText := t.widgetText(widget)
+		t.widgetSpans = append(t.widgetSpans, &textViewWidgetSpan{
+			name: name,
+			line: line,
+			from: offset + len(replaced),
+			to:   offset + len(replaced) + len(widgetText),
+		})
+		replaced = append(replaced, widgetText...)
+		from = match[1]
+	}
+	if replaced == nil {
+		return text
+	}
+	return append(replaced, text[from:]...)
+}
+
+// removeWidgetLines moves the widget spans along with their lines when the
+// provided number of lines at the start of the buffer were discarded.
+func (t *TextView) removeWidgetLines(removed int) {
+	spans := t.widgetSpans[:0]
+	for _, span := range t.widgetSpans {
+		if span.line >= removed {
+			span.line -= removed
+			spans = append(spans, span)
+		}
+	}
+	for i := len(spans); i < len(t.widgetSpans); i++ {
+		t.widgetSpans[i] = nil
+	}
+	t.widgetSpans = spans
+}
+
+// replaceWidgetLines updates the widget spans after the text between the
+// provided positions was replaced by the provided lines. Spans within the
+// replaced text are removed.
+func (t *TextView) replaceWidgetLines(startLine, from, endLine, to int, endLength int, lines [][]byte) {
+	last := startLine + len(lines) - 1
+	tail := len(lines[len(lines)-1]) - (endLength - to)
+	delta := len(lines) - (endLine - startLine + 1)
+	spans := t.widgetSpans[:0]
+	for _, span := range t.widgetSpans {
+		switch {
+		case span.line < startLine || span.line == startLine && span.to <= from:
+		case span.line > endLine:
+			span.line += delta
+		case span.line == endLine && span.from >= to:
+			span.line = last
+			span.from += tail - to
+			span.to += tail - to
+		default:
+			continue
+		}
+		spans = append(spans, span)
+	}
+	for i := len(spans); i < len(t.widgetSpans); i++ {
+		t.widgetSpans[i] = nil
+	}
+	t.widgetSpans = spans
+}
@@ -0,0 +1,343 @@
+package cview
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Timer displays the time elapsed since it was started (stopwatch) or the
+// time remaining until it expires (countdown). While running, the timer
+// updates itself at a configurable interval and calls its changed handler,
+// which may be used to redraw the application.
+type Timer struct {
+	*Box
+
+	// The countdown duration. When zero, the timer counts up.
+	countdown time.Duration
+
+	// The time accumulated before the timer was last started.
+	elapsed time.Duration
+
+	// The time at which the timer was last started.
+	started time.Time
+
+	// Whether the timer is running.
+	running bool
+
+	// Whether the countdown has expired.
+	expired bool
+
+	// The interval at which the timer updates while running.
+	interval time.Duration
+
+	// Closed to stop the update goroutine.
+	stop chan struct{}
+
+	// The text alignment, one of AlignLeft, AlignCenter, or AlignRight.
+	align int
+
+	// The text color.
+	textColor tcell.Color
+
+	// The text color once the countdown has expired.
+	expiredTextColor tcell.Color
+
+	// An optional function which formats the displayed duration.
+	format func(d time.Duration) string
+
+	// An optional function which is called each time the timer updates.
+	changed func()
+
+	// An optional function which is called when the countdown expires.
+	expiredFunc func()
+
+	sync.RWMutex
+}
+
+// NewTimer returns a new timer. The timer counts up from zero until a
+// countdown is set via SetCountdown.
+func NewTimer() *Timer {
+	return &Timer{
+		Box:              NewBox(),
+		interval:         time.Second,
+		align:            AlignLeft,
+		textColor:        Styles.PrimaryTextColor,
+		expiredTextColor: Styles.TertiaryTextColor,
+	}
+}
+
+// SetCountdown sets the duration the timer counts down from. When set to
+// zero, the timer counts up instead.
+func (t *Timer) SetCountdown(d time.Duration) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.countdown = d
+	t.expired = false
+}
+
+// GetCountdown returns the duration the timer counts down from, or zero when
+// the timer counts up.
+func (t *Timer) GetCountdown() time.Duration {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.countdown
+}
+
+// SetInterval sets the interval at which the timer updates while running.
+func (t *Timer) SetInterval(interval time.Duration) {
+	t.Lock()
+	defer t.Unlock()
+
+	if interval <= 0 {
+		interval = time.Second
+	}
+	t.interval = interval
+}
+
+// SetTextAlign sets the text alignment of the timer. This must be either
+// AlignLeft, AlignCenter, or AlignRight.
+func (t *Timer) SetTextAlign(align int) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.align = align
+}
+
+// SetTextColor sets the color of the timer text.
+func (t *Timer) SetTextColor(color tcell.Color) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.textColor = color
+}
+
+// SetExpiredTextColor sets the color of the timer text once the countdown
+// has expired.
+func (t *Timer) SetExpiredTextColor(color tcell.Color) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.expiredTextColor = color
+}
+
+// SetFormatFunc sets a handler which formats the displayed duration. The
+// default format is HH:MM:SS.
+func (t *Timer) SetFormatFunc(handler func(d time.Duration) string) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.format = handler
+}
+
+// SetChangedFunc sets a handler which is called each time the timer updates
+// while running. The handler is called from a separate goroutine, so it
+// should typically queue a redraw:
+//
+//   timer.SetChangedFunc(func() {
+//       app.QueueUpdateDraw(func() {}, timer)
+//   })
+func (t *Timer) SetChangedFunc(handler func()) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.changed = handler
+}
+
+// SetExpiredFunc sets a handler which is called when the countdown expires.
+// The handler is called from a separate goroutine.
+func (t *Timer) SetExpiredFunc(handler func()) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.expiredFunc = handler
+}
+
+// Start starts or resumes the timer.
+func (t *Timer) Start() {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.running || t.expired {
+		return
+	}
+
+	t.started = time.Now()
+	t.running = true
+	t.stop = make(chan struct{})
+	go t.run(t.interval, t.stop)
+}
+
+// Stop pauses the timer. The elapsed time is retained until Reset is called.
+func (t *Timer) Stop() {
+	t.Lock()
+	defer t.Unlock()
+
+	t.pause()
+}
+
+// Reset resets the elapsed time to zero. A running timer keeps running.
+func (t *Timer) Reset() {
+	t.Lock()
+	defer t.Unlock()
+
+	t.elapsed = 0
+	t.expired = false
+	t.started = time.Now()
+}
+
+// IsRunning returns whether the timer is running.
+func (t *Timer) IsRunning() bool {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.running
+}
+
+// IsExpired returns whether the countdown has expired.
+func (t *Timer) IsExpired() bool {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.expired
+}
+
+// GetElapsed returns the time elapsed while the timer was running.
+func (t *Timer) GetElapsed() time.Duration {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.getElapsed()
+}
+
+// GetRemaining returns the time remaining until the countdown expires, or
+// zero when the timer counts up.
+func (t *Timer) GetRemaining() time.Duration {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.getRemaining()
+}
+
+func (t *Timer) getElapsed() time.Duration {
+	elapsed := t.elapsed
+	if t.running {
+		elapsed += time.Since(t.started)
+	}
+	if t.countdown > 0 && elapsed > t.countdown {
+		elapsed = t.countdown
+	}
+	return elapsed
+}
+
+func (t *Timer) getRemaining() time.Duration {
+	if t.countdown <= 0 {
+		return 0
+	}
+	return t.countdown - t.getElapsed()
+}
+
+// pause stops the update goroutine and accumulates the elapsed time.
+func (t *Timer) pause() {
+	if !t.running {
+		return
+	}
+
+	t.elapsed = t.getElapsed()
+	t.running = false
+	close(t.stop)
+	t.stop = nil
+}
+
+// run updates the timer until it is stopped.
+func (t *Timer) run(interval time.Duration, stop chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if !t.tick() {
+				return
+			}
+		}
+	}
+}
+
+// tick is called by the update goroutine. It returns false when the timer
+// has expired.
+func (t *Timer) tick() bool {
+	t.Lock()
+	expired := t.countdown > 0 && !t.expired && t.getElapsed() >= t.countdown
+	if expired {
+		t.pause()
+		t.expired = true
+	}
+	changed := t.changed
+	expiredFunc := t.expiredFunc
+	t.Unlock()
+
+	if changed != nil {
+		changed()
+	}
+	if expired && expiredFunc != nil {
+		expiredFunc()
+	}
+	return !expired
+}
+
+// Draw draws this primitive onto the screen.
+func (t *Timer) Draw(screen tcell.Screen) {
+	if !t.GetVisible() {
+		return
+	}
+
+	t.Box.Draw(screen)
+
+	t.Lock()
+	defer t.Unlock()
+
+	x, y, width, height := t.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	d := t.getElapsed()
+	if t.countdown > 0 {
+		d = t.getRemaining()
+	}
+
+	var text string
+	if t.format != nil {
+		text = t.format(d)
+	} else {
+		text = formatTimerDuration(d)
+	}
+
+	color := t.textColor
+	if t.expired {
+		color = t.expiredTextColor
+	}
+
+	Print(screen, []byte(text), x, y, width, t.align, color)
+}
+
+// formatTimerDuration formats a duration as HH:MM:SS.
+func formatTimerDuration(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	d = d.Round(time.Second)
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+	d -= minutes * time.Minute
+	seconds := d / time.Second
+	return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
+}
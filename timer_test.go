@@ -0,0 +1,91 @@
+package cview
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimer(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	tm := NewTimer()
+	if tm.IsRunning() {
+		t.Errorf("failed to initialize Timer: incorrect initial state: expected stopped, got running")
+	} else if tm.GetElapsed() != 0 {
+		t.Errorf("failed to initialize Timer: incorrect initial state: expected 0 elapsed, got %s", tm.GetElapsed())
+	}
+
+	// Start and stop
+
+	tm.Start()
+	if !tm.IsRunning() {
+		t.Errorf("failed to start Timer: expected running, got stopped")
+	}
+	time.Sleep(10 * time.Millisecond)
+	tm.Stop()
+	elapsed := tm.GetElapsed()
+	if tm.IsRunning() {
+		t.Errorf("failed to stop Timer: expected stopped, got running")
+	} else if elapsed <= 0 {
+		t.Errorf("failed to update Timer: expected positive elapsed time, got %s", elapsed)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if tm.GetElapsed() != elapsed {
+		t.Errorf("failed to stop Timer: expected %s elapsed, got %s", elapsed, tm.GetElapsed())
+	}
+
+	// Reset
+
+	tm.Reset()
+	if tm.GetElapsed() != 0 {
+		t.Errorf("failed to reset Timer: expected 0 elapsed, got %s", tm.GetElapsed())
+	}
+
+	// Countdown
+
+	expired := make(chan struct{})
+	tm.SetCountdown(10 * time.Millisecond)
+	tm.SetInterval(time.Millisecond)
+	tm.SetExpiredFunc(func() {
+		close(expired)
+	})
+	tm.Start()
+	select {
+	case <-expired:
+	case <-time.After(time.Second):
+		t.Fatalf("failed to expire Timer: expired handler was not called")
+	}
+	if !tm.IsExpired() {
+		t.Errorf("failed to expire Timer: expected expired, got not expired")
+	} else if tm.IsRunning() {
+		t.Errorf("failed to expire Timer: expected stopped, got running")
+	} else if tm.GetRemaining() != 0 {
+		t.Errorf("failed to expire Timer: expected 0 remaining, got %s", tm.GetRemaining())
+	}
+
+	// Draw
+
+	app, err := newTestApp(tm)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	tm.Draw(app.screen)
+}
+
+func TestFormatTimerDuration(t *testing.T) {
+	t.Parallel()
+
+	for d, expected := range map[time.Duration]string{
+		0:                "00:00:00",
+		-time.Second:     "00:00:00",
+		59 * time.Second: "00:00:59",
+		time.Hour + 2*time.Minute + 3*time.Second: "01:02:03",
+	} {
+		if got := formatTimerDuration(d); got != expected {
+			t.Errorf("failed to format duration %s: expected %s, got %s", d, expected, got)
+		}
+	}
+}
@@ -15,6 +15,7 @@ const (
 	treeDown
 	treePageUp
 	treePageDown
+	treeJump
 )
 
 // TreeNode represents one node in a tree view.
@@ -31,6 +32,11 @@ type TreeNode struct {
 	// The text color.
 	color tcell.Color
 
+	// A short text (e.g. a glyph) shown before the node's text, and its color
+	// (ColorUnset to use the node's text color).
+	icon      string
+	iconColor tcell.Color
+
 	// Whether or not this node can be focused and selected.
 	selectable bool
 
@@ -40,6 +46,18 @@ type TreeNode struct {
 	// The additional horizontal indent of this node's text.
 	indent int
 
+	// A text shown at the right edge of the node's row.
+	secondaryText string
+
+	// A short text shown to the left of the secondary text, and its color
+	// (ColorUnset to use the tree view's badge color).
+	badge      string
+	badgeColor tcell.Color
+
+	// The progress shown as a bar to the left of the secondary text, and the
+	// progress required to fill the bar (0 = no bar).
+	progress, progressMax int
+
 	// An optional function which is called when the user focuses this node.
 	focused func()
 
@@ -63,6 +81,8 @@ func NewTreeNode(text string) *TreeNode {
 		indent:     2,
 		expanded:   true,
 		selectable: true,
+		badgeColor: ColorUnset,
+		iconColor:  ColorUnset,
 	}
 }
 
@@ -257,6 +277,101 @@ func (n *TreeNode) SetColor(color tcell.Color) {
 	n.color = color
 }
 
+// SetIcon sets a short text, such as a glyph representing the type of a file,
+// which is shown before the node's text. The icon is not part of the node's
+// text, so it is ignored e.g. when jumping to a letter. See
+// TreeView.SetIconWidth.
+func (n *TreeNode) SetIcon(icon string) {
+	n.Lock()
+	defer n.Unlock()
+
+	n.icon = icon
+}
+
+// GetIcon returns the node's icon.
+func (n *TreeNode) GetIcon() string {
+	n.RLock()
+	defer n.RUnlock()
+
+	return n.icon
+}
+
+// SetIconColor sets the color of the node's icon. When set to ColorUnset, the
+// node's text color is used.
+func (n *TreeNode) SetIconColor(color tcell.Color) {
+	n.Lock()
+	defer n.Unlock()
+
+	n.iconColor = color
+}
+
+// SetSecondaryText sets a text shown at the right edge of the node's row, such
+// as a size or a status.
+func (n *TreeNode) SetSecondaryText(text string) {
+	n.Lock()
+	defer n.Unlock()
+
+	n.secondaryText = text
+}
+
+// GetSecondaryText returns the node's secondary text.
+func (n *TreeNode) GetSecondaryText() string {
+	n.RLock()
+	defer n.RUnlock()
+
+	return n.secondaryText
+}
+
+// SetBadge sets a short text (e.g. a count of pending changes) shown to the
+// left of the node's secondary text and progress bar.
+func (n *TreeNode) SetBadge(badge string) {
+	n.Lock()
+	defer n.Unlock()
+
+	n.badge = badge
+}
+
+// GetBadge returns the node's badge text.
+func (n *TreeNode) GetBadge() string {
+	n.RLock()
+	defer n.RUnlock()
+
+	return n.badge
+}
+
+// SetBadgeColor sets the color of the node's badge text. When set to
+// ColorUnset, the tree view's badge color is used.
+func (n *TreeNode) SetBadgeColor(color tcell.Color) {
+	n.Lock()
+	defer n.Unlock()
+
+	n.badgeColor = color
+}
+
+// SetProgress sets the progress shown as a small bar to the left of the node's
+// secondary text, and the progress required to fill the bar. A maximum of 0
+// hides the bar.
+func (n *TreeNode) SetProgress(progress, max int) {
+	n.Lock()
+	defer n.Unlock()
+
+	if progress < 0 {
+		progress = 0
+	} else if progress > max {
+		progress = max
+	}
+	n.progress, n.progressMax = progress, max
+}
+
+// GetProgress returns the node's progress and the progress required to fill
+// its bar.
+func (n *TreeNode) GetProgress() (progress, max int) {
+	n.RLock()
+	defer n.RUnlock()
+
+	return n.progress, n.progressMax
+}
+
 // SetIndent sets an additional indentation for this node's text. A value of 0
 // keeps the text as far left as possible with a minimum of line graphics. Any
 // value greater than that moves the text to the right.
@@ -310,6 +425,15 @@ type TreeView struct {
 	// constants defined above.
 	movement int
 
+	// The letter to jump to when the movement is treeJump.
+	jumpLetter rune
+
+	// If true, typing a letter selects the next node starting with it.
+	jumpToLetter bool
+
+	// If true, jumping to a letter ignores the case of node texts.
+	jumpToLetterIgnoreCase bool
+
 	// The top hierarchical level shown. (0 corresponds to the root level.)
 	topLevel int
 
@@ -334,6 +458,22 @@ type TreeView struct {
 	// The color of the lines.
 	graphicsColor tcell.Color
 
+	// The text color of nodes with the default text color.
+	textColor tcell.Color
+
+	// The colors of node secondary texts, badges and progress bars.
+	secondaryTextColor tcell.Color
+	badgeColor         tcell.Color
+	progressColor      tcell.Color
+
+	// The width of node progress bars.
+	progressWidth int
+
+	// The widths of the columns reserved for node icons and badges (0 = the
+	// width of each icon or badge).
+	iconWidth  int
+	badgeWidth int
+
 	// Visibility of the scroll bar.
 	scrollBarVisibility ScrollBarVisibility
 
@@ -349,6 +489,33 @@ type TreeView struct {
 	// An optional function called when the user moves away from this primitive.
 	done func(key tcell.Key)
 
+	// An optional function which determines the nodes which are shown.
+	filter func(node *TreeNode) bool
+
+	// The nodes which match the filter or have descendants which match it,
+	// as set by process(), or nil if there is no filter.
+	filtered map[*TreeNode]bool
+
+	// Whether the user may edit the text of the current node via
+	// Keys.EditItem.
+	nodesEditable bool
+
+	// Optional functions which are called when the text of a node is edited:
+	// to validate the new text, before the new text is set (returning whether
+	// it is set) and when editing is canceled.
+	nodeValidate     func(node *TreeNode, text string) error
+	nodeEdited       func(node *TreeNode, text string) bool
+	nodeEditCanceled func(node *TreeNode)
+
+	// The input field the text of a node is edited in and the node being
+	// edited, or nil when no node is being edited.
+	editor   *InputField
+	editNode *TreeNode
+
+	// The node which is scrolled into view the next time the tree is
+	// processed, or nil.
+	reveal *TreeNode
+
 	// The visible nodes, top-down, as set by process().
 	nodes []*TreeNode
 
@@ -358,14 +525,78 @@ type TreeView struct {
 // NewTreeView returns a new tree view.
 func NewTreeView() *TreeView {
 	return &TreeView{
-		Box:                 NewBox(),
-		scrollBarVisibility: ScrollBarAuto,
-		graphics:            true,
-		graphicsColor:       Styles.GraphicsColor,
-		scrollBarColor:      Styles.ScrollBarColor,
+		Box:                    NewBox(),
+		scrollBarVisibility:    ScrollBarAuto,
+		graphics:               true,
+		graphicsColor:          Styles.GraphicsColor,
+		textColor:              Styles.PrimaryTextColor,
+		secondaryTextColor:     Styles.SecondaryTextColor,
+		badgeColor:             Styles.SecondaryTextColor,
+		progressColor:          Styles.PrimaryTextColor,
+		progressWidth:          10,
+		scrollBarColor:         Styles.ScrollBarColor,
+		jumpToLetterIgnoreCase: true,
 	}
 }
 
+// SetSecondaryTextColor sets the color of node secondary texts.
+func (t *TreeView) SetSecondaryTextColor(color tcell.Color) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.secondaryTextColor = color
+}
+
+// SetBadgeColor sets the color of node badge texts. Nodes may override this
+// color via TreeNode.SetBadgeColor.
+func (t *TreeView) SetBadgeColor(color tcell.Color) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.badgeColor = color
+}
+
+// SetProgressColor sets the color of node progress bars.
+func (t *TreeView) SetProgressColor(color tcell.Color) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.progressColor = color
+}
+
+// SetProgressWidth sets the width of node progress bars. The default width
+// is 10.
+func (t *TreeView) SetProgressWidth(width int) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.progressWidth = width
+}
+
+// SetIconWidth sets the width of the column reserved for node icons (see
+// TreeNode.SetIcon) between the prefix and the text of each node. When set, the
+// texts of nodes on the same level are aligned even when their icons differ in
+// width or some nodes have no icon. Icons which are wider are truncated. The
+// default width of 0 means that each icon takes up as much space as it needs.
+func (t *TreeView) SetIconWidth(width int) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.iconWidth = width
+}
+
+// SetBadgeWidth sets the width of the column reserved for node badges (see
+// TreeNode.SetBadge). When set, badges are aligned to the right of this column
+// and the column is kept empty for nodes without a badge, so the badges of all
+// nodes line up. Badges which are wider are truncated. The default width of 0
+// means that each badge takes up as much space as it needs.
+func (t *TreeView) SetBadgeWidth(width int) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.badgeWidth = width
+}
+
 // SetRoot sets the root node of the tree.
 func (t *TreeView) SetRoot(root *TreeNode) {
 	t.Lock()
@@ -389,14 +620,15 @@ func (t *TreeView) GetRoot() *TreeNode {
 //
 // This function does NOT trigger the "changed" callback.
 func (t *TreeView) SetCurrentNode(node *TreeNode) {
+	var calls callbacks
+	defer calls.run()
+
 	t.Lock()
 	defer t.Unlock()
 
 	t.currentNode = node
-	if t.currentNode.focused != nil {
-		t.Unlock()
-		t.currentNode.focused()
-		t.Lock()
+	if node != nil {
+		calls.add(node.focused)
 	}
 }
 
@@ -409,6 +641,132 @@ func (t *TreeView) GetCurrentNode() *TreeNode {
 	return t.currentNode
 }
 
+// ExpandToDepth expands the nodes of the tree above the provided depth and
+// collapses all other nodes, so the nodes down to the provided depth are shown.
+// The depth refers to the hierarchy level of the nodes, with 0 referring to
+// the root, 1 to the root's child nodes, and so on.
+func (t *TreeView) ExpandToDepth(depth int) {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.root == nil {
+		return
+	}
+	level := make(map[*TreeNode]int)
+	t.root.walk(func(node, parent *TreeNode) bool {
+		if parent != nil {
+			level[node] = level[parent] + 1
+		}
+		node.expanded = level[node] < depth
+		return true
+	})
+}
+
+// ExpandPath expands the nodes along the provided path and reveals the node at
+// its end (see RevealNode), which is returned. The path consists of the texts
+// of the nodes below the root, starting with a child node of the root. When
+// multiple child nodes have the same text, the first one is used. If no node is
+// found at the path, nothing is expanded and nil is returned.
+func (t *TreeView) ExpandPath(path []string) *TreeNode {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.root == nil {
+		return nil
+	}
+	node := t.root
+	for _, text := range path {
+		var child *TreeNode
+		for _, c := range node.children {
+			if c.text == text {
+				child = c
+				break
+			}
+		}
+		if child == nil {
+			return nil
+		}
+		node = child
+	}
+	t.revealNode(node)
+	return node
+}
+
+// RevealNode expands all ancestors of the provided node and scrolls the node
+// into view the next time the tree is drawn. If the node is selectable, it
+// becomes the current node. Nodes which are not part of the tree are ignored.
+//
+// This function does NOT trigger the "changed" callback.
+func (t *TreeView) RevealNode(node *TreeNode) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.revealNode(node)
+}
+
+// CollapseAllExcept collapses all nodes of the tree except the provided node
+// and its ancestors, which are expanded, so that only the path to the node
+// remains open. If nil is provided, all nodes are collapsed.
+func (t *TreeView) CollapseAllExcept(node *TreeNode) {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.root == nil {
+		return
+	}
+	ancestors, ok := t.ancestors(node)
+	t.root.walk(func(n, parent *TreeNode) bool {
+		n.expanded = false
+		return true
+	})
+	if !ok {
+		return
+	}
+	for _, ancestor := range ancestors {
+		ancestor.expanded = true
+	}
+	node.expanded = true
+}
+
+// ancestors returns the ancestors of the provided node, starting with its
+// parent, and whether the node is part of the tree.
+func (t *TreeView) ancestors(node *TreeNode) ([]*TreeNode, bool) {
+	if t.root == nil || node == nil {
+		return nil, false
+	}
+	var found bool
+	t.root.walk(func(n, parent *TreeNode) bool {
+		if n == node {
+			found = true
+		}
+		return !found
+	})
+	if !found {
+		return nil, false
+	}
+	var ancestors []*TreeNode
+	for parent := node.parent; parent != nil; parent = parent.parent {
+		ancestors = append(ancestors, parent)
+	}
+	return ancestors, true
+}
+
+// revealNode expands all ancestors of the provided node, makes it the current
+// node if it is selectable and scrolls it into view when the tree is processed.
+func (t *TreeView) revealNode(node *TreeNode) {
+	ancestors, ok := t.ancestors(node)
+	if !ok {
+		return
+	}
+	for _, ancestor := range ancestors {
+		ancestor.expanded = true
+	}
+	if node.selectable {
+		t.currentNode = node
+	}
+	t.reveal = node
+}
+
 // SetTopLevel sets the first tree level that is visible with 0 referring to the
 // root, 1 to the root's child nodes, and so on. Nodes above the top level are
 // not displayed.
@@ -457,6 +815,178 @@ func (t *TreeView) SetGraphics(showGraphics bool) {
 	t.graphics = showGraphics
 }
 
+// SetJumpToLetter sets a flag which determines whether typing a letter
+// selects the next visible node whose text starts with that letter. Typing
+// the same letter again cycles through all matching nodes. Letters which do
+// not match any node are handled as usual, e.g. as navigation keys.
+func (t *TreeView) SetJumpToLetter(jump bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.jumpToLetter = jump
+}
+
+// SetJumpToLetterIgnoreCase sets a flag which determines whether jumping to a
+// letter ignores the case of node texts. This is enabled by default.
+func (t *TreeView) SetJumpToLetterIgnoreCase(ignoreCase bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.jumpToLetterIgnoreCase = ignoreCase
+}
+
+// SetFilterFunc sets a function which determines the nodes which are shown.
+// Nodes for which the function returns false are hidden unless any of their
+// descendants match, so the ancestors of each matching node remain visible.
+// While a filter is set, all nodes are searched regardless of whether they are
+// expanded, and the ancestors of matching nodes are shown expanded. The
+// expansion state of the nodes is not modified, so the previous state is shown
+// again when the filter is cleared by providing nil.
+func (t *TreeView) SetFilterFunc(filter func(node *TreeNode) bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.filter = filter
+}
+
+// SetNodesEditable sets a flag which determines whether the user may edit the
+// text of the current node in place by pressing Keys.EditItem (F2 by default).
+// See EditNode.
+func (t *TreeView) SetNodesEditable(editable bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.nodesEditable = editable
+	if !editable {
+		t.editor, t.editNode = nil, nil
+	}
+}
+
+// SetNodeValidateFunc sets a function which validates the text of a node
+// while it is being edited. The function is provided with the node and the
+// new text, and returns an error describing why the text is invalid, or nil.
+// While the text is invalid, the editor is drawn in the FieldError state and
+// the text may not be applied.
+func (t *TreeView) SetNodeValidateFunc(handler func(node *TreeNode, text string) error) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.nodeValidate = handler
+}
+
+// SetNodeEditedFunc sets a function which is called when the user finishes
+// editing the text of a node, before the text is changed. The function is
+// provided with the node and the new text, and returns whether the text of the
+// node is set to the new text.
+func (t *TreeView) SetNodeEditedFunc(handler func(node *TreeNode, text string) bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.nodeEdited = handler
+}
+
+// SetNodeEditCanceledFunc sets a function which is called when the user
+// cancels editing the text of a node.
+func (t *TreeView) SetNodeEditCanceledFunc(handler func(node *TreeNode)) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.nodeEditCanceled = handler
+}
+
+// EditNode makes the provided node the current node and starts editing its
+// text in place, even when editing via the keyboard was not enabled with
+// SetNodesEditable. Pressing Enter or Tab checks the new text with the
+// function set via SetNodeValidateFunc and hands it to the one set via
+// SetNodeEditedFunc, while Escape or a click outside of the node cancels
+// editing. Nodes which are not selectable may not be edited.
+//
+// This function does NOT trigger the "changed" callback.
+func (t *TreeView) EditNode(node *TreeNode) {
+	t.Lock()
+	defer t.Unlock()
+
+	if node == nil || !node.selectable {
+		return
+	}
+	t.editNodeText(node)
+}
+
+// IsEditingNode returns true while the text of a node is being edited.
+func (t *TreeView) IsEditingNode() bool {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.editNode != nil
+}
+
+// editNodeText makes the provided node the current node and starts editing
+// its text.
+func (t *TreeView) editNodeText(node *TreeNode) {
+	editor := NewInputField()
+	editor.SetText(node.GetText())
+	editor.SetChangedFunc(func(text string) {
+		t.validateNodeText(editor, node, text)
+	})
+	editor.SetDoneFunc(func(key tcell.Key) {
+		t.finishEditing(key != tcell.KeyEscape)
+	})
+	t.editor, t.editNode = editor, node
+	t.currentNode = node
+}
+
+// validateNodeText validates the provided text of a node using the function
+// set via SetNodeValidateFunc and updates the state of the provided editor.
+// It returns whether the text is valid.
+func (t *TreeView) validateNodeText(editor *InputField, node *TreeNode, text string) bool {
+	t.RLock()
+	validate := t.nodeValidate
+	t.RUnlock()
+
+	if validate == nil {
+		return true
+	} else if err := validate(node, text); err != nil {
+		editor.SetFieldState(FieldError, err.Error())
+		return false
+	}
+	editor.SetFieldState(FieldNormal, "")
+	return true
+}
+
+// finishEditing stops editing the text of a node and sets the new text if
+// apply is true and the handler set via SetNodeEditedFunc allows it. Editing
+// continues when the new text is invalid.
+func (t *TreeView) finishEditing(apply bool) {
+	t.RLock()
+	editor, node := t.editor, t.editNode
+	edited, canceled := t.nodeEdited, t.nodeEditCanceled
+	t.RUnlock()
+
+	if editor == nil {
+		return
+	}
+	text := editor.GetText()
+	if apply && !t.validateNodeText(editor, node, text) {
+		return
+	}
+
+	t.Lock()
+	if t.editor == editor {
+		t.editor, t.editNode = nil, nil
+	}
+	t.Unlock()
+
+	if !apply {
+		if canceled != nil {
+			canceled(node)
+		}
+		return
+	}
+	if edited == nil || edited(node, text) {
+		node.SetText(text)
+	}
+}
+
 // SetSelectedTextColor sets the text color of selected items.
 func (t *TreeView) SetSelectedTextColor(color tcell.Color) {
 	t.Lock()
@@ -479,6 +1009,25 @@ func (t *TreeView) SetGraphicsColor(color tcell.Color) {
 	t.graphicsColor = color
 }
 
+// inheritColors applies the colors inherited from the parent container.
+func (t *TreeView) inheritColors(background, text tcell.Color) {
+	t.Box.inheritColors(background, text)
+
+	t.Lock()
+	defer t.Unlock()
+
+	t.inheritTextColor(&t.textColor, text)
+}
+
+// nodeTextColor returns the text color of the provided node. Nodes with the
+// default text color are drawn in the text color inherited by the tree view.
+func (t *TreeView) nodeTextColor(node *TreeNode) tcell.Color {
+	if node.color == Styles.PrimaryTextColor {
+		return t.textColor
+	}
+	return node.color
+}
+
 // SetScrollBarVisibility specifies the display of the scroll bar.
 func (t *TreeView) SetScrollBarVisibility(visibility ScrollBarVisibility) {
 	t.Lock()
@@ -545,6 +1094,9 @@ func (t *TreeView) GetRowCount() int {
 
 // Transform modifies the current selection.
 func (t *TreeView) Transform(tr Transformation) {
+	var calls callbacks
+	defer calls.run()
+
 	t.Lock()
 	defer t.Unlock()
 
@@ -563,12 +1115,13 @@ func (t *TreeView) Transform(tr Transformation) {
 		t.movement = treePageDown
 	}
 
-	t.process()
+	t.process(&calls)
 }
 
 // process builds the visible tree, populates the "nodes" slice, and processes
-// pending selection actions.
-func (t *TreeView) process() {
+// pending selection actions, adding the handlers called when the current node
+// changed to the provided callbacks.
+func (t *TreeView) process(calls *callbacks) {
 	_, _, _, height := t.GetInnerRect()
 
 	// Determine visible nodes and their placement.
@@ -579,7 +1132,13 @@ func (t *TreeView) process() {
 	if t.graphics {
 		graphicsOffset = 1
 	}
+	t.filterNodes()
 	t.root.walk(func(node, parent *TreeNode) bool {
+		// Skip nodes which do not match the filter.
+		if t.filtered != nil && !t.filtered[node] {
+			return false
+		}
+
 		// Set node attributes.
 		node.parent = parent
 		if parent == nil {
@@ -619,8 +1178,8 @@ func (t *TreeView) process() {
 			t.nodes = append(t.nodes, node)
 		}
 
-		// Recurse if desired.
-		return node.expanded
+		// Recurse if desired. Ancestors of matching nodes are expanded.
+		return node.expanded || t.filtered != nil
 	})
 
 	// Post-process positions.
@@ -697,20 +1256,25 @@ func (t *TreeView) process() {
 				}
 			}
 			newSelectedIndex = selectedIndex
+		case treeJump:
+			for i := 1; i <= len(t.nodes); i++ {
+				newSelectedIndex = (selectedIndex + i) % len(t.nodes)
+				node := t.nodes[newSelectedIndex]
+				if node.selectable && startsWithLetter([]byte(node.text), t.jumpLetter, t.jumpToLetterIgnoreCase) {
+					break MovementSwitch
+				}
+			}
+			newSelectedIndex = selectedIndex
 		}
 		t.currentNode = t.nodes[newSelectedIndex]
 		if newSelectedIndex != selectedIndex {
 			t.movement = treeNone
-			if t.changed != nil {
-				t.Unlock()
-				t.changed(t.currentNode)
-				t.Lock()
-			}
-			if t.currentNode.focused != nil {
-				t.Unlock()
-				t.currentNode.focused()
-				t.Lock()
+			if changed, node := t.changed, t.currentNode; changed != nil {
+				calls.add(func() {
+					changed(node)
+				})
 			}
+			calls.add(t.currentNode.focused)
 		}
 		selectedIndex = newSelectedIndex
 
@@ -736,6 +1300,58 @@ func (t *TreeView) process() {
 			t.currentNode = nil
 		}
 	}
+
+	// Scroll the revealed node into view.
+	if t.reveal != nil {
+		for index, node := range t.nodes {
+			if node != t.reveal {
+				continue
+			}
+			if index-t.offsetY >= height {
+				t.offsetY = index - height + 1
+			}
+			if index < t.offsetY {
+				t.offsetY = index
+			}
+			break
+		}
+		t.reveal = nil
+	}
+}
+
+// filterNodes determines the nodes which match the filter or have descendants
+// which match it.
+func (t *TreeView) filterNodes() {
+	if t.filter == nil || t.root == nil {
+		t.filtered = nil
+		return
+	}
+	t.filtered = make(map[*TreeNode]bool)
+	var filter func(node *TreeNode) bool
+	filter = func(node *TreeNode) bool {
+		visible := t.filter(node)
+		for _, child := range node.children {
+			if filter(child) {
+				visible = true
+			}
+		}
+		if visible {
+			t.filtered[node] = true
+		}
+		return visible
+	}
+	filter(t.root)
+}
+
+// lastChild returns the last child node of the provided node which is not
+// hidden by the filter, or nil if there is none.
+func (t *TreeView) lastChild(node *TreeNode) *TreeNode {
+	for index := len(node.children) - 1; index >= 0; index-- {
+		if t.filtered == nil || t.filtered[node.children[index]] {
+			return node.children[index]
+		}
+	}
+	return nil
 }
 
 // Draw draws this primitive onto the screen.
@@ -746,6 +1362,9 @@ func (t *TreeView) Draw(screen tcell.Screen) {
 
 	t.Box.Draw(screen)
 
+	var calls callbacks
+	defer calls.run()
+
 	t.Lock()
 	defer t.Unlock()
 
@@ -753,7 +1372,7 @@ func (t *TreeView) Draw(screen tcell.Screen) {
 		return
 	}
 
-	t.process()
+	t.process(&calls)
 
 	// Scroll the tree.
 	x, y, width, height := t.GetInnerRect()
@@ -785,6 +1404,12 @@ func (t *TreeView) Draw(screen tcell.Screen) {
 	rows := len(t.nodes)
 	cursor := int(float64(rows) * (float64(t.offsetY) / float64(rows-height)))
 
+	// Leave room for the scroll bar.
+	rowWidth := width
+	if t.scrollBarVisibility == ScrollBarAlways || (t.scrollBarVisibility == ScrollBarAuto && rows > height) {
+		rowWidth--
+	}
+
 	// Draw the tree.
 	posY := y
 	lineStyle := tcell.StyleDefault.Background(t.backgroundColor).Foreground(t.graphicsColor)
@@ -807,7 +1432,7 @@ func (t *TreeView) Draw(screen tcell.Screen) {
 				}
 
 				// Draw a branch if this ancestor is not a last child.
-				if ancestor.parent.children[len(ancestor.parent.children)-1] != ancestor {
+				if t.lastChild(ancestor.parent) != ancestor {
 					if posY-1 >= y && ancestor.textX > ancestor.graphicsX {
 						PrintJoinedSemigraphics(screen, x+ancestor.graphicsX, posY-1, Borders.Vertical, t.graphicsColor)
 					}
@@ -836,17 +1461,37 @@ func (t *TreeView) Draw(screen tcell.Screen) {
 
 		// Draw the prefix and the text.
 		if node.textX < width && posY < y+height {
+			nodeColor := t.nodeTextColor(node)
+
 			// Prefix.
 			var prefixWidth int
 			if len(t.prefixes) > 0 {
-				_, prefixWidth = Print(screen, t.prefixes[(node.level-t.topLevel)%len(t.prefixes)], x+node.textX, posY, width-node.textX, AlignLeft, node.color)
+				_, prefixWidth = Print(screen, t.prefixes[(node.level-t.topLevel)%len(t.prefixes)], x+node.textX, posY, width-node.textX, AlignLeft, nodeColor)
+			}
+
+			// Decorations.
+			textX := node.textX + prefixWidth
+			textWidth := t.drawDecorations(screen, node, x+textX, posY, rowWidth-textX)
+
+			// Icon.
+			if iconWidth := t.nodeIconWidth(node); iconWidth > 0 && textWidth > 0 {
+				color := nodeColor
+				if node.iconColor != ColorUnset {
+					color = node.iconColor
+				}
+				if iconWidth > textWidth {
+					iconWidth = textWidth
+				}
+				Print(screen, []byte(node.icon), x+textX, posY, iconWidth, AlignLeft, color)
+				textX += iconWidth + 1
+				textWidth -= iconWidth + 1
 			}
 
 			// Text.
-			if node.textX+prefixWidth < width {
-				style := tcell.StyleDefault.Foreground(node.color)
+			if textWidth > 0 {
+				style := tcell.StyleDefault.Foreground(nodeColor)
 				if node == t.currentNode {
-					backgroundColor := node.color
+					backgroundColor := nodeColor
 					foregroundColor := t.backgroundColor
 					if t.selectedTextColor != nil {
 						foregroundColor = *t.selectedTextColor
@@ -856,7 +1501,18 @@ func (t *TreeView) Draw(screen tcell.Screen) {
 					}
 					style = tcell.StyleDefault.Background(backgroundColor).Foreground(foregroundColor)
 				}
-				PrintStyle(screen, []byte(node.text), x+node.textX+prefixWidth, posY, width-node.textX-prefixWidth, AlignLeft, style)
+				PrintStyle(screen, []byte(node.text), x+textX, posY, textWidth, AlignLeft, style)
+			}
+
+			// Editor.
+			if t.editor != nil && node == t.editNode && textWidth > 0 {
+				if t.hasFocus {
+					t.editor.Focus(nil)
+				} else {
+					t.editor.Blur()
+				}
+				t.editor.SetRect(x+textX, posY, textWidth, 1)
+				t.editor.Draw(screen)
 			}
 		}
 
@@ -871,36 +1527,31 @@ func (t *TreeView) Draw(screen tcell.Screen) {
 // InputHandler returns the handler for this primitive.
 func (t *TreeView) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
 	return t.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
-		selectNode := func() {
-			t.Lock()
-			currentNode := t.currentNode
-			t.Unlock()
-			if currentNode == nil {
-				return
-			}
-
-			if t.selected != nil {
-				t.selected(currentNode)
-			}
-			if currentNode.focused != nil {
-				currentNode.focused()
-			}
-			if currentNode.selected != nil {
-				currentNode.selected()
-			}
-		}
+		var calls callbacks
+		defer calls.run()
 
 		t.Lock()
 		defer t.Unlock()
 
+		// Pass events to the editor.
+		if editor := t.editor; editor != nil {
+			calls.add(func() {
+				editor.InputHandler()(event, setFocus)
+			})
+			return
+		}
+
 		// Because the tree is flattened into a list only at drawing time, we also
 		// postpone the (selection) movement to drawing time.
 		if HitShortcut(event, Keys.Cancel, Keys.MovePreviousField, Keys.MoveNextField) {
-			if t.done != nil {
-				t.Unlock()
-				t.done(event.Key())
-				t.Lock()
+			if done := t.done; done != nil {
+				calls.add(func() {
+					done(event.Key())
+				})
 			}
+		} else if ch, ok := t.jumpRune(event); ok {
+			t.movement = treeJump
+			t.jumpLetter = ch
 		} else if HitShortcut(event, Keys.MoveFirst, Keys.MoveFirst2) {
 			t.movement = treeHome
 		} else if HitShortcut(event, Keys.MoveLast, Keys.MoveLast2) {
@@ -914,15 +1565,110 @@ func (t *TreeView) InputHandler() func(event *tcell.EventKey, setFocus func(p Pr
 		} else if HitShortcut(event, Keys.MoveNextPage) {
 			t.movement = treePageDown
 		} else if HitShortcut(event, Keys.Select, Keys.Select2) {
-			t.Unlock()
-			selectNode()
-			t.Lock()
+			t.selectNode(&calls)
+		} else if t.nodesEditable && t.currentNode != nil && HitShortcut(event, Keys.EditItem) {
+			t.editNodeText(t.currentNode)
 		}
 
-		t.process()
+		t.process(&calls)
 	})
 }
 
+// selectNode adds the handlers called when the current node is selected to
+// the provided callbacks.
+func (t *TreeView) selectNode(calls *callbacks) {
+	node := t.currentNode
+	if node == nil {
+		return
+	}
+
+	if selected := t.selected; selected != nil {
+		calls.add(func() {
+			selected(node)
+		})
+	}
+	calls.add(node.focused)
+	calls.add(node.selected)
+}
+
+// jumpRune returns the letter typed when jumping to a letter is enabled and
+// any visible node starts with it.
+func (t *TreeView) jumpRune(event *tcell.EventKey) (rune, bool) {
+	if !t.jumpToLetter {
+		return 0, false
+	}
+	ch, ok := jumpRune(event)
+	if !ok {
+		return 0, false
+	}
+
+	for _, node := range t.nodes {
+		if node.selectable && startsWithLetter([]byte(node.text), ch, t.jumpToLetterIgnoreCase) {
+			return ch, true
+		}
+	}
+	return 0, false
+}
+
+// drawDecorations draws the secondary text, progress bar and badge of a node
+// from the right edge of the provided area towards its left. It returns the
+// width which remains for the node's text.
+func (t *TreeView) drawDecorations(screen tcell.Screen, node *TreeNode, x, y, width int) int {
+	// Secondary text.
+	if node.secondaryText != "" && width > 0 {
+		_, drawnWidth := Print(screen, []byte(node.secondaryText), x, y, width, AlignRight, t.secondaryTextColor)
+		width -= drawnWidth + 1
+	}
+
+	// Progress bar.
+	if node.progressMax > 0 && t.progressWidth > 0 && t.progressWidth < width {
+		filled := t.progressWidth * node.progress / node.progressMax
+		style := tcell.StyleDefault.Background(t.backgroundColor).Foreground(t.progressColor)
+		for i := 0; i < t.progressWidth; i++ {
+			r := '░'
+			if i < filled {
+				r = tcell.RuneBlock
+			}
+			screen.SetContent(x+width-t.progressWidth+i, y, r, nil, style)
+		}
+		width -= t.progressWidth + 1
+	}
+
+	// Badge.
+	if t.badgeWidth > 0 && t.badgeWidth < width {
+		if node.badge != "" {
+			align := AlignRight
+			if TaggedStringWidth(node.badge) > t.badgeWidth {
+				align = AlignLeft // Cut off the end of the badge.
+			}
+			Print(screen, []byte(node.badge), x+width-t.badgeWidth, y, t.badgeWidth, align, t.nodeBadgeColor(node))
+		}
+		width -= t.badgeWidth + 1
+	} else if t.badgeWidth <= 0 && node.badge != "" && width > 0 {
+		_, drawnWidth := Print(screen, []byte(node.badge), x, y, width, AlignRight, t.nodeBadgeColor(node))
+		width -= drawnWidth + 1
+	}
+
+	return width
+}
+
+// nodeBadgeColor returns the color of the provided node's badge.
+func (t *TreeView) nodeBadgeColor(node *TreeNode) tcell.Color {
+	if node.badgeColor != ColorUnset {
+		return node.badgeColor
+	}
+	return t.badgeColor
+}
+
+// nodeIconWidth returns the width of the column of the provided node's icon,
+// or 0 if no column is drawn.
+func (t *TreeView) nodeIconWidth(node *TreeNode) int {
+	if t.iconWidth > 0 {
+		return t.iconWidth
+	}
+	return TaggedStringWidth(node.icon)
+}
+
 // MouseHandler returns the mouse handler for this primitive.
 func (t *TreeView) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return t.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
@@ -931,6 +1677,28 @@ func (t *TreeView) MouseHandler() func(action MouseAction, event *tcell.EventMou
 			return false, nil
 		}
 
+		// Pass events to the editor, and cancel editing when clicking
+		// elsewhere.
+		t.RLock()
+		editor := t.editor
+		t.RUnlock()
+		if editor != nil {
+			if editor.InRect(x, y) {
+				editor.MouseHandler()(action, event, func(p Primitive) {
+					setFocus(t)
+				})
+				return true, nil
+			} else if action == MouseLeftDown {
+				t.finishEditing(false)
+			}
+		}
+
+		var calls callbacks
+		defer calls.run()
+
+		t.Lock()
+		defer t.Unlock()
+
 		switch action {
 		case MouseLeftClick:
 			_, rectY, _, _ := t.GetInnerRect()
@@ -938,11 +1706,15 @@ func (t *TreeView) MouseHandler() func(action MouseAction, event *tcell.EventMou
 			if y >= 0 && y < len(t.nodes) {
 				node := t.nodes[y]
 				if node.selectable {
-					if t.currentNode != node && t.changed != nil {
-						t.changed(node)
+					if changed := t.changed; t.currentNode != node && changed != nil {
+						calls.add(func() {
+							changed(node)
+						})
 					}
-					if t.selected != nil {
-						t.selected(node)
+					if selected := t.selected; selected != nil {
+						calls.add(func() {
+							selected(node)
+						})
 					}
 					t.currentNode = node
 				}
@@ -1,7 +1,12 @@
 package cview
 
 import (
+	"errors"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
 )
 
 const (
@@ -69,3 +74,562 @@ func TestTreeView(t *testing.T) {
 		t.Errorf("failed to initialize TreeView: incorrect row count: expected 1, got %d", tr.GetRowCount())
 	}
 }
+
+func TestTreeViewJumpToLetter(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	rootNode := NewTreeNode("Root")
+	appleNode := NewTreeNode("apple")
+	bananaNode := NewTreeNode("Banana")
+	avocadoNode := NewTreeNode("Avocado")
+	rootNode.AddChild(appleNode)
+	rootNode.AddChild(bananaNode)
+	rootNode.AddChild(avocadoNode)
+
+	tr := NewTreeView()
+	tr.SetRoot(rootNode)
+	tr.SetCurrentNode(rootNode)
+	tr.SetJumpToLetter(true)
+
+	app, err := newTestApp(tr)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	tr.Draw(app.screen)
+
+	// Jump to letter
+
+	press := func(ch rune) {
+		tr.InputHandler()(tcell.NewEventKey(tcell.KeyRune, ch, tcell.ModNone), func(p Primitive) {})
+		tr.Draw(app.screen)
+	}
+	for i, expected := range []*TreeNode{appleNode, avocadoNode, appleNode} {
+		press('a')
+		if node := tr.GetCurrentNode(); node != expected {
+			t.Errorf("failed to jump to letter (%d): expected %s, got %s", i, expected.GetText(), node.GetText())
+		}
+	}
+
+	press('B')
+	if node := tr.GetCurrentNode(); node != bananaNode {
+		t.Errorf("failed to jump to letter: expected %s, got %s", bananaNode.GetText(), node.GetText())
+	}
+}
+
+func TestTreeViewDecorations(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	root := NewTreeNode("root")
+	root.SetSecondaryText("1MB")
+	root.SetBadge("3")
+	root.SetProgress(15, 10)
+	if progress, max := root.GetProgress(); progress != 10 || max != 10 {
+		t.Errorf("failed to set progress: expected 10/10, got %d/%d", progress, max)
+	}
+	root.SetProgress(2, 4)
+
+	tr := NewTreeView()
+	tr.SetRoot(root)
+	tr.SetScrollBarVisibility(ScrollBarNever)
+	tr.SetProgressWidth(4)
+	tr.SetRect(0, 0, 20, 1)
+
+	app, err := newTestApp(tr)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+
+	screenLine := func() string {
+		var line []rune
+		for x := 0; x < 20; x++ {
+			r, _, _, _ := app.screen.GetContent(x, 0)
+			line = append(line, r)
+		}
+		return string(line)
+	}
+
+	// Draw decorations
+
+	tr.Draw(app.screen)
+	expected := "root      3 ██░░ 1MB"
+	if line := screenLine(); line != expected {
+		t.Errorf("failed to draw decorations: expected %q, got %q", expected, line)
+	}
+
+	// Truncate text
+
+	root.SetText("a long node name")
+	tr.Draw(app.screen)
+	expected = "a long no 3 ██░░ 1MB"
+	if line := screenLine(); line != expected {
+		t.Errorf("failed to truncate text: expected %q, got %q", expected, line)
+	}
+}
+
+func TestTreeViewFilter(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	root := NewTreeNode("root")
+	docs := NewTreeNode("docs")
+	docs.SetExpanded(false)
+	docs.AddChild(NewTreeNode("readme.md"))
+	docs.AddChild(NewTreeNode("notes.txt"))
+	src := NewTreeNode("src")
+	src.AddChild(NewTreeNode("main.go"))
+	root.AddChild(docs)
+	root.AddChild(src)
+
+	tr := NewTreeView()
+	tr.SetRoot(root)
+	tr.SetScrollBarVisibility(ScrollBarNever)
+	tr.SetRect(0, 0, 12, 5)
+
+	app, err := newTestApp(tr)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+
+	lines := func(expected ...string) {
+		t.Helper()
+
+		tr.Draw(app.screen)
+		for y, e := range expected {
+			var line []rune
+			for x := 0; x < 12; x++ {
+				r, _, _, _ := app.screen.GetContent(x, y)
+				line = append(line, r)
+			}
+			if string(line) != e {
+				t.Errorf("failed to draw line %d: expected %q, got %q", y, e, string(line))
+			}
+		}
+	}
+
+	// Filter nodes
+
+	tr.SetFilterFunc(func(node *TreeNode) bool {
+		return strings.HasSuffix(node.GetText(), ".md")
+	})
+	lines(
+		"root        ",
+		"└──docs     ",
+		"   └──readme",
+		"            ",
+	)
+	if rows := tr.GetRowCount(); rows != 3 {
+		t.Errorf("failed to filter nodes: expected 3 rows, got %d", rows)
+	}
+
+	// Clear filter
+
+	tr.SetFilterFunc(nil)
+	lines(
+		"root        ",
+		"├──docs     ",
+		"└──src      ",
+		"   └──main.g",
+	)
+	if docs.IsExpanded() {
+		t.Error("failed to restore expansion state: expected docs to be collapsed")
+	}
+}
+
+func TestTreeViewIcons(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	root := NewTreeNode("root")
+	root.SetIcon("D")
+	root.SetIconColor(tcell.ColorYellow)
+	file := NewTreeNode("file")
+	file.SetBadge("12")
+	other := NewTreeNode("other")
+	other.SetIcon("F")
+	other.SetBadge("123")
+	root.AddChild(file)
+	root.AddChild(other)
+
+	tr := NewTreeView()
+	tr.SetRoot(root)
+	tr.SetGraphics(false)
+	tr.SetScrollBarVisibility(ScrollBarNever)
+	tr.SetRect(0, 0, 16, 3)
+
+	app, err := newTestApp(tr)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+
+	lines := func(expected ...string) {
+		t.Helper()
+
+		tr.Draw(app.screen)
+		for y, e := range expected {
+			var line []rune
+			for x := 0; x < 16; x++ {
+				r, _, _, _ := app.screen.GetContent(x, y)
+				line = append(line, r)
+			}
+			if string(line) != e {
+				t.Errorf("failed to draw line %d: expected %q, got %q", y, e, string(line))
+			}
+		}
+	}
+
+	// Draw icons
+
+	lines(
+		"D root          ",
+		"  file        12",
+		"  F other    123",
+	)
+	_, _, style, _ := app.screen.GetContent(0, 0)
+	if fg, _, _ := style.Decompose(); fg != tcell.ColorYellow {
+		t.Errorf("failed to draw icon color: expected %v, got %v", tcell.ColorYellow, fg)
+	}
+
+	// Set icon and badge widths
+
+	tr.SetIconWidth(2)
+	tr.SetBadgeWidth(2)
+	lines(
+		"D  root         ",
+		"     file     12",
+		"  F  other    12",
+	)
+}
+
+func TestTreeViewEditNode(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	root := NewTreeNode("root")
+	file := NewTreeNode("file")
+	root.AddChild(file)
+
+	tr := NewTreeView()
+	tr.SetRoot(root)
+	tr.SetCurrentNode(file)
+	tr.SetNodesEditable(true)
+	tr.SetRect(0, 0, 20, 2)
+
+	var edited, canceled *TreeNode
+	tr.SetNodeValidateFunc(func(node *TreeNode, text string) error {
+		if text == "" {
+			return errors.New("name required")
+		}
+		return nil
+	})
+	tr.SetNodeEditedFunc(func(node *TreeNode, text string) bool {
+		edited = node
+		return true
+	})
+	tr.SetNodeEditCanceledFunc(func(node *TreeNode) {
+		canceled = node
+	})
+
+	app, err := newTestApp(tr)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	tr.Draw(app.screen)
+
+	press := func(key tcell.Key, ch rune) {
+		tr.InputHandler()(tcell.NewEventKey(key, ch, tcell.ModNone), func(p Primitive) {})
+		tr.Draw(app.screen)
+	}
+
+	// Cancel editing
+
+	press(tcell.KeyF2, 0)
+	if !tr.IsEditingNode() {
+		t.Fatal("failed to start editing node: expected editing")
+	}
+	press(tcell.KeyRune, 'x')
+	press(tcell.KeyEscape, 0)
+	if tr.IsEditingNode() {
+		t.Error("failed to cancel editing node: expected not editing")
+	} else if canceled != file {
+		t.Errorf("failed to cancel editing node: expected canceled node %s, got %v", file.GetText(), canceled)
+	} else if text := file.GetText(); text != "file" {
+		t.Errorf("failed to cancel editing node: expected text %q, got %q", "file", text)
+	}
+
+	// Reject invalid text
+
+	tr.EditNode(file)
+	for i := 0; i < 4; i++ {
+		press(tcell.KeyBackspace2, 0)
+	}
+	press(tcell.KeyEnter, 0)
+	if !tr.IsEditingNode() {
+		t.Error("failed to validate node text: expected editing")
+	} else if edited != nil {
+		t.Errorf("failed to validate node text: expected no edited node, got %s", edited.GetText())
+	}
+
+	// Apply text
+
+	for _, ch := range "data" {
+		press(tcell.KeyRune, ch)
+	}
+	press(tcell.KeyEnter, 0)
+	if tr.IsEditingNode() {
+		t.Error("failed to edit node: expected not editing")
+	} else if edited != file {
+		t.Errorf("failed to edit node: expected edited node %s, got %v", file.GetText(), edited)
+	} else if text := file.GetText(); text != "data" {
+		t.Errorf("failed to edit node: expected text %q, got %q", "data", text)
+	}
+}
+
+func TestTreeViewExpandPath(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	root := NewTreeNode("root")
+	a := NewTreeNode("a")
+	b := NewTreeNode("b")
+	c := NewTreeNode("c")
+	d := NewTreeNode("d")
+	e := NewTreeNode("e")
+	b.AddChild(c)
+	a.AddChild(b)
+	d.AddChild(e)
+	root.AddChild(a)
+	root.AddChild(d)
+
+	tr := NewTreeView()
+	tr.SetRoot(root)
+	tr.SetCurrentNode(root)
+	tr.SetRect(0, 0, 10, 2)
+
+	app, err := newTestApp(tr)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+
+	// Expand to depth
+
+	tr.ExpandToDepth(1)
+	tr.Draw(app.screen)
+	if rows := tr.GetRowCount(); rows != 3 {
+		t.Errorf("failed to expand to depth: expected 3 rows, got %d", rows)
+	} else if a.IsExpanded() || !root.IsExpanded() {
+		t.Errorf("failed to expand to depth: expected root expanded and a collapsed, got %v and %v", root.IsExpanded(), a.IsExpanded())
+	}
+
+	// Expand path
+
+	if node := tr.ExpandPath([]string{"a", "x"}); node != nil {
+		t.Errorf("failed to expand invalid path: expected nil, got %s", node.GetText())
+	}
+	if node := tr.ExpandPath([]string{"a", "b", "c"}); node != c {
+		t.Errorf("failed to expand path: expected node c, got %v", node)
+	}
+	tr.Draw(app.screen)
+	if rows := tr.GetRowCount(); rows != 5 {
+		t.Errorf("failed to expand path: expected 5 rows, got %d", rows)
+	} else if node := tr.GetCurrentNode(); node != c {
+		t.Errorf("failed to expand path: expected current node c, got %s", node.GetText())
+	} else if offset := tr.GetScrollOffset(); offset != 2 {
+		t.Errorf("failed to reveal node: expected scroll offset 2, got %d", offset)
+	}
+
+	// Collapse all except node
+
+	tr.CollapseAllExcept(d)
+	tr.Draw(app.screen)
+	if rows := tr.GetRowCount(); rows != 4 {
+		t.Errorf("failed to collapse all except node: expected 4 rows, got %d", rows)
+	} else if a.IsExpanded() || !d.IsExpanded() {
+		t.Errorf("failed to collapse all except node: expected a collapsed and d expanded, got %v and %v", a.IsExpanded(), d.IsExpanded())
+	}
+
+	// Reveal node
+
+	d.Collapse()
+	tr.RevealNode(e)
+	tr.Draw(app.screen)
+	if node := tr.GetCurrentNode(); node != e {
+		t.Errorf("failed to reveal node: expected current node e, got %s", node.GetText())
+	} else if offset := tr.GetScrollOffset(); offset != 2 {
+		t.Errorf("failed to reveal node: expected scroll offset 2, got %d", offset)
+	}
+}
+
+func TestTreeViewCallbacks(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	rootNode := NewTreeNode("Root")
+	childA := NewTreeNode(treeViewTextA)
+	childB := NewTreeNode(treeViewTextB)
+	rootNode.AddChild(childA)
+	rootNode.AddChild(childB)
+
+	tr := NewTreeView()
+	tr.SetRoot(rootNode)
+	tr.SetCurrentNode(rootNode)
+
+	var changed, selected []*TreeNode
+	var focused, done int
+	tr.SetChangedFunc(func(node *TreeNode) {
+		// Calling methods of the tree view from its callbacks must not deadlock.
+		if current := tr.GetCurrentNode(); current != node {
+			t.Errorf("failed to call changed handler: expected current node %s, got %s", node.GetText(), current.GetText())
+		}
+		changed = append(changed, node)
+	})
+	tr.SetSelectedFunc(func(node *TreeNode) {
+		tr.SetRoot(rootNode)
+		selected = append(selected, node)
+	})
+	tr.SetDoneFunc(func(key tcell.Key) {
+		tr.SetCurrentNode(rootNode)
+		done++
+	})
+	childA.SetFocusedFunc(func() {
+		tr.GetRowCount()
+		focused++
+	})
+
+	app, err := newTestApp(tr)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	tr.SetRect(0, 0, 20, 5)
+	tr.Draw(app.screen)
+
+	// Navigate, select and finish
+
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+
+		press := func(key tcell.Key) {
+			tr.InputHandler()(tcell.NewEventKey(key, 0, tcell.ModNone), func(p Primitive) {})
+			tr.Draw(app.screen)
+		}
+		press(tcell.KeyDown)
+		press(tcell.KeyEnter)
+		press(tcell.KeyEscape)
+		tr.MouseHandler()(MouseLeftClick, tcell.NewEventMouse(1, 2, tcell.Button1, tcell.ModNone), func(p Primitive) {})
+	}()
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("failed to call TreeView callbacks: deadlock")
+	}
+
+	if len(changed) != 2 || changed[0] != childA || changed[1] != childB {
+		t.Errorf("failed to call changed handler: expected 2 calls, got %d", len(changed))
+	} else if len(selected) != 2 || selected[0] != childA || selected[1] != childB {
+		t.Errorf("failed to call selected handler: expected 2 calls, got %d", len(selected))
+	} else if focused != 2 {
+		t.Errorf("failed to call focused handler: expected 2 calls, got %d", focused)
+	} else if done != 1 {
+		t.Errorf("failed to call done handler: expected 1 call, got %d", done)
+	} else if current := tr.GetCurrentNode(); current != childB {
+		t.Errorf("failed to select node: expected %s, got %s", childB.GetText(), current.GetText())
+	}
+}
+
+func TestTreeViewItemColors(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	inherited := NewTreeNode("a")
+	explicit := NewTreeNode("b")
+	explicit.SetColor(tcell.ColorRed)
+	root := NewTreeNode("root")
+	root.AddChild(inherited)
+	root.AddChild(explicit)
+
+	tr := NewTreeView()
+	tr.SetRoot(root)
+	tr.SetCurrentNode(root)
+
+	flex := NewFlex()
+	flex.SetItemColors(tcell.ColorNavy, tcell.ColorYellow)
+	flex.AddItem(tr, 0, 1, false)
+	flex.SetRect(0, 0, 20, 5)
+
+	app, err := newTestApp(flex)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+
+	expectColor := func(y int, text rune, expected tcell.Color) {
+		for x := 0; x < 20; x++ {
+			r, _, style, _ := app.screen.GetContent(x, y)
+			if r != text {
+				continue
+			}
+			if fg, _, _ := style.Decompose(); fg != expected {
+				t.Errorf("failed to draw node %q: expected text color %v, got %v", text, expected, fg)
+			}
+			return
+		}
+		t.Errorf("failed to draw node %q: not found", text)
+	}
+
+	// Inherit
+
+	flex.Draw(app.screen)
+
+	if color := tr.GetBackgroundColor(); color != tcell.ColorNavy {
+		t.Errorf("failed to inherit background color: expected %v, got %v", tcell.ColorNavy, color)
+	}
+	expectColor(1, 'a', tcell.ColorYellow)
+	expectColor(2, 'b', tcell.ColorRed)
+
+	// Unset
+
+	flex.SetItemColors(ColorUnset, ColorUnset)
+	flex.Draw(app.screen)
+
+	if color := tr.GetBackgroundColor(); color != Styles.PrimitiveBackgroundColor {
+		t.Errorf("failed to restore background color: expected %v, got %v", Styles.PrimitiveBackgroundColor, color)
+	}
+	expectColor(1, 'a', Styles.PrimaryTextColor)
+	expectColor(2, 'b', tcell.ColorRed)
+}
@@ -1,11 +1,14 @@
 package cview
 
 import (
+	"bytes"
 	"fmt"
 	"math"
 	"regexp"
 	"sort"
 	"strconv"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/mattn/go-runewidth"
@@ -41,9 +44,9 @@ const (
 // Common regular expressions.
 var (
 	colorPattern     = regexp.MustCompile(`\[([a-zA-Z]+|#[0-9a-zA-Z]{6}|\-)?(:([a-zA-Z]+|#[0-9a-zA-Z]{6}|\-)?(:([bdilrsu]+|\-)?)?)?\]`)
-	regionPattern    = regexp.MustCompile(`\["([a-zA-Z0-9_,;: \-\.]*)"\]`)
-	escapePattern    = regexp.MustCompile(`\[([a-zA-Z0-9_,;: \-\."#]+)\[(\[*)\]`)
-	nonEscapePattern = regexp.MustCompile(`(\[[a-zA-Z0-9_,;: \-\."#]+\[*)\]`)
+	regionPattern    = regexp.MustCompile(`\["([a-zA-Z0-9_,;: \-\.]*|[a-zA-Z][a-zA-Z0-9+\-\.]*://[a-zA-Z0-9_,;:\-\./?#&=%+~@!$*()']*)"\]`)
+	escapePattern    = regexp.MustCompile(`\[([a-zA-Z0-9_,;: \-\."#/?&=%+~@!$*()']+)\[(\[*)\]`)
+	nonEscapePattern = regexp.MustCompile(`(\[[a-zA-Z0-9_,;: \-\."#/?&=%+~@!$*()']+\[*)\]`)
 	boundaryPattern  = regexp.MustCompile(`(([,\.\-:;!\?&#+]|\n)[ \t\f\r]*|([ \t\f\r]+))`)
 	spacePattern     = regexp.MustCompile(`\s+`)
 )
@@ -136,6 +139,29 @@ func StripTags(text []byte, colors bool, regions bool) []byte {
 	return escapePattern.ReplaceAll(stripped, []byte(`[$1$2]`))
 }
 
+// jumpRune returns the rune of a key event which may be used to jump to an
+// item starting with that letter.
+func jumpRune(event *tcell.EventKey) (rune, bool) {
+	if event.Key() != tcell.KeyRune || event.Modifiers()&^tcell.ModShift != 0 || unicode.IsSpace(event.Rune()) {
+		return 0, false
+	}
+	return event.Rune(), true
+}
+
+// startsWithLetter returns whether the first letter of the provided text,
+// ignoring color tags and leading whitespace, is the provided rune.
+func startsWithLetter(text []byte, ch rune, ignoreCase bool) bool {
+	text = bytes.TrimLeftFunc(StripTags(text, true, true), unicode.IsSpace)
+	first, _ := utf8.DecodeRune(text)
+	if first == utf8.RuneError {
+		return false
+	}
+	if ignoreCase {
+		return unicode.ToLower(first) == unicode.ToLower(ch)
+	}
+	return first == ch
+}
+
 // ColorHex returns the hexadecimal value of a color as a string, prefixed with #.
 // If the color is invalid, a blank string is returned.
 func ColorHex(c tcell.Color) string {
@@ -270,7 +296,7 @@ func SetAttributes(style tcell.Style, attrs tcell.AttrMask) tcell.Style {
 func decomposeText(text []byte, findColors, findRegions bool) (colorIndices [][]int, colors [][][]byte, regionIndices [][]int, regions [][][]byte, escapeIndices [][]int, stripped []byte, width int) {
 	// Shortcut for the trivial case.
 	if !findColors && !findRegions {
-		return nil, nil, nil, nil, nil, text, runewidth.StringWidth(string(text))
+		return nil, nil, nil, nil, nil, text, stringWidth(string(text))
 	}
 
 	// Get positions of any tags.
@@ -321,7 +347,7 @@ func decomposeText(text []byte, findColors, findRegions bool) (colorIndices [][]
 	stripped = escapePattern.ReplaceAll(buf, []byte("[$1$2]"))
 
 	// Get the width of the stripped string.
-	width = runewidth.StringWidth(string(stripped))
+	width = stringWidth(string(stripped))
 
 	return
 }
@@ -480,6 +506,9 @@ func PrintStyle(screen tcell.Screen, text []byte, x, y, maxWidth, align int, sty
 		}
 
 		// Print the rune sequence.
+		if main == '\t' {
+			main = ' '
+		}
 		finalX := x + drawnWidth
 		_, _, finalStyle, _ := screen.GetContent(finalX, y)
 		_, background, _ := finalStyle.Decompose()
@@ -653,6 +682,80 @@ func Escape(text string) string {
 	return nonEscapePattern.ReplaceAllString(text, "$1[]")
 }
 
+// expandTabs replaces the tab characters of a line with spaces up to the next
+// tab stop, placed every tabWidth columns. The line starts at the provided
+// column. Color and region tags are not counted if requested. A tab width of 0
+// removes tab characters.
+func expandTabs(line []byte, tabWidth, column int, findColors, findRegions bool) []byte {
+	if bytes.IndexByte(line, '\t') < 0 {
+		return line
+	}
+
+	var expanded []byte
+	for {
+		i := bytes.IndexByte(line, '\t')
+		if i < 0 {
+			return append(expanded, line...)
+		}
+		_, _, _, _, _, _, width := decomposeText(line[:i], findColors, findRegions)
+		column += width
+		expanded = append(expanded, line[:i]...)
+		if tabWidth > 0 {
+			spaces := tabWidth - column%tabWidth
+			expanded = append(expanded, bytes.Repeat([]byte{' '}, spaces)...)
+			column += spaces
+		}
+		line = line[i+1:]
+	}
+}
+
+// alignTabs replaces the tab characters of the provided lines with spaces such
+// that the cells separated by tabs of consecutive lines containing tabs are
+// aligned. Each column is widened to the first tab stop after its widest cell.
+// Color and region tags are not counted if requested. A tab width of 0 removes
+// tab characters.
+func alignTabs(lines [][]byte, tabWidth int, findColors, findRegions bool) {
+	for start := 0; start < len(lines); start++ {
+		if bytes.IndexByte(lines[start], '\t') < 0 {
+			continue
+		}
+
+		// Split the block of lines containing tabs into cells.
+		end := start
+		var cells [][][]byte
+		var widths []int
+		for ; end < len(lines) && bytes.IndexByte(lines[end], '\t') >= 0; end++ {
+			lineCells := bytes.Split(lines[end], []byte{'\t'})
+			cells = append(cells, lineCells)
+			for column, cell := range lineCells[:len(lineCells)-1] {
+				_, _, _, _, _, _, width := decomposeText(cell, findColors, findRegions)
+				if column == len(widths) {
+					widths = append(widths, 0)
+				}
+				if width > widths[column] {
+					widths[column] = width
+				}
+			}
+		}
+
+		// Pad the cells.
+		for i, lineCells := range cells {
+			var aligned []byte
+			for column, cell := range lineCells {
+				aligned = append(aligned, cell...)
+				if column == len(lineCells)-1 || tabWidth <= 0 {
+					continue
+				}
+				_, _, _, _, _, _, width := decomposeText(cell, findColors, findRegions)
+				padded := (widths[column]/tabWidth + 1) * tabWidth
+				aligned = append(aligned, bytes.Repeat([]byte{' '}, padded-width)...)
+			}
+			lines[start+i] = aligned
+		}
+		start = end
+	}
+}
+
 // iterateString iterates through the given string one printed character at a
 // time. For each such character, the callback function is called with the
 // Unicode code points of the character (the first rune and any combining runes
@@ -668,7 +771,7 @@ func iterateString(text string, callback func(main rune, comb []rune, textPos, t
 	for gr.Next() {
 		r := gr.Runes()
 		from, to := gr.Positions()
-		width := runewidth.StringWidth(gr.Str())
+		width := graphemeWidth(gr.Str(), screenPos)
 		var comb []rune
 		if len(r) > 1 {
 			comb = r[1:]
@@ -788,3 +891,11 @@ func RenderScrollBar(screen tcell.Screen, visibility ScrollBarVisibility, x int,
 	}
 	Print(screen, text, x, y, 1, AlignLeft, color)
 }
+
+// abs returns the absolute value of x.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,142 @@
+package cview
+
+import (
+	"bytes"
+	"runtime"
+	"sync/atomic"
+	"time"
+)
+
+// watchdog reports when the main goroutine of an application is blocked.
+type watchdog struct {
+	// The time after which a blocked main goroutine is reported.
+	timeout time.Duration
+
+	// The function which is called with the duration the main goroutine has
+	// been blocked for and the stack trace of the blocking goroutine.
+	handler func(blocked time.Duration, stack []byte)
+
+	// Closed to stop the watchdog goroutine.
+	stop chan struct{}
+}
+
+// SetWatchdog enables a watchdog which reports when the main goroutine of the
+// application is blocked for longer than the provided timeout, such as by an
+// event handler or a queued update performing synchronous I/O. This helps to
+// find the cause of an unresponsive application.
+//
+// The handler is called from the watchdog goroutine with the duration the main
+// goroutine has been blocked for and the stack trace of the goroutine which is
+// blocking it. It is called once for each blocking event handler or update.
+// The handler should not call any methods of the application which draw the
+// screen or lock primitives, as these may be held by the blocked goroutine.
+// A timeout of 0 disables the watchdog.
+func (a *Application) SetWatchdog(timeout time.Duration, handler func(blocked time.Duration, stack []byte)) {
+	a.Lock()
+	defer a.Unlock()
+
+	if a.watchdog != nil {
+		close(a.watchdog.stop)
+		a.watchdog = nil
+	}
+	if timeout <= 0 || handler == nil {
+		return
+	}
+
+	w := &watchdog{
+		timeout: timeout,
+		handler: handler,
+		stop:    make(chan struct{}),
+	}
+	a.watchdog = w
+	go a.runWatchdog(w)
+}
+
+// stopWatchdog stops the watchdog, if any.
+func (a *Application) stopWatchdog() {
+	a.SetWatchdog(0, nil)
+}
+
+// runHandler calls the provided event handler or update, followed by any
+// functions it deferred via Defer, while recording the time the main goroutine
+// is busy, so that the watchdog may report it.
+func (a *Application) runHandler(f func()) {
+	atomic.AddUint64(&a.busyID, 1)
+	atomic.StoreInt64(&a.busySince, time.Now().UnixNano())
+	defer atomic.StoreInt64(&a.busySince, 0) // Reset when f panics.
+
+	f()
+	a.finishHandler()
+}
+
+// finishHandler calls the deferred functions until none are left, then marks
+// the main goroutine as idle. Defer checks whether the main goroutine is busy
+// while holding the lock, so each deferred function is either called here or
+// followed by a queued update.
+func (a *Application) finishHandler() {
+	for {
+		a.Lock()
+		if len(a.deferred) == 0 {
+			atomic.StoreInt64(&a.busySince, 0)
+			a.Unlock()
+			return
+		}
+		a.Unlock()
+
+		a.runDeferred()
+	}
+}
+
+// runWatchdog checks whether the main goroutine is blocked until the watchdog
+// is stopped.
+func (a *Application) runWatchdog(w *watchdog) {
+	interval := w.timeout / 4
+	if interval < 10*time.Millisecond {
+		interval = 10 * time.Millisecond
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var reported uint64
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+		}
+
+		since := atomic.LoadInt64(&a.busySince)
+		id := atomic.LoadUint64(&a.busyID)
+		if since == 0 || id == reported {
+			continue
+		}
+		blocked := time.Since(time.Unix(0, since))
+		if blocked < w.timeout {
+			continue
+		}
+		reported = id
+		w.handler(blocked, blockedStack())
+	}
+}
+
+// blockedStack returns the stack trace of the goroutine running an event
+// handler or update, or the stack traces of all goroutines if it may not be
+// found.
+func blockedStack() []byte {
+	buf := make([]byte, 64*1024)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	for _, stack := range bytes.Split(buf, []byte("\n\n")) {
+		if bytes.Contains(stack, []byte("cview.(*Application).runHandler(")) {
+			return stack
+		}
+	}
+	return buf
+}
@@ -0,0 +1,52 @@
+package cview
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestWatchdog(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	app, err := newTestApp(NewBox())
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+
+	reports := make(chan []byte, 2)
+	app.SetWatchdog(20*time.Millisecond, func(blocked time.Duration, stack []byte) {
+		if blocked < 20*time.Millisecond {
+			t.Errorf("failed to detect blocked goroutine: expected at least 20ms, got %s", blocked)
+		}
+		reports <- stack
+	})
+	defer app.stopWatchdog()
+
+	// Block
+
+	app.runHandler(func() {
+		time.Sleep(200 * time.Millisecond)
+	})
+
+	select {
+	case stack := <-reports:
+		if !bytes.Contains(stack, []byte("TestWatchdog")) {
+			t.Errorf("failed to report stack: expected stack of blocking goroutine, got %s", stack)
+		}
+	default:
+		t.Errorf("failed to detect blocked goroutine: no report")
+	}
+
+	// Report once per handler
+
+	time.Sleep(50 * time.Millisecond)
+	select {
+	case <-reports:
+		t.Errorf("failed to report once: got multiple reports")
+	default:
+	}
+}
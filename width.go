@@ -0,0 +1,98 @@
+package cview
+
+import (
+	"strings"
+	"sync/atomic"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
+)
+
+// runeWidthFunc is a function which returns the screen width of a grapheme
+// cluster.
+type runeWidthFunc func(grapheme string) int
+
+// The function set via SetRuneWidthFunc, stored as a runeWidthFunc.
+var customRuneWidth atomic.Value
+
+// SetRuneWidthFunc sets the function which returns the number of cells a
+// grapheme cluster (a user-perceived character, which may consist of multiple
+// runes) takes up on the screen. All primitives measure text using this
+// function, so that the widths of emoji, emoji ZWJ sequences and East Asian
+// characters of ambiguous width may be adjusted to those of the terminal
+// instead of corrupting the alignment of text:
+//
+//   cview.SetRuneWidthFunc(func(grapheme string) int {
+//     if strings.ContainsRune(grapheme, '\u200d') {
+//       return 2 // Rendered as a single emoji.
+//     }
+//     return cview.DefaultRuneWidth(grapheme)
+//   })
+//
+// The function may be called concurrently. It should be set before any text
+// is measured, as text which was already measured is not measured again. Pass
+// nil to restore the default, DefaultRuneWidth.
+func SetRuneWidthFunc(f func(grapheme string) int) {
+	customRuneWidth.Store(runeWidthFunc(f))
+}
+
+// DefaultRuneWidth returns the number of cells a grapheme cluster takes up on
+// the screen as determined by go-runewidth.
+func DefaultRuneWidth(grapheme string) int {
+	return runewidth.StringWidth(grapheme)
+}
+
+// getRuneWidthFunc returns the function set via SetRuneWidthFunc, or nil if
+// the default width is used.
+func getRuneWidthFunc() runeWidthFunc {
+	f, _ := customRuneWidth.Load().(runeWidthFunc)
+	return f
+}
+
+// graphemeWidth returns the screen width of a grapheme cluster at the provided
+// screen position. Tab characters extend to the next tab stop (see TabSize).
+func graphemeWidth(grapheme string, screenPos int) int {
+	if grapheme == "\t" {
+		if TabSize <= 0 {
+			return 0
+		}
+		return TabSize - screenPos%TabSize
+	}
+	if f := getRuneWidthFunc(); f != nil {
+		return f(grapheme)
+	}
+	return runewidth.StringWidth(grapheme)
+}
+
+// stringWidth returns the screen width of a string.
+func stringWidth(text string) int {
+	if getRuneWidthFunc() == nil && strings.IndexByte(text, '\t') < 0 {
+		return runewidth.StringWidth(text)
+	}
+
+	var width int
+	gr := uniseg.NewGraphemes(text)
+	for gr.Next() {
+		width += graphemeWidth(gr.Str(), width)
+	}
+	return width
+}
+
+// truncateString returns the longest prefix of a string which does not exceed
+// the provided screen width, without splitting grapheme clusters.
+func truncateString(text string, maxWidth int) string {
+	if getRuneWidthFunc() == nil && strings.IndexByte(text, '\t') < 0 {
+		return runewidth.Truncate(text, maxWidth, "")
+	}
+
+	var width int
+	gr := uniseg.NewGraphemes(text)
+	for gr.Next() {
+		width += graphemeWidth(gr.Str(), width)
+		if width > maxWidth {
+			from, _ := gr.Positions()
+			return text[:from]
+		}
+	}
+	return text
+}
@@ -0,0 +1,52 @@
+package cview
+
+import (
+	"testing"
+)
+
+// TestRuneWidthFunc is not run in parallel, as it changes the width of
+// characters for all primitives.
+func TestRuneWidthFunc(t *testing.T) {
+	// Initialize
+
+	const family = "\U0001F468\u200d\U0001F469\u200d\U0001F467" // ZWJ sequence
+	const ambiguous = "α"                                       // East Asian ambiguous width
+
+	defaultFamily := stringWidth(family)
+	defer SetRuneWidthFunc(nil)
+
+	SetRuneWidthFunc(func(grapheme string) int {
+		switch grapheme {
+		case family:
+			return 2
+		case ambiguous:
+			return 1
+		}
+		return DefaultRuneWidth(grapheme)
+	})
+
+	// Measure
+
+	if width := TaggedStringWidth("[red]" + family + ambiguous + "a"); width != 4 {
+		t.Errorf("failed to measure width: expected 4, got %d", width)
+	}
+	if s := truncateString("a"+ambiguous+family+"b", 3); s != "a"+ambiguous {
+		t.Errorf("failed to truncate string: expected %q, got %q", "a"+ambiguous, s)
+	}
+
+	var widths []int
+	iterateString(family+"a", func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
+		widths = append(widths, screenWidth)
+		return false
+	})
+	if len(widths) != 2 || widths[0] != 2 || widths[1] != 1 {
+		t.Errorf("failed to iterate string: expected widths [2 1], got %v", widths)
+	}
+
+	// Reset
+
+	SetRuneWidthFunc(nil)
+	if width := stringWidth(family); width != defaultFamily {
+		t.Errorf("failed to reset width function: expected %d, got %d", defaultFamily, width)
+	}
+}
@@ -87,6 +87,17 @@ func (w *Window) HasFocus() bool {
 	return w.Box.HasFocus()
 }
 
+// children returns the primitives contained in this primitive.
+func (w *Window) children() []Primitive {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.primitive == nil {
+		return nil
+	}
+	return []Primitive{w.primitive}
+}
+
 // Draw draws this primitive onto the screen.
 func (w *Window) Draw(screen tcell.Screen) {
 	if !w.GetVisible() {
@@ -100,7 +111,7 @@ func (w *Window) Draw(screen tcell.Screen) {
 
 	x, y, width, height := w.GetInnerRect()
 	w.primitive.SetRect(x, y, width, height)
-	w.primitive.Draw(screen)
+	drawPrimitive(w.primitive, screen)
 }
 
 // InputHandler returns the handler for this primitive.
@@ -68,6 +68,18 @@ func (wm *WindowManager) HasFocus() bool {
 	return false
 }
 
+// children returns the primitives contained in this primitive.
+func (wm *WindowManager) children() []Primitive {
+	wm.RLock()
+	defer wm.RUnlock()
+
+	children := make([]Primitive, len(wm.windows))
+	for i, w := range wm.windows {
+		children[i] = w
+	}
+	return children
+}
+
 // Draw draws this primitive onto the screen.
 func (wm *WindowManager) Draw(screen tcell.Screen) {
 	if !wm.GetVisible() {
@@ -90,7 +102,7 @@ func (wm *WindowManager) Draw(screen tcell.Screen) {
 		hasFullScreen = true
 		w.SetRect(x-1, y, width+2, height+1)
 
-		w.Draw(screen)
+		drawPrimitive(w, screen)
 	}
 	if hasFullScreen {
 		return
@@ -121,7 +133,7 @@ func (wm *WindowManager) Draw(screen tcell.Screen) {
 			w.SetRect(wx, wy, ww, wh)
 		}
 
-		w.Draw(screen)
+		drawPrimitive(w, screen)
 	}
 }
 
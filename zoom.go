@@ -0,0 +1,86 @@
+package cview
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// EnableZoom sets whether zooming may be toggled via Keys.Zoom (Alt+z by
+// default). It is disabled by default, so that the key is passed to the
+// focused primitive. See SetZoom.
+func (a *Application) EnableZoom(enable bool) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.zoomEnabled = enable
+}
+
+// SetZoom zooms or unzooms the pane containing the focused primitive. While a
+// pane is zoomed, it takes up the entire area of the root primitive and the
+// other panes are not drawn. The enclosing Flex and Grid are not modified, so
+// the previous layout is restored when the pane is unzoomed. A pane is a
+// primitive contained directly in a Flex or Grid, the innermost one
+// containing the focused primitive being zoomed.
+//
+// The pane is unzoomed automatically when the focus moves outside of it or
+// when it is removed from the layout. Zooming may also be toggled via
+// Keys.Zoom when enabled via EnableZoom.
+func (a *Application) SetZoom(zoom bool) {
+	a.RLock()
+	root, focus := a.root, a.focus
+	a.RUnlock()
+
+	var pane Primitive
+	if zoom {
+		path := panePath(root, focus)
+		for i := len(path) - 2; i >= 0 && pane == nil; i-- {
+			switch path[i].(type) {
+			case *Flex, *Grid:
+				pane = path[i+1]
+			}
+		}
+	}
+
+	a.Lock()
+	a.zoomed = pane
+	a.Unlock()
+
+	a.queueIndicatorUpdate()
+}
+
+// GetZoom returns whether a pane is zoomed.
+func (a *Application) GetZoom() bool {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.zoomed != nil
+}
+
+// handleZoom toggles zooming when the provided key event is Keys.Zoom and
+// zooming is enabled. Returns whether the event was handled.
+func (a *Application) handleZoom(event *tcell.EventKey, root, focused Primitive) bool {
+	a.RLock()
+	enabled := a.zoomEnabled
+	a.RUnlock()
+
+	if !enabled || !HitShortcut(event, Keys.Zoom) || panePath(root, focused) == nil {
+		return false
+	}
+	a.SetZoom(!a.GetZoom())
+	return true
+}
+
+// zoomedPane returns the zoomed pane, or nil if no pane is zoomed. The pane is
+// unzoomed if it no longer contains the focused primitive or is no longer
+// contained in the root primitive.
+func (a *Application) zoomedPane() Primitive {
+	a.Lock()
+	defer a.Unlock()
+
+	if a.zoomed == nil {
+		return nil
+	}
+	if findPath(a.zoomed, a.focus) == nil || findPath(a.root, a.zoomed) == nil {
+		a.zoomed = nil
+	}
+	return a.zoomed
+}
@@ -0,0 +1,71 @@
+package cview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestZoom(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	left, right := NewBox(), NewBox()
+	flex := NewFlex()
+	flex.AddItem(left, 0, 1, true)
+	flex.AddItem(right, 0, 1, false)
+
+	app, err := newTestApp(flex)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+		return
+	}
+	if err := app.screen.Init(); err != nil {
+		t.Errorf("failed to initialize screen: %s", err)
+		return
+	}
+	app.width, app.height = app.screen.Size()
+	app.SetFocus(left)
+	app.draw()
+
+	// Disabled by default
+
+	if app.handleZoom(tcell.NewEventKey(tcell.KeyRune, 'z', tcell.ModAlt), flex, left) || app.GetZoom() {
+		t.Errorf("failed to pass key: expected zooming to be disabled by default")
+	}
+
+	// Zoom
+
+	app.EnableZoom(true)
+	if !app.handleZoom(tcell.NewEventKey(tcell.KeyRune, 'z', tcell.ModAlt), flex, left) || !app.GetZoom() {
+		t.Errorf("failed to zoom pane")
+	}
+	app.draw()
+	if x, y, width, height := left.GetRect(); x != 0 || y != 0 || width != 80 || height != app.height {
+		t.Errorf("failed to zoom pane: expected rect 0,0,80,%d, got %d,%d,%d,%d", app.height, x, y, width, height)
+	}
+
+	// Unzoom
+
+	app.handleZoom(tcell.NewEventKey(tcell.KeyRune, 'z', tcell.ModAlt), flex, left)
+	app.draw()
+	if app.GetZoom() {
+		t.Errorf("failed to unzoom pane")
+	}
+	if _, _, width, _ := left.GetRect(); width != 40 {
+		t.Errorf("failed to restore layout: expected width 40, got %d", width)
+	}
+
+	// Move focus
+
+	app.SetZoom(true)
+	app.SetFocus(right)
+	app.draw()
+	if app.GetZoom() {
+		t.Errorf("failed to unzoom pane when focus moved outside of it")
+	}
+	if x, _, width, _ := right.GetRect(); x != 40 || width != 40 {
+		t.Errorf("failed to restore layout: expected x 40 and width 40, got %d and %d", x, width)
+	}
+}